@@ -103,10 +103,25 @@ func setDefaultValue(v *viper.Viper) {
 	v.SetDefault("PORT", "8080")
 	v.SetDefault("PLUGIN_DIR", "bin/plugins")
 	v.SetDefault("REMOTE_PLUGIN_DIR", "python/plugins")
+	// spawn a process for each remote plugin call by default, or keep one alive per plugin with "rpc"
+	v.SetDefault("REMOTE_PLUGIN_INVOKER", "cmd")
+	v.SetDefault("REMOTE_PLUGIN_RPC_WORKERS", 4)
 	v.SetDefault("SWAGGER_DOCS_DIR", "resources/swagger")
 	v.SetDefault("RESUME_PIPELINES", true)
 	// v.SetDefault("CORS_ALLOW_ORIGIN", "*")
 	v.SetDefault("CONSUME_PIPELINES", true)
+	// RBAC is opt-in, callers without any role information keep full access unless configured otherwise
+	v.SetDefault("RBAC_DEFAULT_ROLE", "admin")
+	v.SetDefault("OIDC_SCOPES", "openid,profile,email")
+	v.SetDefault("OIDC_GROUPS_CLAIM", "groups")
+	v.SetDefault("OIDC_SESSION_TTL", "8h")
+	// resumable collectors discard checkpoints older than this, collections resumed later are likely stale
+	v.SetDefault("COLLECTOR_CHECKPOINT_TTL", "72h")
+	// responses cached for conditional requests are requested again without validators once they are this old
+	v.SetDefault("API_RESPONSE_CACHE_TTL", "168h")
+	v.SetDefault("SECRET_REF_ENV_PREFIX", "DEVLAKE_SECRET_")
+	v.SetDefault("SECRET_REF_FILE_DIRS", "/run/secrets")
+	v.SetDefault("VAULT_KV_VERSION", 2)
 }
 
 func init() {
@@ -33,6 +33,8 @@ type ApiKey struct {
 	AllowedPath string     `json:"allowedPath"`
 	Type        string     `json:"type"`
 	Extra       string     `json:"extra"`
+	Workspace   string     `json:"workspace" gorm:"index;type:varchar(100)"`
+	Role        string     `json:"role" gorm:"type:varchar(20)"`
 }
 
 func (apiKey *ApiKey) TableName() string {
@@ -48,6 +50,8 @@ type ApiInputApiKey struct {
 	Type        string     `json:"type" validate:"required"`
 	AllowedPath string     `json:"allowedPath" validate:"required"`
 	ExpiredAt   *time.Time `json:"expiredAt" `
+	Workspace   string     `json:"workspace" validate:"max=100"`
+	Role        string     `json:"role" validate:"omitempty,oneof=viewer operator admin"`
 }
 
 type ApiOutputApiKey = ApiKey
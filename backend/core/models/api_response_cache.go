@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// ApiResponseCache keeps the validators and the payload of the last response of a request, so collectors could send
+// conditional requests and reuse the payload when the resource was not modified
+type ApiResponseCache struct {
+	// Id is the sha256 of the raw data table, raw data params, method and url of the request
+	Id            string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	RawDataTable  string    `gorm:"type:varchar(255);index" json:"rawDataTable"`
+	RawDataParams string    `gorm:"type:varchar(255);index" json:"rawDataParams"`
+	Url           string    `gorm:"type:text" json:"url"`
+	Etag          string    `gorm:"type:varchar(255)" json:"etag"`
+	LastModified  string    `gorm:"type:varchar(255)" json:"lastModified"`
+	Header        string    `gorm:"type:text" json:"header"`
+	Body          []byte    `json:"-"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func (ApiResponseCache) TableName() string {
+	return "_devlake_api_response_cache"
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// AuditLog records a mutating call made through the REST API
+type AuditLog struct {
+	ID         uint64    `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"index"`
+	UserName   string    `json:"userName" gorm:"type:varchar(255);index"`
+	Email      string    `json:"email" gorm:"type:varchar(255)"`
+	Role       string    `json:"role" gorm:"type:varchar(20)"`
+	Workspace  string    `json:"workspace" gorm:"type:varchar(100)"`
+	ApiKeyId   uint64    `json:"apiKeyId"`
+	Method     string    `json:"method" gorm:"type:varchar(10)"`
+	Route      string    `json:"route" gorm:"type:varchar(255)"`
+	Path       string    `json:"path" gorm:"type:text"`
+	Status     int       `json:"status"`
+	ClientIp   string    `json:"clientIp" gorm:"type:varchar(64)"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+func (AuditLog) TableName() string {
+	return "_devlake_audit_logs"
+}
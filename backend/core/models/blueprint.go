@@ -42,6 +42,7 @@ type Blueprint struct {
 	Labels       []string               `json:"labels" gorm:"-"`
 	Connections  []*BlueprintConnection `json:"connections" gorm:"-"`
 	Priority     int                    `json:"priority"` // greater is higher
+	Workspace    string                 `json:"workspace" gorm:"index;type:varchar(100)"`
 	SyncPolicy   `gorm:"embedded"`
 	common.Model `swaggerignore:"true"`
 }
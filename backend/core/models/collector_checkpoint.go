@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// CollectorCheckpoint records the progress of a collector, so the collection could be resumed from where it stopped
+// when the task was rerun after a crash. Checkpoints are removed once the collector finished successfully
+type CollectorCheckpoint struct {
+	// Id is the sha256 of the raw data table, raw data params, url template, options and input of the collector
+	Id            string `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	RawDataTable  string `gorm:"type:varchar(255);index" json:"rawDataTable"`
+	RawDataParams string `gorm:"type:varchar(255);index" json:"rawDataParams"`
+	// Collector is the url template of the collector, or the subtask name for graphql collectors, there might be
+	// multiple collectors for the same raw table
+	Collector string `gorm:"type:varchar(255)" json:"collector"`
+	// Options is the sha256 of the options the requests depend on, like the timeAfter of the sync policy
+	Options     string `gorm:"type:varchar(64)" json:"options"`
+	Incremental bool   `json:"incremental"`
+	// Input is the sha256 of the input of which all pages were collected, empty for the progress of collectors
+	// without input
+	Input string `gorm:"type:varchar(64)" json:"input"`
+	// Page is the last page of which all previous pages were collected
+	Page int `json:"page"`
+	// Cursor is the value returned by GetNextPageCustomData for collecting the page after Page
+	Cursor    *string   `gorm:"type:text" json:"cursor"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (CollectorCheckpoint) TableName() string {
+	return "_devlake_collector_checkpoints"
+}
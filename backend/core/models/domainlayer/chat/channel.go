@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chat
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.Scope = (*ChatChannel)(nil)
+
+// ChatChannel represents a channel, group or chat of an instant messaging platform
+type ChatChannel struct {
+	domainlayer.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	IsPrivate   bool
+	IsArchived  bool
+	CreatorId   string `gorm:"type:varchar(255);comment:original user id of the creator in the chat platform"`
+	CreatedDate *time.Time
+}
+
+func (ChatChannel) TableName() string {
+	return "chat_channels"
+}
+
+func (c *ChatChannel) ScopeId() string {
+	return c.Id
+}
+
+func (c *ChatChannel) ScopeName() string {
+	return c.Name
+}
+
+func NewChatChannel(id string, name string) *ChatChannel {
+	return &ChatChannel{
+		DomainEntity: domainlayer.NewDomainEntity(id),
+		Name:         name,
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codequality
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// CqAnalysis is a single analysis of a project, along with the quality gate status computed by it
+type CqAnalysis struct {
+	domainlayer.DomainEntity
+	ProjectKey                  string `gorm:"index;type:varchar(255)"` //domain project key
+	AnalysisDate                *common.Iso8601Time
+	ProjectVersion              string `gorm:"type:varchar(255)"`
+	CommitSha                   string `gorm:"type:varchar(128)"`
+	QualityGateStatus           string `gorm:"type:varchar(20)"`
+	QualityGateFailedConditions string
+}
+
+func (CqAnalysis) TableName() string {
+	return "cq_analyses"
+}
+
+// CqAnalysisMeasure holds the project measures recorded by an analysis, a measure is nil when it was not computed
+type CqAnalysisMeasure struct {
+	common.NoPKModel
+	AnalysisId             string `gorm:"primaryKey;type:varchar(255)"`
+	ProjectKey             string `gorm:"index;type:varchar(255)"` //domain project key
+	AnalysisDate           *common.Iso8601Time
+	Coverage               *float64
+	DuplicatedLinesDensity *float64
+	SqaleIndex             *int
+	Bugs                   *int
+	Vulnerabilities        *int
+	CodeSmells             *int
+	SecurityHotspots       *int
+	Ncloc                  *int
+}
+
+func (CqAnalysisMeasure) TableName() string {
+	return "cq_analysis_measures"
+}
@@ -44,8 +44,16 @@ type CicdDeploymentCommit struct {
 	RepoUrl                       string `gorm:"index;not null"`
 	PrevSuccessDeploymentCommitId string `gorm:"type:varchar(255)"`
 	SubtaskName                   string `gorm:"type:varchar(255)"`
+	IsRework                      bool
+	ReworkType                    string `gorm:"type:varchar(100)"`
 }
 
+const (
+	REWORK_REVERT   = "REVERT"
+	REWORK_HOTFIX   = "HOTFIX"
+	REWORK_ROLLBACK = "ROLLBACK"
+)
+
 func (cicdDeploymentCommit CicdDeploymentCommit) TableName() string {
 	return "cicd_deployment_commits"
 }
@@ -19,6 +19,7 @@ package domaininfo
 
 import (
 	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
@@ -56,6 +57,8 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&codequality.CqIssue{},
 		&codequality.CqIssueImpact{},
 		&codequality.CqProject{},
+		&codequality.CqAnalysis{},
+		&codequality.CqAnalysisMeasure{},
 		// crossdomain
 		&crossdomain.Account{},
 		&crossdomain.BoardRepo{},
@@ -90,6 +93,10 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.IssueWorklog{},
 		&ticket.Sprint{},
 		&ticket.SprintIssue{},
+		&ticket.Release{},
+		&ticket.ReleaseIssue{},
+		&ticket.BoardRelease{},
+		&ticket.IssueSla{},
 		&ticket.IssueAssignee{},
 		&ticket.IssueRelationship{},
 		&ticket.IssueCustomArrayField{},
@@ -100,5 +107,9 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&qa.QaApi{},
 		&qa.QaTestCase{},
 		&qa.QaTestCaseExecution{},
+		// chat
+		&chat.ChatChannel{},
+		&chat.ChatMessage{},
+		&chat.ChatThread{},
 	}
 }
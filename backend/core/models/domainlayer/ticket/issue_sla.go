@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueSla is a cycle of a service level agreement tracked on an issue, e.g. "Time to first response".
+// An SLA may be restarted several times, each one of them is recorded as a cycle, the ongoing one comes last.
+type IssueSla struct {
+	common.NoPKModel
+	IssueId          string `gorm:"primaryKey;type:varchar(255)"`
+	Name             string `gorm:"primaryKey;type:varchar(255)"`
+	Cycle            int    `gorm:"primaryKey;autoIncrement:false"`
+	IsOngoing        bool
+	IsBreached       bool
+	IsPaused         bool
+	StartedDate      *time.Time
+	StoppedDate      *time.Time
+	BreachDate       *time.Time
+	GoalMinutes      int64
+	ElapsedMinutes   int64
+	RemainingMinutes int64
+}
+
+func (IssueSla) TableName() string {
+	return "issue_slas"
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+var (
+	ReleaseUnreleased = "UNRELEASED"
+	ReleaseReleased   = "RELEASED"
+	ReleaseArchived   = "ARCHIVED"
+)
+
+// Release is a planned delivery of a set of issues, e.g. a Jira fix version.
+// Name usually matches the tag of the corresponding cicd_releases / deployment, so they can be joined by name.
+type Release struct {
+	domainlayer.DomainEntity
+	Name               string `gorm:"type:varchar(255)"`
+	Description        string
+	Url                string `gorm:"type:varchar(255)"`
+	Status             string `gorm:"type:varchar(100)"`
+	StartedDate        *time.Time
+	PlannedReleaseDate *time.Time
+	ReleasedDate       *time.Time
+}
+
+func (Release) TableName() string {
+	return "releases"
+}
+
+type ReleaseIssue struct {
+	common.NoPKModel
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (ReleaseIssue) TableName() string {
+	return "release_issues"
+}
+
+type BoardRelease struct {
+	common.NoPKModel
+	BoardId   string `gorm:"primaryKey;type:varchar(255)"`
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (BoardRelease) TableName() string {
+	return "board_releases"
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addWorkspaces)(nil)
+
+type workspace20251110 struct {
+	Name         string `gorm:"primaryKey;type:varchar(100)"`
+	Description  string `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	Creator      string
+	CreatorEmail string
+}
+
+func (workspace20251110) TableName() string {
+	return "_devlake_workspaces"
+}
+
+type workspaceConnection20251110 struct {
+	Plugin       string `gorm:"primaryKey;type:varchar(255)"`
+	ConnectionId uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Workspace    string `gorm:"index;type:varchar(100)"`
+	CreatedAt    time.Time
+}
+
+func (workspaceConnection20251110) TableName() string {
+	return "_devlake_workspace_connections"
+}
+
+type project20251110 struct {
+	Workspace string `gorm:"index;type:varchar(100)"`
+}
+
+func (project20251110) TableName() string {
+	return "projects"
+}
+
+type blueprint20251110 struct {
+	Workspace string `gorm:"index;type:varchar(100)"`
+}
+
+func (blueprint20251110) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type apiKey20251110 struct {
+	Workspace string `gorm:"index;type:varchar(100)"`
+}
+
+func (apiKey20251110) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type addWorkspaces struct{}
+
+func (*addWorkspaces) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(workspace20251110),
+		new(workspaceConnection20251110),
+		new(project20251110),
+		new(blueprint20251110),
+		new(apiKey20251110),
+	)
+}
+
+func (*addWorkspaces) Version() uint64 {
+	return 20251110100000
+}
+
+func (*addWorkspaces) Name() string {
+	return "add workspaces and bind projects, blueprints, connections and api keys to them"
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addRoleBasedAccessControl)(nil)
+
+type apiKey20251117 struct {
+	Role string `gorm:"type:varchar(20)"`
+}
+
+func (apiKey20251117) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type auditLog20251117 struct {
+	ID         uint64    `gorm:"primaryKey"`
+	CreatedAt  time.Time `gorm:"index"`
+	UserName   string    `gorm:"type:varchar(255);index"`
+	Email      string    `gorm:"type:varchar(255)"`
+	Role       string    `gorm:"type:varchar(20)"`
+	Workspace  string    `gorm:"type:varchar(100)"`
+	ApiKeyId   uint64
+	Method     string `gorm:"type:varchar(10)"`
+	Route      string `gorm:"type:varchar(255)"`
+	Path       string `gorm:"type:text"`
+	Status     int
+	ClientIp   string `gorm:"type:varchar(64)"`
+	DurationMs int64
+}
+
+func (auditLog20251117) TableName() string {
+	return "_devlake_audit_logs"
+}
+
+type addRoleBasedAccessControl struct{}
+
+func (*addRoleBasedAccessControl) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	err := migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(apiKey20251117),
+		new(auditLog20251117),
+	)
+	if err != nil {
+		return err
+	}
+	// existing api keys were restricted by the allowed path only, keep them working as they were
+	return db.UpdateColumn(
+		new(apiKey20251117),
+		"role", "admin",
+		dal.Where("role IS NULL OR role = ''"),
+	)
+}
+
+func (*addRoleBasedAccessControl) Version() uint64 {
+	return 20251117100000
+}
+
+func (*addRoleBasedAccessControl) Name() string {
+	return "add role to api keys and create audit logs table"
+}
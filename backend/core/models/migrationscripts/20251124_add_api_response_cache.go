@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addApiResponseCache)(nil)
+
+type apiResponseCache20251124 struct {
+	Id            string `gorm:"primaryKey;type:varchar(64)"`
+	RawDataTable  string `gorm:"type:varchar(255);index"`
+	RawDataParams string `gorm:"type:varchar(255);index"`
+	Url           string `gorm:"type:text"`
+	Etag          string `gorm:"type:varchar(255)"`
+	LastModified  string `gorm:"type:varchar(255)"`
+	Header        string `gorm:"type:text"`
+	Body          []byte
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (apiResponseCache20251124) TableName() string {
+	return "_devlake_api_response_cache"
+}
+
+type addApiResponseCache struct{}
+
+func (*addApiResponseCache) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(apiResponseCache20251124))
+}
+
+func (*addApiResponseCache) Version() uint64 {
+	return 20251124100000
+}
+
+func (*addApiResponseCache) Name() string {
+	return "add api response cache for conditional requests"
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCollectorCheckpoints)(nil)
+
+type collectorCheckpoint20251208 struct {
+	Id            string `gorm:"primaryKey;type:varchar(64)"`
+	RawDataTable  string `gorm:"type:varchar(255);index"`
+	RawDataParams string `gorm:"type:varchar(255);index"`
+	Collector     string `gorm:"type:varchar(255)"`
+	Options       string `gorm:"type:varchar(64)"`
+	Incremental   bool
+	Input         string `gorm:"type:varchar(64)"`
+	Page          int
+	Cursor        *string `gorm:"type:text"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (collectorCheckpoint20251208) TableName() string {
+	return "_devlake_collector_checkpoints"
+}
+
+type addCollectorCheckpoints struct{}
+
+func (*addCollectorCheckpoints) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(basicRes, new(collectorCheckpoint20251208))
+}
+
+func (*addCollectorCheckpoints) Version() uint64 {
+	return 20251208100000
+}
+
+func (*addCollectorCheckpoints) Name() string {
+	return "add collector checkpoints for resuming collection"
+}
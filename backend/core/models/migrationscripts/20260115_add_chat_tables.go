@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addChatTables)(nil)
+
+type chatChannel20260115 struct {
+	archived.DomainEntity
+	Name        string `gorm:"type:varchar(255)"`
+	Description string
+	Url         string `gorm:"type:varchar(255)"`
+	IsPrivate   bool
+	IsArchived  bool
+	CreatorId   string `gorm:"type:varchar(255)"`
+	CreatedDate *time.Time
+}
+
+func (chatChannel20260115) TableName() string {
+	return "chat_channels"
+}
+
+type chatMessage20260115 struct {
+	archived.DomainEntity
+	ChannelId   string `gorm:"index;type:varchar(255)"`
+	ThreadId    string `gorm:"index;type:varchar(255)"`
+	ParentId    string `gorm:"type:varchar(255)"`
+	AuthorId    string `gorm:"type:varchar(255)"`
+	Type        string `gorm:"type:varchar(100)"`
+	Content     string
+	CreatedDate time.Time
+	UpdatedDate *time.Time
+}
+
+func (chatMessage20260115) TableName() string {
+	return "chat_messages"
+}
+
+type chatThread20260115 struct {
+	archived.DomainEntity
+	ChannelId       string `gorm:"index;type:varchar(255)"`
+	RootMessageId   string `gorm:"type:varchar(255)"`
+	AuthorId        string `gorm:"type:varchar(255)"`
+	ReplyCount      int
+	ReplyUsersCount int
+	CreatedDate     time.Time
+	LatestReplyDate *time.Time
+}
+
+func (chatThread20260115) TableName() string {
+	return "chat_threads"
+}
+
+type addChatTables struct{}
+
+func (*addChatTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(chatChannel20260115),
+		new(chatMessage20260115),
+		new(chatThread20260115),
+	)
+}
+
+func (*addChatTables) Version() uint64 {
+	return 20260115100000
+}
+
+func (*addChatTables) Name() string {
+	return "add chat channels, messages and threads"
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addReleaseTables)(nil)
+
+type release20260117 struct {
+	archived.DomainEntity
+	Name               string `gorm:"type:varchar(255)"`
+	Description        string
+	Url                string `gorm:"type:varchar(255)"`
+	Status             string `gorm:"type:varchar(100)"`
+	StartedDate        *time.Time
+	PlannedReleaseDate *time.Time
+	ReleasedDate       *time.Time
+}
+
+func (release20260117) TableName() string {
+	return "releases"
+}
+
+type releaseIssue20260117 struct {
+	archived.NoPKModel
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+	IssueId   string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (releaseIssue20260117) TableName() string {
+	return "release_issues"
+}
+
+type boardRelease20260117 struct {
+	archived.NoPKModel
+	BoardId   string `gorm:"primaryKey;type:varchar(255)"`
+	ReleaseId string `gorm:"primaryKey;type:varchar(255)"`
+}
+
+func (boardRelease20260117) TableName() string {
+	return "board_releases"
+}
+
+type addReleaseTables struct{}
+
+func (*addReleaseTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(release20260117),
+		new(releaseIssue20260117),
+		new(boardRelease20260117),
+	)
+}
+
+func (*addReleaseTables) Version() uint64 {
+	return 20260117000001
+}
+
+func (*addReleaseTables) Name() string {
+	return "add releases, release_issues and board_releases"
+}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueSlas)(nil)
+
+type issueSla20260118 struct {
+	archived.NoPKModel
+	IssueId          string `gorm:"primaryKey;type:varchar(255)"`
+	Name             string `gorm:"primaryKey;type:varchar(255)"`
+	Cycle            int    `gorm:"primaryKey;autoIncrement:false"`
+	IsOngoing        bool
+	IsBreached       bool
+	IsPaused         bool
+	StartedDate      *time.Time
+	StoppedDate      *time.Time
+	BreachDate       *time.Time
+	GoalMinutes      int64
+	ElapsedMinutes   int64
+	RemainingMinutes int64
+}
+
+func (issueSla20260118) TableName() string {
+	return "issue_slas"
+}
+
+type addIssueSlas struct{}
+
+func (*addIssueSlas) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(issueSla20260118),
+	)
+}
+
+func (*addIssueSlas) Version() uint64 {
+	return 20260118000001
+}
+
+func (*addIssueSlas) Name() string {
+	return "add issue_slas"
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCqAnalyses)(nil)
+
+type cqAnalysis20260119 struct {
+	archived.DomainEntity
+	ProjectKey                  string `gorm:"index;type:varchar(255)"`
+	AnalysisDate                *time.Time
+	ProjectVersion              string `gorm:"type:varchar(255)"`
+	CommitSha                   string `gorm:"type:varchar(128)"`
+	QualityGateStatus           string `gorm:"type:varchar(20)"`
+	QualityGateFailedConditions string
+}
+
+func (cqAnalysis20260119) TableName() string {
+	return "cq_analyses"
+}
+
+type cqAnalysisMeasure20260119 struct {
+	archived.NoPKModel
+	AnalysisId             string `gorm:"primaryKey;type:varchar(255)"`
+	ProjectKey             string `gorm:"index;type:varchar(255)"`
+	AnalysisDate           *time.Time
+	Coverage               *float64
+	DuplicatedLinesDensity *float64
+	SqaleIndex             *int
+	Bugs                   *int
+	Vulnerabilities        *int
+	CodeSmells             *int
+	SecurityHotspots       *int
+	Ncloc                  *int
+}
+
+func (cqAnalysisMeasure20260119) TableName() string {
+	return "cq_analysis_measures"
+}
+
+type addCqAnalyses struct{}
+
+func (*addCqAnalyses) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		new(cqAnalysis20260119),
+		new(cqAnalysisMeasure20260119),
+	)
+}
+
+func (*addCqAnalyses) Version() uint64 {
+	return 20260119000001
+}
+
+func (*addCqAnalyses) Name() string {
+	return "add cq_analyses and cq_analysis_measures"
+}
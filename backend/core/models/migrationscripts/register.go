@@ -141,5 +141,14 @@ func All() []plugin.MigrationScript {
 		new(addIssueFixVerion),
 		new(addPipelinePriority),
 		new(fixNullPriority),
+		new(addReworkToCicdDeploymentCommits),
+		new(addWorkspaces),
+		new(addRoleBasedAccessControl),
+		new(addApiResponseCache),
+		new(addCollectorCheckpoints),
+		new(addChatTables),
+		new(addReleaseTables),
+		new(addIssueSlas),
+		new(addCqAnalyses),
 	}
 }
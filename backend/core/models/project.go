@@ -27,6 +27,7 @@ import (
 type BaseProject struct {
 	Name        string `json:"name" mapstructure:"name" gorm:"primaryKey;type:varchar(255)" validate:"required"`
 	Description string `json:"description" mapstructure:"description" gorm:"type:text"`
+	Workspace   string `json:"workspace" mapstructure:"workspace" gorm:"index;type:varchar(100)"`
 }
 
 type Project struct {
@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// Roles decide what a caller is allowed to do through the REST API, each role includes the permissions of the
+// roles below it
+const (
+	ROLE_VIEWER   = "viewer"   // read-only access
+	ROLE_OPERATOR = "operator" // triggering, cancelling and rerunning pipelines
+	ROLE_ADMIN    = "admin"    // full access
+)
+
+var roleLevels = map[string]int{
+	ROLE_VIEWER:   1,
+	ROLE_OPERATOR: 2,
+	ROLE_ADMIN:    3,
+}
+
+// IsValidRole reports whether the role is one of the predefined roles
+func IsValidRole(role string) bool {
+	_, ok := roleLevels[role]
+	return ok
+}
+
+// RoleCovers reports whether the role is granted the permissions of the required role
+func RoleCovers(role string, required string) bool {
+	level, ok := roleLevels[role]
+	return ok && level >= roleLevels[required]
+}
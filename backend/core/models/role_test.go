@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleCovers(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		required string
+		want     bool
+	}{
+		{name: "viewer reads", role: ROLE_VIEWER, required: ROLE_VIEWER, want: true},
+		{name: "viewer operates", role: ROLE_VIEWER, required: ROLE_OPERATOR, want: false},
+		{name: "operator operates", role: ROLE_OPERATOR, required: ROLE_OPERATOR, want: true},
+		{name: "operator administrates", role: ROLE_OPERATOR, required: ROLE_ADMIN, want: false},
+		{name: "admin administrates", role: ROLE_ADMIN, required: ROLE_ADMIN, want: true},
+		{name: "admin reads", role: ROLE_ADMIN, required: ROLE_VIEWER, want: true},
+		{name: "unknown role", role: "guest", required: ROLE_VIEWER, want: false},
+		{name: "empty role", role: "", required: ROLE_VIEWER, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RoleCovers(tt.role, tt.required))
+		})
+	}
+}
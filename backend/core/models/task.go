@@ -32,10 +32,11 @@ const (
 	TASK_FAILED    = "TASK_FAILED"
 	TASK_CANCELLED = "TASK_CANCELLED"
 	TASK_PARTIAL   = "TASK_PARTIAL"
+	TASK_PAUSED    = "TASK_PAUSED"
 )
 
 var (
-	PendingTaskStatus  = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING}
+	PendingTaskStatus  = []string{TASK_CREATED, TASK_RERUN, TASK_RUNNING, TASK_PAUSED}
 	FinishedTaskStatus = []string{TASK_PARTIAL, TASK_CANCELLED, TASK_FAILED, TASK_COMPLETED}
 )
 
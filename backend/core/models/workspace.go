@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// Workspace isolates projects, blueprints, plugin connections and api keys of a tenant from the others.
+// Resources without a workspace are only visible to callers which are not bound to any workspace.
+type Workspace struct {
+	Name        string    `json:"name" gorm:"primaryKey;type:varchar(100)" validate:"required,max=100"`
+	Description string    `json:"description" gorm:"type:text"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	common.Creator
+}
+
+func (Workspace) TableName() string {
+	return "_devlake_workspaces"
+}
+
+// WorkspaceConnection assigns a plugin connection to a workspace
+type WorkspaceConnection struct {
+	Plugin       string    `json:"plugin" gorm:"primaryKey;type:varchar(255)" validate:"required"`
+	ConnectionId uint64    `json:"connectionId" gorm:"primaryKey;autoIncrement:false" validate:"required"`
+	Workspace    string    `json:"workspace" gorm:"index;type:varchar(100)"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (WorkspaceConnection) TableName() string {
+	return "_devlake_workspace_connections"
+}
@@ -33,6 +33,8 @@ type ApiResourceInput struct {
 	Request *http.Request
 
 	User *common.User
+	// Workspace the caller is bound to, empty means the caller may access resources of all workspaces
+	Workspace string
 }
 
 // GetPlugin get the plugin in context
@@ -36,6 +36,14 @@ type ApiConnection interface {
 	GetRateLimitPerHour() int
 }
 
+// TlsConnection is to be implemented by connections talking to servers signed by a custom CA or requiring
+// client certificates, all values are PEM encoded and may be empty
+type TlsConnection interface {
+	GetCaCert() string
+	GetClientCert() string
+	GetClientKey() string
+}
+
 type CacheableConnection interface {
 	ApiConnection
 	GetHash() string
@@ -65,12 +73,14 @@ const (
 	AUTH_METHOD_BASIC  = "BasicAuth"
 	AUTH_METHOD_TOKEN  = "AccessToken"
 	AUTH_METHOD_APPKEY = "AppKey"
+	AUTH_METHOD_OAUTH2 = "OAuth2"
 )
 
 var ALL_AUTH = map[string]bool{
 	AUTH_METHOD_BASIC:  true,
 	AUTH_METHOD_TOKEN:  true,
 	AUTH_METHOD_APPKEY: true,
+	AUTH_METHOD_OAUTH2: true,
 }
 
 // MultiAuthenticator represents the API Connection supports multiple authorization methods
@@ -93,6 +103,11 @@ type AppKeyAuthenticator interface {
 	GetAppKeyAuthenticator() ApiAuthenticator
 }
 
+// OAuth2Authenticator represents HTTP Bearer Authentication with Access Token obtained by OAuth 2.0 grants
+type OAuth2Authenticator interface {
+	GetOAuth2Authenticator() ApiAuthenticator
+}
+
 // Scope represents the top level entity for a data source, i.e. github repo,
 // gitlab project, jira board. They turn into repo, board in Domain Layer. In
 // Apache Devlake, a Project is essentially a set of these top level entities,
@@ -24,6 +24,7 @@ import (
 	goplugin "plugin"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -33,6 +34,13 @@ import (
 	"github.com/apache/incubator-devlake/server/services/remote/models"
 )
 
+const (
+	// how long the server waits on startup for the plugins running as http services to be ready
+	remotePluginReadyTimeout = time.Minute
+	remotePluginPingTimeout  = 5 * time.Second
+	remotePluginPingInterval = 2 * time.Second
+)
+
 // LoadPlugins load plugins from local directory
 func LoadPlugins(basicRes context.BasicRes) errors.Error {
 	err := LoadGoPlugins(basicRes)
@@ -90,38 +98,111 @@ func LoadGoPlugins(basicRes context.BasicRes) errors.Error {
 
 func LoadRemotePlugins(basicRes context.BasicRes) errors.Error {
 	remotePluginDir := basicRes.GetConfig("REMOTE_PLUGIN_DIR")
+	remotePluginUrls := basicRes.GetConfig("REMOTE_PLUGIN_URLS")
+	if remotePluginDir == "" && remotePluginUrls == "" {
+		return nil
+	}
+	basicRes.GetLogger().Info("Loading remote plugins")
+	remote.Init(basicRes)
 	if remotePluginDir != "" {
-		basicRes.GetLogger().Info("Loading remote plugins")
-		remote.Init(basicRes)
 		walkErr := filepath.WalkDir(remotePluginDir, func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
 			fileName := d.Name()
 			if fileName == "run.sh" {
-				invoker := bridge.NewCmdInvoker(path)
-				result := invoker.Call("plugin-info", bridge.DefaultContext)
-				if result.Err != nil {
-					return errors.Default.Wrap(result.Err, "Error calling plugin-info")
-				}
-				pluginInfo := &models.PluginInfo{}
-				err := result.Get(pluginInfo)
-				if err != nil {
-					return err
-				}
-				remotePlugin, err := remote.NewRemotePlugin(pluginInfo)
-				if err != nil {
-					return err
-				}
-				err = plugin.RegisterPlugin(pluginInfo.Name, remotePlugin)
-				if err != nil {
-					return err
-				}
-				basicRes.GetLogger().Info(`remote plugin loaded %s`, pluginInfo.Name)
+				return loadRemotePlugin(basicRes, path)
 			}
 			return nil
 		})
-		return errors.Convert(walkErr)
+		if walkErr != nil {
+			return errors.Convert(walkErr)
+		}
+	}
+	// plugins running as http services, i.e. sidecar containers, separated by comma
+	for _, url := range strings.Split(remotePluginUrls, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		// sidecar containers might start later than the server
+		_, err := loadHttpPlugin(basicRes, url, remotePluginReadyTimeout)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// LoadRemotePluginByUrl registers the plugin running as a http service at the url while the server is running,
+// the service is expected to be ready already
+func LoadRemotePluginByUrl(basicRes context.BasicRes, url string) (models.RemotePlugin, errors.Error) {
+	remote.Init(basicRes)
+	return loadHttpPlugin(basicRes, url, 0)
+}
+
+func loadHttpPlugin(basicRes context.BasicRes, url string, readyTimeout time.Duration) (models.RemotePlugin, errors.Error) {
+	if !bridge.IsHttpPlugin(url) {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid remote plugin url %s", url))
+	}
+	invoker, err := bridge.NewHttpInvoker(url)
+	if err != nil {
+		return nil, err
+	}
+	err = waitForHttpPlugin(basicRes, invoker, url, readyTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return registerRemotePlugin(basicRes, invoker, url)
+}
+
+func loadRemotePlugin(basicRes context.BasicRes, pluginPath string) errors.Error {
+	invoker, err := bridge.NewInvoker(pluginPath)
+	if err != nil {
+		return err
+	}
+	_, err = registerRemotePlugin(basicRes, invoker, pluginPath)
+	return err
+}
+
+func registerRemotePlugin(basicRes context.BasicRes, invoker bridge.Invoker, pluginPath string) (models.RemotePlugin, errors.Error) {
+	result := invoker.Call("plugin-info", bridge.DefaultContext)
+	if result.Err != nil {
+		return nil, errors.Default.Wrap(result.Err, "Error calling plugin-info")
+	}
+	pluginInfo := &models.PluginInfo{}
+	err := result.Get(pluginInfo)
+	if err != nil {
+		return nil, err
+	}
+	if bridge.IsHttpPlugin(pluginPath) {
+		// the service might not know the url it is reachable at
+		pluginInfo.PluginPath = pluginPath
+	}
+	remotePlugin, err := remote.NewRemotePlugin(pluginInfo)
+	if err != nil {
+		return nil, err
+	}
+	err = plugin.RegisterPlugin(pluginInfo.Name, remotePlugin)
+	if err != nil {
+		return nil, err
+	}
+	basicRes.GetLogger().Info(`remote plugin loaded %s`, pluginInfo.Name)
+	return remotePlugin, nil
+}
+
+// waitForHttpPlugin pings the service until it is ready or the timeout elapsed, it is pinged once at least
+func waitForHttpPlugin(basicRes context.BasicRes, invoker *bridge.HttpInvoker, url string, timeout time.Duration) errors.Error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := invoker.Ping(remotePluginPingTimeout)
+		if err == nil {
+			return nil
+		}
+		if time.Now().Add(remotePluginPingInterval).After(deadline) {
+			return errors.Default.Wrap(err, fmt.Sprintf("remote plugin %s is not ready", url))
+		}
+		basicRes.GetLogger().Warn(err, "waiting for remote plugin %s to be ready", url)
+		time.Sleep(remotePluginPingInterval)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"sync/atomic"
+)
+
+// PauseSignal is raised when a running pipeline gets paused, its tasks check it before each subtask so
+// they stop without querying the database
+type PauseSignal struct {
+	paused atomic.Bool
+}
+
+// Pause asks the tasks to stop after their current subtask
+func (s *PauseSignal) Pause() {
+	s.paused.Store(true)
+}
+
+// Withdraw cancels a pause that hasn't taken effect yet
+func (s *PauseSignal) Withdraw() {
+	s.paused.Store(false)
+}
+
+// IsPaused returns whether a pause was requested, a nil signal is never paused
+func (s *PauseSignal) IsPaused() bool {
+	return s != nil && s.paused.Load()
+}
+
+type pauseSignalKey struct{}
+
+// WithPauseSignal returns a copy of ctx carrying the pause signal of the pipeline
+func WithPauseSignal(ctx gocontext.Context, signal *PauseSignal) gocontext.Context {
+	return gocontext.WithValue(ctx, pauseSignalKey{}, signal)
+}
+
+func pauseSignalFrom(ctx gocontext.Context) *PauseSignal {
+	signal, _ := ctx.Value(pauseSignalKey{}).(*PauseSignal)
+	return signal
+}
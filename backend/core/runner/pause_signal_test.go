@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/stretchr/testify/assert"
+)
+
+// pipelineDal keeps a single pipeline in memory
+type pipelineDal struct {
+	dal.Dal
+	pipeline models.Pipeline
+}
+
+func (d *pipelineDal) First(dst interface{}, _ ...dal.Clause) errors.Error {
+	*dst.(*models.Pipeline) = d.pipeline
+	return nil
+}
+
+func (d *pipelineDal) UpdateColumns(_ interface{}, set []dal.DalSet, _ ...dal.Clause) errors.Error {
+	// the stage updates skip paused pipelines
+	if d.pipeline.Status == models.TASK_PAUSED {
+		return nil
+	}
+	for _, s := range set {
+		switch s.ColumnName {
+		case "status":
+			d.pipeline.Status = s.Value.(string)
+		case "stage":
+			d.pipeline.Stage = s.Value.(int)
+		}
+	}
+	return nil
+}
+
+func (d *pipelineDal) Count(_ ...dal.Clause) (int64, errors.Error) {
+	if d.pipeline.Status == models.TASK_PAUSED {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+type pipelineBasicRes struct {
+	context.BasicRes
+	db dal.Dal
+}
+
+func (r *pipelineBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+func (r *pipelineBasicRes) GetLogger() log.Logger {
+	return logruslog.Global
+}
+
+// runStages runs a pipeline of three single task stages and pauses it while running the given stage
+func runStages(pauseAtStage int) (*pipelineDal, []uint64, errors.Error) {
+	db := &pipelineDal{pipeline: models.Pipeline{Model: common.Model{ID: 1}, Status: models.TASK_RUNNING}}
+	var ran []uint64
+	err := runPipelineTasks(&pipelineBasicRes{db: db}, 1, [][]uint64{{1}, {2}, {3}}, func(taskIds []uint64) errors.Error {
+		ran = append(ran, taskIds...)
+		if db.pipeline.Stage == pauseAtStage {
+			db.pipeline.Status = models.TASK_PAUSED
+		}
+		return nil
+	})
+	return db, ran, err
+}
+
+func TestRunPipelineTasksPausedBetweenStages(t *testing.T) {
+	db, ran, err := runStages(2)
+	assert.True(t, errors.Is(err, ErrPipelinePaused))
+	assert.Equal(t, []uint64{1, 2}, ran)
+	assert.Equal(t, models.TASK_PAUSED, db.pipeline.Status)
+	assert.Equal(t, 2, db.pipeline.Stage)
+}
+
+func TestRunPipelineTasksPausedDuringLastStage(t *testing.T) {
+	db, ran, err := runStages(3)
+	assert.True(t, errors.Is(err, ErrPipelinePaused))
+	assert.Equal(t, []uint64{1, 2, 3}, ran)
+	assert.Equal(t, models.TASK_PAUSED, db.pipeline.Status)
+}
+
+func TestRunPipelineTasksNotPaused(t *testing.T) {
+	db, ran, err := runStages(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, ran)
+	assert.Equal(t, models.TASK_RUNNING, db.pipeline.Status)
+}
+
+func TestPauseSignal(t *testing.T) {
+	assert.False(t, pauseSignalFrom(gocontext.Background()).IsPaused())
+
+	signal := &PauseSignal{}
+	ctx, cancel := gocontext.WithCancel(WithPauseSignal(gocontext.Background(), signal))
+	defer cancel()
+	assert.False(t, pauseSignalFrom(ctx).IsPaused())
+	signal.Pause()
+	assert.True(t, pauseSignalFrom(ctx).IsPaused())
+	signal.Withdraw()
+	assert.False(t, pauseSignalFrom(ctx).IsPaused())
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/apache/incubator-devlake/core/models"
 )
 
+// ErrPipelinePaused is returned when the pipeline was paused, the running tasks stop after their current subtask
+var ErrPipelinePaused = errors.Default.New("pipeline paused")
+
 // RunPipeline FIXME ...
 func RunPipeline(
 	basicRes context.BasicRes,
@@ -77,25 +80,35 @@ func runPipelineTasks(
 	// This double for loop executes each set of tasks sequentially while
 	// executing the set of tasks concurrently.
 	for i, row := range taskIds {
-		// update stage
-		err = db.UpdateColumns(dbPipeline, []dal.DalSet{
+		// update stage, leave the status alone if the pipeline was paused in the meantime
+		err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
 			{ColumnName: "status", Value: models.TASK_RUNNING},
 			{ColumnName: "stage", Value: i + 1},
-		})
+		}, dal.Where("id = ? AND status <> ?", pipelineId, models.TASK_PAUSED))
 		if err != nil {
 			log.Error(err, "update pipeline state failed")
 			break
 		}
+		// stop before the next stage if the pipeline was paused
+		if isPipelinePaused(basicRes, pipelineId) {
+			log.Info("pipeline paused before stage %d", i+1)
+			return ErrPipelinePaused
+		}
 		// run tasks in parallel
 		err = runTasks(row)
 		if err != nil {
 			log.Error(err, "run tasks failed")
-			if errors.Is(err, gocontext.Canceled) || !dbPipeline.SkipOnFail {
+			if errors.Is(err, gocontext.Canceled) || errors.Is(err, ErrPipelinePaused) || !dbPipeline.SkipOnFail {
 				log.Info("return error")
 				return err
 			}
 		}
 	}
+	// the pause may arrive while the last stage is running, keep the pipeline paused instead of finishing it
+	if isPipelinePaused(basicRes, pipelineId) {
+		log.Info("pipeline paused after stage %d", len(taskIds))
+		return ErrPipelinePaused
+	}
 	if dbPipeline.BeganAt != nil {
 		log.Info("pipeline finished in %d ms: %v", time.Now().UnixMilli()-dbPipeline.BeganAt.UnixMilli(), err)
 	} else {
@@ -103,3 +116,19 @@ func runPipelineTasks(
 	}
 	return err
 }
+
+// isPipelinePaused checks whether a pause was requested for the pipeline
+func isPipelinePaused(basicRes context.BasicRes, pipelineId uint64) bool {
+	if pipelineId == 0 {
+		return false
+	}
+	count, err := basicRes.GetDal().Count(
+		dal.From(&models.Pipeline{}),
+		dal.Where("id = ? AND status = ?", pipelineId, models.TASK_PAUSED),
+	)
+	if err != nil {
+		basicRes.GetLogger().Error(err, "failed to check pipeline #%d status", pipelineId)
+		return false
+	}
+	return count > 0
+}
@@ -75,6 +75,17 @@ func RunTask(
 			err = errors.Default.Wrap(e, fmt.Sprintf("run task failed with panic (%s)", utils.GatherCallFrames(0)))
 			logger.Error(err, "run task failed with panic")
 		}
+		// the pipeline was paused, the task would continue from the next subtask once resumed
+		if errors.Is(err, ErrPipelinePaused) {
+			dbe := db.UpdateColumns(task, []dal.DalSet{
+				{ColumnName: "status", Value: models.TASK_PAUSED},
+				{ColumnName: "message", Value: ""},
+			})
+			if dbe != nil {
+				logger.Error(dbe, "failed to finalize task status into db (task paused)")
+			}
+			return
+		}
 		finishedAt := time.Now()
 		spentSeconds := finishedAt.Unix() - beganAt.Unix()
 		if err != nil {
@@ -324,6 +335,9 @@ func RunPluginSubTasks(
 		}
 		if subtaskFinished {
 			logger.Info("subtask %s already finished previously", subtaskMeta.Name)
+		} else if pauseSignalFrom(ctx).IsPaused() {
+			logger.Info("pipeline paused, stop before subtask %s", subtaskMeta.Name)
+			return ErrPipelinePaused
 		} else {
 			logger.Info("executing subtask %s", subtaskMeta.Name)
 			start := time.Now()
@@ -61,11 +61,18 @@ func NewApiKeyHelper(basicRes context.BasicRes, logger log.Logger) *ApiKeyHelper
 	}
 }
 
-func (c *ApiKeyHelper) Create(tx dal.Transaction, user *common.User, name string, expiredAt *time.Time, allowedPath string, apiKeyType string, extra string) (*models.ApiKey, errors.Error) {
+func (c *ApiKeyHelper) Create(tx dal.Transaction, user *common.User, name string, expiredAt *time.Time, allowedPath string, apiKeyType string, extra string, workspace string, role string) (*models.ApiKey, errors.Error) {
 	if _, err := regexp.Compile(allowedPath); err != nil {
 		c.logger.Error(err, "Compile allowed path")
 		return nil, errors.Default.Wrap(err, fmt.Sprintf("compile allowed path: %s", allowedPath))
 	}
+	// keys created without a role get the least privileges
+	if role == "" {
+		role = models.ROLE_VIEWER
+	}
+	if !models.IsValidRole(role) {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid role: %s", role))
+	}
 	apiKey, hashedApiKey, err := c.generateApiKey()
 	if err != nil {
 		c.logger.Error(err, "generateApiKey")
@@ -83,6 +90,8 @@ func (c *ApiKeyHelper) Create(tx dal.Transaction, user *common.User, name string
 		AllowedPath: allowedPath,
 		Type:        apiKeyType,
 		Extra:       extra,
+		Workspace:   workspace,
+		Role:        role,
 	}
 	if user != nil {
 		apiKeyRecord.Creator = common.Creator{
@@ -105,8 +114,10 @@ func (c *ApiKeyHelper) Create(tx dal.Transaction, user *common.User, name string
 	return apiKeyRecord, nil
 }
 
-func (c *ApiKeyHelper) CreateForPlugin(tx dal.Transaction, user *common.User, name string, pluginName string, allowedPath string, extra string) (*models.ApiKey, errors.Error) {
-	return c.Create(tx, user, name, nil, allowedPath, fmt.Sprintf("plugin:%s", pluginName), extra)
+// CreateForPlugin creates the api key used by the plugin, it is bound to the workspace of the caller with the
+// role the plugin needs so it can't reach beyond what the caller could
+func (c *ApiKeyHelper) CreateForPlugin(tx dal.Transaction, user *common.User, name string, pluginName string, allowedPath string, extra string, workspace string, role string) (*models.ApiKey, errors.Error) {
+	return c.Create(tx, user, name, nil, allowedPath, fmt.Sprintf("plugin:%s", pluginName), extra, workspace, role)
 }
 
 func (c *ApiKeyHelper) Put(user *common.User, id uint64) (*models.ApiKey, errors.Error) {
@@ -225,6 +225,16 @@ func (t *DataFlowTester) Subtask(subtaskMeta plugin.SubTaskMeta, taskData interf
 	}
 }
 
+// IncrementalSubtask executes specified subtasks without full sync, so collectors would pick up from where the
+// previous run stopped
+func (t *DataFlowTester) IncrementalSubtask(subtaskMeta plugin.SubTaskMeta, taskData interface{}) {
+	subtaskCtx := t.subtaskContext(taskData, &models.SyncPolicy{})
+	err := subtaskMeta.EntryPoint(subtaskCtx)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // SubtaskContext creates a subtask context
 func (t *DataFlowTester) SubtaskContext(taskData interface{}) plugin.SubTaskContext {
 	syncPolicy := &models.SyncPolicy{
@@ -232,9 +242,56 @@ func (t *DataFlowTester) SubtaskContext(taskData interface{}) plugin.SubTaskCont
 			FullSync: true,
 		},
 	}
+	return t.subtaskContext(taskData, syncPolicy)
+}
+
+func (t *DataFlowTester) subtaskContext(taskData interface{}, syncPolicy *models.SyncPolicy) plugin.SubTaskContext {
 	return contextimpl.NewStandaloneSubTaskContext(context.Background(), runner.CreateBasicRes(t.Cfg, t.Log, t.Db), t.Name, taskData, t.Name, syncPolicy)
 }
 
+// NewCassetteApiClient creates an ApiAsyncClient replaying the interactions recorded in the cassette file, so
+// collectors can be tested end-to-end without network access. Set `E2E_CASSETTE_MODE=record` to (re)record the
+// cassette against the real endpoint of the connection, the cassette would be saved when the test finishes.
+// Replayed tests fail if any recorded interaction is left unplayed. The volatileQueryParams vary between runs,
+// e.g. the `since` of incremental collections, so they are matched by name only.
+func (t *DataFlowTester) NewCassetteApiClient(cassetteRelPath string, connection plugin.ApiConnection, volatileQueryParams ...string) *api.ApiAsyncClient {
+	mode := t.Cfg.GetString(`E2E_CASSETTE_MODE`)
+	if mode == `` {
+		mode = api.CASSETTE_REPLAY
+	}
+	cassette, err := api.NewCassette(cassetteRelPath, mode)
+	if err != nil {
+		panic(err)
+	}
+	cassette.AddVolatileQueryParams(volatileQueryParams...)
+	taskCtx := t.SubtaskContext(nil).TaskContext()
+	var apiClient *api.ApiClient
+	var rateLimiter *api.ApiRateLimitCalculator
+	if mode == api.CASSETTE_RECORD {
+		apiClient, err = api.NewApiClientFromConnection(context.Background(), taskCtx, connection)
+		if err != nil {
+			panic(err)
+		}
+		t.T.Cleanup(func() {
+			errors.Must(cassette.Save())
+		})
+	} else {
+		// neither connectivity check nor authentication is needed for replaying
+		apiClient = &api.ApiClient{}
+		apiClient.Setup(connection.GetEndpoint(), nil, 0)
+		rateLimiter = &api.ApiRateLimitCalculator{UserRateLimitPerHour: 360000}
+		t.T.Cleanup(func() {
+			assert.Empty(t.T, cassette.Unplayed(), "interactions recorded in %s were not replayed", cassetteRelPath)
+		})
+	}
+	apiClient.UseCassette(cassette)
+	asyncClient, err := api.CreateAsyncApiClient(taskCtx, apiClient, rateLimiter)
+	if err != nil {
+		panic(err)
+	}
+	return asyncClient
+}
+
 func filterColumn(column dal.ColumnMeta, opts TableOptions) bool {
 	for _, ignore := range opts.IgnoreFields {
 		if column.Name() == ignore {
@@ -112,6 +112,22 @@ func CreateAsyncApiClient(
 		duration.String(),
 		tickInterval.String(),
 	)
+
+	// the adaptive rate limiter is shared by all tasks calling the same connection and keeps adjusting the pace
+	// according to the rate limit headers, the scheduler only needs to make sure it never exceeds the user setting
+	if taskCtx.GetConfig("API_ADAPTIVE_RATE_LIMIT") != "false" {
+		// the pace must stay within both the user setting and the API_REQUESTS_PER_HOUR cap
+		minInterval := time.Duration(0)
+		for _, rateLimitPerHour := range []int{rateLimiter.UserRateLimitPerHour, globalRateLimitPerHour} {
+			if rateLimitPerHour > 0 && time.Hour/time.Duration(rateLimitPerHour) > minInterval {
+				minInterval = time.Hour / time.Duration(rateLimitPerHour)
+			}
+		}
+		adaptiveRateLimiter := GetAdaptiveRateLimiter(apiClient.GetRateLimitKey(), tickInterval, minInterval)
+		apiClient.SetRateLimiter(adaptiveRateLimiter)
+		tickInterval = adaptiveRateLimiter.minInterval
+		logger.Info("adaptive rate limit enabled for api \"%s\", current interval: %s", apiClient.GetEndpoint(), adaptiveRateLimiter.GetInterval())
+	}
 	scheduler, err := NewWorkerScheduler(
 		taskCtx.GetContext(),
 		numOfWorkers,
@@ -20,7 +20,6 @@ package api
 import (
 	"bytes"
 	gocontext "context"
-	"crypto/tls"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -38,9 +37,13 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/helpers/secrethelper"
+	"github.com/apache/incubator-devlake/helpers/workspacehelper"
+	"gorm.io/gorm/schema"
 )
 
 // ErrIgnoreAndContinue is a error which should be ignored
@@ -67,6 +70,9 @@ type ApiClient struct {
 	afterResponse plugin.ApiClientAfterResponse
 	ctx           gocontext.Context
 	logger        log.Logger
+	rateLimitKey  string
+	rateLimiter   *AdaptiveRateLimiter
+	oauth2        *OAuth2
 }
 
 // NewApiClientFromConnection creates ApiClient based on given connection.
@@ -78,10 +84,20 @@ func NewApiClientFromConnection(
 	if reflect.ValueOf(connection).Kind() != reflect.Ptr {
 		panic(fmt.Errorf("connection is not a pointer"))
 	}
-	apiClient, err := NewApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), br)
+	// replace the references to external secrets with the secrets themselves, in a copy of the connection
+	if secrethelper.HasReferences(connection) {
+		var err errors.Error
+		connection, err = resolveTaskConnectionSecrets(ctx, br, connection)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tlsConnection, _ := connection.(plugin.TlsConnection)
+	apiClient, err := newApiClient(ctx, connection.GetEndpoint(), nil, 0, connection.GetProxy(), tlsConnection, br)
 	if err != nil {
 		return nil, err
 	}
+	apiClient.rateLimitKey = RateLimitKey(connection)
 
 	// if connection needs to prepare the ApiClient, i.e. fetch token for future requests
 	if prepareApiClient, ok := connection.(plugin.PrepareApiClient); ok {
@@ -91,6 +107,12 @@ func NewApiClientFromConnection(
 		}
 	}
 
+	// share the OAuth2 access token among clients of the same connection and renew it on 401
+	if oauth2, ok := getOAuth2(connection); ok {
+		oauth2.bind(apiClient.rateLimitKey, apiClient.client, refreshTokenPersister(br, connection, oauth2))
+		apiClient.oauth2 = oauth2
+	}
+
 	// if connection requires authorization
 	if authenticator, ok := connection.(plugin.ApiAuthenticator); ok {
 		apiClient.SetAuthFunction(func(req *http.Request) errors.Error {
@@ -101,6 +123,177 @@ func NewApiClientFromConnection(
 	return apiClient, nil
 }
 
+// resolveTaskConnectionSecrets resolves the secret references of the connection a task runs with. References of
+// connections given by api callers are never resolved since the secrets would be sent to whatever endpoint the
+// callers specify, see DsConnectionApiHelper.GetMergedConnection for testing the saved connections
+func resolveTaskConnectionSecrets(ctx gocontext.Context, br context.BasicRes, connection plugin.ApiConnection) (plugin.ApiConnection, errors.Error) {
+	var taskCtx plugin.TaskContext
+	switch c := br.(type) {
+	case plugin.TaskContext:
+		taskCtx = c
+	case plugin.SubTaskContext:
+		taskCtx = c.TaskContext()
+	}
+	var connectionId uint64
+	if hasField(connection, "ID") {
+		if id := reflectField(connection, "ID"); id.CanUint() {
+			connectionId = id.Uint()
+		}
+	}
+	if taskCtx == nil || connectionId == 0 {
+		return nil, errors.BadInput.New("secret references are only resolved for the saved connections")
+	}
+	return resolveConnectionSecrets(ctx, br, connectionPluginName(connection, taskCtx.GetName()), connectionId, connection)
+}
+
+// resolveConnectionSecrets returns a copy of the connection with the secret references resolved, limited to the
+// prefixes configured for the workspace the connection belongs to
+func resolveConnectionSecrets[T any](ctx gocontext.Context, br context.BasicRes, pluginName string, connectionId uint64, connection T) (T, errors.Error) {
+	var resolved T
+	workspace, err := workspacehelper.NewWorkspaceHelper(br).GetConnectionWorkspace(pluginName, connectionId)
+	if err != nil {
+		return resolved, err
+	}
+	obj, err := secrethelper.NewResolver(br.GetConfigReader(), workspace).ResolveStruct(ctx, connection)
+	if err != nil {
+		return resolved, err
+	}
+	return obj.(T), nil
+}
+
+// ResolveConnectionSecrets returns a copy of the connection loaded from the database with the secret references
+// resolved, for the clients which are not created by NewApiClientFromConnection, i.e. the graphql clients
+func ResolveConnectionSecrets[T dal.Tabler](ctx gocontext.Context, br context.BasicRes, connectionId uint64, connection T) (T, errors.Error) {
+	if !secrethelper.HasReferences(connection) {
+		return connection, nil
+	}
+	return resolveConnectionSecrets(ctx, br, connectionPluginName(connection, ""), connectionId, connection)
+}
+
+// ResolveConnectionReference resolves the reference only if it is stored in the connection loaded from the
+// database, so references made up by the callers, i.e. in the pipeline plans, would never be resolved
+func ResolveConnectionReference(ctx gocontext.Context, br context.BasicRes, connectionId uint64, connection dal.Tabler, reference string) (string, errors.Error) {
+	resolved, err := ResolveConnectionSecrets(ctx, br, connectionId, connection)
+	if err != nil {
+		return "", err
+	}
+	secret, found := findResolvedSecret(reflect.ValueOf(connection), reflect.ValueOf(resolved), reference)
+	if !found {
+		return "", errors.Forbidden.New(fmt.Sprintf("secret reference %s is not stored in the connection", reference))
+	}
+	return secret, nil
+}
+
+// findResolvedSecret returns the field of resolved which holds the reference in original
+func findResolvedSecret(original reflect.Value, resolved reflect.Value, reference string) (string, bool) {
+	switch original.Kind() {
+	case reflect.Ptr:
+		if original.IsNil() || resolved.IsNil() {
+			return "", false
+		}
+		return findResolvedSecret(original.Elem(), resolved.Elem(), reference)
+	case reflect.Struct:
+		for i := 0; i < original.NumField(); i++ {
+			if field := original.Type().Field(i); !field.IsExported() && !field.Anonymous {
+				continue
+			}
+			if secret, found := findResolvedSecret(original.Field(i), resolved.Field(i), reference); found {
+				return secret, true
+			}
+		}
+	case reflect.String:
+		if original.String() == reference {
+			return resolved.String(), true
+		}
+	}
+	return "", false
+}
+
+var connectionTablePattern = regexp.MustCompile(`^_tool_(.+)_connections$`)
+
+// connectionPluginName returns the plugin owning the connection table, which is not always the one running the
+// task, i.e. github_graphql runs with the github connections
+func connectionPluginName(connection interface{}, fallback string) string {
+	if tabler, ok := connection.(dal.Tabler); ok {
+		if matches := connectionTablePattern.FindStringSubmatch(tabler.TableName()); matches != nil {
+			return matches[1]
+		}
+	}
+	return fallback
+}
+
+// getOAuth2 returns the OAuth2 authenticator of the connection if it is the one in use
+func getOAuth2(connection plugin.ApiConnection) (*OAuth2, bool) {
+	authenticator, ok := connection.(plugin.OAuth2Authenticator)
+	if !ok {
+		return nil, false
+	}
+	if multiAuth, ok := connection.(plugin.MultiAuthenticator); ok && multiAuth.GetAuthMethod() != plugin.AUTH_METHOD_OAUTH2 {
+		return nil, false
+	}
+	oauth2, ok := authenticator.GetOAuth2Authenticator().(*OAuth2)
+	return oauth2, ok
+}
+
+// refreshTokenPersister returns a function to save the rotated refresh token to the connection record,
+// nothing would be saved for connections not stored yet, i.e. testing connection before creating it
+func refreshTokenPersister(br context.BasicRes, connection plugin.ApiConnection, oauth2 *OAuth2) func(string) errors.Error {
+	return func(refreshToken string) errors.Error {
+		if _, ok := connection.(dal.Tabler); !ok {
+			return nil
+		}
+		idField := reflect.ValueOf(connection).Elem().FieldByName("ID")
+		if !idField.IsValid() || idField.IsZero() {
+			return nil
+		}
+		column, err := refreshTokenColumn(connection, oauth2)
+		if err != nil {
+			return err
+		}
+		encrypted, err := plugin.Encrypt(br.GetConfig(plugin.EncodeKeyEnvStr), refreshToken)
+		if err != nil {
+			return err
+		}
+		return br.GetDal().UpdateColumn(connection, column, encrypted, dal.Where("id = ?", idField.Interface()))
+	}
+}
+
+// refreshTokenColumn looks up the column of the RefreshToken of the OAuth2 by the gorm tags of the connection,
+// so the OAuth2 could be embedded with a prefix or have the column renamed
+func refreshTokenColumn(connection plugin.ApiConnection, oauth2 *OAuth2) (string, errors.Error) {
+	column, found := findFieldColumn(reflect.ValueOf(connection).Elem(), reflect.ValueOf(&oauth2.RefreshToken).Pointer(), "")
+	if !found {
+		return "", errors.Default.New("the refresh token of the connection is not stored in any column")
+	}
+	return column, nil
+}
+
+func findFieldColumn(value reflect.Value, target uintptr, prefix string) (string, bool) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		gormTag := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")
+		if gormTag["-"] != "" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if (field.Anonymous || gormTag["EMBEDDED"] != "") && field.Type.Kind() == reflect.Struct {
+			if column, found := findFieldColumn(fieldValue, target, prefix+gormTag["EMBEDDEDPREFIX"]); found {
+				return column, true
+			}
+			continue
+		}
+		if fieldValue.Addr().Pointer() != target {
+			continue
+		}
+		column := gormTag["COLUMN"]
+		if column == "" {
+			column = schema.NamingStrategy{}.ColumnName("", field.Name)
+		}
+		return prefix + column, true
+	}
+	return "", false
+}
+
 // NewApiClient creates a new synchronize ApiClient
 func NewApiClient(
 	ctx gocontext.Context,
@@ -109,6 +302,18 @@ func NewApiClient(
 	timeout time.Duration,
 	proxy string,
 	br context.BasicRes,
+) (*ApiClient, errors.Error) {
+	return newApiClient(ctx, endpoint, headers, timeout, proxy, nil, br)
+}
+
+func newApiClient(
+	ctx gocontext.Context,
+	endpoint string,
+	headers map[string]string,
+	timeout time.Duration,
+	proxy string,
+	tlsConnection plugin.TlsConnection,
+	br context.BasicRes,
 ) (*ApiClient, errors.Error) {
 	cfg := br.GetConfigReader()
 	log := br.GetLogger()
@@ -131,10 +336,14 @@ func NewApiClient(
 	// create the Transport
 	apiClient.client.Transport = &http.Transport{}
 
-	// set insecureSkipVerify
+	// set insecureSkipVerify, custom CA and client certificate
 	insecureSkipVerify := cfg.GetBool("IN_SECURE_SKIP_VERIFY")
-	if insecureSkipVerify {
-		apiClient.client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	tlsConfig, err := NewTlsConfig(insecureSkipVerify, tlsConnection)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		apiClient.client.Transport.(*http.Transport).TLSClientConfig = tlsConfig
 	}
 
 	if proxy != "" {
@@ -299,6 +508,24 @@ func (apiClient *ApiClient) SetLogger(logger log.Logger) {
 	apiClient.logger = logger
 }
 
+// GetRateLimitKey returns the key for sharing the AdaptiveRateLimiter among tasks calling the same connection
+func (apiClient *ApiClient) GetRateLimitKey() string {
+	if apiClient.rateLimitKey == "" {
+		return apiClient.endpoint
+	}
+	return apiClient.rateLimitKey
+}
+
+// SetRateLimiter paces all requests sent by the client with the AdaptiveRateLimiter
+func (apiClient *ApiClient) SetRateLimiter(rateLimiter *AdaptiveRateLimiter) {
+	apiClient.rateLimiter = rateLimiter
+}
+
+// GetRateLimiter returns the AdaptiveRateLimiter of the client, nil if none was set
+func (apiClient *ApiClient) GetRateLimiter() *AdaptiveRateLimiter {
+	return apiClient.rateLimiter
+}
+
 // GetClient returns the underlying http.Client
 func (apiClient *ApiClient) GetClient() *http.Client {
 	return apiClient.client
@@ -323,6 +550,17 @@ func (apiClient *ApiClient) Do(
 	query url.Values,
 	body interface{},
 	headers http.Header,
+) (*http.Response, errors.Error) {
+	return apiClient.do(method, path, query, body, headers, false)
+}
+
+func (apiClient *ApiClient) do(
+	method string,
+	path string,
+	query url.Values,
+	body interface{},
+	headers http.Header,
+	retried bool,
 ) (*http.Response, errors.Error) {
 	uri, err := GetURIStringPointer(apiClient.endpoint, path, query)
 	if err != nil {
@@ -377,12 +615,27 @@ func (apiClient *ApiClient) Do(
 			return nil, err
 		}
 	}
+	if apiClient.rateLimiter != nil {
+		err = apiClient.rateLimiter.Wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
 	apiClient.logDebug("[api-client] %v %v", method, *uri)
 	res, err = errors.Convert01(apiClient.client.Do(req))
 	if err != nil {
 		apiClient.logError(err, "[api-client] failed to request %s with error", req.URL.String())
 		return nil, err
 	}
+	if apiClient.rateLimiter != nil {
+		apiClient.rateLimiter.Update(res)
+	}
+	// the OAuth2 access token might be revoked before it expires, renew it and retry once
+	if res.StatusCode == http.StatusUnauthorized && apiClient.oauth2 != nil && !retried {
+		res.Body.Close()
+		apiClient.oauth2.InvalidateAccessToken(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+		return apiClient.do(method, path, query, body, headers, true)
+	}
 	// after receive
 	if apiClient.afterResponse != nil {
 		err = apiClient.afterResponse(res)
@@ -0,0 +1,299 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	CASSETTE_RECORD = "record"
+	CASSETTE_REPLAY = "replay"
+
+	scrubbed = "***"
+)
+
+// query parameters and headers carrying credentials, they are never written into cassettes
+var sensitiveQueryParams = []string{"token", "access_token", "private_token", "api_key", "apikey", "key", "password", "client_secret", "sig", "signature"}
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "Private-Token", "X-Api-Key"}
+var defaultBodyScrubPattern = regexp.MustCompile(`"(?:access_token|refresh_token|id_token|token|private_token|password|secret|client_secret)"\s*:\s*"([^"]*)"`)
+
+// CassetteRequest is the recorded request, credentials are scrubbed
+type CassetteRequest struct {
+	Method string `json:"method"`
+	Url    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// CassetteResponse is the recorded response, Body is base64 encoded if Base64 is true
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+	Base64     bool        `json:"base64,omitempty"`
+}
+
+// CassetteInteraction is a request and the response received for it
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// Cassette records the responses received by the ApiClient into a file, and replays them in the same order later
+// on, so collectors can be tested deterministically without network access
+type Cassette struct {
+	Interactions []*CassetteInteraction `json:"interactions"`
+
+	path          string
+	mode          string
+	transport     http.RoundTripper
+	scrubPatterns []*regexp.Regexp
+	// volatileQueryParams are matched by name only since their values vary between runs
+	volatileQueryParams []string
+	played              []bool
+	mu                  sync.Mutex
+}
+
+// NewCassette creates a cassette backed by the file, the file is loaded in CASSETTE_REPLAY mode
+func NewCassette(path string, mode string) (*Cassette, errors.Error) {
+	cassette := &Cassette{
+		path:          path,
+		mode:          mode,
+		scrubPatterns: []*regexp.Regexp{defaultBodyScrubPattern},
+	}
+	switch mode {
+	case CASSETTE_RECORD:
+	case CASSETTE_REPLAY:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to read cassette %s", path))
+		}
+		if err := json.Unmarshal(data, cassette); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to parse cassette %s", path))
+		}
+		cassette.played = make([]bool, len(cassette.Interactions))
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unknown cassette mode %s", mode))
+	}
+	return cassette, nil
+}
+
+// AddScrubPattern makes sure the matched content of the bodies would never be written into the cassette,
+// a pattern with a capturing group only replaces the first group
+func (c *Cassette) AddScrubPattern(pattern *regexp.Regexp) {
+	c.scrubPatterns = append(c.scrubPatterns, pattern)
+}
+
+// AddVolatileQueryParams makes the query parameters whose values vary between runs, like the `since` of the
+// incremental collections, match by name only
+func (c *Cassette) AddVolatileQueryParams(names ...string) {
+	c.volatileQueryParams = append(c.volatileQueryParams, names...)
+}
+
+// UseCassette sends all requests of the ApiClient through the cassette
+func (apiClient *ApiClient) UseCassette(cassette *Cassette) {
+	cassette.transport = apiClient.client.Transport
+	if cassette.transport == nil {
+		cassette.transport = http.DefaultTransport
+	}
+	apiClient.client.Transport = cassette
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recordedReq := CassetteRequest{
+		Method: req.Method,
+		Url:    c.scrubUrl(req.URL),
+		Body:   c.scrubBody(reqBody),
+	}
+	if c.mode == CASSETTE_REPLAY {
+		return c.replay(req, recordedReq)
+	}
+	res, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	recordedRes := CassetteResponse{
+		StatusCode: res.StatusCode,
+		Header:     scrubHeader(res.Header),
+	}
+	if utf8.Valid(resBody) {
+		recordedRes.Body = c.scrubBody(string(resBody))
+	} else {
+		recordedRes.Body = base64.StdEncoding.EncodeToString(resBody)
+		recordedRes.Base64 = true
+	}
+	c.mu.Lock()
+	c.Interactions = append(c.Interactions, &CassetteInteraction{Request: recordedReq, Response: recordedRes})
+	c.mu.Unlock()
+	return res, nil
+}
+
+// replay responds with the first unplayed interaction matching the request
+func (c *Cassette) replay(req *http.Request, recordedReq CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.Interactions {
+		if c.played[i] || interaction.Request != recordedReq {
+			continue
+		}
+		c.played[i] = true
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.Base64 {
+			decoded, err := base64.StdEncoding.DecodeString(interaction.Response.Body)
+			if err != nil {
+				return nil, err
+			}
+			body = decoded
+		}
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, errors.NotFound.New(fmt.Sprintf("no interaction recorded in %s for %s %s", c.path, recordedReq.Method, recordedReq.Url))
+}
+
+// Unplayed returns the interactions which have not been replayed yet
+func (c *Cassette) Unplayed() []*CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var unplayed []*CassetteInteraction
+	for i, interaction := range c.Interactions {
+		if !c.played[i] {
+			unplayed = append(unplayed, interaction)
+		}
+	}
+	return unplayed
+}
+
+// Save writes the recorded interactions into the cassette file
+func (c *Cassette) Save() errors.Error {
+	if c.mode != CASSETTE_RECORD {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to serialize cassette")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to create directory for cassette %s", c.path))
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to write cassette %s", c.path))
+	}
+	return nil
+}
+
+func (c *Cassette) scrubBody(body string) string {
+	for _, pattern := range c.scrubPatterns {
+		var sb strings.Builder
+		last := 0
+		for _, loc := range pattern.FindAllStringSubmatchIndex(body, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			sb.WriteString(body[last:start])
+			sb.WriteString(scrubbed)
+			last = end
+		}
+		sb.WriteString(body[last:])
+		body = sb.String()
+	}
+	return body
+}
+
+func (c *Cassette) scrubUrl(u *url.URL) string {
+	scrubbedUrl := *u
+	scrubbedUrl.User = nil
+	query := scrubbedUrl.Query()
+	for name := range query {
+		for _, sensitive := range sensitiveQueryParams {
+			if strings.EqualFold(name, sensitive) {
+				query.Set(name, scrubbed)
+			}
+		}
+		for _, volatile := range c.volatileQueryParams {
+			if name == volatile {
+				query.Set(name, scrubbed)
+			}
+		}
+	}
+	// Encode sorts the parameters so the order they were added doesn't matter
+	scrubbedUrl.RawQuery = query.Encode()
+	return scrubbedUrl.String()
+}
+
+func scrubHeader(header http.Header) http.Header {
+	scrubbedHeader := header.Clone()
+	for _, name := range sensitiveHeaders {
+		scrubbedHeader.Del(name)
+	}
+	return scrubbedHeader
+}
+
+func readAndRestoreRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return string(body), nil
+}
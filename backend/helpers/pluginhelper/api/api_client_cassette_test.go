@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Total-Pages", "2")
+		_, _ = fmt.Fprintf(w, `{"page":"%s","token": "ghs_secret","email":"alice@example.com"}`, r.URL.Query().Get("page"))
+	}))
+	defer server.Close()
+	cassettePath := filepath.Join(t.TempDir(), "cassettes", "pages.json")
+
+	// record
+	cassette, err := NewCassette(cassettePath, CASSETTE_RECORD)
+	assert.Nil(t, err)
+	cassette.AddScrubPattern(regexp.MustCompile(`"email":"([^"]*)"`))
+	apiClient := &ApiClient{}
+	apiClient.Setup(server.URL, map[string]string{"Authorization": "Bearer secret"}, 0)
+	apiClient.UseCassette(cassette)
+	for _, page := range []string{"1", "2"} {
+		res, err := apiClient.Get("repos", map[string][]string{"page": {page}, "access_token": {"secret"}}, nil)
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(res.Body)
+		assert.Contains(t, string(body), "ghs_secret")
+	}
+	assert.Nil(t, cassette.Save())
+	data, e := os.ReadFile(cassettePath)
+	assert.Nil(t, e)
+	assert.False(t, strings.Contains(string(data), "secret"))
+	assert.False(t, strings.Contains(string(data), "alice@example.com"))
+
+	// replay without the server
+	server.Close()
+	cassette, err = NewCassette(cassettePath, CASSETTE_REPLAY)
+	assert.Nil(t, err)
+	apiClient = &ApiClient{}
+	apiClient.Setup(server.URL, nil, 0)
+	apiClient.UseCassette(cassette)
+	for _, page := range []string{"2", "1"} {
+		res, err := apiClient.Get("repos", map[string][]string{"access_token": {"another"}, "page": {page}}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get("X-Total-Pages"))
+		assert.Empty(t, res.Header.Get("Set-Cookie"))
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, fmt.Sprintf(`{"page":"%s","token": "***","email":"***"}`, page), string(body))
+	}
+	assert.Empty(t, cassette.Unplayed())
+
+	// each interaction is replayed once
+	_, err = apiClient.Get("repos", map[string][]string{"page": {"1"}}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestCassetteVolatileQueryParams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[]`)
+	}))
+	defer server.Close()
+	cassettePath := filepath.Join(t.TempDir(), "issues.json")
+
+	cassette, err := NewCassette(cassettePath, CASSETTE_RECORD)
+	assert.Nil(t, err)
+	cassette.AddVolatileQueryParams("since")
+	apiClient := &ApiClient{}
+	apiClient.Setup(server.URL, nil, 0)
+	apiClient.UseCassette(cassette)
+	_, err = apiClient.Get("issues", map[string][]string{"since": {"2026-10-19T00:00:00Z"}}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, cassette.Save())
+
+	cassette, err = NewCassette(cassettePath, CASSETTE_REPLAY)
+	assert.Nil(t, err)
+	cassette.AddVolatileQueryParams("since")
+	apiClient = &ApiClient{}
+	apiClient.Setup(server.URL, nil, 0)
+	apiClient.UseCassette(cassette)
+	// the value may differ but the parameter must be present
+	_, err = apiClient.Get("issues", nil, nil)
+	assert.NotNil(t, err)
+	res, err := apiClient.Get("issues", map[string][]string{"since": {"2026-10-20T00:00:00Z"}}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Empty(t, cassette.Unplayed())
+}
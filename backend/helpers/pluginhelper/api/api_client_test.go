@@ -18,10 +18,17 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/config"
+	corecontext "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,3 +102,132 @@ func TestApiClientBlackList(t *testing.T) {
 		})
 	}
 }
+
+type testSecretConnection struct {
+	BaseConnection
+	RestConnection
+	AccessToken
+}
+
+// secretTaskContext runs the task of the plugin with the connections bound to the workspaces in memory
+type secretTaskContext struct {
+	plugin.TaskContext
+	dal.Dal
+	config      *viper.Viper
+	connections []*models.WorkspaceConnection
+}
+
+func (c *secretTaskContext) GetName() string {
+	return "github"
+}
+
+func (c *secretTaskContext) GetConfigReader() config.ConfigReader {
+	return c.config
+}
+
+func (c *secretTaskContext) GetDal() dal.Dal {
+	return c
+}
+
+func (c *secretTaskContext) First(dst interface{}, clauses ...dal.Clause) errors.Error {
+	params := clauses[0].Data.(dal.DalClause).Params
+	for _, connection := range c.connections {
+		if connection.Plugin == params[0] && connection.ConnectionId == params[1] {
+			*dst.(*models.WorkspaceConnection) = *connection
+			return nil
+		}
+	}
+	return errors.NotFound.New("record not found")
+}
+
+func (c *secretTaskContext) IsErrorNotFound(err error) bool {
+	lakeErr := errors.AsLakeErrorType(err)
+	return lakeErr != nil && lakeErr.GetType() == errors.NotFound
+}
+
+type secretBasicRes struct {
+	corecontext.BasicRes
+}
+
+func TestResolveTaskConnectionSecrets(t *testing.T) {
+	t.Setenv("DEVLAKE_SECRET_TEAM_A_TOKEN", "team_a_token")
+	config := viper.New()
+	config.Set("SECRET_REF_ENV_PREFIX", "DEVLAKE_SECRET_")
+	config.Set("SECRET_REF_WORKSPACE_PREFIXES", "team-a:env:DEVLAKE_SECRET_TEAM_A_")
+	taskCtx := &secretTaskContext{
+		config:      config,
+		connections: []*models.WorkspaceConnection{{Plugin: "github", ConnectionId: 1, Workspace: "team-a"}, {Plugin: "github", ConnectionId: 2, Workspace: "team-b"}},
+	}
+	connection := &testSecretConnection{}
+	connection.ID = 1
+	connection.Token = "env:DEVLAKE_SECRET_TEAM_A_TOKEN"
+
+	resolved, err := resolveTaskConnectionSecrets(context.Background(), taskCtx, connection)
+	assert.Nil(t, err)
+	assert.Equal(t, "team_a_token", resolved.(*testSecretConnection).Token)
+	// the connection keeps the reference in case it gets saved
+	assert.Equal(t, "env:DEVLAKE_SECRET_TEAM_A_TOKEN", connection.Token)
+
+	// the secret is out of the prefixes of the workspace of the connection
+	connection.ID = 2
+	_, err = resolveTaskConnectionSecrets(context.Background(), taskCtx, connection)
+	assert.NotNil(t, err)
+
+	// connections given by api callers, i.e. testing a connection before saving it
+	_, err = resolveTaskConnectionSecrets(context.Background(), &secretBasicRes{}, connection)
+	assert.NotNil(t, err)
+	connection.ID = 0
+	_, err = resolveTaskConnectionSecrets(context.Background(), taskCtx, connection)
+	assert.NotNil(t, err)
+}
+
+type testSecretGithubConnection struct {
+	testSecretConnection
+	Password string `gorm:"serializer:encdec"`
+}
+
+func (testSecretGithubConnection) TableName() string {
+	return "_tool_github_connections"
+}
+
+// graphqlTaskContext runs the task of a plugin other than the one owning the connection
+type graphqlTaskContext struct {
+	*secretTaskContext
+}
+
+func (c *graphqlTaskContext) GetName() string {
+	return "github_graphql"
+}
+
+func TestResolveConnectionReference(t *testing.T) {
+	t.Setenv("DEVLAKE_SECRET_TEAM_A_TOKEN", "team_a_token")
+	t.Setenv("DEVLAKE_SECRET_TEAM_A_OTHER", "team_a_other")
+	config := viper.New()
+	config.Set("SECRET_REF_ENV_PREFIX", "DEVLAKE_SECRET_")
+	config.Set("SECRET_REF_WORKSPACE_PREFIXES", "team-a:env:DEVLAKE_SECRET_TEAM_A_")
+	taskCtx := &graphqlTaskContext{&secretTaskContext{
+		config:      config,
+		connections: []*models.WorkspaceConnection{{Plugin: "github", ConnectionId: 1, Workspace: "team-a"}},
+	}}
+	connection := &testSecretGithubConnection{}
+	connection.ID = 1
+	connection.Token = "env:DEVLAKE_SECRET_TEAM_A_TOKEN"
+
+	// the workspace is looked up by the plugin owning the connection rather than the one running the task
+	resolved, err := ResolveConnectionSecrets(context.Background(), taskCtx, 1, connection)
+	assert.Nil(t, err)
+	assert.Equal(t, "team_a_token", resolved.Token)
+	assert.Equal(t, "env:DEVLAKE_SECRET_TEAM_A_TOKEN", connection.Token)
+	taskCtx.connections[0].Workspace = "team-b"
+	_, err = ResolveConnectionSecrets(context.Background(), taskCtx, 1, connection)
+	assert.NotNil(t, err)
+	taskCtx.connections[0].Workspace = "team-a"
+
+	secret, err := ResolveConnectionReference(context.Background(), taskCtx, 1, connection, "env:DEVLAKE_SECRET_TEAM_A_TOKEN")
+	assert.Nil(t, err)
+	assert.Equal(t, "team_a_token", secret)
+
+	// references which are not stored in the connection are never resolved
+	_, err = ResolveConnectionReference(context.Background(), taskCtx, 1, connection, "env:DEVLAKE_SECRET_TEAM_A_OTHER")
+	assert.NotNil(t, err)
+}
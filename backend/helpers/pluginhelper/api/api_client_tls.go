@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// NewTlsConfig creates the tls.Config for the connection with custom CA bundle and client certificate,
+// nil is returned if the default config would do
+func NewTlsConfig(insecureSkipVerify bool, connection plugin.TlsConnection) (*tls.Config, errors.Error) {
+	var caCert, clientCert, clientKey string
+	if connection != nil {
+		caCert = strings.TrimSpace(connection.GetCaCert())
+		clientCert = strings.TrimSpace(connection.GetClientCert())
+		clientKey = strings.TrimSpace(connection.GetClientKey())
+	}
+	if !insecureSkipVerify && caCert == "" && clientCert == "" && clientKey == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if caCert != "" {
+		// keep trusting the system CAs, the custom bundle is usually just the internal root
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.BadInput.New("invalid CA certificate, it must be PEM encoded")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, errors.BadInput.New("client certificate and client key must be provided together")
+		}
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid client certificate or key")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTlsConnection struct {
+	RestConnection
+}
+
+func TestNewTlsConfigDefault(t *testing.T) {
+	tlsConfig, err := NewTlsConfig(false, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, tlsConfig)
+
+	tlsConfig, err = NewTlsConfig(true, &testTlsConnection{})
+	assert.Nil(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+}
+
+func TestNewTlsConfigInvalid(t *testing.T) {
+	_, err := NewTlsConfig(false, &testTlsConnection{RestConnection{CaCert: "not a certificate"}})
+	assert.NotNil(t, err)
+
+	_, err = NewTlsConfig(false, &testTlsConnection{RestConnection{ClientCert: "cert only"}})
+	assert.NotNil(t, err)
+}
+
+func TestNewTlsConfigMutualTls(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// the test server's certificate is self-signed, which serves as the CA bundle and the client certificate
+	serverCert := server.TLS.Certificates[0]
+	certPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Certificate[0]}))
+	keyDer, e := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	assert.Nil(t, e)
+	keyPem := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+
+	// unknown CA is rejected
+	client := &http.Client{Transport: &http.Transport{}}
+	_, e = client.Get(server.URL)
+	assert.NotNil(t, e)
+
+	tlsConfig, err := NewTlsConfig(false, &testTlsConnection{RestConnection{
+		CaCert:     certPem,
+		ClientCert: certPem,
+		ClientKey:  keyPem,
+	}})
+	assert.Nil(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+	client = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	res, e := client.Get(server.URL)
+	assert.Nil(t, e)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res.Body.Close()
+}
@@ -32,6 +32,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 )
 
 var _ plugin.SubTask = (*ApiCollector)(nil)
@@ -51,6 +52,8 @@ type RequestData struct {
 	InputJSON []byte
 	// equal to the return value from GetNextPageCustomData when PageSize>0 and not the first request
 	CustomData interface{}
+	// tracks the pending requests of the input for the collector checkpoint
+	checkpoint *checkpointInput
 }
 
 // AsyncResponseHandler FIXME ...
@@ -91,6 +94,14 @@ type ApiCollectorArgs struct {
 	AfterResponse  plugin.ApiClientAfterResponse
 	RequestBody    func(reqData *RequestData) map[string]interface{}
 	Method         string
+	// ConditionalRequest sends GET requests with the ETag / Last-Modified of the previous responses, and reuses the
+	// previous payload when the server responds with 304 Not Modified, which saves the rate limit quota for
+	// servers like GitHub and GitLab. Cached responses expire after API_RESPONSE_CACHE_TTL
+	ConditionalRequest bool
+	// Resumable records the progress of the collection, i.e. the pages or inputs collected, so a rerun of the task
+	// after a crash would resume from where it stopped instead of starting over, which matters for collections
+	// taking hours. Checkpoints expire after COLLECTOR_CHECKPOINT_TTL
+	Resumable bool
 }
 
 // ApiCollector FIXME ...
@@ -98,6 +109,8 @@ type ApiCollector struct {
 	*RawDataSubTask
 	args        *ApiCollectorArgs
 	urlTemplate *template.Template
+	cache       *conditionalRequestCache
+	checkpoint  *collectorCheckpoint
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -128,6 +141,13 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 		args:           &args,
 		urlTemplate:    tpl,
 	}
+	if args.ConditionalRequest && args.Method != http.MethodPost {
+		ttl, parseErr := time.ParseDuration(args.Ctx.GetConfig("API_RESPONSE_CACHE_TTL"))
+		if parseErr != nil {
+			return nil, errors.BadInput.Wrap(parseErr, "invalid API_RESPONSE_CACHE_TTL")
+		}
+		apiCollector.cache = newConditionalRequestCache(args.Ctx.GetDal(), rawDataSubTask.table, rawDataSubTask.params, ttl)
+	}
 	if args.AfterResponse != nil {
 		apiCollector.SetAfterResponse(args.AfterResponse)
 	} else {
@@ -167,8 +187,26 @@ func (collector *ApiCollector) Execute() errors.Error {
 	if syncPolicy != nil && syncPolicy.FullSync {
 		isIncremental = false
 	}
-	// flush data if not incremental collection
-	if !isIncremental {
+	// resume the previous collection if it crashed, collections of a single request are not worth it
+	if collector.args.Resumable && (collector.args.Input != nil || collector.args.PageSize > 0) {
+		ttl, parseErr := time.ParseDuration(collector.args.Ctx.GetConfig("COLLECTOR_CHECKPOINT_TTL"))
+		if parseErr != nil {
+			return errors.BadInput.Wrap(parseErr, "invalid COLLECTOR_CHECKPOINT_TTL")
+		}
+		options := collectorCheckpointOptions(collector.args.Ctx.TaskContext(), collector.args.PageSize)
+		collector.checkpoint, err = loadCollectorCheckpoint(db, logger, collector.table, collector.params, collector.args.UrlTemplate, options, isIncremental, ttl)
+		if err != nil {
+			return err
+		}
+	}
+	if collector.cache != nil {
+		err = collector.cache.purge()
+		if err != nil {
+			return err
+		}
+	}
+	// flush data if not incremental collection, and keep the data collected before the crash when resuming
+	if !isIncremental && (collector.checkpoint == nil || !collector.checkpoint.resumed) {
 		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
 		if err != nil {
 			return errors.Default.Wrap(err, "error deleting data from collector")
@@ -234,6 +272,12 @@ func (collector *ApiCollector) Execute() errors.Error {
 	} else {
 		logger.Info("end api collection without error")
 	}
+	if collector.cache != nil {
+		collector.cache.report(logger)
+	}
+	if err == nil && collector.checkpoint != nil {
+		err = collector.checkpoint.clear(db)
+	}
 
 	return err
 }
@@ -250,6 +294,26 @@ func (collector *ApiCollector) exec(input interface{}) {
 		Page: 1,
 		Size: collector.args.PageSize,
 	}
+	if collector.checkpoint != nil {
+		if collector.args.Input != nil {
+			reqData.checkpoint = collector.checkpoint.startInput(inputJson)
+			// the input was collected completely before the crash
+			if reqData.checkpoint == nil {
+				collector.args.Ctx.IncProgress(1)
+				return
+			}
+			defer collector.releaseCheckpoint(reqData, nil)
+		} else if collector.args.PageSize > 0 {
+			page, cursor := collector.checkpoint.resumePage()
+			if page > 1 && (collector.args.GetNextPageCustomData == nil || cursor != nil) {
+				reqData.Pager.Page = page
+				reqData.Pager.Skip = collector.args.PageSize * (page - 1)
+				if cursor != nil {
+					reqData.CustomData = *cursor
+				}
+			}
+		}
+	}
 	// fetch the detail
 	if collector.args.PageSize <= 0 {
 		collector.fetchAsync(reqData, nil)
@@ -281,15 +345,21 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 					panic(err)
 				}
 			}
+			if collector.checkpoint != nil && collector.args.Input == nil {
+				err = collector.checkpoint.cursorDone(reqData.Pager.Page, customData)
+				if err != nil {
+					return err
+				}
+			}
 			reqData.CustomData = customData
 			reqData.Pager.Skip += collector.args.PageSize
 			reqData.Pager.Page += 1
-			collector.args.ApiClient.NextTick(collect)
+			collector.nextTick(reqData, collect)
 			return nil
 		})
 		return nil
 	}
-	collector.args.ApiClient.NextTick(collect)
+	collector.nextTick(reqData, collect)
 }
 
 // fetchPagesDetermined fetches data of all pages for APIs that return paging information
@@ -307,16 +377,17 @@ func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
 			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
 		}
 		// spawn a none blocking go routine to fetch other pages
-		collector.args.ApiClient.NextTick(func() errors.Error {
-			for page := 2; page <= totalPages; page++ {
+		collector.nextTick(reqData, func() errors.Error {
+			for page := reqData.Pager.Page + 1; page <= totalPages; page++ {
 				reqDataTemp := &RequestData{
 					Pager: &Pager{
 						Page: page,
 						Skip: collector.args.PageSize * (page - 1),
 						Size: collector.args.PageSize,
 					},
-					Input:     reqData.Input,
-					InputJSON: reqData.InputJSON,
+					Input:      reqData.Input,
+					InputJSON:  reqData.InputJSON,
+					checkpoint: reqData.checkpoint,
 				}
 				collector.fetchAsync(reqDataTemp, nil)
 			}
@@ -349,18 +420,22 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData, skip
 			}
 		}
 	}
+	// start from the page of reqData, which is not the first one when resuming
+	firstPage := reqData.Pager.Page
 	for i := 0; i < concurrency; i++ {
 		reqDataCopy := RequestData{
 			Pager: &Pager{
-				Page: i + 1,
+				Page: firstPage + i,
 				Size: collector.args.PageSize,
-				Skip: collector.args.PageSize * (i),
+				Skip: collector.args.PageSize * (firstPage + i - 1),
 			},
-			Input:     reqData.Input,
-			InputJSON: reqData.InputJSON,
+			Input:      reqData.Input,
+			InputJSON:  reqData.InputJSON,
+			checkpoint: reqData.checkpoint,
 		}
-		if skipFirstPage && reqDataCopy.Pager.Page == 1 {
+		if skipFirstPage && i == 0 {
 			reqDataCopy.Pager.Page += concurrency
+			reqDataCopy.Pager.Skip += collector.args.PageSize * concurrency
 		}
 		var collect func() errors.Error
 		collect = func() errors.Error {
@@ -368,7 +443,7 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData, skip
 				if count < collector.args.PageSize {
 					return nil
 				}
-				apiClient.NextTick(func() errors.Error {
+				collector.nextTick(&reqDataCopy, func() errors.Error {
 					reqDataCopy.Pager.Skip += collector.args.PageSize * concurrency
 					reqDataCopy.Pager.Page += concurrency
 					return collect()
@@ -377,7 +452,7 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData, skip
 			})
 			return nil
 		}
-		apiClient.NextTick(collect)
+		collector.nextTick(&reqDataCopy, collect)
 	}
 }
 
@@ -442,6 +517,16 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	var cacheKey string
+	var cached *models.ApiResponseCache
+	if collector.cache != nil {
+		cacheKey = collector.cache.key(http.MethodGet, apiUrl, apiQuery)
+		cached, err = collector.cache.load(cacheKey)
+		if err != nil {
+			panic(err)
+		}
+		apiHeader = collector.cache.withValidators(apiHeader, cached)
+	}
 	logger := collector.args.Ctx.GetLogger()
 	logger.Debug("fetchAsync <<< enqueueing for %s %v", apiUrl, apiQuery)
 	responseHandler := func(res *http.Response) errors.Error {
@@ -453,6 +538,12 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			return errors.Default.Wrap(err, fmt.Sprintf("error reading response from %s", apiUrl))
 		}
 		res.Body.Close()
+		if collector.cache != nil {
+			body, err = collector.cache.handleResponse(cacheKey, cached, res, body)
+			if err != nil {
+				return errors.Convert(err)
+			}
+		}
 		res.Body = io.NopCloser(bytes.NewBuffer(body))
 		// convert body to array of RawJSON
 		items, err := collector.args.ResponseParser(res)
@@ -468,7 +559,7 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		count := len(items)
 		if count == 0 {
 			collector.args.Ctx.IncProgress(1)
-			return nil
+			return collector.pageDone(reqData)
 		}
 		db := collector.args.Ctx.GetDal()
 		urlString := res.Request.URL.String()
@@ -486,6 +577,9 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			return errors.Default.Wrap(err, fmt.Sprintf("error inserting raw rows into %s", collector.table))
 		}
 		logger.Debug("fetchAsync === total %d rows were saved into database", count)
+		if checkpointErr := collector.pageDone(reqData); checkpointErr != nil {
+			return checkpointErr
+		}
 		// increase progress only when it was not nested
 		collector.args.Ctx.IncProgress(1)
 		if handler != nil {
@@ -495,6 +589,14 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		}
 		return nil
 	}
+	if reqData.checkpoint != nil {
+		reqData.checkpoint.acquire()
+		handleResponse := responseHandler
+		responseHandler = func(res *http.Response) errors.Error {
+			err := handleResponse(res)
+			return collector.releaseCheckpoint(reqData, err)
+		}
+	}
 	if collector.args.Method == http.MethodPost {
 		collector.args.ApiClient.DoPostAsync(apiUrl, apiQuery, reqBody, apiHeader, responseHandler)
 	} else {
@@ -502,3 +604,35 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 	}
 	logger.Debug("fetchAsync === enqueued for %s %v", apiUrl, apiQuery)
 }
+
+// nextTick schedules the task and keeps the input of reqData pending until the task finished
+func (collector *ApiCollector) nextTick(reqData *RequestData, task func() errors.Error) {
+	if reqData.checkpoint == nil {
+		collector.args.ApiClient.NextTick(task)
+		return
+	}
+	reqData.checkpoint.acquire()
+	collector.args.ApiClient.NextTick(func() errors.Error {
+		err := task()
+		return collector.releaseCheckpoint(reqData, err)
+	})
+}
+
+// releaseCheckpoint marks a request or tick of the input finished, the input is recorded as done once all of them
+// finished without error
+func (collector *ApiCollector) releaseCheckpoint(reqData *RequestData, err errors.Error) errors.Error {
+	checkpointErr := reqData.checkpoint.release(err)
+	if err != nil {
+		return err
+	}
+	return checkpointErr
+}
+
+// pageDone records the page was collected for collectors without input, the progress of sequential collectors
+// is recorded with the cursor instead
+func (collector *ApiCollector) pageDone(reqData *RequestData) errors.Error {
+	if collector.checkpoint == nil || collector.args.Input != nil || collector.args.PageSize <= 0 || collector.args.GetNextPageCustomData != nil {
+		return nil
+	}
+	return collector.checkpoint.pageDone(reqData.Pager.Page)
+}
@@ -0,0 +1,249 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// collectorCheckpoint keeps track of the progress of an ApiCollector or a GraphqlCollector, so a rerun after a crash
+// would skip the inputs that were collected completely and the pages that were collected already. The raw data
+// collected before the crash is kept, duplicated rows of pages collected twice are fine since extractors would upsert them
+type collectorCheckpoint struct {
+	table     string
+	params    string
+	collector string
+	// options is the hash of the options changing the requests, i.e. the time range and the page size
+	options     string
+	incremental bool
+	save        func(checkpoint *models.CollectorCheckpoint) errors.Error
+	mu          sync.Mutex
+	// resumed indicates the previous collection didn't finish
+	resumed bool
+	// page is the last page of which all previous pages were collected, for collectors without input
+	page           int
+	cursor         *string
+	completedPages map[int]bool
+	// doneInputs are hashes of the inputs collected completely
+	doneInputs map[string]bool
+}
+
+// checkpointInput counts the pending requests of an input, the input is done once all requests and the ticks
+// scheduled by them finished without error
+type checkpointInput struct {
+	checkpoint *collectorCheckpoint
+	hash       string
+	pending    int
+	failed     bool
+}
+
+// loadCollectorCheckpoint loads the checkpoints left by the previous collection, checkpoints older than the ttl or
+// created in a different mode or with different options are discarded
+func loadCollectorCheckpoint(db dal.Dal, logger log.Logger, table, params, collector, options string, incremental bool, ttl time.Duration) (*collectorCheckpoint, errors.Error) {
+	checkpoint := newCollectorCheckpoint(table, params, collector, options, incremental)
+	checkpoint.save = func(row *models.CollectorCheckpoint) errors.Error {
+		return db.CreateOrUpdate(row)
+	}
+	var rows []*models.CollectorCheckpoint
+	clauses := []dal.Clause{dal.Where("raw_data_table = ? AND raw_data_params = ? AND collector = ?", table, params, checkpoint.collector)}
+	err := db.All(&rows, clauses...)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load collector checkpoints")
+	}
+	for _, row := range rows {
+		if row.Incremental != incremental || row.Options != options || time.Since(row.UpdatedAt) > ttl {
+			logger.Info("discard the collector checkpoints of %s which were created in a different mode, with different options or expired", table)
+			err = db.Delete(&models.CollectorCheckpoint{}, clauses...)
+			if err != nil {
+				return nil, errors.Default.Wrap(err, "failed to delete collector checkpoints")
+			}
+			return checkpoint, nil
+		}
+	}
+	checkpoint.restore(rows)
+	if checkpoint.resumed {
+		logger.Info("resume the collection of %s from page %d with %d inputs collected", table, checkpoint.page, len(checkpoint.doneInputs))
+	}
+	return checkpoint, nil
+}
+
+func newCollectorCheckpoint(table, params, collector, options string, incremental bool) *collectorCheckpoint {
+	if len(collector) > 255 {
+		collector = collector[:255]
+	}
+	return &collectorCheckpoint{
+		table:          table,
+		params:         params,
+		collector:      collector,
+		options:        options,
+		incremental:    incremental,
+		completedPages: make(map[int]bool),
+		doneInputs:     make(map[string]bool),
+	}
+}
+
+// collectorCheckpointOptions hashes the options the requests of a collection depend on, the time range of the sync
+// policy and the page size, the pages collected with other options can't be reused
+func collectorCheckpointOptions(taskCtx plugin.TaskContext, pageSize int) string {
+	options := map[string]interface{}{"pageSize": pageSize}
+	if syncPolicy := taskCtx.SyncPolicy(); syncPolicy != nil && syncPolicy.TimeAfter != nil {
+		options["timeAfter"] = syncPolicy.TimeAfter.UTC()
+	}
+	optionsJson, _ := json.Marshal(options)
+	sum := sha256.Sum256(optionsJson)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *collectorCheckpoint) restore(rows []*models.CollectorCheckpoint) {
+	for _, row := range rows {
+		c.resumed = true
+		if row.Input == "" {
+			c.page = row.Page
+			c.cursor = row.Cursor
+		} else {
+			c.doneInputs[row.Input] = true
+		}
+	}
+}
+
+func (c *collectorCheckpoint) id(input string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s\n%s\n%s", c.table, c.params, c.collector, c.options, input)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *collectorCheckpoint) newRow(input string) *models.CollectorCheckpoint {
+	return &models.CollectorCheckpoint{
+		Id:            c.id(input),
+		RawDataTable:  c.table,
+		RawDataParams: c.params,
+		Collector:     c.collector,
+		Options:       c.options,
+		Incremental:   c.incremental,
+		Input:         input,
+	}
+}
+
+// startInput returns the tracker of the input, or nil if the input was collected completely before
+func (c *collectorCheckpoint) startInput(inputJson []byte) *checkpointInput {
+	sum := sha256.Sum256(inputJson)
+	hash := hex.EncodeToString(sum[:])
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.doneInputs[hash] {
+		return nil
+	}
+	return &checkpointInput{checkpoint: c, hash: hash, pending: 1}
+}
+
+// pageDone records the page was collected, the progress is saved when all pages before it were collected too
+func (c *collectorCheckpoint) pageDone(page int) errors.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if page <= c.page {
+		return nil
+	}
+	c.completedPages[page] = true
+	advanced := false
+	for c.completedPages[c.page+1] {
+		delete(c.completedPages, c.page+1)
+		c.page++
+		advanced = true
+	}
+	if !advanced {
+		return nil
+	}
+	row := c.newRow("")
+	row.Page = c.page
+	return c.save(row)
+}
+
+// cursorDone records the page was collected with the cursor for collecting the next one, only string cursors could
+// be restored, the progress of collectors using other types is not recorded
+func (c *collectorCheckpoint) cursorDone(page int, cursor interface{}) errors.Error {
+	cursorString, ok := cursor.(string)
+	if !ok {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.page = page
+	c.cursor = &cursorString
+	row := c.newRow("")
+	row.Page = page
+	row.Cursor = c.cursor
+	return c.save(row)
+}
+
+// resumePage returns the page to start with and the cursor for it
+func (c *collectorCheckpoint) resumePage() (int, *string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.page + 1, c.cursor
+}
+
+func (c *collectorCheckpoint) inputDone(hash string) errors.Error {
+	c.mu.Lock()
+	c.doneInputs[hash] = true
+	c.mu.Unlock()
+	return c.save(c.newRow(hash))
+}
+
+func (i *checkpointInput) acquire() {
+	if i == nil {
+		return
+	}
+	i.checkpoint.mu.Lock()
+	defer i.checkpoint.mu.Unlock()
+	i.pending++
+}
+
+func (i *checkpointInput) release(err error) errors.Error {
+	if i == nil {
+		return nil
+	}
+	i.checkpoint.mu.Lock()
+	i.pending--
+	if err != nil {
+		i.failed = true
+	}
+	done := i.pending == 0 && !i.failed
+	i.checkpoint.mu.Unlock()
+	if done {
+		return i.checkpoint.inputDone(i.hash)
+	}
+	return nil
+}
+
+// clear removes the checkpoints once the collection finished successfully
+func (c *collectorCheckpoint) clear(db dal.Dal) errors.Error {
+	return db.Delete(
+		&models.CollectorCheckpoint{},
+		dal.Where("raw_data_table = ? AND raw_data_params = ? AND collector = ?", c.table, c.params, c.collector),
+	)
+}
@@ -0,0 +1,169 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCollectorCheckpoint() (*collectorCheckpoint, map[string]*models.CollectorCheckpoint) {
+	saved := make(map[string]*models.CollectorCheckpoint)
+	checkpoint := newCollectorCheckpoint("_raw_jira_api_issues", `{"ConnectionId":1,"BoardId":2}`, "agile/1.0/board/{{ .Params.BoardId }}/issue", "options", false)
+	checkpoint.save = func(row *models.CollectorCheckpoint) errors.Error {
+		saved[row.Id] = row
+		return nil
+	}
+	return checkpoint, saved
+}
+
+func TestCollectorCheckpointPages(t *testing.T) {
+	checkpoint, saved := newTestCollectorCheckpoint()
+	page, cursor := checkpoint.resumePage()
+	assert.Equal(t, 1, page)
+	assert.Nil(t, cursor)
+
+	// pages finished out of order, only the contiguous ones are recorded
+	assert.Nil(t, checkpoint.pageDone(2))
+	assert.Empty(t, saved)
+	assert.Nil(t, checkpoint.pageDone(1))
+	assert.Nil(t, checkpoint.pageDone(4))
+	page, _ = checkpoint.resumePage()
+	assert.Equal(t, 3, page)
+	assert.Len(t, saved, 1)
+	assert.Equal(t, 2, saved[checkpoint.id("")].Page)
+
+	resumed := newCollectorCheckpoint(checkpoint.table, checkpoint.params, checkpoint.collector, checkpoint.options, false)
+	resumed.restore([]*models.CollectorCheckpoint{saved[checkpoint.id("")]})
+	assert.True(t, resumed.resumed)
+	page, _ = resumed.resumePage()
+	assert.Equal(t, 3, page)
+}
+
+func TestCollectorCheckpointCursor(t *testing.T) {
+	checkpoint, saved := newTestCollectorCheckpoint()
+	// only string cursors could be restored
+	assert.Nil(t, checkpoint.cursorDone(1, map[string]int{"start": 100}))
+	assert.Empty(t, saved)
+	assert.Nil(t, checkpoint.cursorDone(1, "token-2"))
+	page, cursor := checkpoint.resumePage()
+	assert.Equal(t, 2, page)
+	assert.Equal(t, "token-2", *cursor)
+	assert.Equal(t, "token-2", *saved[checkpoint.id("")].Cursor)
+}
+
+func TestCollectorCheckpointInputs(t *testing.T) {
+	checkpoint, saved := newTestCollectorCheckpoint()
+	input := checkpoint.startInput([]byte(`{"IssueId":1}`))
+	// two pages and a tick scheduled for the input
+	input.acquire()
+	input.acquire()
+	assert.Nil(t, input.release(nil))
+	input.acquire()
+	assert.Nil(t, input.release(nil))
+	assert.Nil(t, input.release(nil))
+	assert.Empty(t, saved)
+	assert.Nil(t, input.release(nil))
+	assert.Len(t, saved, 1)
+	assert.Nil(t, checkpoint.startInput([]byte(`{"IssueId":1}`)))
+
+	failed := checkpoint.startInput([]byte(`{"IssueId":2}`))
+	failed.acquire()
+	assert.Nil(t, failed.release(errors.Default.New("boom")))
+	assert.Nil(t, failed.release(nil))
+	assert.Len(t, saved, 1)
+	assert.NotNil(t, checkpoint.startInput([]byte(`{"IssueId":2}`)))
+
+	resumed := newCollectorCheckpoint(checkpoint.table, checkpoint.params, checkpoint.collector, checkpoint.options, false)
+	rows := make([]*models.CollectorCheckpoint, 0)
+	for _, row := range saved {
+		rows = append(rows, row)
+	}
+	resumed.restore(rows)
+	assert.Nil(t, resumed.startInput([]byte(`{"IssueId":1}`)))
+	assert.NotNil(t, resumed.startInput([]byte(`{"IssueId":2}`)))
+}
+
+type syncPolicyTaskContext struct {
+	plugin.TaskContext
+	syncPolicy *models.SyncPolicy
+}
+
+func (c *syncPolicyTaskContext) SyncPolicy() *models.SyncPolicy {
+	return c.syncPolicy
+}
+
+func TestCollectorCheckpointOptions(t *testing.T) {
+	timeAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	otherTimeAfter := timeAfter.AddDate(0, 6, 0)
+	options := collectorCheckpointOptions(&syncPolicyTaskContext{syncPolicy: &models.SyncPolicy{TimeAfter: &timeAfter}}, 100)
+	assert.Equal(t, options, collectorCheckpointOptions(&syncPolicyTaskContext{syncPolicy: &models.SyncPolicy{TimeAfter: &timeAfter}}, 100))
+	assert.NotEqual(t, options, collectorCheckpointOptions(&syncPolicyTaskContext{syncPolicy: &models.SyncPolicy{TimeAfter: &otherTimeAfter}}, 100))
+	assert.NotEqual(t, options, collectorCheckpointOptions(&syncPolicyTaskContext{syncPolicy: &models.SyncPolicy{TimeAfter: &timeAfter}}, 50))
+	assert.NotEqual(t, options, collectorCheckpointOptions(&syncPolicyTaskContext{}, 100))
+
+	// the same page of a collection with other options is another checkpoint
+	checkpoint, _ := newTestCollectorCheckpoint()
+	other := newCollectorCheckpoint(checkpoint.table, checkpoint.params, checkpoint.collector, "other options", false)
+	assert.NotEqual(t, checkpoint.id(""), other.id(""))
+}
+
+// checkpointDal serves the checkpoints in memory
+type checkpointDal struct {
+	dal.Dal
+	rows    []*models.CollectorCheckpoint
+	deleted bool
+}
+
+func (d *checkpointDal) All(dst interface{}, _ ...dal.Clause) errors.Error {
+	*dst.(*[]*models.CollectorCheckpoint) = d.rows
+	return nil
+}
+
+func (d *checkpointDal) Delete(_ interface{}, _ ...dal.Clause) errors.Error {
+	d.deleted = true
+	return nil
+}
+
+func TestLoadCollectorCheckpointDiscardsOtherOptions(t *testing.T) {
+	row := newCollectorCheckpoint("_raw_github_graphql_issues", "{}", "graphql:collectIssues#0", "options", false).newRow("")
+	row.Page = 9
+	row.UpdatedAt = time.Now()
+
+	db := &checkpointDal{rows: []*models.CollectorCheckpoint{row}}
+	checkpoint, err := loadCollectorCheckpoint(db, logruslog.Global, row.RawDataTable, row.RawDataParams, row.Collector, "options", false, time.Hour)
+	assert.Nil(t, err)
+	assert.False(t, db.deleted)
+	assert.True(t, checkpoint.resumed)
+	page, _ := checkpoint.resumePage()
+	assert.Equal(t, 10, page)
+
+	// the timeAfter changed since the crash
+	db = &checkpointDal{rows: []*models.CollectorCheckpoint{row}}
+	checkpoint, err = loadCollectorCheckpoint(db, logruslog.Global, row.RawDataTable, row.RawDataParams, row.Collector, "other options", false, time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, db.deleted)
+	assert.False(t, checkpoint.resumed)
+}
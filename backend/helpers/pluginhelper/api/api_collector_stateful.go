@@ -19,6 +19,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"time"
@@ -87,6 +88,7 @@ func (m *StatefulApiCollector) InitGraphQLCollector(args GraphqlCollectorArgs) e
 	if err != nil {
 		return err
 	}
+	graphqlCollector.checkpointName = fmt.Sprintf("graphql:%s#%d", args.Ctx.GetName(), len(m.nestedCollectors))
 	m.nestedCollectors = append(m.nestedCollectors, graphqlCollector)
 	return nil
 }
@@ -166,7 +168,8 @@ func NewStatefulApiCollectorForFinalizableEntity(args FinalizableApiCollectorArg
 			}
 			return nil, nil
 		},
-		MinTickInterval: args.CollectNewRecordsByList.MinTickInterval,
+		MinTickInterval:    args.CollectNewRecordsByList.MinTickInterval,
+		ConditionalRequest: args.CollectNewRecordsByList.ConditionalRequest,
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			items, err := args.CollectNewRecordsByList.ResponseParser(res)
 			if err != nil {
@@ -259,11 +262,12 @@ func NewStatefulApiCollectorForFinalizableEntity(args FinalizableApiCollectorArg
 			}
 			return nil, nil
 		},
-		MinTickInterval: args.CollectUnfinishedDetails.MinTickInterval,
-		ResponseParser:  args.CollectUnfinishedDetails.ResponseParser,
-		AfterResponse:   args.CollectUnfinishedDetails.AfterResponse,
-		RequestBody:     args.CollectUnfinishedDetails.RequestBody,
-		Method:          args.CollectUnfinishedDetails.Method,
+		MinTickInterval:    args.CollectUnfinishedDetails.MinTickInterval,
+		ConditionalRequest: args.CollectUnfinishedDetails.ConditionalRequest,
+		ResponseParser:     args.CollectUnfinishedDetails.ResponseParser,
+		AfterResponse:      args.CollectUnfinishedDetails.AfterResponse,
+		RequestBody:        args.CollectUnfinishedDetails.RequestBody,
+		Method:             args.CollectUnfinishedDetails.Method,
 	})
 	return manager, err
 }
@@ -286,6 +290,8 @@ type FinalizableApiCollectorCommonArgs struct {
 	MinTickInterval *time.Duration                                                                  // optional, minimum interval between two requests, some endpoints might have a more conservative rate limit than others within the same instance, you can mitigate this by setting a higher MinTickInterval to override the connection level rate limit.
 	AfterResponse   plugin.ApiClientAfterResponse                                                   // optional, hook to run after each response, would be called before the ResponseParser
 	ResponseParser  func(res *http.Response) ([]json.RawMessage, errors.Error)                      // required, parse the response body and return a list of entities
+	// optional, send the requests with the validators of the previous responses, see ApiCollectorArgs.ConditionalRequest
+	ConditionalRequest bool
 }
 
 // FinalizableApiCollectorListArgs is the arguments for the list collector
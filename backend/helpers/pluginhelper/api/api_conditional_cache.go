@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// conditionalRequestCache sends requests with the validators (ETag / Last-Modified) of the previous responses,
+// the stored payload would be used instead when the server responds with 304 Not Modified which normally
+// doesn't count against the rate limit. Responses cached longer than the ttl are requested again without validators
+type conditionalRequestCache struct {
+	db       dal.Dal
+	table    string
+	params   string
+	ttl      time.Duration
+	requests int64
+	hits     int64
+}
+
+func newConditionalRequestCache(db dal.Dal, table string, params string, ttl time.Duration) *conditionalRequestCache {
+	return &conditionalRequestCache{
+		db:     db,
+		table:  table,
+		params: params,
+		ttl:    ttl,
+	}
+}
+
+// key identifies the request within the raw data params
+func (c *conditionalRequestCache) key(method string, apiUrl string, query url.Values) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%s\n%s?%s", c.table, c.params, method, apiUrl, query.Encode())))
+	return hex.EncodeToString(sum[:])
+}
+
+// load returns the cached response of the request, nil if it was never cached
+func (c *conditionalRequestCache) load(key string) (*models.ApiResponseCache, errors.Error) {
+	cached := &models.ApiResponseCache{}
+	err := c.db.First(cached, dal.Where("id = ?", key))
+	if err != nil {
+		if c.db.IsErrorNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Default.Wrap(err, "failed to load cached response")
+	}
+	if c.expired(cached) {
+		return nil, nil
+	}
+	return cached, nil
+}
+
+// expired tells whether the cached response is older than the ttl, a non-positive ttl never expires
+func (c *conditionalRequestCache) expired(cached *models.ApiResponseCache) bool {
+	return c.ttl > 0 && time.Since(cached.UpdatedAt) > c.ttl
+}
+
+// purge removes the expired responses of the collector, requests with changing urls, i.e. the ones carrying the
+// time of the last collection, would never hit them again
+func (c *conditionalRequestCache) purge() errors.Error {
+	if c.ttl <= 0 {
+		return nil
+	}
+	err := c.db.Delete(
+		&models.ApiResponseCache{},
+		dal.Where("raw_data_table = ? AND raw_data_params = ? AND updated_at < ?", c.table, c.params, time.Now().Add(-c.ttl)),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to purge expired cached responses")
+	}
+	return nil
+}
+
+// withValidators returns a copy of the header carrying the validators of the cached response
+func (c *conditionalRequestCache) withValidators(header http.Header, cached *models.ApiResponseCache) http.Header {
+	atomic.AddInt64(&c.requests, 1)
+	if cached == nil {
+		return header
+	}
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if cached.Etag != "" {
+		header.Set("If-None-Match", cached.Etag)
+	}
+	if cached.LastModified != "" {
+		header.Set("If-Modified-Since", cached.LastModified)
+	}
+	return header
+}
+
+// handleResponse replaces the body and headers of a 304 response with the cached ones, or caches the response
+// if it carries any validator. The body to be processed is returned
+func (c *conditionalRequestCache) handleResponse(key string, cached *models.ApiResponseCache, res *http.Response, body []byte) ([]byte, errors.Error) {
+	if res.StatusCode == http.StatusNotModified && cached != nil {
+		atomic.AddInt64(&c.hits, 1)
+		header := http.Header{}
+		if cached.Header != "" {
+			if err := json.Unmarshal([]byte(cached.Header), &header); err != nil {
+				return nil, errors.Default.Wrap(err, "failed to decode cached response header")
+			}
+		}
+		// the live headers carry the up-to-date information such as rate limit
+		for name, values := range res.Header {
+			header[name] = values
+		}
+		res.Header = header
+		res.StatusCode = http.StatusOK
+		res.Status = http.StatusText(http.StatusOK)
+		return cached.Body, nil
+	}
+	etag := res.Header.Get("ETag")
+	lastModified := res.Header.Get("Last-Modified")
+	if res.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return body, nil
+	}
+	header := res.Header.Clone()
+	header.Del("Set-Cookie")
+	headerJson, err := json.Marshal(header)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to encode response header")
+	}
+	now := time.Now()
+	entry := &models.ApiResponseCache{
+		Id:            key,
+		RawDataTable:  c.table,
+		RawDataParams: c.params,
+		Url:           res.Request.URL.String(),
+		Etag:          etag,
+		LastModified:  lastModified,
+		Header:        string(headerJson),
+		Body:          body,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if cached != nil {
+		entry.CreatedAt = cached.CreatedAt
+	}
+	if err := c.db.CreateOrUpdate(entry); err != nil {
+		return nil, errors.Default.Wrap(err, "failed to cache response")
+	}
+	return body, nil
+}
+
+// report logs the hit rate of the cache
+func (c *conditionalRequestCache) report(logger log.Logger) {
+	requests := atomic.LoadInt64(&c.requests)
+	if requests == 0 {
+		return
+	}
+	hits := atomic.LoadInt64(&c.hits)
+	logger.Info("conditional request cache: %d of %d requests were not modified, hit rate %.1f%%", hits, requests, float64(hits)*100/float64(requests))
+}
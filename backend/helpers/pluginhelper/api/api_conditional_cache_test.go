@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalRequestCacheKey(t *testing.T) {
+	cache := newConditionalRequestCache(nil, "_raw_github_api_accounts", `{"ConnectionId":1}`, 0)
+	other := newConditionalRequestCache(nil, "_raw_github_api_accounts", `{"ConnectionId":2}`, 0)
+	query := url.Values{"page": []string{"1"}}
+	key := cache.key(http.MethodGet, "users/alice", query)
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, cache.key(http.MethodGet, "users/alice", url.Values{"page": []string{"1"}}))
+	assert.NotEqual(t, key, cache.key(http.MethodGet, "users/alice", url.Values{"page": []string{"2"}}))
+	assert.NotEqual(t, key, other.key(http.MethodGet, "users/alice", query))
+}
+
+func TestConditionalRequestCacheWithValidators(t *testing.T) {
+	cache := newConditionalRequestCache(nil, "table", "params", 0)
+	header := http.Header{"Accept": []string{"application/json"}}
+
+	assert.Equal(t, header, cache.withValidators(header, nil))
+
+	withValidators := cache.withValidators(header, &models.ApiResponseCache{
+		Etag:         `W/"abc"`,
+		LastModified: "Mon, 02 Jan 2006 15:04:05 GMT",
+	})
+	assert.Equal(t, `W/"abc"`, withValidators.Get("If-None-Match"))
+	assert.Equal(t, "Mon, 02 Jan 2006 15:04:05 GMT", withValidators.Get("If-Modified-Since"))
+	assert.Equal(t, "application/json", withValidators.Get("Accept"))
+	// the original header must not be modified
+	assert.Empty(t, header.Get("If-None-Match"))
+	assert.Equal(t, int64(2), cache.requests)
+}
+
+func TestConditionalRequestCacheNotModified(t *testing.T) {
+	cache := newConditionalRequestCache(nil, "table", "params", 0)
+	cachedHeader, _ := json.Marshal(http.Header{
+		"Link":                  []string{`<https://api.github.com/users?page=2>; rel="next"`},
+		"X-Ratelimit-Remaining": []string{"10"},
+	})
+	cached := &models.ApiResponseCache{
+		Etag:   `"abc"`,
+		Header: string(cachedHeader),
+		Body:   []byte(`{"login":"alice"}`),
+	}
+	res := &http.Response{
+		StatusCode: http.StatusNotModified,
+		Header:     http.Header{"X-Ratelimit-Remaining": []string{"4999"}},
+	}
+	body, err := cache.handleResponse("key", cached, res, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"login":"alice"}`, string(body))
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "4999", res.Header.Get("X-Ratelimit-Remaining"))
+	assert.Equal(t, `<https://api.github.com/users?page=2>; rel="next"`, res.Header.Get("Link"))
+	assert.Equal(t, int64(1), cache.hits)
+}
+
+func TestConditionalRequestCacheWithoutValidators(t *testing.T) {
+	cache := newConditionalRequestCache(nil, "table", "params", 0)
+	res := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+	// responses without validators are not cached, so the nil dal is never touched
+	body, err := cache.handleResponse("key", nil, res, []byte(`[]`))
+	assert.Nil(t, err)
+	assert.Equal(t, `[]`, string(body))
+}
+
+func TestConditionalRequestCacheExpired(t *testing.T) {
+	cached := &models.ApiResponseCache{UpdatedAt: time.Now().Add(-2 * time.Hour)}
+	assert.True(t, newConditionalRequestCache(nil, "table", "params", time.Hour).expired(cached))
+	assert.False(t, newConditionalRequestCache(nil, "table", "params", 3*time.Hour).expired(cached))
+	// a non-positive ttl never expires
+	assert.False(t, newConditionalRequestCache(nil, "table", "params", 0).expired(cached))
+}
@@ -0,0 +1,271 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	// the shortest interval between 2 requests when the server reports plenty of quota left
+	minAdaptiveInterval = 10 * time.Millisecond
+	// the backoff applied when the server responds with 429 without telling us how long to wait
+	defaultRateLimitBackoff = 10 * time.Second
+	maxRateLimitBackoff     = 10 * time.Minute
+	// leave some quota to other clients sharing the same credential
+	rateLimitSafetyRatio = 0.95
+	// limiters which have not been used for this long are dropped, tasks started later would start over
+	adaptiveRateLimiterTtl = time.Hour
+)
+
+var rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining", "X-Rate-Limit-Remaining"}
+var rateLimitResetHeaders = []string{"X-RateLimit-Reset", "RateLimit-Reset", "X-Rate-Limit-Reset"}
+
+// AdaptiveRateLimiter paces the requests sent to a connection, it starts with the interval calculated by the
+// ApiRateLimitCalculator and keeps adapting to the quota reported by the server through the
+// `X-RateLimit-Remaining`/`X-RateLimit-Reset`, `Retry-After` headers and 429 responses.
+// The limiter is shared by all tasks using the same connection, see GetAdaptiveRateLimiter
+type AdaptiveRateLimiter struct {
+	mu          sync.Mutex
+	interval    time.Duration
+	minInterval time.Duration
+	next        time.Time
+	pausedUntil time.Time
+	backoff     time.Duration
+	lastUsed    time.Time
+	now         func() time.Time
+}
+
+// adaptiveRateLimiterKey tells apart the limiters of the same connection created with different rate limit
+// settings, so changing the settings takes effect on the next run
+type adaptiveRateLimiterKey struct {
+	connection  string
+	minInterval time.Duration
+}
+
+var adaptiveRateLimiters = make(map[adaptiveRateLimiterKey]*AdaptiveRateLimiter)
+var adaptiveRateLimitersMutex sync.Mutex
+
+// NewAdaptiveRateLimiter creates an AdaptiveRateLimiter with the initial interval, the interval would never be
+// shorter than minInterval no matter what the server reports
+func NewAdaptiveRateLimiter(interval time.Duration, minInterval time.Duration) *AdaptiveRateLimiter {
+	if minInterval < minAdaptiveInterval {
+		minInterval = minAdaptiveInterval
+	}
+	if interval < minInterval {
+		interval = minInterval
+	}
+	return &AdaptiveRateLimiter{
+		interval:    interval,
+		minInterval: minInterval,
+		lastUsed:    time.Now(),
+		now:         time.Now,
+	}
+}
+
+// GetAdaptiveRateLimiter returns the AdaptiveRateLimiter shared by all tasks calling the same connection with the
+// same minInterval, a new one would be created with the given intervals if none exists. Limiters left idle for
+// adaptiveRateLimiterTtl or replaced due to the minInterval change are evicted
+func GetAdaptiveRateLimiter(connectionKey string, interval time.Duration, minInterval time.Duration) *AdaptiveRateLimiter {
+	adaptiveRateLimitersMutex.Lock()
+	defer adaptiveRateLimitersMutex.Unlock()
+	key := adaptiveRateLimiterKey{connection: connectionKey, minInterval: minInterval}
+	for k, limiter := range adaptiveRateLimiters {
+		if k != key && (k.connection == connectionKey || limiter.idleFor() > adaptiveRateLimiterTtl) {
+			delete(adaptiveRateLimiters, k)
+		}
+	}
+	limiter, ok := adaptiveRateLimiters[key]
+	if !ok {
+		limiter = NewAdaptiveRateLimiter(interval, minInterval)
+		adaptiveRateLimiters[key] = limiter
+	}
+	return limiter
+}
+
+func (l *AdaptiveRateLimiter) idleFor() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.now().Sub(l.lastUsed)
+}
+
+// RateLimitKey identifies the connection for sharing the AdaptiveRateLimiter, connections of different plugins
+// are told apart by their types
+func RateLimitKey(connection plugin.ApiConnection) string {
+	if connection == nil {
+		return ""
+	}
+	if hasField(connection, "ID") {
+		if id := reflectField(connection, "ID"); id.CanUint() && id.Uint() > 0 {
+			return fmt.Sprintf("%T#%d", connection, id.Uint())
+		}
+	}
+	return fmt.Sprintf("%T@%s", connection, connection.GetEndpoint())
+}
+
+// Wait blocks until the next request is allowed to be sent or the context is done
+func (l *AdaptiveRateLimiter) Wait(ctx context.Context) errors.Error {
+	l.mu.Lock()
+	now := l.now()
+	l.lastUsed = now
+	at := now
+	if l.next.After(at) {
+		at = l.next
+	}
+	if l.pausedUntil.After(at) {
+		at = l.pausedUntil
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return errors.Convert(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Update adapts the pace to the rate limit information carried by the response
+func (l *AdaptiveRateLimiter) Update(res *http.Response) {
+	if res == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	retryAfter, hasRetryAfter := parseRetryAfter(res.Header.Get("Retry-After"), now)
+	reset, hasReset := parseRateLimitReset(res.Header, now)
+	remaining, hasRemaining := parseRateLimitRemaining(res.Header)
+
+	if res.StatusCode == http.StatusTooManyRequests || hasRetryAfter || (hasRemaining && remaining == 0) {
+		var pause time.Duration
+		switch {
+		case hasRetryAfter:
+			pause = retryAfter
+		case hasReset:
+			pause = reset
+		default:
+			// no hint from the server, back off exponentially
+			if l.backoff == 0 {
+				l.backoff = defaultRateLimitBackoff
+			} else if l.backoff < maxRateLimitBackoff {
+				l.backoff *= 2
+			}
+			pause = l.backoff
+		}
+		if pause <= 0 {
+			return
+		}
+		if until := now.Add(pause); until.After(l.pausedUntil) {
+			l.pausedUntil = until
+		}
+		return
+	}
+	if res.StatusCode < http.StatusBadRequest {
+		l.backoff = 0
+	}
+	if hasRemaining && hasReset && reset > 0 {
+		interval := time.Duration(float64(reset) / (float64(remaining) * rateLimitSafetyRatio))
+		if interval < l.minInterval {
+			interval = l.minInterval
+		}
+		l.interval = interval
+	}
+}
+
+// GetInterval returns current interval between 2 requests
+func (l *AdaptiveRateLimiter) GetInterval() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.interval
+}
+
+func parseRateLimitRemaining(header http.Header) (int, bool) {
+	for _, name := range rateLimitRemainingHeaders {
+		if value := strings.TrimSpace(header.Get(name)); value != "" {
+			remaining, err := strconv.Atoi(value)
+			if err == nil && remaining >= 0 {
+				return remaining, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseRateLimitReset returns how long until the quota resets, the header could be either an unix timestamp
+// (GitHub, GitLab) or a number of seconds (IETF draft)
+func parseRateLimitReset(header http.Header, now time.Time) (time.Duration, bool) {
+	for _, name := range rateLimitResetHeaders {
+		value := strings.TrimSpace(header.Get(name))
+		if value == "" {
+			continue
+		}
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+		// anything after 2001-09-09 must be a timestamp
+		if seconds > 1e9 {
+			return time.Unix(seconds, 0).Sub(serverTime(header, now)), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses the Retry-After header which is either a number of seconds or a http date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return at.Sub(now), true
+	}
+	return 0, false
+}
+
+// serverTime prefers the Date header to avoid being fooled by clock skew
+func serverTime(header http.Header, now time.Time) time.Time {
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		return date
+	}
+	return now
+}
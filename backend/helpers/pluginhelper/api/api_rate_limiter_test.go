@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAdaptiveRateLimiter(now time.Time) *AdaptiveRateLimiter {
+	limiter := NewAdaptiveRateLimiter(time.Second, 0)
+	limiter.now = func() time.Time { return now }
+	return limiter
+}
+
+func TestAdaptiveRateLimiterAdaptsToRemainingQuota(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestAdaptiveRateLimiter(now)
+
+	// plenty of quota, speed up
+	limiter.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"4750"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(time.Hour).Unix(), 10)},
+		"Date":                  []string{now.Format(http.TimeFormat)},
+	}})
+	assert.InDelta(t, float64(time.Hour)/(4750*0.95), float64(limiter.GetInterval()), float64(time.Millisecond))
+
+	// running out of quota, slow down, the reset header could be relative as well
+	limiter.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"Ratelimit-Remaining": []string{"19"},
+		"Ratelimit-Reset":     []string{"60"},
+	}})
+	assert.InDelta(t, float64(60*time.Second)/(19*0.95), float64(limiter.GetInterval()), float64(time.Millisecond))
+
+	// never faster than the min interval
+	limiter.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"100000"},
+		"X-Ratelimit-Reset":     []string{"1"},
+	}})
+	assert.Equal(t, minAdaptiveInterval, limiter.GetInterval())
+}
+
+func TestAdaptiveRateLimiterPauses(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	limiter := newTestAdaptiveRateLimiter(now)
+	limiter.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{
+		"Retry-After": []string{"30"},
+	}})
+	assert.Equal(t, now.Add(30*time.Second), limiter.pausedUntil)
+
+	limiter = newTestAdaptiveRateLimiter(now)
+	limiter.Update(&http.Response{StatusCode: http.StatusForbidden, Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(5*time.Minute).Unix(), 10)},
+	}})
+	assert.Equal(t, now.Add(5*time.Minute), limiter.pausedUntil)
+
+	// no hint at all, back off exponentially
+	limiter = newTestAdaptiveRateLimiter(now)
+	limiter.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	assert.Equal(t, now.Add(defaultRateLimitBackoff), limiter.pausedUntil)
+	limiter.Update(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}})
+	assert.Equal(t, now.Add(2*defaultRateLimitBackoff), limiter.pausedUntil)
+	limiter.Update(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}})
+	assert.Equal(t, time.Duration(0), limiter.backoff)
+}
+
+func TestAdaptiveRateLimiterWait(t *testing.T) {
+	limiter := NewAdaptiveRateLimiter(20*time.Millisecond, 0)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	limiter.pausedUntil = time.Now().Add(time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.NotNil(t, limiter.Wait(ctx))
+}
+
+func TestGetAdaptiveRateLimiterIsShared(t *testing.T) {
+	a := GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterIsShared", time.Second, 0)
+	b := GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterIsShared", time.Minute, 0)
+	assert.Same(t, a, b)
+	assert.Equal(t, time.Second, b.GetInterval())
+}
+
+func TestGetAdaptiveRateLimiterEviction(t *testing.T) {
+	a := GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterEviction", time.Second, time.Second)
+	// the rate limit setting of the connection changed
+	b := GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterEviction", time.Second, 2*time.Second)
+	assert.NotSame(t, a, b)
+	assert.Equal(t, 2*time.Second, b.GetInterval())
+	_, ok := adaptiveRateLimiters[adaptiveRateLimiterKey{connection: "TestGetAdaptiveRateLimiterEviction", minInterval: time.Second}]
+	assert.False(t, ok)
+
+	// idle limiters of other connections are dropped as well
+	idle := GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterEvictionIdle", time.Second, 0)
+	idle.now = func() time.Time { return time.Now().Add(adaptiveRateLimiterTtl + time.Minute) }
+	GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterEviction", time.Second, 2*time.Second)
+	assert.NotSame(t, idle, GetAdaptiveRateLimiter("TestGetAdaptiveRateLimiterEvictionIdle", time.Second, 0))
+}
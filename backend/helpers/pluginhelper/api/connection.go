@@ -43,6 +43,12 @@ type RestConnection struct {
 	Endpoint         string `mapstructure:"endpoint" validate:"required" json:"endpoint"`
 	Proxy            string `mapstructure:"proxy" json:"proxy"`
 	RateLimitPerHour int    `comment:"api request rate limit per hour" json:"rateLimitPerHour"`
+	// CaCert is the PEM encoded CA bundle to verify the server certificate, for servers signed by an internal CA
+	CaCert string `mapstructure:"caCert" json:"caCert" gorm:"type:text;serializer:encdec"`
+	// ClientCert and ClientKey are the PEM encoded client certificate and private key for mutual TLS,
+	// the key is never returned by the api
+	ClientCert string `mapstructure:"clientCert" json:"clientCert" gorm:"type:text;serializer:encdec"`
+	ClientKey  string `mapstructure:"clientKey" json:"-" gorm:"type:text;serializer:encdec"`
 }
 
 // GetEndpoint returns the API endpoint of the connection, which always ends with "/"
@@ -63,3 +69,18 @@ func (rc RestConnection) GetProxy() string {
 func (rc RestConnection) GetRateLimitPerHour() int {
 	return rc.RateLimitPerHour
 }
+
+// GetCaCert returns the CA bundle for verifying the server certificate
+func (rc RestConnection) GetCaCert() string {
+	return rc.CaCert
+}
+
+// GetClientCert returns the client certificate for mutual TLS
+func (rc RestConnection) GetClientCert() string {
+	return rc.ClientCert
+}
+
+// GetClientKey returns the private key of the client certificate for mutual TLS
+func (rc RestConnection) GetClientKey() string {
+	return rc.ClientKey
+}
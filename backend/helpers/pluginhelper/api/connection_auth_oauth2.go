@@ -0,0 +1,229 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	gocontext "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// refresh the access token a little earlier to avoid using an expired one due to latency or clock skew
+const oauth2ExpiryDelta = time.Minute
+
+// OAuth2 implements the OAuth 2.0 client credentials and refresh token grants. The refresh token grant is used when
+// RefreshToken is provided, e.g. Jira Cloud 3LO or Bitbucket Cloud, otherwise the client credentials grant is used,
+// e.g. Azure DevOps service principals.
+// The access token is cached and shared by all tasks using the same connection, it is refreshed before it expires
+// or when the server responds with 401. The rotated refresh token is persisted to the column of RefreshToken in
+// the connection table
+type OAuth2 struct {
+	TokenUrl     string `mapstructure:"tokenUrl" validate:"required,url" json:"tokenUrl"`
+	ClientId     string `mapstructure:"clientId" validate:"required" json:"clientId"`
+	ClientSecret string `mapstructure:"clientSecret" json:"clientSecret" gorm:"serializer:encdec"`
+	// Scopes are separated by spaces
+	Scopes       string `mapstructure:"scopes" json:"scopes"`
+	RefreshToken string `mapstructure:"refreshToken" json:"refreshToken" gorm:"serializer:encdec"`
+
+	token      *oauth2Token
+	key        string
+	httpClient *http.Client
+	persist    func(refreshToken string) errors.Error
+}
+
+type oauth2Token struct {
+	mu           sync.Mutex
+	id           string
+	accessToken  string
+	expiresAt    time.Time
+	refreshToken string
+}
+
+var oauth2Tokens = make(map[string]*oauth2Token)
+var oauth2TokensMutex sync.Mutex
+
+// SetupAuthentication sets up the request headers for authentication
+func (o *OAuth2) SetupAuthentication(request *http.Request) errors.Error {
+	accessToken, err := o.GetAccessToken(request.Context())
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", accessToken))
+	return nil
+}
+
+// GetOAuth2Authenticator returns SetupAuthentication
+func (o *OAuth2) GetOAuth2Authenticator() plugin.ApiAuthenticator {
+	return o
+}
+
+// GetAccessToken returns the cached access token, a new one would be requested if it is missing or expiring
+func (o *OAuth2) GetAccessToken(ctx gocontext.Context) (string, errors.Error) {
+	token := o.getToken()
+	token.mu.Lock()
+	defer token.mu.Unlock()
+	if token.accessToken != "" && (token.expiresAt.IsZero() || time.Now().Add(oauth2ExpiryDelta).Before(token.expiresAt)) {
+		return token.accessToken, nil
+	}
+	err := o.requestToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return token.accessToken, nil
+}
+
+// InvalidateAccessToken drops the cached access token if it is still the rejected one, so the next request
+// would get a new one. Nothing happens if the token was renewed by another request already
+func (o *OAuth2) InvalidateAccessToken(rejectedAccessToken string) {
+	token := o.getToken()
+	token.mu.Lock()
+	defer token.mu.Unlock()
+	if token.accessToken == rejectedAccessToken {
+		token.accessToken = ""
+	}
+}
+
+// bind shares the token among the clients of the connection identified by the key, and sets up the http client
+// for requesting tokens and the function for persisting the rotated refresh token
+func (o *OAuth2) bind(key string, httpClient *http.Client, persist func(refreshToken string) errors.Error) {
+	o.key = key
+	o.httpClient = httpClient
+	o.persist = persist
+	o.token = loadOAuth2Token(key, o)
+}
+
+func (o *OAuth2) getToken() *oauth2Token {
+	if o.token == nil {
+		o.token = loadOAuth2Token("", o)
+	}
+	return o.token
+}
+
+// loadOAuth2Token returns the token state shared by the clients with the same credentials, credentials are part of
+// the key so tokens would never be shared between different credentials, i.e. testing an unsaved connection
+func loadOAuth2Token(key string, o *OAuth2) *oauth2Token {
+	id := oauth2TokenId(key, o)
+	oauth2TokensMutex.Lock()
+	defer oauth2TokensMutex.Unlock()
+	token, ok := oauth2Tokens[id]
+	if !ok {
+		token = &oauth2Token{id: id, refreshToken: o.RefreshToken}
+		oauth2Tokens[id] = token
+	}
+	return token
+}
+
+func oauth2TokenId(key string, o *OAuth2) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{key, o.TokenUrl, o.ClientId, o.ClientSecret, o.Scopes, o.RefreshToken}, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// rekeyOAuth2Token moves the token to the key of the rotated refresh token, so the clients created after the
+// rotation would share it while the entry of the revoked refresh token is evicted
+func rekeyOAuth2Token(token *oauth2Token, id string) {
+	oauth2TokensMutex.Lock()
+	defer oauth2TokensMutex.Unlock()
+	if oauth2Tokens[token.id] == token {
+		delete(oauth2Tokens, token.id)
+	}
+	token.id = id
+	oauth2Tokens[id] = token
+}
+
+func (o *OAuth2) requestToken(ctx gocontext.Context, token *oauth2Token) errors.Error {
+	form := url.Values{}
+	form.Set("client_id", o.ClientId)
+	if o.ClientSecret != "" {
+		form.Set("client_secret", o.ClientSecret)
+	}
+	if o.Scopes != "" {
+		form.Set("scope", o.Scopes)
+	}
+	if token.refreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", token.refreshToken)
+	} else {
+		form.Set("grant_type", "client_credentials")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to create oauth2 token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	httpClient := o.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to request oauth2 token")
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to read oauth2 token response")
+	}
+	result := &struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	_ = json.Unmarshal(body, result)
+	if res.StatusCode != http.StatusOK || result.AccessToken == "" {
+		status := res.StatusCode
+		if status == http.StatusOK {
+			status = http.StatusBadGateway
+		}
+		// the token endpoint would respond with 400 for invalid credentials, which should be reported as 401
+		if status == http.StatusBadRequest && (result.Error == "invalid_grant" || result.Error == "invalid_client") {
+			status = http.StatusUnauthorized
+		}
+		return errors.HttpStatus(status).New(fmt.Sprintf("failed to request oauth2 token: %s %s", result.Error, result.ErrorDescription))
+	}
+	token.accessToken = result.AccessToken
+	token.expiresAt = time.Time{}
+	if result.ExpiresIn > 0 {
+		token.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	if result.RefreshToken != "" && result.RefreshToken != token.refreshToken {
+		// the refresh token was rotated, the old one might be revoked already
+		token.refreshToken = result.RefreshToken
+		o.RefreshToken = result.RefreshToken
+		rekeyOAuth2Token(token, oauth2TokenId(o.key, o))
+		if o.persist != nil {
+			if err := o.persist(result.RefreshToken); err != nil {
+				return errors.Default.Wrap(err, "failed to persist the rotated oauth2 refresh token")
+			}
+		}
+	}
+	return nil
+}
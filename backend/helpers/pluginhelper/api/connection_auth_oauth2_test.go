@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTokenServer(t *testing.T, issued *int32, rotate bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		if r.PostForm.Get("client_id") != "client" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		n := atomic.AddInt32(issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.PostForm.Get("grant_type") {
+		case "client_credentials":
+			_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","expires_in":3600}`, n)
+		case "refresh_token":
+			refreshToken := r.PostForm.Get("refresh_token")
+			if rotate {
+				refreshToken = fmt.Sprintf("refresh-%d", n)
+			}
+			_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","expires_in":3600,"refresh_token":"%s"}`, n, refreshToken)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	var issued int32
+	server := newTestTokenServer(t, &issued, false)
+	defer server.Close()
+
+	oauth2 := &OAuth2{TokenUrl: server.URL, ClientId: "client", ClientSecret: "secret"}
+	oauth2.bind(t.Name(), server.Client(), nil)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	assert.Nil(t, oauth2.SetupAuthentication(req))
+	assert.Equal(t, "Bearer access-1", req.Header.Get("Authorization"))
+
+	// the token is cached and shared by the authenticators of the same connection
+	another := &OAuth2{TokenUrl: server.URL, ClientId: "client", ClientSecret: "secret"}
+	another.bind(t.Name(), server.Client(), nil)
+	token, err := another.GetAccessToken(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", token)
+	assert.Equal(t, int32(1), issued)
+
+	// expiring token would be renewed
+	oauth2.token.expiresAt = time.Now().Add(30 * time.Second)
+	token, err = oauth2.GetAccessToken(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "access-2", token)
+
+	invalid := &OAuth2{TokenUrl: server.URL, ClientId: "client", ClientSecret: "wrong"}
+	_, err = invalid.GetAccessToken(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, errors.Unauthorized, err.GetType())
+}
+
+func TestOAuth2RefreshTokenRotation(t *testing.T) {
+	var issued int32
+	server := newTestTokenServer(t, &issued, true)
+	defer server.Close()
+
+	var persisted []string
+	oauth2 := &OAuth2{TokenUrl: server.URL, ClientId: "client", ClientSecret: "secret", RefreshToken: "refresh-0"}
+	oauth2.bind(t.Name(), server.Client(), func(refreshToken string) errors.Error {
+		persisted = append(persisted, refreshToken)
+		return nil
+	})
+	token, err := oauth2.GetAccessToken(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "access-1", token)
+	assert.Equal(t, "refresh-1", oauth2.RefreshToken)
+	assert.Equal(t, []string{"refresh-1"}, persisted)
+
+	// the rotated refresh token is used for the next grant
+	oauth2.InvalidateAccessToken("access-1")
+	token, err = oauth2.GetAccessToken(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "access-2", token)
+	assert.Equal(t, []string{"refresh-1", "refresh-2"}, persisted)
+
+	// the token is kept under the key of the latest refresh token only
+	oauth2TokensMutex.Lock()
+	defer oauth2TokensMutex.Unlock()
+	for _, refreshToken := range []string{"refresh-0", "refresh-1"} {
+		_, ok := oauth2Tokens[oauth2TokenId(t.Name(), &OAuth2{TokenUrl: server.URL, ClientId: "client", ClientSecret: "secret", RefreshToken: refreshToken})]
+		assert.False(t, ok)
+	}
+	assert.Equal(t, oauth2.token, oauth2Tokens[oauth2TokenId(t.Name(), oauth2)])
+}
+
+type testOAuth2Connection struct {
+	BaseConnection `mapstructure:",squash"`
+	RestConnection `mapstructure:",squash"`
+	OAuth2         `mapstructure:",squash"`
+}
+
+func (testOAuth2Connection) TableName() string {
+	return "_tool_test_oauth2_connections"
+}
+
+type testPrefixedOAuth2Connection struct {
+	BaseConnection `mapstructure:",squash"`
+	RestConnection `mapstructure:",squash"`
+	Auth           OAuth2 `mapstructure:"auth" gorm:"embedded;embeddedPrefix:auth_"`
+	RefreshToken   string `gorm:"-"`
+}
+
+func (testPrefixedOAuth2Connection) TableName() string {
+	return "_tool_test_prefixed_oauth2_connections"
+}
+
+func TestRefreshTokenColumn(t *testing.T) {
+	connection := &testOAuth2Connection{}
+	column, err := refreshTokenColumn(connection, &connection.OAuth2)
+	assert.Nil(t, err)
+	assert.Equal(t, "refresh_token", column)
+
+	prefixed := &testPrefixedOAuth2Connection{}
+	column, err = refreshTokenColumn(prefixed, &prefixed.Auth)
+	assert.Nil(t, err)
+	assert.Equal(t, "auth_refresh_token", column)
+
+	_, err = refreshTokenColumn(prefixed, &OAuth2{})
+	assert.NotNil(t, err)
+}
+
+func TestApiClientRetryOnOAuth2Unauthorized(t *testing.T) {
+	var issued int32
+	tokenServer := newTestTokenServer(t, &issued, false)
+	defer tokenServer.Close()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		// the first access token was revoked
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	oauth2 := &OAuth2{TokenUrl: tokenServer.URL, ClientId: "client", ClientSecret: "secret"}
+	oauth2.bind(t.Name(), tokenServer.Client(), nil)
+	apiClient := &ApiClient{}
+	apiClient.Setup(server.URL, nil, 0)
+	apiClient.oauth2 = oauth2
+	apiClient.SetAuthFunction(oauth2.SetupAuthentication)
+
+	res, err := apiClient.Get("", nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, int32(2), requests)
+	assert.Equal(t, int32(2), issued)
+}
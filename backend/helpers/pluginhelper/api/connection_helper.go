@@ -23,6 +23,8 @@ import (
 	"strconv"
 
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/services"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
+	"github.com/apache/incubator-devlake/helpers/workspacehelper"
 	"github.com/apache/incubator-devlake/server/api/shared"
 
 	"github.com/apache/incubator-devlake/core/context"
@@ -41,6 +43,7 @@ type ConnectionApiHelper struct {
 	db               dal.Dal
 	validator        *validator.Validate
 	bpManager        *services.BlueprintManager
+	workspaceHelper  *workspacehelper.WorkspaceHelper
 	pluginName       string
 }
 
@@ -59,16 +62,25 @@ func NewConnectionHelper(
 		db:               basicRes.GetDal(),
 		validator:        vld,
 		bpManager:        services.NewBlueprintManager(basicRes.GetDal()),
+		workspaceHelper:  workspacehelper.NewWorkspaceHelper(basicRes),
 		pluginName:       pluginName,
 	}
 }
 
-// Create a connection record based on request body
+// Create a connection record based on request body, the connection and its workspace are saved in one transaction
 func (c *ConnectionApiHelper) Create(connection interface{}, input *plugin.ApiResourceInput) errors.Error {
-	return c.CreateWithTx(nil, connection, input)
+	tx := c.db.Begin()
+	err := c.CreateWithTx(tx, connection, input)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			c.log.Error(rollbackErr, "rollback creating connection")
+		}
+		return err
+	}
+	return tx.Commit()
 }
 
-// Create a connection record based on request body
+// CreateWithTx creates a connection record based on request body within the transaction
 func (c *ConnectionApiHelper) CreateWithTx(tx dal.Transaction, connection interface{}, input *plugin.ApiResourceInput) errors.Error {
 	// update fields from request body
 	db := c.db
@@ -83,7 +95,8 @@ func (c *ConnectionApiHelper) CreateWithTx(tx dal.Transaction, connection interf
 		c.log.Error(err, "create connection")
 		return err
 	}
-	return nil
+	connectionId := reflectField(connection, "ID").Uint()
+	return c.workspaceHelper.AddConnection(db, input.Workspace, c.pluginName, connectionId)
 }
 
 // Patch (Modify) a connection record based on request body
@@ -147,6 +160,14 @@ func (c *ConnectionApiHelper) List(connections interface{}) errors.Error {
 	return CallDB(c.db.All, connections)
 }
 
+// ListByWorkspace returns all connections visible to the workspace with password/token decrypted
+func (c *ConnectionApiHelper) ListByWorkspace(connections interface{}, workspace string) errors.Error {
+	if workspace == "" {
+		return c.List(connections)
+	}
+	return CallDB(c.db.All, connections, c.workspaceHelper.ConnectionFilter(c.pluginName, workspace))
+}
+
 // Delete connection.
 func (c *ConnectionApiHelper) deleteConnection(connection interface{}) (*services.BlueprintProjectPairs, errors.Error) {
 	connectionId := reflectField(connection, "ID").Uint()
@@ -172,6 +193,14 @@ func (c *ConnectionApiHelper) deleteConnection(connection interface{}) (*service
 			return nil, errors.Default.Wrap(err, fmt.Sprintf("error deleting scope-configs for plugin %s using connection %d", c.pluginName, connectionId))
 		}
 	}
+	err = c.workspaceHelper.RemoveConnection(c.db, c.pluginName, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	err = srvhelper.DeleteConnectionApiResponseCache(c.db, c.pluginName, connectionId)
+	if err != nil {
+		return nil, err
+	}
 	return nil, CallDB(c.db.Delete, connection)
 }
 
@@ -18,6 +18,8 @@ limitations under the License.
 package api
 
 import (
+	gocontext "context"
+	"net/http"
 	"strconv"
 
 	"github.com/apache/incubator-devlake/server/api/shared"
@@ -25,6 +27,7 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/secrethelper"
 	"github.com/apache/incubator-devlake/helpers/srvhelper"
 )
 
@@ -53,12 +56,59 @@ func (connApi *DsConnectionApiHelper[C, S, SC]) GetMergedConnection(input *plugi
 	if err != nil {
 		return nil, errors.BadInput.Wrap(err, "find connection from db")
 	}
+	endpoint, proxy := getEndpointAndProxy(connection)
 	if input.Body != nil {
 		if err := DecodeMapStruct(input.Body, connection, false); err != nil {
 			return nil, err
 		}
 	}
-	return connection, nil
+	if !secrethelper.HasReferences(connection) {
+		return connection, nil
+	}
+	// testing the connection sends the secrets to the endpoint, which must not be the one the caller just gave
+	if mergedEndpoint, mergedProxy := getEndpointAndProxy(connection); mergedEndpoint != endpoint || mergedProxy != proxy {
+		return nil, errors.BadInput.New("secret references are not resolved for a changed endpoint or proxy, please save the connection before testing it")
+	}
+	ctx := gocontext.Background()
+	if input.Request != nil {
+		ctx = input.Request.Context()
+	}
+	return resolveConnectionSecrets(ctx, connApi.basicRes, connApi.GetPluginName(), (*connection).ConnectionId(), connection)
+}
+
+func getEndpointAndProxy(connection interface{}) (string, string) {
+	if apiConnection, ok := connection.(plugin.ApiConnection); ok {
+		return apiConnection.GetEndpoint(), apiConnection.GetProxy()
+	}
+	return "", ""
+}
+
+// GetAll returns all connections visible to the caller
+func (connApi *DsConnectionApiHelper[C, S, SC]) GetAll(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connections, err := connApi.ConnectionSrvHelper.GetAllByWorkspace(input.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: connApi.BatchSanitize(connections),
+	}, nil
+}
+
+// Post creates a connection and assigns it to the workspace of the caller
+func (connApi *DsConnectionApiHelper[C, S, SC]) Post(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connection := new(C)
+	err := DecodeMapStruct(input.Body, connection, false)
+	if err != nil {
+		return nil, err
+	}
+	err = connApi.ConnectionSrvHelper.CreateInWorkspace(connection, input.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Status: http.StatusCreated,
+		Body:   connApi.Sanitize(connection),
+	}, nil
 }
 
 func (connApi *DsConnectionApiHelper[C, S, SC]) Delete(input *plugin.ApiResourceInput) (out *plugin.ApiResourceOutput, err errors.Error) {
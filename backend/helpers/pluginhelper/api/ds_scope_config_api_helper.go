@@ -18,6 +18,8 @@ limitations under the License.
 package api
 
 import (
+	"strconv"
+
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -29,8 +31,17 @@ import (
 type DsScopeConfigApiHelper[C plugin.ToolLayerConnection, S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] struct {
 	*ModelApiHelper[SC]
 	*srvhelper.ScopeConfigSrvHelper[C, S, SC]
+	scopeSrv *srvhelper.ModelSrvHelper[S]
 }
 
+// ScopeConfigDryRunEvaluator evaluates the draft scope config against the tool-layer rows collected for the scope
+// and records the outcome into the result, it must not write anything into the database
+type ScopeConfigDryRunEvaluator[S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] func(
+	scope *S,
+	draft *SC,
+	result *ScopeConfigDryRunResult,
+) errors.Error
+
 func NewDsScopeConfigApiHelper[
 	C plugin.ToolLayerConnection,
 	S plugin.ToolLayerScope,
@@ -43,6 +54,7 @@ func NewDsScopeConfigApiHelper[
 	return &DsScopeConfigApiHelper[C, S, SC]{
 		ModelApiHelper:       NewModelApiHelper[SC](basicRes, dalHelper.ModelSrvHelper, []string{"scopeConfigId"}, sterilizer),
 		ScopeConfigSrvHelper: dalHelper,
+		scopeSrv:             srvhelper.NewModelSrvHelper[S](basicRes, nil),
 	}
 }
 
@@ -107,3 +119,58 @@ func (connApi *DsScopeConfigApiHelper[C, S, SC]) Delete(input *plugin.ApiResourc
 		Body: scopeConfig,
 	}, nil
 }
+
+// DryRun evaluates the draft scope config from the request body against the data collected for the scope
+// specified by the `scopeId` path variable, nothing would be saved.
+func (connApi *DsScopeConfigApiHelper[C, S, SC]) DryRun(
+	input *plugin.ApiResourceInput,
+	evaluator ScopeConfigDryRunEvaluator[S, SC],
+) (*plugin.ApiResourceOutput, errors.Error) {
+	return dryRunScopeConfig(input, func(connectionId uint64, scopeId string) (*S, errors.Error) {
+		return connApi.scopeSrv.FindByPk(connectionId, scopeId)
+	}, evaluator)
+}
+
+// dryRunScopeConfig validates the request before looking up the scope so bad requests never hit the database
+func dryRunScopeConfig[S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig](
+	input *plugin.ApiResourceInput,
+	findScope func(connectionId uint64, scopeId string) (*S, errors.Error),
+	evaluator ScopeConfigDryRunEvaluator[S, SC],
+) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := extractConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	scopeId, ok := input.Params["scopeId"]
+	if !ok || scopeId == "" {
+		return nil, errors.BadInput.New("scopeId is required")
+	}
+	sampleSize := 10
+	if s := input.Query.Get("sampleSize"); s != "" {
+		sampleSize, err = errors.Convert01(strconv.Atoi(s))
+		if err != nil || sampleSize < 0 {
+			return nil, errors.BadInput.New("sampleSize must be a non-negative number")
+		}
+	}
+	if input.Body == nil {
+		return nil, errors.BadInput.New("draft scope config is required")
+	}
+	input.Body["connectionId"] = connectionId
+	draft := new(SC)
+	err = DecodeMapStruct(input.Body, draft, false)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid scope config")
+	}
+	scope, err := findScope(connectionId, scopeId)
+	if err != nil {
+		return nil, err
+	}
+	result := NewScopeConfigDryRunResult(sampleSize)
+	err = evaluator(scope, draft, result)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: result,
+	}, nil
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestDraftScopeConfig struct {
+	common.ScopeConfig `mapstructure:",squash"`
+	DeploymentPattern  string `mapstructure:"deploymentPattern,omitempty"`
+}
+
+func (TestDraftScopeConfig) TableName() string {
+	return "_tool_test_scope_configs"
+}
+
+func TestDsScopeConfigApiHelper_DryRun(t *testing.T) {
+	scope := &TestFakeGithubRepo{GithubId: 1, Name: "repo"}
+	findCalls := 0
+	findScope := func(connectionId uint64, scopeId string) (*TestFakeGithubRepo, errors.Error) {
+		findCalls++
+		if connectionId != 2 || scopeId != "1" {
+			return nil, errors.NotFound.New("scope not found")
+		}
+		return scope, nil
+	}
+	evaluator := func(s *TestFakeGithubRepo, draft *TestDraftScopeConfig, result *ScopeConfigDryRunResult) errors.Error {
+		assert.Equal(t, scope, s)
+		assert.Equal(t, uint64(2), draft.ConnectionId)
+		if draft.DeploymentPattern == "(" {
+			return errors.BadInput.New("invalid value for `deploymentPattern`")
+		}
+		result.Evaluate("runs")
+		result.Match("runs", "DEPLOYMENT", draft.DeploymentPattern)
+		return nil
+	}
+	newInput := func(params map[string]string, query url.Values, body map[string]interface{}) *plugin.ApiResourceInput {
+		return &plugin.ApiResourceInput{Params: params, Query: query, Body: body}
+	}
+
+	// a valid draft is decoded and evaluated against the scope
+	out, err := dryRunScopeConfig(newInput(
+		map[string]string{"connectionId": "2", "scopeId": "1"},
+		url.Values{"sampleSize": []string{"1"}},
+		map[string]interface{}{"deploymentPattern": "deploy"},
+	), findScope, evaluator)
+	assert.Nil(t, err)
+	result := out.Body.(*ScopeConfigDryRunResult)
+	assert.Equal(t, map[string]int{"runs": 1}, result.Evaluated)
+	assert.Equal(t, []interface{}{"deploy"}, result.Matches[0].Samples)
+	assert.Equal(t, 1, findCalls)
+
+	// malformed requests are rejected before the scope is looked up
+	for name, input := range map[string]*plugin.ApiResourceInput{
+		"missing connectionId": newInput(map[string]string{"scopeId": "1"}, url.Values{}, map[string]interface{}{}),
+		"invalid connectionId": newInput(map[string]string{"connectionId": "x", "scopeId": "1"}, url.Values{}, map[string]interface{}{}),
+		"missing scopeId":      newInput(map[string]string{"connectionId": "2"}, url.Values{}, map[string]interface{}{}),
+		"negative sampleSize":  newInput(map[string]string{"connectionId": "2", "scopeId": "1"}, url.Values{"sampleSize": []string{"-1"}}, map[string]interface{}{}),
+		"invalid sampleSize":   newInput(map[string]string{"connectionId": "2", "scopeId": "1"}, url.Values{"sampleSize": []string{"x"}}, map[string]interface{}{}),
+		"missing body":         newInput(map[string]string{"connectionId": "2", "scopeId": "1"}, url.Values{}, nil),
+		"undecodable body":     newInput(map[string]string{"connectionId": "2", "scopeId": "1"}, url.Values{}, map[string]interface{}{"deploymentPattern": []int{1}}),
+	} {
+		_, err = dryRunScopeConfig(input, findScope, evaluator)
+		assert.NotNil(t, err, name)
+		assert.Equal(t, errors.BadInput, err.GetType(), name)
+	}
+	assert.Equal(t, 1, findCalls)
+
+	// unknown scope
+	_, err = dryRunScopeConfig(newInput(
+		map[string]string{"connectionId": "2", "scopeId": "3"}, url.Values{}, map[string]interface{}{},
+	), findScope, evaluator)
+	assert.Equal(t, errors.NotFound, err.GetType())
+
+	// evaluator errors are returned as is
+	_, err = dryRunScopeConfig(newInput(
+		map[string]string{"connectionId": "2", "scopeId": "1"}, url.Values{}, map[string]interface{}{"deploymentPattern": "("},
+	), findScope, evaluator)
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
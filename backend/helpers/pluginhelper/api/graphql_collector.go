@@ -43,6 +43,10 @@ type GraphqlRequestData struct {
 	Params    interface{}
 	Input     interface{}
 	InputJSON []byte
+	// the page number, the collector checkpoint records the cursor of it
+	page int
+	// tracks the input for the collector checkpoint
+	checkpoint *checkpointInput
 }
 
 // GraphqlQueryPageInfo contains the pagination data
@@ -81,6 +85,9 @@ type GraphqlCollectorArgs struct {
 	// one of ResponseParser and ResponseParserEvenWhenDataErrors is required to parse response
 	ResponseParser    func(queryWrapper interface{}) ([]json.RawMessage, errors.Error)
 	IgnoreQueryErrors bool
+	// Resumable records the progress of the collection like ApiCollectorArgs.Resumable, the inputs collected completely,
+	// or the cursor of the last page collected for collections without input
+	Resumable bool
 }
 
 // GraphqlCollector help you collect data from Graphql services
@@ -89,6 +96,9 @@ type GraphqlCollector struct {
 	args         *GraphqlCollectorArgs
 	workerErrors []error
 	batchSave    *BatchSave
+	checkpoint   *collectorCheckpoint
+	// checkpointName tells the checkpoints of the collectors of the same subtask apart, the subtask name by default
+	checkpointName string
 }
 
 // ErrFinishCollect is an error which will finish this collector
@@ -139,8 +149,25 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 	if err != nil {
 		return errors.Default.Wrap(err, "error running auto-migrate")
 	}
-	// flush data if not incremental collection
-	if !collector.args.Incremental {
+	// resume the previous collection if it crashed
+	if collector.args.Resumable {
+		ttl, parseErr := time.ParseDuration(collector.args.Ctx.GetConfig("COLLECTOR_CHECKPOINT_TTL"))
+		if parseErr != nil {
+			return errors.BadInput.Wrap(parseErr, "invalid COLLECTOR_CHECKPOINT_TTL")
+		}
+		// graphql collectors have no url template, the queries are built by the subtask
+		name := collector.checkpointName
+		if name == "" {
+			name = "graphql:" + collector.args.Ctx.GetName()
+		}
+		options := collectorCheckpointOptions(collector.args.Ctx.TaskContext(), collector.args.PageSize)
+		collector.checkpoint, err = loadCollectorCheckpoint(db, logger, collector.table, collector.params, name, options, collector.args.Incremental, ttl)
+		if err != nil {
+			return err
+		}
+	}
+	// flush data if not incremental collection, and keep the data collected before the crash when resuming
+	if !collector.args.Incremental && (collector.checkpoint == nil || !collector.checkpoint.resumed) {
 		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
 		if err != nil {
 			return errors.Default.Wrap(err, "error deleting data from collector")
@@ -196,6 +223,9 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 	}
 
 	err = collector.batchSave.Close()
+	if err == nil && collector.checkpoint != nil {
+		err = collector.checkpoint.clear(db)
+	}
 	return err
 }
 
@@ -211,6 +241,23 @@ func (collector *GraphqlCollector) exec(input interface{}) {
 		SkipCursor: nil,
 		Size:       collector.args.PageSize,
 	}
+	reqData.page = 1
+	if collector.checkpoint != nil {
+		if collector.args.Input != nil {
+			reqData.checkpoint = collector.checkpoint.startInput(inputJson)
+			// the input was collected completely before the crash
+			if reqData.checkpoint == nil {
+				collector.args.Ctx.IncProgress(1)
+				return
+			}
+		} else if collector.args.GetPageInfo != nil {
+			page, cursor := collector.checkpoint.resumePage()
+			if cursor != nil {
+				reqData.page = page
+				reqData.Pager.SkipCursor = cursor
+			}
+		}
+	}
 	if collector.args.GetPageInfo != nil {
 		collector.fetchOneByOne(reqData)
 	} else {
@@ -221,8 +268,8 @@ func (collector *GraphqlCollector) exec(input interface{}) {
 // fetchOneByOne fetches data of all pages for APIs that return paging information
 func (collector *GraphqlCollector) fetchOneByOne(reqData *GraphqlRequestData) {
 	// fetch first page
-	var fetchNextPage func(query interface{}) errors.Error
-	fetchNextPage = func(query interface{}) errors.Error {
+	var fetchNextPage func(current *GraphqlRequestData, query interface{}) errors.Error
+	fetchNextPage = func(current *GraphqlRequestData, query interface{}) errors.Error {
 		pageInfo, err := collector.args.GetPageInfo(query, collector.args)
 		if err != nil {
 			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
@@ -230,26 +277,35 @@ func (collector *GraphqlCollector) fetchOneByOne(reqData *GraphqlRequestData) {
 		if pageInfo == nil {
 			return errors.Default.New("fetchPagesDetermined got pageInfo is nil")
 		}
-		if pageInfo.HasNextPage {
-			collector.args.GraphqlClient.NextTick(func() errors.Error {
-				reqDataTemp := &GraphqlRequestData{
-					Pager: &CursorPager{
-						SkipCursor: &pageInfo.EndCursor,
-						Size:       collector.args.PageSize,
-					},
-					Input:     reqData.Input,
-					InputJSON: reqData.InputJSON,
-				}
-				collector.fetchAsync(reqDataTemp, fetchNextPage)
-				return nil
-			}, collector.checkError)
+		if !pageInfo.HasNextPage {
+			return collector.inputDone(current)
+		}
+		if collector.checkpoint != nil && collector.args.Input == nil {
+			checkpointErr := collector.checkpoint.cursorDone(current.page, pageInfo.EndCursor)
+			if checkpointErr != nil {
+				return checkpointErr
+			}
 		}
+		collector.args.GraphqlClient.NextTick(func() errors.Error {
+			reqDataTemp := &GraphqlRequestData{
+				Pager: &CursorPager{
+					SkipCursor: &pageInfo.EndCursor,
+					Size:       collector.args.PageSize,
+				},
+				Input:      reqData.Input,
+				InputJSON:  reqData.InputJSON,
+				page:       current.page + 1,
+				checkpoint: reqData.checkpoint,
+			}
+			collector.fetchAsync(reqDataTemp, fetchNextPage)
+			return nil
+		}, collector.checkError)
 		return nil
 	}
 	collector.fetchAsync(reqData, fetchNextPage)
 }
 
-func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handler func(query interface{}) errors.Error) {
+func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handler func(reqData *GraphqlRequestData, query interface{}) errors.Error) {
 	if reqData.Pager == nil {
 		reqData.Pager = &CursorPager{
 			SkipCursor: nil,
@@ -324,14 +380,24 @@ func (collector *GraphqlCollector) fetchAsync(reqData *GraphqlRequestData, handl
 	collector.args.Ctx.IncProgress(1)
 	if handler != nil {
 		// trigger next fetch, but return if ErrFinishCollect got from ResponseParser
-		err = handler(query)
+		err = handler(reqData, query)
 		if err != nil {
 			collector.checkError(errors.Default.Wrap(err, `handle failed in graphql collector`))
 			return
 		}
+	} else {
+		err = collector.inputDone(reqData)
+		if err != nil {
+			collector.checkError(err)
+		}
 	}
 }
 
+// inputDone records the input as collected once its last page was saved, the pages of an input are fetched one by one
+func (collector *GraphqlCollector) inputDone(reqData *GraphqlRequestData) errors.Error {
+	return reqData.checkpoint.release(nil)
+}
+
 func (collector *GraphqlCollector) checkError(err error) {
 	if err == nil {
 		return
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+// ScopeConfigDryRunMatch holds how many rows of a tool-layer table would fall into the specified category
+type ScopeConfigDryRunMatch struct {
+	Table    string        `json:"table"`
+	Category string        `json:"category"`
+	Count    int           `json:"count"`
+	Samples  []interface{} `json:"samples"`
+}
+
+// ScopeConfigDryRunResult accumulates the outcome of evaluating a draft scope config against collected data
+type ScopeConfigDryRunResult struct {
+	Evaluated  map[string]int            `json:"evaluated"`
+	Matches    []*ScopeConfigDryRunMatch `json:"matches"`
+	sampleSize int
+	index      map[string]*ScopeConfigDryRunMatch
+}
+
+// NewScopeConfigDryRunResult creates a ScopeConfigDryRunResult keeping at most sampleSize samples per category
+func NewScopeConfigDryRunResult(sampleSize int) *ScopeConfigDryRunResult {
+	return &ScopeConfigDryRunResult{
+		Evaluated:  make(map[string]int),
+		Matches:    make([]*ScopeConfigDryRunMatch, 0),
+		sampleSize: sampleSize,
+		index:      make(map[string]*ScopeConfigDryRunMatch),
+	}
+}
+
+// Evaluate records that a row from the table has been evaluated
+func (r *ScopeConfigDryRunResult) Evaluate(table string) {
+	r.Evaluated[table]++
+}
+
+// Match records that a row from the table falls into the category, the sample is kept if there is still room
+func (r *ScopeConfigDryRunResult) Match(table, category string, sample interface{}) {
+	key := table + ":" + category
+	match, ok := r.index[key]
+	if !ok {
+		match = &ScopeConfigDryRunMatch{
+			Table:    table,
+			Category: category,
+			Samples:  make([]interface{}, 0),
+		}
+		r.index[key] = match
+		r.Matches = append(r.Matches, match)
+	}
+	match.Count++
+	if len(match.Samples) < r.sampleSize {
+		match.Samples = append(match.Samples, sample)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScopeConfigDryRunResult(t *testing.T) {
+	result := NewScopeConfigDryRunResult(2)
+	for i := 0; i < 5; i++ {
+		result.Evaluate("runs")
+	}
+	result.Evaluate("issues")
+	result.Match("runs", "DEPLOYMENT", "deploy 1")
+	result.Match("runs", "DEPLOYMENT", "deploy 2")
+	result.Match("runs", "DEPLOYMENT", "deploy 3")
+	result.Match("issues", "BUG", "bug 1")
+
+	assert.Equal(t, map[string]int{"runs": 5, "issues": 1}, result.Evaluated)
+	assert.Len(t, result.Matches, 2)
+	assert.Equal(t, "runs", result.Matches[0].Table)
+	assert.Equal(t, "DEPLOYMENT", result.Matches[0].Category)
+	assert.Equal(t, 3, result.Matches[0].Count)
+	assert.Equal(t, []interface{}{"deploy 1", "deploy 2"}, result.Matches[0].Samples)
+	assert.Equal(t, "BUG", result.Matches[1].Category)
+	assert.Equal(t, 1, result.Matches[1].Count)
+}
@@ -39,6 +39,7 @@ type GetBlueprintQuery struct {
 	PageSize    int
 	Mode        string
 	Type        string
+	Workspace   string
 }
 
 type BlueprintProjectPairs struct {
@@ -155,6 +156,9 @@ func (b *BlueprintManager) GetDbBlueprints(query *GetBlueprintQuery) ([]*models.
 	if query.Mode != "" {
 		clauses = append(clauses, dal.Where("mode = ?", query.Mode))
 	}
+	if query.Workspace != "" {
+		clauses = append(clauses, dal.Where("_devlake_blueprints.workspace = ?", query.Workspace))
+	}
 
 	// count total records
 	// var count int64
@@ -0,0 +1,356 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrethelper
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+const (
+	// SecretRefScheme is the prefix of the references to secrets stored outside of DevLake, i.e.
+	// secretref://env/NAME, secretref://file/run/secrets/token or secretref://vault/secret/devlake/github#token
+	SecretRefScheme = "secretref://"
+	// EnvRefPrefix is the short form of secretref://env/NAME
+	EnvRefPrefix = "env:"
+)
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsReference tells whether the value refers to a secret stored outside of DevLake
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, SecretRefScheme) || strings.HasPrefix(value, EnvRefPrefix)
+}
+
+// Resolver resolves references to secrets stored in the environment, files or a HashiCorp Vault compatible
+// KV engine. To prevent connections from leaking arbitrary files or environment variables of the server,
+// only variables starting with SECRET_REF_ENV_PREFIX and files under SECRET_REF_FILE_DIRS are allowed.
+// References of connections bound to a workspace are further limited to the prefixes configured for the
+// workspace by SECRET_REF_WORKSPACE_PREFIXES, so tenants can't read the secrets of each other
+type Resolver struct {
+	envPrefix      string
+	fileDirs       []string
+	vaultAddr      string
+	vaultToken     string
+	vaultNamespace string
+	vaultKvVersion int
+	httpClient     *http.Client
+	workspace      string
+	// workspacePrefixes are the canonical reference prefixes allowed for the workspace
+	workspacePrefixes []string
+}
+
+// NewResolver creates a Resolver for the connections of the workspace, an empty workspace stands for the
+// connections not bound to any workspace
+func NewResolver(cfg config.ConfigReader, workspace string) *Resolver {
+	var fileDirs []string
+	for _, dir := range strings.Split(cfg.GetString("SECRET_REF_FILE_DIRS"), ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			fileDirs = append(fileDirs, filepath.Clean(dir))
+		}
+	}
+	kvVersion := cfg.GetInt("VAULT_KV_VERSION")
+	if kvVersion == 0 {
+		kvVersion = 2
+	}
+	resolver := &Resolver{
+		envPrefix:      cfg.GetString("SECRET_REF_ENV_PREFIX"),
+		fileDirs:       fileDirs,
+		vaultAddr:      strings.TrimSuffix(cfg.GetString("VAULT_ADDR"), "/"),
+		vaultToken:     cfg.GetString("VAULT_TOKEN"),
+		vaultNamespace: cfg.GetString("VAULT_NAMESPACE"),
+		vaultKvVersion: kvVersion,
+		httpClient:     &http.Client{Timeout: 30 * time.Second},
+		workspace:      workspace,
+	}
+	if workspace != "" {
+		// SECRET_REF_WORKSPACE_PREFIXES is a comma separated list of `workspace:prefix` pairs, a workspace may
+		// have multiple prefixes, i.e. `team-a:secretref://vault/secret/team-a/,team-a:env:DEVLAKE_SECRET_TEAM_A_`
+		for _, binding := range strings.Split(cfg.GetString("SECRET_REF_WORKSPACE_PREFIXES"), ",") {
+			name, prefix, ok := strings.Cut(strings.TrimSpace(binding), ":")
+			if ok && strings.TrimSpace(name) == workspace && strings.TrimSpace(prefix) != "" {
+				prefix = strings.TrimSpace(prefix)
+				if strings.HasPrefix(prefix, EnvRefPrefix) {
+					prefix = SecretRefScheme + "env/" + strings.TrimPrefix(prefix, EnvRefPrefix)
+				}
+				resolver.workspacePrefixes = append(resolver.workspacePrefixes, prefix)
+			}
+		}
+	}
+	return resolver
+}
+
+// Resolve returns the secret the value refers to, the value is returned as is if it is not a reference
+func (r *Resolver) Resolve(ctx gocontext.Context, value string) (string, errors.Error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	ref, err := canonicalReference(value)
+	if err != nil {
+		return "", err
+	}
+	if r.workspace != "" && !r.isAllowedForWorkspace(ref.String()) {
+		return "", errors.Forbidden.New(fmt.Sprintf("secret reference %s is not allowed for workspace %s", ref.String(), r.workspace))
+	}
+	switch ref.Host {
+	case "env":
+		return r.resolveEnv(strings.TrimPrefix(ref.Path, "/"))
+	case "file":
+		return r.resolveFile(ref.Path)
+	default:
+		return r.resolveVault(ctx, strings.TrimPrefix(ref.Path, "/"), ref.Fragment)
+	}
+}
+
+// canonicalReference converts the short forms to secretref:// and cleans the path, so the workspace prefixes
+// can't be bypassed by `..` segments
+func canonicalReference(value string) (*url.URL, errors.Error) {
+	if strings.HasPrefix(value, EnvRefPrefix) {
+		value = SecretRefScheme + "env/" + strings.TrimPrefix(value, EnvRefPrefix)
+	}
+	ref, err := url.Parse(value)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid secret reference")
+	}
+	switch ref.Host {
+	case "env", "file", "vault":
+	default:
+		return nil, errors.BadInput.New(fmt.Sprintf("unsupported secret provider: %s", ref.Host))
+	}
+	if ref.Path != "" {
+		ref.Path = path.Clean(ref.Path)
+	}
+	ref.RawPath = ""
+	return ref, nil
+}
+
+func (r *Resolver) isAllowedForWorkspace(ref string) bool {
+	for _, prefix := range r.workspacePrefixes {
+		if strings.HasPrefix(ref, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Resolver) resolveEnv(name string) (string, errors.Error) {
+	if !envNamePattern.MatchString(name) {
+		return "", errors.BadInput.New(fmt.Sprintf("invalid environment variable name: %s", name))
+	}
+	if !strings.HasPrefix(name, r.envPrefix) {
+		return "", errors.Forbidden.New(fmt.Sprintf("environment variable %s is not allowed, it must start with %s", name, r.envPrefix))
+	}
+	secret, ok := os.LookupEnv(name)
+	if !ok || secret == "" {
+		return "", errors.NotFound.New(fmt.Sprintf("environment variable %s is not set", name))
+	}
+	return secret, nil
+}
+
+func (r *Resolver) resolveFile(path string) (string, errors.Error) {
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) {
+		return "", errors.BadInput.New(fmt.Sprintf("secret file path must be absolute: %s", path))
+	}
+	if !r.isAllowedFile(path) {
+		return "", errors.Forbidden.New(fmt.Sprintf("secret file %s is not under any of SECRET_REF_FILE_DIRS", path))
+	}
+	// follow the symlinks (i.e. kubernetes secret volumes) and check again, so they can't lead us elsewhere
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.NotFound.Wrap(err, fmt.Sprintf("secret file %s does not exist", path))
+		}
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to read secret file %s", path))
+	}
+	if !r.isAllowedFile(realPath) {
+		return "", errors.Forbidden.New(fmt.Sprintf("secret file %s is not under any of SECRET_REF_FILE_DIRS", path))
+	}
+	content, err := os.ReadFile(realPath)
+	if err != nil {
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to read secret file %s", path))
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+func (r *Resolver) isAllowedFile(path string) bool {
+	for _, dir := range r.fileDirs {
+		dirs := []string{dir}
+		if realDir, err := filepath.EvalSymlinks(dir); err == nil && realDir != dir {
+			dirs = append(dirs, realDir)
+		}
+		for _, d := range dirs {
+			if rel, err := filepath.Rel(d, path); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// resolveVault reads the key of the secret from the KV engine, the first segment of the path is the mount point
+func (r *Resolver) resolveVault(ctx gocontext.Context, path string, key string) (string, errors.Error) {
+	if r.vaultAddr == "" {
+		return "", errors.BadInput.New("VAULT_ADDR is required to resolve vault secret references")
+	}
+	mount, secretPath, found := strings.Cut(path, "/")
+	if !found || mount == "" || secretPath == "" || key == "" {
+		return "", errors.BadInput.New("vault secret reference must look like secretref://vault/<mount>/<path>#<key>")
+	}
+	apiUrl := fmt.Sprintf("%s/v1/%s/%s", r.vaultAddr, mount, secretPath)
+	if r.vaultKvVersion == 2 {
+		apiUrl = fmt.Sprintf("%s/v1/%s/data/%s", r.vaultAddr, mount, secretPath)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl, nil)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to create vault request")
+	}
+	req.Header.Set("X-Vault-Token", r.vaultToken)
+	if r.vaultNamespace != "" {
+		req.Header.Set("X-Vault-Namespace", r.vaultNamespace)
+	}
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to request vault")
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return "", errors.NotFound.New(fmt.Sprintf("vault secret %s does not exist", path))
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to read vault secret %s", path))
+	}
+	body := &struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(body); err != nil {
+		return "", errors.Default.Wrap(err, "failed to decode vault response")
+	}
+	data := body.Data
+	if r.vaultKvVersion == 2 {
+		// kv v2 wraps the secret with metadata
+		data, _ = data["data"].(map[string]interface{})
+	}
+	secret, ok := data[key].(string)
+	if !ok {
+		return "", errors.NotFound.New(fmt.Sprintf("key %s does not exist in vault secret %s", key, path))
+	}
+	return secret, nil
+}
+
+// HasReferences tells whether any of the encrypted (`serializer:encdec`) string fields of the struct pointed by
+// obj refers to an external secret, nested and embedded structs are included
+func HasReferences(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	return hasReferences(v.Elem())
+}
+
+func hasReferences(v reflect.Value) bool {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			if hasReferences(fieldValue) {
+				return true
+			}
+		case reflect.Ptr:
+			if field.IsExported() && !fieldValue.IsNil() && fieldValue.Elem().Kind() == reflect.Struct && hasReferences(fieldValue.Elem()) {
+				return true
+			}
+		case reflect.String:
+			if isEncryptedField(field) && IsReference(fieldValue.String()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveStruct returns a copy of the struct pointed by obj with the references in the encrypted
+// (`serializer:encdec`) string fields replaced by the secrets they refer to, nested and embedded structs are
+// included. obj is left untouched so the secrets would never be saved in place of the references
+func (r *Resolver) ResolveStruct(ctx gocontext.Context, obj interface{}) (interface{}, errors.Error) {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return obj, nil
+	}
+	resolved := reflect.New(v.Elem().Type())
+	resolved.Elem().Set(v.Elem())
+	if err := r.resolveStruct(ctx, resolved.Elem()); err != nil {
+		return nil, err
+	}
+	return resolved.Interface(), nil
+}
+
+// resolveStruct resolves the references of the copied struct, the nested structs referred by pointers are
+// copied before being resolved since they are shared with the original struct
+func (r *Resolver) resolveStruct(ctx gocontext.Context, v reflect.Value) errors.Error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldValue := v.Field(i)
+		switch fieldValue.Kind() {
+		case reflect.Struct:
+			if err := r.resolveStruct(ctx, fieldValue); err != nil {
+				return err
+			}
+		case reflect.Ptr:
+			if !fieldValue.CanSet() || fieldValue.IsNil() || fieldValue.Elem().Kind() != reflect.Struct || !hasReferences(fieldValue.Elem()) {
+				continue
+			}
+			nested := reflect.New(fieldValue.Elem().Type())
+			nested.Elem().Set(fieldValue.Elem())
+			if err := r.resolveStruct(ctx, nested.Elem()); err != nil {
+				return err
+			}
+			fieldValue.Set(nested)
+		case reflect.String:
+			if !fieldValue.CanSet() || !isEncryptedField(field) || !IsReference(fieldValue.String()) {
+				continue
+			}
+			secret, err := r.Resolve(ctx, fieldValue.String())
+			if err != nil {
+				return errors.Default.Wrap(err, fmt.Sprintf("failed to resolve secret reference of %s", field.Name))
+			}
+			fieldValue.SetString(secret)
+		}
+	}
+	return nil
+}
+
+func isEncryptedField(field reflect.StructField) bool {
+	return strings.Contains(field.Tag.Get("gorm"), "serializer:encdec")
+}
@@ -0,0 +1,189 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrethelper
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"gorm.io/gorm/schema"
+)
+
+// RotationResult summarizes the values re-encrypted by RotateEncryptionSecret
+type RotationResult struct {
+	// Columns lists the encrypted columns in the form of table.column
+	Columns []string
+	// Rotated is the number of distinct values re-encrypted with the new secret
+	Rotated int
+	// Skipped is the number of distinct values which were encrypted with the new secret already
+	Skipped int
+}
+
+// EncryptedColumn is a column whose values are encrypted by the `encdec` serializer
+type EncryptedColumn struct {
+	Table  string
+	Column string
+}
+
+// RotateEncryptionSecret re-encrypts all values of the `serializer:encdec` fields of the models with the new
+// secret in a single transaction, models whose tables don't exist are skipped.
+// Values encrypted with the new secret already are skipped, so the rotation can be safely re-run after a failure.
+// Note that api keys are hashed with ENCRYPTION_SECRET and have to be regenerated after the rotation.
+func RotateEncryptionSecret(db dal.Dal, logger log.Logger, tables []dal.Tabler, oldSecret string, newSecret string, dryRun bool) (*RotationResult, errors.Error) {
+	if oldSecret == "" || newSecret == "" {
+		return nil, errors.BadInput.New("both the old and the new encryption secret are required")
+	}
+	if oldSecret == newSecret {
+		return nil, errors.BadInput.New("the new encryption secret must be different from the old one")
+	}
+	result := &RotationResult{}
+	var err errors.Error
+	tx := db.Begin()
+	defer func() {
+		if err != nil || dryRun {
+			if rollbackErr := tx.Rollback(); rollbackErr != nil {
+				logger.Error(rollbackErr, "failed to rollback")
+			}
+		}
+	}()
+	for _, column := range GetEncryptedColumns(tables) {
+		if !tx.HasTable(column.Table) {
+			logger.Info("skip %s.%s since the table doesn't exist", column.Table, column.Column)
+			continue
+		}
+		logger.Info("re-encrypting %s.%s", column.Table, column.Column)
+		result.Columns = append(result.Columns, fmt.Sprintf("%s.%s", column.Table, column.Column))
+		err = rotateColumn(tx, column.Table, column.Column, oldSecret, newSecret, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+	err = tx.Commit()
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to commit the rotation")
+	}
+	return result, nil
+}
+
+// GetEncryptedColumns returns the columns of the `serializer:encdec` fields of the models, fields of the
+// embedded structs are included
+func GetEncryptedColumns(tables []dal.Tabler) []EncryptedColumn {
+	var columns []EncryptedColumn
+	seen := make(map[EncryptedColumn]bool)
+	for _, table := range tables {
+		model := interface{}(table)
+		if dynamicTabler, ok := table.(models.DynamicTabler); ok {
+			model = dynamicTabler.Unwrap()
+		}
+		modelType := reflect.TypeOf(model)
+		for modelType.Kind() == reflect.Ptr {
+			modelType = modelType.Elem()
+		}
+		if modelType.Kind() != reflect.Struct {
+			continue
+		}
+		for _, column := range getEncryptedColumnNames(modelType) {
+			encryptedColumn := EncryptedColumn{Table: table.TableName(), Column: column}
+			if !seen[encryptedColumn] {
+				seen[encryptedColumn] = true
+				columns = append(columns, encryptedColumn)
+			}
+		}
+	}
+	return columns
+}
+
+func getEncryptedColumnNames(modelType reflect.Type) []string {
+	var columns []string
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		gormTag := schema.ParseTagSetting(field.Tag.Get("gorm"), ";")
+		if gormTag["-"] != "" {
+			continue
+		}
+		if (field.Anonymous || gormTag["EMBEDDED"] != "") && field.Type.Kind() == reflect.Struct {
+			for _, column := range getEncryptedColumnNames(field.Type) {
+				columns = append(columns, gormTag["EMBEDDEDPREFIX"]+column)
+			}
+			continue
+		}
+		if gormTag["SERIALIZER"] != "encdec" {
+			continue
+		}
+		column := gormTag["COLUMN"]
+		if column == "" {
+			column = schema.NamingStrategy{}.ColumnName("", field.Name)
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func rotateColumn(tx dal.Transaction, table string, column string, oldSecret string, newSecret string, result *RotationResult) errors.Error {
+	var values []string
+	err := tx.Pluck(
+		column,
+		&values,
+		dal.From(table),
+		dal.Where(fmt.Sprintf("%s IS NOT NULL AND %s <> ''", quote(tx, column), quote(tx, column))),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to load %s.%s", table, column))
+	}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		plainText, decryptErr := plugin.Decrypt(oldSecret, value)
+		if decryptErr != nil {
+			if _, e := plugin.Decrypt(newSecret, value); e == nil {
+				result.Skipped++
+				continue
+			}
+			return errors.Default.Wrap(decryptErr, fmt.Sprintf("failed to decrypt a value of %s.%s with either secret", table, column))
+		}
+		encrypted, encryptErr := plugin.Encrypt(newSecret, plainText)
+		if encryptErr != nil {
+			return encryptErr
+		}
+		// the encryption is deterministic, all rows sharing the same value are updated at once
+		err = tx.UpdateColumn(table, column, encrypted, dal.Where(fmt.Sprintf("%s = ?", quote(tx, column)), value))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("failed to update %s.%s", table, column))
+		}
+		result.Rotated++
+	}
+	return nil
+}
+
+func quote(db dal.Dal, name string) string {
+	if db.Dialect() == "postgres" {
+		return fmt.Sprintf(`"%s"`, name)
+	}
+	return fmt.Sprintf("`%s`", name)
+}
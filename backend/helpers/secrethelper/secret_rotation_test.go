@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrethelper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRestConnection struct {
+	Endpoint   string
+	CaCert     string `gorm:"type:text;serializer:encdec"`
+	ClientCert string `gorm:"type:text;serializer:encdec"`
+	ClientKey  string `gorm:"type:text;serializer:encdec"`
+}
+
+type testRotationConnection struct {
+	ID uint64 `gorm:"primaryKey"`
+	testRestConnection
+	testAccessToken
+	Creator   testAccessToken `gorm:"embedded;embeddedPrefix:creator_"`
+	AppKey    string          `gorm:"serializer:encdec"`
+	Ignored   string          `gorm:"-"`
+	Renamed   string          `gorm:"column:private_key;serializer:encdec"`
+	PlainText string
+}
+
+func (testRotationConnection) TableName() string {
+	return "_tool_test_connections"
+}
+
+func TestGetEncryptedColumns(t *testing.T) {
+	dynamicTabler := models.NewDynamicTabler("_tool_remote_connections", reflect.TypeOf(struct {
+		Token string `gorm:"serializer:encdec"`
+	}{})).New()
+	columns := GetEncryptedColumns([]dal.Tabler{
+		&testRotationConnection{},
+		&models.Blueprint{},
+		dynamicTabler,
+		// duplicated tables are listed once
+		&testRotationConnection{},
+	})
+	assert.Equal(t, []EncryptedColumn{
+		{"_tool_test_connections", "ca_cert"},
+		{"_tool_test_connections", "client_cert"},
+		{"_tool_test_connections", "client_key"},
+		{"_tool_test_connections", "token"},
+		{"_tool_test_connections", "creator_token"},
+		{"_tool_test_connections", "app_key"},
+		{"_tool_test_connections", "private_key"},
+		{"_devlake_blueprints", "plan"},
+		{"_devlake_blueprints", "before_plan"},
+		{"_devlake_blueprints", "after_plan"},
+		{"_tool_remote_connections", "token"},
+	}, columns)
+}
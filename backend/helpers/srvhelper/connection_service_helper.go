@@ -18,6 +18,7 @@ limitations under the License.
 package srvhelper
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/apache/incubator-devlake/core/context"
@@ -25,12 +26,15 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/dbhelper"
+	"github.com/apache/incubator-devlake/helpers/workspacehelper"
 )
 
 // ConnectionSrvHelper
 type ConnectionSrvHelper[C plugin.ToolLayerConnection, S plugin.ToolLayerScope, SC plugin.ToolLayerScopeConfig] struct {
 	*ModelSrvHelper[C]
-	pluginName string
+	pluginName      string
+	workspaceHelper *workspacehelper.WorkspaceHelper
 }
 
 // NewConnectionSrvHelper creates a ConnectionDalHelper for connection management
@@ -43,11 +47,44 @@ func NewConnectionSrvHelper[
 	pluginName string,
 ) *ConnectionSrvHelper[C, S, SC] {
 	return &ConnectionSrvHelper[C, S, SC]{
-		ModelSrvHelper: NewModelSrvHelper[C](basicRes, nil),
-		pluginName:     pluginName,
+		ModelSrvHelper:  NewModelSrvHelper[C](basicRes, nil),
+		pluginName:      pluginName,
+		workspaceHelper: workspacehelper.NewWorkspaceHelper(basicRes),
 	}
 }
 
+// GetPluginName returns the name of the plugin the connections belong to
+func (connSrv *ConnectionSrvHelper[C, S, SC]) GetPluginName() string {
+	return connSrv.pluginName
+}
+
+// GetAllByWorkspace returns all connections visible to the workspace
+func (connSrv *ConnectionSrvHelper[C, S, SC]) GetAllByWorkspace(workspace string) ([]*C, errors.Error) {
+	if workspace == "" {
+		return connSrv.GetAll()
+	}
+	connections := make([]*C, 0)
+	err := connSrv.db.All(&connections, connSrv.workspaceHelper.ConnectionFilter(connSrv.pluginName, workspace))
+	return connections, err
+}
+
+// CreateInWorkspace creates the connection and assigns it to the workspace in one transaction
+func (connSrv *ConnectionSrvHelper[C, S, SC]) CreateInWorkspace(connection *C, workspace string) (err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(connSrv.basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	err = connSrv.ModelSrvHelper.NewTx(tx).Create(connection)
+	if err != nil {
+		return err
+	}
+	return connSrv.workspaceHelper.AddConnection(tx, workspace, connSrv.pluginName, (*connection).ConnectionId())
+}
+
+// VerifyWorkspace returns a NotFound error if the connection is not visible to the workspace
+func (connSrv *ConnectionSrvHelper[C, S, SC]) VerifyWorkspace(connectionId uint64, workspace string) errors.Error {
+	return connSrv.workspaceHelper.VerifyConnection(workspace, connSrv.pluginName, connectionId)
+}
+
 func (connSrv *ConnectionSrvHelper[C, S, SC]) DeleteConnection(connection *C) (refs *DsRefs, err errors.Error) {
 	err = connSrv.ModelSrvHelper.NoRunningPipeline(func(tx dal.Transaction) errors.Error {
 		// make sure no blueprint is using the connection
@@ -61,6 +98,8 @@ func (connSrv *ConnectionSrvHelper[C, S, SC]) DeleteConnection(connection *C) (r
 			return errors.Conflict.New("Please delete all data scope(s) before you delete this Data Connection.")
 		}
 		errors.Must(tx.Delete(connection))
+		errors.Must(connSrv.workspaceHelper.RemoveConnection(tx, connSrv.pluginName, connectionId))
+		errors.Must(DeleteConnectionApiResponseCache(tx, connSrv.pluginName, connectionId))
 		if reflect.TypeOf(new(SC)) != reflect.TypeOf(new(NoScopeConfig)) {
 			errors.Must(connSrv.db.Delete(new(SC), dal.Where("connection_id = ?", connectionId)))
 		}
@@ -69,6 +108,20 @@ func (connSrv *ConnectionSrvHelper[C, S, SC]) DeleteConnection(connection *C) (r
 	return
 }
 
+// DeleteConnectionApiResponseCache removes the responses cached for the conditional requests of the connection,
+// the ones of its scopes are removed along with the scope data
+func DeleteConnectionApiResponseCache(db dal.Dal, pluginName string, connectionId uint64) errors.Error {
+	return db.Delete(
+		&models.ApiResponseCache{},
+		dal.Where(
+			"raw_data_table LIKE ? AND (raw_data_params LIKE ? OR raw_data_params LIKE ?)",
+			fmt.Sprintf("_raw_%s%%", pluginName),
+			fmt.Sprintf(`%%"ConnectionId":%d,%%`, connectionId),
+			fmt.Sprintf(`%%"ConnectionId":%d}%%`, connectionId),
+		),
+	)
+}
+
 func (connSrv *ConnectionSrvHelper[C, S, SC]) getAllBlueprinsByConnection(connectionId uint64) []*models.Blueprint {
 	blueprints := make([]*models.Blueprint, 0)
 	errors.Must(connSrv.db.All(
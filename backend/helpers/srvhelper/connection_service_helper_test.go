@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConnection struct {
+	common.Model
+	Name string `validate:"required"`
+}
+
+func (testConnection) TableName() string {
+	return "_tool_test_connections"
+}
+
+func (c testConnection) ConnectionId() uint64 {
+	return c.ID
+}
+
+type testScope struct {
+	common.Scope
+	Id string
+}
+
+func (testScope) TableName() string {
+	return "_tool_test_scopes"
+}
+
+func (s testScope) ScopeId() string {
+	return s.Id
+}
+
+func (s testScope) ScopeName() string {
+	return s.Id
+}
+
+func (s testScope) ScopeFullName() string {
+	return s.Id
+}
+
+func (s testScope) ScopeParams() interface{} {
+	return nil
+}
+
+// workspaceDal keeps the connections and their workspaces in memory, it only understands the queries issued by
+// ConnectionSrvHelper on listing and creating connections
+type workspaceDal struct {
+	dal.Dal
+	connections          []*testConnection
+	workspaceConnections []*models.WorkspaceConnection
+	failAssigning        bool
+	committed            bool
+	rolledBack           bool
+}
+
+func (d *workspaceDal) GetColumns(_ dal.Tabler, _ func(columnMeta dal.ColumnMeta) bool) ([]dal.ColumnMeta, errors.Error) {
+	return nil, nil
+}
+
+func (d *workspaceDal) All(dst interface{}, clauses ...dal.Clause) errors.Error {
+	connections := dst.(*[]*testConnection)
+	for _, c := range d.connections {
+		if len(clauses) == 0 || d.inWorkspace(c, clauses[0].Data.(dal.DalClause).Params) {
+			*connections = append(*connections, c)
+		}
+	}
+	return nil
+}
+
+func (d *workspaceDal) inWorkspace(c *testConnection, params []interface{}) bool {
+	for _, wc := range d.workspaceConnections {
+		if wc.Plugin == params[0] && wc.Workspace == params[1] && wc.ConnectionId == c.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *workspaceDal) Begin() dal.Transaction {
+	return &workspaceTx{workspaceDal: d}
+}
+
+// workspaceTx stages the writes until committed
+type workspaceTx struct {
+	*workspaceDal
+	connections          []*testConnection
+	workspaceConnections []*models.WorkspaceConnection
+}
+
+func (tx *workspaceTx) Create(entity interface{}, _ ...dal.Clause) errors.Error {
+	connection := entity.(*testConnection)
+	connection.ID = uint64(len(tx.workspaceDal.connections) + 1)
+	tx.connections = append(tx.connections, connection)
+	return nil
+}
+
+func (tx *workspaceTx) CreateOrUpdate(entity interface{}, _ ...dal.Clause) errors.Error {
+	if tx.failAssigning {
+		return errors.Default.New("failed to assign")
+	}
+	tx.workspaceConnections = append(tx.workspaceConnections, entity.(*models.WorkspaceConnection))
+	return nil
+}
+
+func (tx *workspaceTx) LockTables(_ dal.LockTables) errors.Error {
+	return nil
+}
+
+func (tx *workspaceTx) UnlockTables() errors.Error {
+	return nil
+}
+
+func (tx *workspaceTx) Commit() errors.Error {
+	tx.workspaceDal.connections = append(tx.workspaceDal.connections, tx.connections...)
+	tx.workspaceDal.workspaceConnections = append(tx.workspaceDal.workspaceConnections, tx.workspaceConnections...)
+	tx.committed = true
+	return nil
+}
+
+func (tx *workspaceTx) Rollback() errors.Error {
+	tx.rolledBack = true
+	return nil
+}
+
+type workspaceBasicRes struct {
+	context.BasicRes
+	db dal.Dal
+}
+
+func (r *workspaceBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+func (r *workspaceBasicRes) GetLogger() log.Logger {
+	return logruslog.Global
+}
+
+func TestConnectionSrvHelper_Workspace(t *testing.T) {
+	db := &workspaceDal{}
+	connSrv := NewConnectionSrvHelper[testConnection, testScope, NoScopeConfig](&workspaceBasicRes{db: db}, "test")
+	assert.Nil(t, connSrv.CreateInWorkspace(&testConnection{Name: "a"}, "a"))
+	assert.Nil(t, connSrv.CreateInWorkspace(&testConnection{Name: "b"}, "b"))
+	assert.Nil(t, connSrv.CreateInWorkspace(&testConnection{Name: "global"}, ""))
+	assert.Len(t, db.connections, 3)
+	assert.Len(t, db.workspaceConnections, 2)
+
+	names := func(workspace string) []string {
+		connections, err := connSrv.GetAllByWorkspace(workspace)
+		assert.Nil(t, err)
+		var names []string
+		for _, c := range connections {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"a"}, names("a"))
+	assert.Equal(t, []string{"b"}, names("b"))
+	assert.Empty(t, names("c"))
+	assert.Equal(t, []string{"a", "b", "global"}, names(""))
+}
+
+func TestConnectionSrvHelper_CreateInWorkspaceRollback(t *testing.T) {
+	db := &workspaceDal{failAssigning: true}
+	connSrv := NewConnectionSrvHelper[testConnection, testScope, NoScopeConfig](&workspaceBasicRes{db: db}, "test")
+	err := connSrv.CreateInWorkspace(&testConnection{Name: "a"}, "a")
+	assert.NotNil(t, err)
+	// the connection must not be left behind without its workspace
+	assert.True(t, db.rolledBack)
+	assert.False(t, db.committed)
+	assert.Empty(t, db.connections)
+
+	// invalid connections are rejected before anything is written
+	db.failAssigning = false
+	db.rolledBack = false
+	err = connSrv.CreateInWorkspace(&testConnection{}, "a")
+	assert.Equal(t, errors.BadInput, err.GetType())
+	assert.True(t, db.rolledBack)
+	assert.Empty(t, db.connections)
+}
@@ -241,8 +241,8 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) deleteScopeData(scope plugin.ToolLayer
 			params = []interface{}{rawDataParams}
 		} else {
 			// framework tables: should check plugin, connection and scope
-			if table == (models.CollectorLatestState{}.TableName()) {
-				// diff sync state
+			if table == (models.CollectorLatestState{}.TableName()) || table == (models.ApiResponseCache{}.TableName()) {
+				// diff sync state and cached responses of conditional requests
 				where = "raw_data_table LIKE ? AND raw_data_params = ?"
 			} else {
 				// domain layer table
@@ -300,7 +300,7 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) getAffectedTables() ([]string, errors.
 		}
 	}
 	// additional tables
-	tables = append(tables, models.CollectorLatestState{}.TableName())
+	tables = append(tables, models.CollectorLatestState{}.TableName(), models.ApiResponseCache{}.TableName())
 	scopeSrv.log.Debug("Discovered %d tables used by plugin \"%s\": %v", len(tables), scopeSrv.pluginName, tables)
 	return tables, nil
 }
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacehelper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// WorkspaceHelper keeps track of which workspace the plugin connections belong to
+type WorkspaceHelper struct {
+	basicRes context.BasicRes
+}
+
+func NewWorkspaceHelper(basicRes context.BasicRes) *WorkspaceHelper {
+	return &WorkspaceHelper{
+		basicRes: basicRes,
+	}
+}
+
+// ConnectionFilter returns a clause limiting `id` of the connection table to the connections of the workspace
+func (w *WorkspaceHelper) ConnectionFilter(pluginName, workspace string) dal.Clause {
+	return dal.Where(
+		"id IN (SELECT connection_id FROM _devlake_workspace_connections WHERE plugin = ? AND workspace = ?)",
+		pluginName, workspace,
+	)
+}
+
+// AddConnection assigns the connection to the workspace, nothing would be done if workspace is empty
+func (w *WorkspaceHelper) AddConnection(tx dal.Dal, workspace, pluginName string, connectionId uint64) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	if tx == nil {
+		tx = w.basicRes.GetDal()
+	}
+	err := tx.CreateOrUpdate(&models.WorkspaceConnection{
+		Plugin:       pluginName,
+		ConnectionId: connectionId,
+		Workspace:    workspace,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to assign %s connection %d to workspace %s", pluginName, connectionId, workspace))
+	}
+	return nil
+}
+
+// RemoveConnection unassigns the connection from whatever workspace it belongs to
+func (w *WorkspaceHelper) RemoveConnection(tx dal.Dal, pluginName string, connectionId uint64) errors.Error {
+	if tx == nil {
+		tx = w.basicRes.GetDal()
+	}
+	return tx.Delete(
+		&models.WorkspaceConnection{},
+		dal.Where("plugin = ? AND connection_id = ?", pluginName, connectionId),
+	)
+}
+
+// VerifyConnection returns a NotFound error if the connection is not visible to the workspace,
+// callers which are not bound to any workspace can see all connections
+func (w *WorkspaceHelper) VerifyConnection(workspace, pluginName string, connectionId uint64) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	count, err := w.basicRes.GetDal().Count(
+		dal.From(&models.WorkspaceConnection{}),
+		dal.Where("plugin = ? AND connection_id = ? AND workspace = ?", pluginName, connectionId, workspace),
+	)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.NotFound.New(fmt.Sprintf("%s connection %d not found", pluginName, connectionId))
+	}
+	return nil
+}
+
+// GetConnectionWorkspace returns the workspace the connection belongs to, empty if it is not bound to any
+func (w *WorkspaceHelper) GetConnectionWorkspace(pluginName string, connectionId uint64) (string, errors.Error) {
+	db := w.basicRes.GetDal()
+	workspaceConnection := &models.WorkspaceConnection{}
+	err := db.First(workspaceConnection, dal.Where("plugin = ? AND connection_id = ?", pluginName, connectionId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Default.Wrap(err, fmt.Sprintf("failed to get the workspace of %s connection %d", pluginName, connectionId))
+	}
+	return workspaceConnection.Workspace, nil
+}
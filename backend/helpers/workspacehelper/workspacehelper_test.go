@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacehelper
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// workspaceDal keeps the workspace connections in memory, it only understands the queries issued by WorkspaceHelper
+type workspaceDal struct {
+	dal.Dal
+	connections []*models.WorkspaceConnection
+}
+
+func (d *workspaceDal) Count(clauses ...dal.Clause) (int64, errors.Error) {
+	params := clauses[1].Data.(dal.DalClause).Params
+	count := int64(0)
+	for _, c := range d.connections {
+		if c.Plugin == params[0] && c.ConnectionId == params[1] && c.Workspace == params[2] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (d *workspaceDal) First(dst interface{}, clauses ...dal.Clause) errors.Error {
+	params := clauses[0].Data.(dal.DalClause).Params
+	for _, c := range d.connections {
+		if c.Plugin == params[0] && c.ConnectionId == params[1] {
+			*dst.(*models.WorkspaceConnection) = *c
+			return nil
+		}
+	}
+	return errors.NotFound.New("record not found")
+}
+
+func (d *workspaceDal) IsErrorNotFound(err error) bool {
+	lakeErr := errors.AsLakeErrorType(err)
+	return lakeErr != nil && lakeErr.GetType() == errors.NotFound
+}
+
+func (d *workspaceDal) CreateOrUpdate(entity interface{}, _ ...dal.Clause) errors.Error {
+	d.connections = append(d.connections, entity.(*models.WorkspaceConnection))
+	return nil
+}
+
+type workspaceBasicRes struct {
+	context.BasicRes
+	db dal.Dal
+}
+
+func (r *workspaceBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+func TestWorkspaceHelper_VerifyConnection(t *testing.T) {
+	db := &workspaceDal{}
+	helper := NewWorkspaceHelper(&workspaceBasicRes{db: db})
+	assert.Nil(t, helper.AddConnection(nil, "a", "github", 1))
+	assert.Nil(t, helper.AddConnection(nil, "b", "github", 2))
+	// connections created by callers bound to no workspace are not assigned
+	assert.Nil(t, helper.AddConnection(nil, "", "github", 3))
+	assert.Len(t, db.connections, 2)
+
+	assert.Nil(t, helper.VerifyConnection("a", "github", 1))
+	assert.Nil(t, helper.VerifyConnection("b", "github", 2))
+	for _, tc := range []struct {
+		workspace    string
+		plugin       string
+		connectionId uint64
+	}{
+		{"a", "github", 2},
+		{"b", "github", 1},
+		{"a", "gitlab", 1},
+		{"a", "github", 3},
+	} {
+		err := helper.VerifyConnection(tc.workspace, tc.plugin, tc.connectionId)
+		assert.NotNil(t, err, "%+v", tc)
+		assert.Equal(t, errors.NotFound, err.GetType(), "%+v", tc)
+	}
+	// callers bound to no workspace see all connections
+	assert.Nil(t, helper.VerifyConnection("", "github", 2))
+	assert.Nil(t, helper.VerifyConnection("", "github", 3))
+}
+
+func TestWorkspaceHelper_ConnectionFilter(t *testing.T) {
+	filter := NewWorkspaceHelper(&workspaceBasicRes{}).ConnectionFilter("github", "a")
+	assert.Equal(t, dal.WhereClause, filter.Type)
+	assert.Equal(t, []interface{}{"github", "a"}, filter.Data.(dal.DalClause).Params)
+}
+
+func TestWorkspaceHelper_GetConnectionWorkspace(t *testing.T) {
+	db := &workspaceDal{}
+	helper := NewWorkspaceHelper(&workspaceBasicRes{db: db})
+	assert.Nil(t, helper.AddConnection(nil, "a", "github", 1))
+
+	workspace, err := helper.GetConnectionWorkspace("github", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", workspace)
+	// connections not bound to any workspace
+	workspace, err = helper.GetConnectionWorkspace("gitlab", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "", workspace)
+}
@@ -132,7 +132,7 @@ func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 // @Router /plugins/ae/connections [GET]
 func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	var connections []models.AeConnection
-	err := connectionHelper.List(&connections)
+	err := connectionHelper.ListByWorkspace(&connections, input.Workspace)
 	if err != nil {
 		return nil, err
 	}
@@ -25,5 +25,6 @@ func All() []plugin.MigrationScript {
 	return []plugin.MigrationScript{
 		new(addInitTables),
 		new(addImageSupportArtifacts),
+		new(addTlsFieldsToConnections),
 	}
 }
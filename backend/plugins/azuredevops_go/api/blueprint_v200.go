@@ -184,6 +184,8 @@ func makePipelinePlanV200(
 					"repoId":         didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(connection.ID, azuredevopsRepo.Id),
 					"proxy":          connection.Proxy,
 					"noShallowClone": true,
+					"connectionId":   connection.ID,
+					"pluginName":     "azuredevops_go",
 				},
 			})
 		}
@@ -49,6 +49,7 @@ func TestAzuredevopsRrCommitDataFlow(t *testing.T) {
 	// verify extraction
 	dataflowTester.FlushTabler(&models.AzuredevopsPullRequest{})
 	dataflowTester.FlushTabler(&models.AzuredevopsPrLabel{})
+	dataflowTester.FlushTabler(&models.AzuredevopsPrReviewer{})
 	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, taskData)
 
 	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsPullRequest{}, e2ehelper.TableOptions{
@@ -49,6 +49,7 @@ func TestAzuredevopsPRDataFlow(t *testing.T) {
 	// verify extraction
 	dataflowTester.FlushTabler(&models.AzuredevopsPullRequest{})
 	dataflowTester.FlushTabler(&models.AzuredevopsPrLabel{})
+	dataflowTester.FlushTabler(&models.AzuredevopsPrReviewer{})
 	dataflowTester.Subtask(tasks.ExtractApiPullRequestsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsPullRequest{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/_tool_azuredevops_go_pull_requests.csv",
@@ -60,6 +61,11 @@ func TestAzuredevopsPRDataFlow(t *testing.T) {
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
 
+	dataflowTester.VerifyTableWithOptions(&models.AzuredevopsPrReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_azuredevops_go_pull_request_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
 	// verify conversion
 	dataflowTester.FlushTabler(&code.PullRequest{})
 	dataflowTester.Subtask(tasks.ConvertApiPullRequestsMeta, taskData)
@@ -76,4 +82,12 @@ func TestAzuredevopsPRDataFlow(t *testing.T) {
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
 
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequestReviewer{})
+	dataflowTester.Subtask(tasks.ConvertPrReviewersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
 }
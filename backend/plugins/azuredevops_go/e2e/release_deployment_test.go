@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/impl"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/tasks"
+)
+
+func TestAzuredevopsReleaseDeploymentDataFlow(t *testing.T) {
+
+	var azuredevops impl.Azuredevops
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "azuredevops_go", azuredevops)
+
+	regexEnricher := api.NewRegexEnricher()
+	_ = regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, "(?i)prod")
+
+	taskData := &tasks.AzuredevopsTaskData{
+		Options: &tasks.AzuredevopsOptions{
+			ConnectionId:   1,
+			ProjectId:      "test-project",
+			OrganizationId: "johndoe",
+			RepositoryId:   "0d50ba13-f9ad-49b0-9b21-d29eda50ca33",
+			RepositoryType: models.RepositoryTypeADO,
+			ScopeConfig:    new(models.AzuredevopsScopeConfig),
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	dataflowTester.FlushTabler(&models.AzuredevopsReleaseDeployment{})
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_azuredevops_go_api_release_deployments.csv", "_raw_azuredevops_go_api_release_deployments")
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_azuredevops_go_repos.csv", &models.AzuredevopsRepo{})
+	dataflowTester.Subtask(tasks.ExtractApiReleaseDeploymentsMeta, taskData)
+
+	// Omit the datetime columns to avoid test failures caused by the varying precision
+	// in MySQL’s datetime(3) and PostgreSQL’s 'timestamp with time zone'.
+	dataflowTester.VerifyTable(
+		models.AzuredevopsReleaseDeployment{},
+		"./snapshot_tables/_tool_azuredevops_go_release_deployments.csv",
+		[]string{
+			"connection_id",
+			"azuredevops_id",
+			"project_id",
+			"repository_id",
+			"release_id",
+			"release_name",
+			"release_definition_id",
+			"release_definition_name",
+			"release_environment_id",
+			"release_environment_name",
+			"definition_environment_id",
+			"attempt",
+			"reason",
+			"deployment_status",
+			"operation_status",
+			"commit_sha",
+			"source_branch",
+			"requested_for_id",
+			"requested_for_name",
+			"url",
+		},
+	)
+
+	dataflowTester.FlushTabler(&devops.CICDDeployment{})
+	dataflowTester.FlushTabler(&devops.CicdDeploymentCommit{})
+	dataflowTester.Subtask(tasks.ConvertReleaseDeploymentsMeta, taskData)
+
+	dataflowTester.VerifyTable(
+		devops.CicdDeploymentCommit{},
+		"./snapshot_tables/cicd_deployment_commits.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"cicd_deployment_id",
+			"name",
+			"display_title",
+			"url",
+			"result",
+			"status",
+			"original_status",
+			"original_result",
+			"environment",
+			"original_environment",
+			"duration_sec",
+			"queued_duration_sec",
+			"commit_sha",
+			"ref_name",
+			"repo_id",
+			"repo_url",
+		},
+	)
+
+	dataflowTester.VerifyTable(
+		devops.CICDDeployment{},
+		"./snapshot_tables/cicd_deployments.csv",
+		[]string{
+			"id",
+			"cicd_scope_id",
+			"name",
+			"display_title",
+			"url",
+			"result",
+			"status",
+			"original_status",
+			"original_result",
+			"environment",
+			"original_environment",
+			"duration_sec",
+			"queued_duration_sec",
+		},
+	)
+}
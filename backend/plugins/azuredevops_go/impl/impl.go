@@ -93,6 +93,13 @@ func (p Azuredevops) GetTablesInfo() []dal.Tabler {
 		&models.AzuredevopsScopeConfig{},
 		&models.AzuredevopsTimelineRecord{},
 		&models.AzuredevopsUser{},
+		&models.AzuredevopsWorkItem{},
+		&models.AzuredevopsWorkItemChange{},
+		&models.AzuredevopsIteration{},
+		&models.AzuredevopsArea{},
+		&models.AzuredevopsPrComment{},
+		&models.AzuredevopsPrReviewer{},
+		&models.AzuredevopsReleaseDeployment{},
 	}
 }
 
@@ -173,6 +180,9 @@ func (p Azuredevops) PrepareTaskData(taskCtx plugin.TaskContext, options map[str
 	if err = regexEnricher.TryAdd(devops.PRODUCTION, op.ScopeConfig.ProductionPattern); err != nil {
 		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
 	}
+	if err = regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, op.ScopeConfig.EnvNamePattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
+	}
 
 	taskData := &tasks.AzuredevopsTaskData{
 		Options:       op,
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type azuredevopsConnection20251201 struct {
+	CaCert     string `gorm:"type:text;serializer:encdec"`
+	ClientCert string `gorm:"type:text;serializer:encdec"`
+	ClientKey  string `gorm:"type:text;serializer:encdec"`
+}
+
+func (azuredevopsConnection20251201) TableName() string {
+	return "_tool_azuredevops_go_connections"
+}
+
+type addTlsFieldsToConnections struct{}
+
+func (*addTlsFieldsToConnections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&azuredevopsConnection20251201{},
+	)
+}
+
+func (*addTlsFieldsToConnections) Version() uint64 {
+	return 20251201000001
+}
+
+func (*addTlsFieldsToConnections) Name() string {
+	return "add ca cert and client cert/key to _tool_azuredevops_go_connections"
+}
@@ -0,0 +1,150 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type azuredevopsScopeConfig20251229 struct {
+	TypeMappings string `gorm:"type:json"`
+}
+
+func (azuredevopsScopeConfig20251229) TableName() string {
+	return "_tool_azuredevops_go_scope_configs"
+}
+
+type azuredevopsWorkItem20251229 struct {
+	archived.NoPKModel
+
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	AzuredevopsId    int    `gorm:"primaryKey"`
+	OrganizationId   string `gorm:"type:varchar(255)"`
+	ProjectId        string `gorm:"type:varchar(255)"`
+	Rev              int
+	Title            string
+	Description      string
+	Type             string `gorm:"type:varchar(100)"`
+	State            string `gorm:"type:varchar(100)"`
+	Reason           string `gorm:"type:varchar(255)"`
+	StdType          string `gorm:"type:varchar(100)"`
+	StdStatus        string `gorm:"type:varchar(100)"`
+	Priority         string `gorm:"type:varchar(100)"`
+	Severity         string `gorm:"type:varchar(100)"`
+	StoryPoint       *float64
+	OriginalEstimate *float64
+	RemainingWork    *float64
+	CompletedWork    *float64
+	AreaPath         string
+	IterationPath    string
+	ParentId         int
+	AssignedToId     string `gorm:"type:varchar(255)"`
+	AssignedToName   string `gorm:"type:varchar(255)"`
+	CreatedById      string `gorm:"type:varchar(255)"`
+	CreatedByName    string `gorm:"type:varchar(255)"`
+	CreatedDate      *time.Time
+	ChangedDate      *time.Time
+	ClosedDate       *time.Time
+	DueDate          *time.Time
+	Url              string
+}
+
+func (azuredevopsWorkItem20251229) TableName() string {
+	return "_tool_azuredevops_go_work_items"
+}
+
+type azuredevopsWorkItemChange20251229 struct {
+	archived.NoPKModel
+
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	WorkItemId    int    `gorm:"primaryKey"`
+	UpdateId      int    `gorm:"primaryKey"`
+	Field         string `gorm:"primaryKey;type:varchar(255)"`
+	Rev           int
+	RevisedById   string `gorm:"type:varchar(255)"`
+	RevisedByName string `gorm:"type:varchar(255)"`
+	RevisedDate   *time.Time
+	OldValue      string
+	NewValue      string
+}
+
+func (azuredevopsWorkItemChange20251229) TableName() string {
+	return "_tool_azuredevops_go_work_item_changes"
+}
+
+type azuredevopsIteration20251229 struct {
+	archived.NoPKModel
+
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	Identifier     string `gorm:"primaryKey;type:varchar(255)"`
+	AzuredevopsId  int
+	OrganizationId string `gorm:"type:varchar(255)"`
+	ProjectId      string `gorm:"type:varchar(255)"`
+	Name           string `gorm:"type:varchar(255)"`
+	Path           string
+	StartDate      *time.Time
+	FinishDate     *time.Time
+	Url            string
+}
+
+func (azuredevopsIteration20251229) TableName() string {
+	return "_tool_azuredevops_go_iterations"
+}
+
+type azuredevopsArea20251229 struct {
+	archived.NoPKModel
+
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	Identifier     string `gorm:"primaryKey;type:varchar(255)"`
+	AzuredevopsId  int
+	OrganizationId string `gorm:"type:varchar(255)"`
+	ProjectId      string `gorm:"type:varchar(255)"`
+	Name           string `gorm:"type:varchar(255)"`
+	Path           string
+	Url            string
+}
+
+func (azuredevopsArea20251229) TableName() string {
+	return "_tool_azuredevops_go_areas"
+}
+
+type addBoardsSupport struct{}
+
+func (*addBoardsSupport) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&azuredevopsScopeConfig20251229{},
+		&azuredevopsWorkItem20251229{},
+		&azuredevopsWorkItemChange20251229{},
+		&azuredevopsIteration20251229{},
+		&azuredevopsArea20251229{},
+	)
+}
+
+func (*addBoardsSupport) Version() uint64 {
+	return 20251229000001
+}
+
+func (*addBoardsSupport) Name() string {
+	return "add work items, work item changes, iterations and areas tables and type mappings of scope configs to support Azure Boards"
+}
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type azuredevopsScopeConfig20260112 struct {
+	EnvNamePattern string `gorm:"type:varchar(255)"`
+}
+
+func (azuredevopsScopeConfig20260112) TableName() string {
+	return "_tool_azuredevops_go_scope_configs"
+}
+
+type azuredevopsPrComment20260112 struct {
+	archived.NoPKModel
+
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	PullRequestId   int    `gorm:"primaryKey"`
+	ThreadId        int    `gorm:"primaryKey"`
+	AzuredevopsId   int    `gorm:"primaryKey"`
+	ParentCommentId int
+	Body            string
+	AuthorId        string `gorm:"type:varchar(255)"`
+	AuthorName      string `gorm:"type:varchar(255)"`
+	CommentType     string `gorm:"type:varchar(100)"`
+	ThreadType      string `gorm:"type:varchar(100)"`
+	ThreadStatus    string `gorm:"type:varchar(100)"`
+	FilePath        string
+	Vote            int
+	CreatedDate     *time.Time
+	UpdatedDate     *time.Time
+}
+
+func (azuredevopsPrComment20260112) TableName() string {
+	return "_tool_azuredevops_go_pull_request_comments"
+}
+
+type azuredevopsPrReviewer20260112 struct {
+	archived.NoPKModel
+
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	PullRequestId int    `gorm:"primaryKey"`
+	ReviewerId    string `gorm:"primaryKey;type:varchar(255)"`
+	Name          string `gorm:"type:varchar(255)"`
+	UniqueName    string `gorm:"type:varchar(255)"`
+	Vote          int
+	IsRequired    bool
+	HasDeclined   bool
+	IsContainer   bool
+}
+
+func (azuredevopsPrReviewer20260112) TableName() string {
+	return "_tool_azuredevops_go_pull_request_reviewers"
+}
+
+type azuredevopsReleaseDeployment20260112 struct {
+	archived.NoPKModel
+
+	ConnectionId            uint64 `gorm:"primaryKey"`
+	AzuredevopsId           int    `gorm:"primaryKey"`
+	ProjectId               string `gorm:"type:varchar(255)"`
+	RepositoryId            string `gorm:"type:varchar(255)"`
+	ReleaseId               int
+	ReleaseName             string `gorm:"type:varchar(255)"`
+	ReleaseDefinitionId     int
+	ReleaseDefinitionName   string `gorm:"type:varchar(255)"`
+	ReleaseEnvironmentId    int
+	ReleaseEnvironmentName  string `gorm:"type:varchar(255)"`
+	DefinitionEnvironmentId int
+	Attempt                 int
+	Reason                  string `gorm:"type:varchar(100)"`
+	DeploymentStatus        string `gorm:"type:varchar(100)"`
+	OperationStatus         string `gorm:"type:varchar(100)"`
+	CommitSha               string `gorm:"type:varchar(255)"`
+	SourceBranch            string `gorm:"type:varchar(255)"`
+	RequestedForId          string `gorm:"type:varchar(255)"`
+	RequestedForName        string `gorm:"type:varchar(255)"`
+	QueuedOn                *time.Time
+	StartedOn               *time.Time
+	CompletedOn             *time.Time
+	LastModifiedOn          *time.Time
+	Url                     string
+}
+
+func (azuredevopsReleaseDeployment20260112) TableName() string {
+	return "_tool_azuredevops_go_release_deployments"
+}
+
+type addPrReviewsAndReleases struct{}
+
+func (*addPrReviewsAndReleases) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&azuredevopsScopeConfig20260112{},
+		&azuredevopsPrComment20260112{},
+		&azuredevopsPrReviewer20260112{},
+		&azuredevopsReleaseDeployment20260112{},
+	)
+}
+
+func (*addPrReviewsAndReleases) Version() uint64 {
+	return 20260112000001
+}
+
+func (*addPrReviewsAndReleases) Name() string {
+	return "add pull request comments, pull request reviewers and release deployments tables and env name pattern of scope configs"
+}
@@ -99,7 +99,8 @@ type AzuredevopsApiPullRequest struct {
 		CommitId string `json:"commitId"`
 		Url      string `json:"url"`
 	} `json:"lastMergeCommit"`
-	Url                 string     `json:"url"`
-	SupportsIterations  bool       `json:"supportsIterations"`
-	CompletionQueueTime *time.Time `json:"completionQueueTime"`
+	Url                 string                     `json:"url"`
+	SupportsIterations  bool                       `json:"supportsIterations"`
+	CompletionQueueTime *time.Time                 `json:"completionQueueTime"`
+	Reviewers           []AzuredevopsApiPrReviewer `json:"reviewers"`
 }
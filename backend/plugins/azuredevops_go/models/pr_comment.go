@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type AzuredevopsPrComment struct {
+	common.NoPKModel
+
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	PullRequestId   int    `gorm:"primaryKey"`
+	ThreadId        int    `gorm:"primaryKey"`
+	AzuredevopsId   int    `gorm:"primaryKey"`
+	ParentCommentId int
+	Body            string
+	AuthorId        string `gorm:"type:varchar(255)"`
+	AuthorName      string `gorm:"type:varchar(255)"`
+	CommentType     string `gorm:"type:varchar(100)"`
+	ThreadType      string `gorm:"type:varchar(100)"`
+	ThreadStatus    string `gorm:"type:varchar(100)"`
+	FilePath        string
+	Vote            int
+	CreatedDate     *time.Time
+	UpdatedDate     *time.Time
+}
+
+func (AzuredevopsPrComment) TableName() string {
+	return "_tool_azuredevops_go_pull_request_comments"
+}
+
+type AzuredevopsApiPrThreadProperty struct {
+	Type  string      `json:"$type"`
+	Value interface{} `json:"$value"`
+}
+
+type AzuredevopsApiPrThread struct {
+	Id              int                 `json:"id"`
+	PublishedDate   *common.Iso8601Time `json:"publishedDate"`
+	LastUpdatedDate *common.Iso8601Time `json:"lastUpdatedDate"`
+	Status          string              `json:"status"`
+	IsDeleted       bool                `json:"isDeleted"`
+	ThreadContext   *struct {
+		FilePath string `json:"filePath"`
+	} `json:"threadContext"`
+	Properties map[string]AzuredevopsApiPrThreadProperty `json:"properties"`
+	Comments   []struct {
+		Id              int                    `json:"id"`
+		ParentCommentId int                    `json:"parentCommentId"`
+		Author          AzuredevopsApiIdentity `json:"author"`
+		Content         string                 `json:"content"`
+		PublishedDate   *common.Iso8601Time    `json:"publishedDate"`
+		LastUpdatedDate *common.Iso8601Time    `json:"lastUpdatedDate"`
+		CommentType     string                 `json:"commentType"`
+		IsDeleted       bool                   `json:"isDeleted"`
+	} `json:"comments"`
+}
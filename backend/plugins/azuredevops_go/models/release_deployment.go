@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AzuredevopsReleaseDeployment is a deployment of a classic release to one of its stages (environments)
+type AzuredevopsReleaseDeployment struct {
+	common.NoPKModel
+
+	ConnectionId            uint64 `gorm:"primaryKey"`
+	AzuredevopsId           int    `gorm:"primaryKey"`
+	ProjectId               string `gorm:"type:varchar(255)"`
+	RepositoryId            string `gorm:"type:varchar(255)"`
+	ReleaseId               int
+	ReleaseName             string `gorm:"type:varchar(255)"`
+	ReleaseDefinitionId     int
+	ReleaseDefinitionName   string `gorm:"type:varchar(255)"`
+	ReleaseEnvironmentId    int
+	ReleaseEnvironmentName  string `gorm:"type:varchar(255)"`
+	DefinitionEnvironmentId int
+	Attempt                 int
+	Reason                  string `gorm:"type:varchar(100)"`
+	DeploymentStatus        string `gorm:"type:varchar(100)"`
+	OperationStatus         string `gorm:"type:varchar(100)"`
+	CommitSha               string `gorm:"type:varchar(255)"`
+	SourceBranch            string `gorm:"type:varchar(255)"`
+	RequestedForId          string `gorm:"type:varchar(255)"`
+	RequestedForName        string `gorm:"type:varchar(255)"`
+	QueuedOn                *time.Time
+	StartedOn               *time.Time
+	CompletedOn             *time.Time
+	LastModifiedOn          *time.Time
+	Url                     string
+}
+
+func (AzuredevopsReleaseDeployment) TableName() string {
+	return "_tool_azuredevops_go_release_deployments"
+}
+
+type AzuredevopsApiReference struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type AzuredevopsApiReleaseArtifact struct {
+	SourceId            string                             `json:"sourceId"`
+	Type                string                             `json:"type"`
+	Alias               string                             `json:"alias"`
+	IsPrimary           bool                               `json:"isPrimary"`
+	DefinitionReference map[string]AzuredevopsApiReference `json:"definitionReference"`
+}
+
+type AzuredevopsApiReleaseDeployment struct {
+	Id      int `json:"id"`
+	Release struct {
+		Id        int                             `json:"id"`
+		Name      string                          `json:"name"`
+		Artifacts []AzuredevopsApiReleaseArtifact `json:"artifacts"`
+		Links     struct {
+			Web struct {
+				Href string `json:"href"`
+			} `json:"web"`
+		} `json:"_links"`
+	} `json:"release"`
+	ReleaseDefinition struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"releaseDefinition"`
+	ReleaseEnvironment struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"releaseEnvironment"`
+	DefinitionEnvironmentId int                    `json:"definitionEnvironmentId"`
+	Attempt                 int                    `json:"attempt"`
+	Reason                  string                 `json:"reason"`
+	DeploymentStatus        string                 `json:"deploymentStatus"`
+	OperationStatus         string                 `json:"operationStatus"`
+	RequestedFor            AzuredevopsApiIdentity `json:"requestedFor"`
+	QueuedOn                *common.Iso8601Time    `json:"queuedOn"`
+	StartedOn               *common.Iso8601Time    `json:"startedOn"`
+	CompletedOn             *common.Iso8601Time    `json:"completedOn"`
+	LastModifiedOn          *common.Iso8601Time    `json:"lastModifiedOn"`
+}
@@ -25,12 +25,27 @@ import (
 
 var _ plugin.ToolLayerScopeConfig = (*AzuredevopsScopeConfig)(nil)
 
+type StatusMapping struct {
+	StandardStatus string `json:"standardStatus"`
+}
+
+type StatusMappings map[string]StatusMapping
+
+// TypeMapping maps a work item type to a standard issue type, and its states to standard statuses
+type TypeMapping struct {
+	StandardType   string         `json:"standardType"`
+	StatusMappings StatusMappings `json:"statusMappings"`
+}
+
 type AzuredevopsScopeConfig struct {
 	common.ScopeConfig `mapstructure:",squash" json:",inline"`
 
 	DeploymentPattern string            `mapstructure:"deploymentPattern,omitempty" json:"deploymentPattern"`
 	ProductionPattern string            `mapstructure:"productionPattern,omitempty" json:"productionPattern"`
+	EnvNamePattern    string            `mapstructure:"envNamePattern,omitempty" json:"envNamePattern" gorm:"type:varchar(255)"`
 	Refdiff           datatypes.JSONMap `mapstructure:"refdiff,omitempty" json:"refdiff" swaggertype:"object" format:"json"`
+
+	TypeMappings map[string]TypeMapping `mapstructure:"typeMappings,omitempty" json:"typeMappings" gorm:"type:json;serializer:json"`
 }
 
 // GetConnectionId implements plugin.ToolLayerScopeConfig.
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type AzuredevopsWorkItem struct {
+	common.NoPKModel
+
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	AzuredevopsId    int    `gorm:"primaryKey"`
+	OrganizationId   string `gorm:"type:varchar(255)"`
+	ProjectId        string `gorm:"type:varchar(255)"`
+	Rev              int
+	Title            string
+	Description      string
+	Type             string `gorm:"type:varchar(100)"`
+	State            string `gorm:"type:varchar(100)"`
+	Reason           string `gorm:"type:varchar(255)"`
+	StdType          string `gorm:"type:varchar(100)"`
+	StdStatus        string `gorm:"type:varchar(100)"`
+	Priority         string `gorm:"type:varchar(100)"`
+	Severity         string `gorm:"type:varchar(100)"`
+	StoryPoint       *float64
+	OriginalEstimate *float64
+	RemainingWork    *float64
+	CompletedWork    *float64
+	AreaPath         string
+	IterationPath    string
+	ParentId         int
+	AssignedToId     string `gorm:"type:varchar(255)"`
+	AssignedToName   string `gorm:"type:varchar(255)"`
+	CreatedById      string `gorm:"type:varchar(255)"`
+	CreatedByName    string `gorm:"type:varchar(255)"`
+	CreatedDate      *time.Time
+	ChangedDate      *time.Time
+	ClosedDate       *time.Time
+	DueDate          *time.Time
+	Url              string
+}
+
+func (AzuredevopsWorkItem) TableName() string {
+	return "_tool_azuredevops_go_work_items"
+}
+
+type AzuredevopsApiIdentity struct {
+	DisplayName string `json:"displayName"`
+	Id          string `json:"id"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+type AzuredevopsApiWorkItem struct {
+	Id     int `json:"id"`
+	Rev    int `json:"rev"`
+	Fields struct {
+		TeamProject      string                  `json:"System.TeamProject"`
+		AreaPath         string                  `json:"System.AreaPath"`
+		IterationPath    string                  `json:"System.IterationPath"`
+		WorkItemType     string                  `json:"System.WorkItemType"`
+		State            string                  `json:"System.State"`
+		Reason           string                  `json:"System.Reason"`
+		Title            string                  `json:"System.Title"`
+		Description      string                  `json:"System.Description"`
+		Parent           int                     `json:"System.Parent"`
+		AssignedTo       *AzuredevopsApiIdentity `json:"System.AssignedTo"`
+		CreatedBy        *AzuredevopsApiIdentity `json:"System.CreatedBy"`
+		CreatedDate      *common.Iso8601Time     `json:"System.CreatedDate"`
+		ChangedDate      *common.Iso8601Time     `json:"System.ChangedDate"`
+		ClosedDate       *common.Iso8601Time     `json:"Microsoft.VSTS.Common.ClosedDate"`
+		DueDate          *common.Iso8601Time     `json:"Microsoft.VSTS.Scheduling.DueDate"`
+		Priority         *int                    `json:"Microsoft.VSTS.Common.Priority"`
+		Severity         string                  `json:"Microsoft.VSTS.Common.Severity"`
+		StoryPoints      *float64                `json:"Microsoft.VSTS.Scheduling.StoryPoints"`
+		Effort           *float64                `json:"Microsoft.VSTS.Scheduling.Effort"`
+		OriginalEstimate *float64                `json:"Microsoft.VSTS.Scheduling.OriginalEstimate"`
+		RemainingWork    *float64                `json:"Microsoft.VSTS.Scheduling.RemainingWork"`
+		CompletedWork    *float64                `json:"Microsoft.VSTS.Scheduling.CompletedWork"`
+	} `json:"fields"`
+	Links struct {
+		Html struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"_links"`
+	Url string `json:"url"`
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// AzuredevopsWorkItemChange stores one field change of a work item update (revision)
+type AzuredevopsWorkItemChange struct {
+	common.NoPKModel
+
+	ConnectionId  uint64 `gorm:"primaryKey"`
+	WorkItemId    int    `gorm:"primaryKey"`
+	UpdateId      int    `gorm:"primaryKey"`
+	Field         string `gorm:"primaryKey;type:varchar(255)"`
+	Rev           int
+	RevisedById   string `gorm:"type:varchar(255)"`
+	RevisedByName string `gorm:"type:varchar(255)"`
+	RevisedDate   *time.Time
+	OldValue      string
+	NewValue      string
+}
+
+func (AzuredevopsWorkItemChange) TableName() string {
+	return "_tool_azuredevops_go_work_item_changes"
+}
+
+type AzuredevopsApiWorkItemUpdate struct {
+	Id          int                    `json:"id"`
+	WorkItemId  int                    `json:"workItemId"`
+	Rev         int                    `json:"rev"`
+	RevisedBy   AzuredevopsApiIdentity `json:"revisedBy"`
+	RevisedDate *common.Iso8601Time    `json:"revisedDate"`
+	Fields      map[string]struct {
+		OldValue interface{} `json:"oldValue"`
+		NewValue interface{} `json:"newValue"`
+	} `json:"fields"`
+}
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIterationsMeta)
+}
+
+var ConvertIterationsMeta = plugin.SubTaskMeta{
+	Name:             "convertIterations",
+	EntryPoint:       ConvertIterations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_iterations into domain layer table sprints and board_sprints",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{models.AzuredevopsIteration{}.TableName()},
+	ProductTables: []string{
+		ticket.Sprint{}.TableName(),
+		ticket.BoardSprint{}.TableName(),
+	},
+}
+
+func ConvertIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawIterationTable)
+	db := taskCtx.GetDal()
+	clauses := []dal.Clause{
+		dal.From(&models.AzuredevopsIteration{}),
+		dal.Where("connection_id = ? AND organization_id = ? AND project_id = ?",
+			data.Options.ConnectionId, data.Options.OrganizationId, data.Options.ProjectId),
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	domainBoardId := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(data.Options.ConnectionId, data.Options.RepositoryId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsIteration{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			iteration := inputRow.(*models.AzuredevopsIteration)
+			// the root node is the project itself rather than an iteration
+			if !strings.Contains(iteration.Path, `\`) {
+				return nil, nil
+			}
+			sprint := &ticket.Sprint{
+				DomainEntity:    domainlayer.DomainEntity{Id: sprintIdGen.Generate(data.Options.ConnectionId, iteration.Identifier)},
+				Name:            iteration.Name,
+				Url:             iteration.Url,
+				Status:          getIterationStatus(iteration, time.Now()),
+				StartedDate:     iteration.StartDate,
+				EndedDate:       iteration.FinishDate,
+				OriginalBoardID: domainBoardId,
+			}
+			if sprint.Status == "CLOSED" {
+				sprint.CompletedDate = iteration.FinishDate
+			}
+			boardSprint := &ticket.BoardSprint{
+				BoardId:  domainBoardId,
+				SprintId: sprint.Id,
+			}
+			return []interface{}{
+				sprint,
+				boardSprint,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getIterationStatus derives the sprint status from the dates, Azure DevOps doesn't keep the state of iterations
+func getIterationStatus(iteration *models.AzuredevopsIteration, now time.Time) string {
+	if iteration.FinishDate != nil && iteration.FinishDate.Before(now) {
+		return "CLOSED"
+	}
+	if iteration.StartDate == nil || iteration.StartDate.After(now) {
+		return "FUTURE"
+	}
+	return "ACTIVE"
+}
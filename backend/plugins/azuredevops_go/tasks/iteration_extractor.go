@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiIterationsMeta)
+}
+
+var ExtractApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiIterations",
+	EntryPoint:       ExtractApiIterations,
+	EnabledByDefault: true,
+	Description:      "Extract raw Iterations data into tool layer table azuredevops_iterations",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{RawIterationTable},
+	ProductTables:    []string{models.AzuredevopsIteration{}.TableName()},
+}
+
+func ExtractApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawIterationTable)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			node := &models.AzuredevopsApiClassificationNode{}
+			err := errors.Convert(json.Unmarshal(row.Data, node))
+			if err != nil {
+				return nil, err
+			}
+			iteration := &models.AzuredevopsIteration{
+				ConnectionId:   data.Options.ConnectionId,
+				Identifier:     node.Identifier,
+				AzuredevopsId:  node.Id,
+				OrganizationId: data.Options.OrganizationId,
+				ProjectId:      data.Options.ProjectId,
+				Name:           node.Name,
+				Path:           convertClassificationNodePath(node.Path),
+				Url:            node.Url,
+			}
+			if node.Attributes != nil {
+				iteration.StartDate = common.Iso8601TimeToTime(node.Attributes.StartDate)
+				iteration.FinishDate = common.Iso8601TimeToTime(node.Attributes.FinishDate)
+			}
+			return []interface{}{iteration}, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps Iteration extractor")
+	}
+
+	return extractor.Execute()
+}
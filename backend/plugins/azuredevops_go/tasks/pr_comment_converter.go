@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertApiPrCommentsMeta)
+}
+
+var ConvertApiPrCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertApiPullRequestComments",
+	EntryPoint:       ConvertApiPullRequestComments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_go_pull_request_comments into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	DependencyTables: []string{
+		models.AzuredevopsPrComment{}.TableName(),
+	},
+	ProductTables: []string{code.PullRequestComment{}.TableName()},
+}
+
+func ConvertApiPullRequestComments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawPrThreadTable)
+	db := taskCtx.GetDal()
+
+	// system comments are generated for every status, reviewer or ref update of a pull request, only the vote
+	// updates are kept and converted as reviews
+	clauses := []dal.Clause{
+		dal.Select("_tool_azuredevops_go_pull_request_comments.*"),
+		dal.From(&models.AzuredevopsPrComment{}),
+		dal.Join(`left join _tool_azuredevops_go_pull_requests
+			on _tool_azuredevops_go_pull_requests.azuredevops_id = _tool_azuredevops_go_pull_request_comments.pull_request_id
+			and _tool_azuredevops_go_pull_requests.connection_id = _tool_azuredevops_go_pull_request_comments.connection_id`),
+		dal.Where(`_tool_azuredevops_go_pull_requests.repository_id = ?
+			and _tool_azuredevops_go_pull_requests.connection_id = ?
+			and (_tool_azuredevops_go_pull_request_comments.comment_type != ?
+			or _tool_azuredevops_go_pull_request_comments.thread_type = ?)`,
+			data.Options.RepositoryId, data.Options.ConnectionId, prCommentTypeSystem, prThreadTypeVoteUpdate),
+		dal.Orderby("pull_request_id ASC"),
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	commentIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsPrComment{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsPullRequest{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsPrComment{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*models.AzuredevopsPrComment)
+			domainComment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: commentIdGen.Generate(data.Options.ConnectionId, comment.PullRequestId, comment.ThreadId, comment.AzuredevopsId),
+				},
+				PullRequestId: prIdGen.Generate(data.Options.ConnectionId, comment.PullRequestId),
+				Body:          comment.Body,
+				AccountId:     accountIdGen.Generate(data.Options.ConnectionId, comment.AuthorId),
+				Type:          code.NORMAL_COMMENT,
+				Status:        comment.ThreadStatus,
+			}
+			if comment.CreatedDate != nil {
+				domainComment.CreatedDate = *comment.CreatedDate
+			}
+			if comment.ThreadType == prThreadTypeVoteUpdate {
+				domainComment.Type = code.REVIEW
+				domainComment.Status = getPrVoteStatus(comment.Vote)
+			} else if comment.FilePath != "" {
+				domainComment.Type = code.DIFF_COMMENT
+			}
+			return []interface{}{
+				domainComment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
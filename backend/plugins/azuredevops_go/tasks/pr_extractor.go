@@ -19,6 +19,7 @@ package tasks
 
 import (
 	"encoding/json"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -40,11 +41,28 @@ var ExtractApiPullRequestsMeta = plugin.SubTaskMeta{
 	ProductTables: []string{
 		models.AzuredevopsPullRequest{}.TableName(),
 		models.AzuredevopsPrLabel{}.TableName(),
+		models.AzuredevopsPrReviewer{}.TableName(),
 	},
 }
 
 func ExtractApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawPullRequestTable)
+	db := taskCtx.GetDal()
+
+	// all pull requests are extracted again, drop their reviewers first so the ones removed from a pull request
+	// don't linger
+	err := db.Delete(
+		&models.AzuredevopsPrReviewer{},
+		dal.Where(
+			`connection_id = ? AND pull_request_id IN (
+				SELECT azuredevops_id FROM _tool_azuredevops_go_pull_requests WHERE connection_id = ? AND repository_id = ?
+			)`,
+			data.Options.ConnectionId, data.Options.ConnectionId, data.Options.RepositoryId,
+		),
+	)
+	if err != nil {
+		return err
+	}
 
 	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
 		RawDataSubTaskArgs: *rawDataSubTaskArgs,
@@ -55,7 +73,7 @@ func ExtractApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 				return nil, err
 			}
 
-			results := make([]interface{}, 0, 2)
+			results := make([]interface{}, 0, 1+len(rawL.Labels)+len(rawL.Reviewers))
 
 			//If this is a pr, ignore
 			adoApiPr, err := convertAzuredevopsPullRequest(rawL, data.Options.ConnectionId)
@@ -70,6 +88,20 @@ func ExtractApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 				})
 			}
 
+			for _, reviewer := range rawL.Reviewers {
+				results = append(results, &models.AzuredevopsPrReviewer{
+					ConnectionId:  data.Options.ConnectionId,
+					PullRequestId: adoApiPr.AzuredevopsId,
+					ReviewerId:    reviewer.Id,
+					Name:          reviewer.DisplayName,
+					UniqueName:    reviewer.UniqueName,
+					Vote:          reviewer.Vote,
+					IsRequired:    reviewer.IsRequired,
+					HasDeclined:   reviewer.HasDeclined,
+					IsContainer:   reviewer.IsContainer,
+				})
+			}
+
 			results = append(results, adoApiPr)
 
 			return results, nil
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiPullRequestThreadsMeta)
+}
+
+const RawPrThreadTable = "azuredevops_go_api_pull_request_threads"
+
+var CollectApiPullRequestThreadsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPullRequestThreads",
+	EntryPoint:       CollectApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Collect PullRequestThreads data from Azure DevOps API.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	DependencyTables: []string{models.AzuredevopsPullRequest{}.TableName()},
+	ProductTables:    []string{RawPrThreadTable},
+}
+
+func CollectApiPullRequestThreads(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawPrThreadTable)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("azuredevops_id"),
+		dal.From(models.AzuredevopsPullRequest{}.TableName()),
+		dal.Where("repository_id = ? and connection_id=?", data.Options.RepositoryId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimplePr{}))
+	if err != nil {
+		return err
+	}
+
+	// the threads api is not paginated, all threads of a pull request are returned at once
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		Incremental:        false,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/git/repositories/{{ .Params.RepositoryId }}/pullRequests/{{ .Input.AzuredevopsId }}/threads?api-version=7.1",
+		ResponseParser:     ParseRawMessageFromValue,
+		AfterResponse:      change203To401,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
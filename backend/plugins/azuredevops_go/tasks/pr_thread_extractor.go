@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiPullRequestThreadsMeta)
+}
+
+var ExtractApiPullRequestThreadsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPullRequestThreads",
+	EntryPoint:       ExtractApiPullRequestThreads,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull request threads data into tool layer table azuredevops_go_pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	DependencyTables: []string{RawPrThreadTable},
+	ProductTables:    []string{models.AzuredevopsPrComment{}.TableName()},
+}
+
+func ExtractApiPullRequestThreads(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawPrThreadTable)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			thread := &models.AzuredevopsApiPrThread{}
+			err := errors.Convert(json.Unmarshal(row.Data, thread))
+			if err != nil {
+				return nil, err
+			}
+			if thread.IsDeleted {
+				return nil, nil
+			}
+
+			input := &SimplePr{}
+			err = errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+
+			threadType := getPrThreadProperty(thread, "CodeReviewThreadType")
+			vote := 0
+			if threadType == prThreadTypeVoteUpdate {
+				vote, _ = strconv.Atoi(getPrThreadProperty(thread, "CodeReviewVoteResult"))
+			}
+			filePath := ""
+			if thread.ThreadContext != nil {
+				filePath = thread.ThreadContext.FilePath
+			}
+
+			results := make([]interface{}, 0, len(thread.Comments))
+			for _, comment := range thread.Comments {
+				if comment.IsDeleted {
+					continue
+				}
+				results = append(results, &models.AzuredevopsPrComment{
+					ConnectionId:    data.Options.ConnectionId,
+					PullRequestId:   input.AzuredevopsId,
+					ThreadId:        thread.Id,
+					AzuredevopsId:   comment.Id,
+					ParentCommentId: comment.ParentCommentId,
+					Body:            comment.Content,
+					AuthorId:        comment.Author.Id,
+					AuthorName:      comment.Author.DisplayName,
+					CommentType:     comment.CommentType,
+					ThreadType:      threadType,
+					ThreadStatus:    thread.Status,
+					FilePath:        filePath,
+					Vote:            vote,
+					CreatedDate:     common.Iso8601TimeToTime(comment.PublishedDate),
+					UpdatedDate:     common.Iso8601TimeToTime(comment.LastUpdatedDate),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps PR thread extractor")
+	}
+
+	return extractor.Execute()
+}
+
+func getPrThreadProperty(thread *models.AzuredevopsApiPrThread, name string) string {
+	property, ok := thread.Properties[name]
+	if !ok || property.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(property.Value)
+}
@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertReleaseDeploymentsMeta)
+}
+
+var ConvertReleaseDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "convertApiReleaseDeployments",
+	EntryPoint:       ConvertReleaseDeployments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_go_release_deployments into domain layer table cicd_deployments and cicd_deployment_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	DependencyTables: []string{
+		models.AzuredevopsReleaseDeployment{}.TableName(),
+	},
+	ProductTables: []string{
+		devops.CicdDeploymentCommit{}.TableName(),
+		devops.CICDDeployment{}.TableName(),
+	},
+}
+
+type JoinedReleaseDeployment struct {
+	models.AzuredevopsReleaseDeployment
+
+	RepoUrl string
+}
+
+func ConvertReleaseDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawReleaseDeploymentTable)
+	clauses := []dal.Clause{
+		dal.Select("_tool_azuredevops_go_release_deployments.*, _tool_azuredevops_go_repos.url as repo_url"),
+		dal.From(&models.AzuredevopsReleaseDeployment{}),
+		dal.Join(`left join _tool_azuredevops_go_repos
+			on _tool_azuredevops_go_release_deployments.repository_id = _tool_azuredevops_go_repos.id`),
+		dal.Where(`_tool_azuredevops_go_release_deployments.repository_id = ?
+			and _tool_azuredevops_go_release_deployments.connection_id = ?`,
+			data.Options.RepositoryId, data.Options.ConnectionId),
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	deploymentIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsReleaseDeployment{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(JoinedReleaseDeployment{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			deployment := inputRow.(*JoinedReleaseDeployment)
+			repoId := repoIdGen.Generate(data.Options.ConnectionId, deployment.RepositoryId)
+
+			deploymentCommit := &devops.CicdDeploymentCommit{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: deploymentIdGen.Generate(data.Options.ConnectionId, deployment.AzuredevopsId),
+				},
+				CicdScopeId:         repoId,
+				Name:                deployment.ReleaseDefinitionName,
+				DisplayTitle:        deployment.ReleaseName,
+				Url:                 deployment.Url,
+				Result:              devops.GetResult(cicdReleaseDeploymentResultRule, deployment.DeploymentStatus),
+				Status:              devops.GetStatus(cicdReleaseDeploymentStatusRule, deployment.DeploymentStatus),
+				OriginalStatus:      deployment.DeploymentStatus,
+				OriginalResult:      deployment.OperationStatus,
+				Environment:         deployment.ReleaseEnvironmentName,
+				OriginalEnvironment: deployment.ReleaseEnvironmentName,
+				TaskDatesInfo: devops.TaskDatesInfo{
+					QueuedDate:   deployment.QueuedOn,
+					StartedDate:  deployment.StartedOn,
+					FinishedDate: deployment.CompletedOn,
+				},
+				CommitSha: deployment.CommitSha,
+				RefName:   deployment.SourceBranch,
+				RepoId:    repoId,
+				RepoUrl:   deployment.RepoUrl,
+			}
+			if deployment.QueuedOn != nil {
+				deploymentCommit.CreatedDate = *deployment.QueuedOn
+			}
+			if deployment.StartedOn != nil && deployment.CompletedOn != nil {
+				durationSec := float64(deployment.CompletedOn.Sub(*deployment.StartedOn).Milliseconds() / 1e3)
+				deploymentCommit.DurationSec = &durationSec
+			}
+			if deployment.QueuedOn != nil && deployment.StartedOn != nil {
+				queuedDurationSec := float64(deployment.StartedOn.Sub(*deployment.QueuedOn).Milliseconds() / 1e3)
+				deploymentCommit.QueuedDurationSec = &queuedDurationSec
+			}
+			if data.RegexEnricher != nil {
+				if data.RegexEnricher.ReturnNameIfMatched(devops.ENV_NAME_PATTERN, deployment.ReleaseEnvironmentName) != "" {
+					deploymentCommit.Environment = devops.PRODUCTION
+				}
+			}
+
+			deploymentCommit.CicdDeploymentId = deploymentCommit.Id
+			return []interface{}{
+				deploymentCommit,
+				deploymentCommit.ToDeployment(),
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
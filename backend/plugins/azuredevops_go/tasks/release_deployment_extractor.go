@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiReleaseDeploymentsMeta)
+}
+
+var ExtractApiReleaseDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiReleaseDeployments",
+	EntryPoint:       ExtractApiReleaseDeployments,
+	EnabledByDefault: true,
+	Description:      "Extract raw Release Deployments data into tool layer table azuredevops_go_release_deployments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+	DependencyTables: []string{RawReleaseDeploymentTable},
+	ProductTables:    []string{models.AzuredevopsReleaseDeployment{}.TableName()},
+}
+
+func ExtractApiReleaseDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawReleaseDeploymentTable)
+
+	// repositories hosted outside Azure Repos are referred by their external id in build artifacts
+	repoIds := []string{data.Options.RepositoryId}
+	if data.Options.ExternalId != "" {
+		repoIds = append(repoIds, data.Options.ExternalId)
+	}
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			deployment := &models.AzuredevopsApiReleaseDeployment{}
+			err := errors.Convert(json.Unmarshal(row.Data, deployment))
+			if err != nil {
+				return nil, err
+			}
+
+			commitSha, branch, ok := findReleaseArtifactCommit(deployment.Release.Artifacts, repoIds)
+			if !ok {
+				// the release doesn't deploy anything from this repository
+				return nil, nil
+			}
+
+			return []interface{}{
+				&models.AzuredevopsReleaseDeployment{
+					ConnectionId:            data.Options.ConnectionId,
+					AzuredevopsId:           deployment.Id,
+					ProjectId:               data.Options.ProjectId,
+					RepositoryId:            data.Options.RepositoryId,
+					ReleaseId:               deployment.Release.Id,
+					ReleaseName:             deployment.Release.Name,
+					ReleaseDefinitionId:     deployment.ReleaseDefinition.Id,
+					ReleaseDefinitionName:   deployment.ReleaseDefinition.Name,
+					ReleaseEnvironmentId:    deployment.ReleaseEnvironment.Id,
+					ReleaseEnvironmentName:  deployment.ReleaseEnvironment.Name,
+					DefinitionEnvironmentId: deployment.DefinitionEnvironmentId,
+					Attempt:                 deployment.Attempt,
+					Reason:                  deployment.Reason,
+					DeploymentStatus:        deployment.DeploymentStatus,
+					OperationStatus:         deployment.OperationStatus,
+					CommitSha:               commitSha,
+					SourceBranch:            branch,
+					RequestedForId:          deployment.RequestedFor.Id,
+					RequestedForName:        deployment.RequestedFor.DisplayName,
+					QueuedOn:                common.Iso8601TimeToTime(deployment.QueuedOn),
+					StartedOn:               common.Iso8601TimeToTime(deployment.StartedOn),
+					CompletedOn:             common.Iso8601TimeToTime(deployment.CompletedOn),
+					LastModifiedOn:          common.Iso8601TimeToTime(deployment.LastModifiedOn),
+					Url:                     deployment.Release.Links.Web.Href,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps Release Deployment extractor")
+	}
+
+	return extractor.Execute()
+}
+
+// findReleaseArtifactCommit returns the commit and branch of the artifact built from one of the given repositories,
+// the primary artifact is preferred when several artifacts come from the same repository
+func findReleaseArtifactCommit(artifacts []models.AzuredevopsApiReleaseArtifact, repoIds []string) (string, string, bool) {
+	var found *models.AzuredevopsApiReleaseArtifact
+	for i := range artifacts {
+		artifact := &artifacts[i]
+		repoId, commitSha := getReleaseArtifactSource(artifact)
+		if commitSha == "" || !utils.StringsContains(repoIds, repoId) {
+			continue
+		}
+		if found == nil || artifact.IsPrimary {
+			found = artifact
+		}
+	}
+	if found == nil {
+		return "", "", false
+	}
+	_, commitSha := getReleaseArtifactSource(found)
+	return commitSha, found.DefinitionReference["branch"].Id, true
+}
+
+// getReleaseArtifactSource returns the repository and the commit of an artifact, Azure Repos artifacts refer to the
+// repository as the definition, while Build artifacts carry the repository and the commit of the build
+func getReleaseArtifactSource(artifact *models.AzuredevopsApiReleaseArtifact) (string, string) {
+	ref := artifact.DefinitionReference
+	switch artifact.Type {
+	case "Git":
+		return ref["definition"].Id, ref["version"].Id
+	case "Build":
+		return ref["repository"].Id, ref["sourceVersion"].Id
+	default:
+		return "", ""
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
@@ -38,7 +39,7 @@ var ConvertRepoMeta = plugin.SubTaskMeta{
 	Name:             "convertRepo",
 	EntryPoint:       ConvertRepo,
 	EnabledByDefault: true,
-	Description:      "Convert tool layer table _tool_azuredevops_go_repos into domain layer table repos and cicd scope",
+	Description:      "Convert tool layer table _tool_azuredevops_go_repos into domain layer table repos, boards and cicd scope",
 	DomainTypes: []string{
 		plugin.DOMAIN_TYPE_CODE,
 		plugin.DOMAIN_TYPE_TICKET,
@@ -50,6 +51,7 @@ var ConvertRepoMeta = plugin.SubTaskMeta{
 	},
 	ProductTables: []string{
 		code.Repo{}.TableName(),
+		ticket.Board{}.TableName(),
 		devops.CicdScope{}.TableName()},
 }
 
@@ -76,9 +78,11 @@ func ConvertRepo(taskCtx plugin.SubTaskContext) errors.Error {
 
 			domainRepository := convertToRepositoryModel(repository)
 			domainCiCdScope := convertToCicdScopeModel(repository)
+			domainBoard := convertToBoardModel(repository)
 			return []interface{}{
 				domainRepository,
 				domainCiCdScope,
+				domainBoard,
 			}, nil
 		},
 	})
@@ -101,6 +105,18 @@ func convertToCicdScopeModel(repo *models.AzuredevopsRepo) *devops.CicdScope {
 	return domainCicdScope
 }
 
+// convertToBoardModel creates the board of the work items in the project of the repository
+func convertToBoardModel(repo *models.AzuredevopsRepo) *ticket.Board {
+	domainBoard := &ticket.Board{
+		DomainEntity: domainlayer.DomainEntity{
+			Id: didgen.NewDomainIdGenerator(repo).Generate(repo.ConnectionId, repo.Id),
+		},
+		Name: repo.ProjectId + "/" + repo.Name,
+		Url:  repo.Url,
+	}
+	return domainBoard
+}
+
 func convertToRepositoryModel(repo *models.AzuredevopsRepo) *code.Repo {
 	repoIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{})
 	domainRepository := &code.Repo{
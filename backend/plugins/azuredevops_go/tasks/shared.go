@@ -22,10 +22,13 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // Build and TimeLine Record State and Result types can be found here:
@@ -62,6 +65,18 @@ func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api
 	return RawDataSubTaskArgs, data
 }
 
+// CreateProjectRawDataSubTaskArgs identifies the raw data by the project instead of the repository, the work items of
+// a project are shared by all of its repositories so they are collected only once
+func CreateProjectRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, Table string) (*api.RawDataSubTaskArgs, *AzuredevopsTaskData) {
+	data := taskCtx.GetData().(*AzuredevopsTaskData)
+	RawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx:     taskCtx,
+		Options: &AzuredevopsProjectOptions{data.Options},
+		Table:   Table,
+	}
+	return RawDataSubTaskArgs, data
+}
+
 func ParseRawMessageFromValue(res *http.Response) ([]json.RawMessage, errors.Error) {
 	var data struct {
 		Value []json.RawMessage `json:"value"`
@@ -132,6 +147,140 @@ var cicdTaskStatusRule = &devops.StatusRule{
 	Default:    devops.STATUS_OTHER,
 }
 
+// Work item states of the default processes (Basic, Agile, Scrum and CMMI) can be found here:
+// https://learn.microsoft.com/en-us/azure/devops/boards/work-items/workflow-and-state-categories
+var workItemStatusRule = &ticket.StatusRule{
+	Todo:       []string{"New", "To Do", "Proposed", "Approved"},
+	InProgress: []string{"Active", "Doing", "Committed", "In Progress", "Resolved"},
+	Done:       []string{"Done", "Closed", "Removed", "Completed"},
+	Default:    ticket.OTHER,
+}
+
+var workItemTypeRule = map[string]string{
+	"Bug":                  ticket.BUG,
+	"Epic":                 ticket.REQUIREMENT,
+	"Feature":              ticket.REQUIREMENT,
+	"Issue":                ticket.REQUIREMENT,
+	"Product Backlog Item": ticket.REQUIREMENT,
+	"Requirement":          ticket.REQUIREMENT,
+	"User Story":           ticket.REQUIREMENT,
+	"Task":                 ticket.TASK,
+}
+
+// getWorkItemStdTypeAndStatus resolves the standard type and status of a work item, the type mappings of the scope
+// config take precedence over the default rules of the built-in processes
+func getWorkItemStdTypeAndStatus(scopeConfig *models.AzuredevopsScopeConfig, workItemType, state string) (string, string) {
+	stdType, ok := workItemTypeRule[workItemType]
+	if !ok {
+		stdType = strings.ToUpper(workItemType)
+	}
+	stdStatus := ticket.GetStatus(workItemStatusRule, state)
+	if scopeConfig == nil {
+		return stdType, stdStatus
+	}
+	if mapping, ok := scopeConfig.TypeMappings[workItemType]; ok {
+		if mapping.StandardType != "" {
+			stdType = strings.ToUpper(mapping.StandardType)
+		}
+		if statusMapping, ok := mapping.StatusMappings[state]; ok && statusMapping.StandardStatus != "" {
+			stdStatus = statusMapping.StandardStatus
+		}
+	}
+	return stdType, stdStatus
+}
+
+// flattenClassificationNodes turns the iteration or area tree into a list of nodes without children
+func flattenClassificationNodes(node *models.AzuredevopsApiClassificationNode) ([]json.RawMessage, errors.Error) {
+	children := node.Children
+	node.Children = nil
+	blob, err := errors.Convert01(json.Marshal(node))
+	if err != nil {
+		return nil, err
+	}
+	nodes := []json.RawMessage{blob}
+	for _, child := range children {
+		childNodes, err := flattenClassificationNodes(child)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, childNodes...)
+	}
+	return nodes, nil
+}
+
+// ParseClassificationNodes parses the tree returned by the classification nodes api
+func ParseClassificationNodes(res *http.Response) ([]json.RawMessage, errors.Error) {
+	root := &models.AzuredevopsApiClassificationNode{}
+	err := api.UnmarshalResponse(res, root)
+	if err != nil {
+		return nil, err
+	}
+	return flattenClassificationNodes(root)
+}
+
+// convertClassificationNodePath converts the path of a classification node, e.g. `\Project\Iteration\Sprint 1`,
+// to the format used by System.IterationPath and System.AreaPath of work items, e.g. `Project\Sprint 1`
+func convertClassificationNodePath(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, `\`), `\`)
+	if len(parts) < 2 {
+		return strings.Join(parts, `\`)
+	}
+	return strings.Join(append(parts[:1], parts[2:]...), `\`)
+}
+
+// Pull request thread types and reviewer votes can be found here:
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-reviewers/list?view=azure-devops-rest-7.1#identityrefwithvote
+const (
+	prCommentTypeSystem    = "system"
+	prThreadTypeVoteUpdate = "VoteUpdate"
+)
+
+// getPrVoteStatus converts the vote of a reviewer to a readable review status
+func getPrVoteStatus(vote int) string {
+	switch {
+	case vote >= 10:
+		return "APPROVED"
+	case vote > 0:
+		return "APPROVED_WITH_SUGGESTIONS"
+	case vote <= -10:
+		return "REJECTED"
+	case vote < 0:
+		return "WAITING_FOR_AUTHOR"
+	default:
+		return "NO_VOTE"
+	}
+}
+
+// Release deployment statuses can be found here:
+// https://learn.microsoft.com/en-us/rest/api/azure/devops/release/deployments/list?view=azure-devops-rest-7.1#deploymentstatus
+var cicdReleaseDeploymentResultRule = &devops.ResultRule{
+	Success: []string{succeeded, partiallySucceeded},
+	Failure: []string{failed, "notDeployed"},
+	Default: devops.RESULT_DEFAULT,
+}
+
+var cicdReleaseDeploymentStatusRule = &devops.StatusRule{
+	Done:       []string{succeeded, partiallySucceeded, failed, "notDeployed"},
+	InProgress: []string{inProgress},
+	Default:    devops.STATUS_OTHER,
+}
+
+// getReleaseApiEndpoint derives the endpoint of the Release API from the endpoint of the connection. Azure DevOps
+// Services serve it from the vsrm subdomain, e.g. https://vsrm.dev.azure.com/ or https://{org}.vsrm.visualstudio.com/,
+// while Azure DevOps Server serves it along with the other APIs
+func getReleaseApiEndpoint(endpoint string) (string, errors.Error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.BadInput.Wrap(err, fmt.Sprintf("invalid endpoint %s", endpoint))
+	}
+	if u.Host == "dev.azure.com" {
+		u.Host = "vsrm.dev.azure.com"
+	} else if strings.HasSuffix(u.Host, ".visualstudio.com") && !strings.HasSuffix(u.Host, ".vsrm.visualstudio.com") {
+		u.Host = strings.TrimSuffix(u.Host, ".visualstudio.com") + ".vsrm.visualstudio.com"
+	}
+	return strings.TrimSuffix(u.String(), "/") + "/", nil
+}
+
 func change203To401(res *http.Response) errors.Error {
 	if res.StatusCode == http.StatusUnauthorized {
 		return errors.Unauthorized.New("authentication failed, please check your AccessToken")
@@ -151,3 +300,10 @@ func ignoreDeletedBuilds(res *http.Response) errors.Error {
 	}
 	return nil
 }
+
+func ignoreDeletedWorkItems(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
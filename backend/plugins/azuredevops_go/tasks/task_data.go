@@ -68,3 +68,20 @@ func (p *AzuredevopsOptions) GetParams() any {
 		RepositoryId:   p.RepositoryId,
 	}
 }
+
+type AzuredevopsProjectParams struct {
+	OrganizationId string
+	ProjectId      string
+}
+
+// AzuredevopsProjectOptions leaves the repository out of the params of the raw data
+type AzuredevopsProjectOptions struct {
+	*AzuredevopsOptions
+}
+
+func (p *AzuredevopsProjectOptions) GetParams() any {
+	return AzuredevopsProjectParams{
+		OrganizationId: p.OrganizationId,
+		ProjectId:      p.ProjectId,
+	}
+}
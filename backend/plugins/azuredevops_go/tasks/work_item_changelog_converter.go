@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertWorkItemChangelogsMeta)
+}
+
+var ConvertWorkItemChangelogsMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItemChangelogs",
+	EntryPoint:       ConvertWorkItemChangelogs,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_work_item_changes into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.AzuredevopsWorkItemChange{}.TableName(),
+		models.AzuredevopsWorkItem{}.TableName(),
+		models.AzuredevopsIteration{}.TableName(),
+	},
+	ProductTables: []string{ticket.IssueChangelogs{}.TableName()},
+}
+
+type WorkItemChangeResult struct {
+	models.AzuredevopsWorkItemChange
+	Type string
+}
+
+func ConvertWorkItemChangelogs(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemUpdateTable)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+
+	sprintIds, err := getSprintIdsByPath(db, data)
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("c.*, w.type"),
+		dal.From("_tool_azuredevops_go_work_item_changes c"),
+		dal.Join(`LEFT JOIN _tool_azuredevops_go_work_items w ON (
+			w.connection_id = c.connection_id AND w.azuredevops_id = c.work_item_id
+		)`),
+		dal.Where("c.connection_id = ? AND w.organization_id = ? AND w.project_id = ?",
+			connectionId, data.Options.OrganizationId, data.Options.ProjectId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changelogIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItemChange{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItem{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsUser{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(WorkItemChangeResult{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			change := inputRow.(*WorkItemChangeResult)
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: changelogIdGen.Generate(connectionId, change.WorkItemId, change.UpdateId, change.Field),
+				},
+				IssueId:           issueIdGen.Generate(connectionId, change.WorkItemId),
+				AuthorName:        change.RevisedByName,
+				FieldId:           change.Field,
+				FieldName:         change.Field,
+				OriginalFromValue: change.OldValue,
+				OriginalToValue:   change.NewValue,
+			}
+			if change.RevisedById != "" {
+				changelog.AuthorId = userIdGen.Generate(connectionId, change.RevisedById)
+			}
+			if change.RevisedDate != nil {
+				changelog.CreatedDate = *change.RevisedDate
+			}
+			// use the same field names as the other ticket plugins for the fields used by the metrics
+			switch change.Field {
+			case "System.State":
+				changelog.FieldName = "status"
+				if change.OldValue != "" {
+					_, changelog.FromValue = getWorkItemStdTypeAndStatus(data.Options.ScopeConfig, change.Type, change.OldValue)
+				}
+				if change.NewValue != "" {
+					_, changelog.ToValue = getWorkItemStdTypeAndStatus(data.Options.ScopeConfig, change.Type, change.NewValue)
+				}
+			case "System.AssignedTo":
+				changelog.FieldName = "assignee"
+			case "System.IterationPath":
+				changelog.FieldName = "Sprint"
+				changelog.FromValue = sprintIds[change.OldValue]
+				changelog.ToValue = sprintIds[change.NewValue]
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiWorkItemsMeta)
+}
+
+const RawWorkItemTable = "azuredevops_go_api_work_items"
+
+// the work items batch api accepts at most 200 ids per request
+const workItemBatchSize = 200
+
+// the wiql api refuses to return more than 20000 work items at once
+const wiqlPageSize = 20000
+
+var CollectApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiWorkItems",
+	EntryPoint:       CollectApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Collect Work Items data from Azure DevOps API, supports timeFilter but not diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{},
+	ProductTables:    []string{RawWorkItemTable},
+}
+
+type WorkItemBatch struct {
+	Ids string
+}
+
+func CollectApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+
+	apiCollector, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	// the work items api doesn't support listing, ids have to be queried by WIQL first
+	ids, err := queryWorkItemIds(data, apiCollector.GetSince())
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	for i := 0; i < len(ids); i += workItemBatchSize {
+		end := i + workItemBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		iterator.Push(&WorkItemBatch{Ids: strings.Join(ids[i:end], ",")})
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "{{ .Params.OrganizationId }}/{{ .Params.ProjectId }}/_apis/wit/workitems?api-version=7.1",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("ids", reqData.Input.(*WorkItemBatch).Ids)
+			// work items deleted after the WIQL query are returned as null instead of failing the whole batch
+			query.Set("errorPolicy", "omit")
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			items, err := ParseRawMessageFromValue(res)
+			if err != nil {
+				return nil, err
+			}
+			workItems := make([]json.RawMessage, 0, len(items))
+			for _, item := range items {
+				if string(item) != "null" {
+					workItems = append(workItems, item)
+				}
+			}
+			return workItems, nil
+		},
+		AfterResponse: change203To401,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
+
+// queryWorkItemIds pages through the ids by [System.Id] since a single WIQL query fails once it matches more than
+// 20000 work items
+func queryWorkItemIds(data *AzuredevopsTaskData, since *time.Time) ([]string, errors.Error) {
+	ids := make([]string, 0)
+	lastId := 0
+	for {
+		pageIds, err := queryWorkItemIdPage(data, since, lastId)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range pageIds {
+			ids = append(ids, fmt.Sprint(id))
+		}
+		if len(pageIds) < wiqlPageSize {
+			return ids, nil
+		}
+		lastId = pageIds[len(pageIds)-1]
+	}
+}
+
+func queryWorkItemIdPage(data *AzuredevopsTaskData, since *time.Time, lastId int) ([]int, errors.Error) {
+	wiql := fmt.Sprintf("SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.Id] > %d", lastId)
+	if since != nil {
+		wiql += fmt.Sprintf(" AND [System.ChangedDate] >= '%s'", since.UTC().Format(time.RFC3339))
+	}
+	wiql += " ORDER BY [System.Id] ASC"
+
+	query := url.Values{}
+	query.Set("api-version", "7.1")
+	query.Set("timePrecision", "true")
+	query.Set("$top", fmt.Sprint(wiqlPageSize))
+	res, err := data.ApiClient.Post(
+		fmt.Sprintf("%s/%s/_apis/wit/wiql", data.Options.OrganizationId, data.Options.ProjectId),
+		query,
+		map[string]string{"query": wiql},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = change203To401(res); err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to query work items of project %s", data.Options.ProjectId))
+	}
+	var result struct {
+		WorkItems []struct {
+			Id int `json:"id"`
+		} `json:"workItems"`
+	}
+	err = api.UnmarshalResponse(res, &result)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(result.WorkItems))
+	for _, workItem := range result.WorkItems {
+		ids = append(ids, workItem.Id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,183 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertWorkItemsMeta)
+}
+
+var ConvertWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "convertWorkItems",
+	EntryPoint:       ConvertWorkItems,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table azuredevops_work_items into domain layer table issues, board_issues, sprint_issues and issue_assignees",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.AzuredevopsWorkItem{}.TableName(),
+		models.AzuredevopsIteration{}.TableName(),
+	},
+	ProductTables: []string{
+		ticket.Issue{}.TableName(),
+		ticket.BoardIssue{}.TableName(),
+		ticket.SprintIssue{}.TableName(),
+		ticket.IssueAssignee{}.TableName(),
+	},
+}
+
+func ConvertWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+	db := taskCtx.GetDal()
+
+	sprintIds, err := getSprintIdsByPath(db, data)
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.From(&models.AzuredevopsWorkItem{}),
+		dal.Where("connection_id = ? AND organization_id = ? AND project_id = ?",
+			data.Options.ConnectionId, data.Options.OrganizationId, data.Options.ProjectId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsWorkItem{})
+	userIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsUser{})
+	domainBoardId := didgen.NewDomainIdGenerator(&models.AzuredevopsRepo{}).Generate(data.Options.ConnectionId, data.Options.RepositoryId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.AzuredevopsWorkItem{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			workItem := inputRow.(*models.AzuredevopsWorkItem)
+			issue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: issueIdGen.Generate(data.Options.ConnectionId, workItem.AzuredevopsId)},
+				Url:            workItem.Url,
+				IssueKey:       strconv.Itoa(workItem.AzuredevopsId),
+				Title:          workItem.Title,
+				Description:    workItem.Description,
+				Type:           workItem.StdType,
+				OriginalType:   workItem.Type,
+				Status:         workItem.StdStatus,
+				OriginalStatus: workItem.State,
+				StoryPoint:     workItem.StoryPoint,
+				CreatedDate:    workItem.CreatedDate,
+				UpdatedDate:    workItem.ChangedDate,
+				Priority:       workItem.Priority,
+				Severity:       workItem.Severity,
+				Component:      workItem.AreaPath,
+				DueDate:        workItem.DueDate,
+				CreatorName:    workItem.CreatedByName,
+				AssigneeName:   workItem.AssignedToName,
+				IsSubtask:      workItem.ParentId != 0 && workItem.StdType == ticket.TASK,
+			}
+			if workItem.StdStatus == ticket.DONE {
+				issue.ResolutionDate = workItem.ClosedDate
+				if issue.ResolutionDate != nil && issue.CreatedDate != nil {
+					leadTimeMinutes := uint(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+					issue.LeadTimeMinutes = &leadTimeMinutes
+				}
+			}
+			// estimates of work items are in hours
+			issue.OriginalEstimateMinutes = hoursToMinutes(workItem.OriginalEstimate)
+			issue.TimeRemainingMinutes = hoursToMinutes(workItem.RemainingWork)
+			issue.TimeSpentMinutes = hoursToMinutes(workItem.CompletedWork)
+			if workItem.CreatedById != "" {
+				issue.CreatorId = userIdGen.Generate(data.Options.ConnectionId, workItem.CreatedById)
+			}
+			if workItem.ParentId != 0 {
+				issue.ParentIssueId = issueIdGen.Generate(data.Options.ConnectionId, workItem.ParentId)
+			}
+
+			result := []interface{}{
+				issue,
+				&ticket.BoardIssue{
+					BoardId: domainBoardId,
+					IssueId: issue.Id,
+				},
+			}
+			if workItem.AssignedToId != "" {
+				issue.AssigneeId = userIdGen.Generate(data.Options.ConnectionId, workItem.AssignedToId)
+				result = append(result, &ticket.IssueAssignee{
+					IssueId:      issue.Id,
+					AssigneeId:   issue.AssigneeId,
+					AssigneeName: issue.AssigneeName,
+				})
+			}
+			if sprintId, ok := sprintIds[workItem.IterationPath]; ok {
+				result = append(result, &ticket.SprintIssue{
+					SprintId: sprintId,
+					IssueId:  issue.Id,
+				})
+			}
+			return result, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getSprintIdsByPath maps the iteration paths of the project to the domain sprint ids
+func getSprintIdsByPath(db dal.Dal, data *AzuredevopsTaskData) (map[string]string, errors.Error) {
+	var iterations []models.AzuredevopsIteration
+	err := db.All(&iterations, dal.Where("connection_id = ? AND organization_id = ? AND project_id = ?",
+		data.Options.ConnectionId, data.Options.OrganizationId, data.Options.ProjectId))
+	if err != nil {
+		return nil, err
+	}
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.AzuredevopsIteration{})
+	sprintIds := make(map[string]string, len(iterations))
+	for _, iteration := range iterations {
+		// work items not planned in any iteration refer to the root node, which is not a sprint
+		if !strings.Contains(iteration.Path, `\`) {
+			continue
+		}
+		sprintIds[iteration.Path] = sprintIdGen.Generate(data.Options.ConnectionId, iteration.Identifier)
+	}
+	return sprintIds, nil
+}
+
+func hoursToMinutes(hours *float64) *int64 {
+	if hours == nil {
+		return nil
+	}
+	minutes := int64(*hours * 60)
+	return &minutes
+}
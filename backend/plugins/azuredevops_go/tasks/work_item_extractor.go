@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiWorkItemsMeta)
+}
+
+var ExtractApiWorkItemsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItems",
+	EntryPoint:       ExtractApiWorkItems,
+	EnabledByDefault: true,
+	Description:      "Extract raw Work Items data into tool layer table azuredevops_work_items",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{RawWorkItemTable},
+	ProductTables:    []string{models.AzuredevopsWorkItem{}.TableName()},
+}
+
+func ExtractApiWorkItems(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemTable)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiWorkItem := &models.AzuredevopsApiWorkItem{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiWorkItem))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				convertAzuredevopsWorkItem(apiWorkItem, data),
+			}, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps Work Item extractor")
+	}
+
+	return extractor.Execute()
+}
+
+func convertAzuredevopsWorkItem(apiWorkItem *models.AzuredevopsApiWorkItem, data *AzuredevopsTaskData) *models.AzuredevopsWorkItem {
+	fields := apiWorkItem.Fields
+	workItem := &models.AzuredevopsWorkItem{
+		ConnectionId:     data.Options.ConnectionId,
+		AzuredevopsId:    apiWorkItem.Id,
+		OrganizationId:   data.Options.OrganizationId,
+		ProjectId:        data.Options.ProjectId,
+		Rev:              apiWorkItem.Rev,
+		Title:            fields.Title,
+		Description:      fields.Description,
+		Type:             fields.WorkItemType,
+		State:            fields.State,
+		Reason:           fields.Reason,
+		Severity:         fields.Severity,
+		StoryPoint:       fields.StoryPoints,
+		OriginalEstimate: fields.OriginalEstimate,
+		RemainingWork:    fields.RemainingWork,
+		CompletedWork:    fields.CompletedWork,
+		AreaPath:         fields.AreaPath,
+		IterationPath:    fields.IterationPath,
+		ParentId:         fields.Parent,
+		CreatedDate:      common.Iso8601TimeToTime(fields.CreatedDate),
+		ChangedDate:      common.Iso8601TimeToTime(fields.ChangedDate),
+		ClosedDate:       common.Iso8601TimeToTime(fields.ClosedDate),
+		DueDate:          common.Iso8601TimeToTime(fields.DueDate),
+		Url:              apiWorkItem.Links.Html.Href,
+	}
+	// the Scrum process tracks the size of backlog items with effort instead of story points
+	if workItem.StoryPoint == nil {
+		workItem.StoryPoint = fields.Effort
+	}
+	if fields.Priority != nil {
+		workItem.Priority = fmt.Sprint(*fields.Priority)
+	}
+	if fields.AssignedTo != nil {
+		workItem.AssignedToId = fields.AssignedTo.Id
+		workItem.AssignedToName = fields.AssignedTo.DisplayName
+	}
+	if fields.CreatedBy != nil {
+		workItem.CreatedById = fields.CreatedBy.Id
+		workItem.CreatedByName = fields.CreatedBy.DisplayName
+	}
+	if workItem.Url == "" {
+		workItem.Url = apiWorkItem.Url
+	}
+	workItem.StdType, workItem.StdStatus = getWorkItemStdTypeAndStatus(data.Options.ScopeConfig, workItem.Type, workItem.State)
+	return workItem
+}
@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/azuredevops_go/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiWorkItemUpdatesMeta)
+}
+
+var ExtractApiWorkItemUpdatesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiWorkItemUpdates",
+	EntryPoint:       ExtractApiWorkItemUpdates,
+	EnabledByDefault: true,
+	Description:      "Extract raw Work Item Updates data into tool layer table azuredevops_work_item_changes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{RawWorkItemUpdateTable},
+	ProductTables:    []string{models.AzuredevopsWorkItemChange{}.TableName()},
+}
+
+// these fields are changed by every update, they carry no information about the work item itself
+var ignoredWorkItemFields = map[string]bool{
+	"System.Rev":            true,
+	"System.Watermark":      true,
+	"System.ChangedDate":    true,
+	"System.ChangedBy":      true,
+	"System.AuthorizedDate": true,
+	"System.AuthorizedAs":   true,
+	"System.RevisedDate":    true,
+	"System.PersonId":       true,
+}
+
+func ExtractApiWorkItemUpdates(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateProjectRawDataSubTaskArgs(taskCtx, RawWorkItemUpdateTable)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			update := &models.AzuredevopsApiWorkItemUpdate{}
+			err := errors.Convert(json.Unmarshal(row.Data, update))
+			if err != nil {
+				return nil, err
+			}
+			// the first revision only contains the initial values of the work item
+			if update.Rev <= 1 {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, len(update.Fields))
+			for field, value := range update.Fields {
+				if ignoredWorkItemFields[field] {
+					continue
+				}
+				results = append(results, &models.AzuredevopsWorkItemChange{
+					ConnectionId:  data.Options.ConnectionId,
+					WorkItemId:    update.WorkItemId,
+					UpdateId:      update.Id,
+					Field:         field,
+					Rev:           update.Rev,
+					RevisedById:   update.RevisedBy.Id,
+					RevisedByName: update.RevisedBy.DisplayName,
+					RevisedDate:   common.Iso8601TimeToTime(update.RevisedDate),
+					OldValue:      formatWorkItemFieldValue(value.OldValue),
+					NewValue:      formatWorkItemFieldValue(value.NewValue),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return errors.Default.Wrap(err, "error initializing Azure DevOps Work Item Update extractor")
+	}
+
+	return extractor.Execute()
+}
+
+// formatWorkItemFieldValue converts a field value to string, identities are represented by their display names
+func formatWorkItemFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}:
+		if displayName, ok := v["displayName"]; ok {
+			return fmt.Sprint(displayName)
+		}
+		blob, _ := json.Marshal(v)
+		return string(blob)
+	default:
+		return fmt.Sprint(v)
+	}
+}
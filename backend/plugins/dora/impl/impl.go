@@ -96,6 +96,7 @@ func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CalculateChangeLeadTimeMeta,
 		tasks.IssuesToIncidentsMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.DetectDeploymentReworkMeta,
 	}
 }
 
@@ -162,6 +163,7 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 					"calculateChangeLeadTime",
 					tasks.IssuesToIncidentsMeta.Name,
 					"ConnectIncidentToDeployment",
+					tasks.DetectDeploymentReworkMeta.Name,
 				},
 			},
 		},
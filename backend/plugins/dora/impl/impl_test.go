@@ -63,6 +63,7 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 					"calculateChangeLeadTime",
 					tasks.IssuesToIncidentsMeta.Name,
 					"ConnectIncidentToDeployment",
+					tasks.DetectDeploymentReworkMeta.Name,
 				},
 				Options: map[string]interface{}{"projectName": projectName},
 			},
@@ -0,0 +1,212 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var DetectDeploymentReworkMeta = plugin.SubTaskMeta{
+	Name:             "detectDeploymentRework",
+	EntryPoint:       DetectDeploymentRework,
+	EnabledByDefault: true,
+	Description:      "flag reverted, hotfix and rollback deployments in cicd_deployment_commits for rework rate",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{"cicd_deployment_commits", "commits_diffs", "commits"},
+	ProductTables:    []string{"cicd_deployment_commits"},
+}
+
+var (
+	revertCommitPattern = regexp.MustCompile(`(?i)^revert\b`)
+	hotfixPattern       = regexp.MustCompile(`(?i)hot[-_ ]?fix`)
+	rollbackPattern     = regexp.MustCompile(`(?i)roll[-_ ]?back`)
+)
+
+// DetectDeploymentRework flags successful deployments that were caused by a
+// need to rework a previous change:
+//   - ROLLBACK: the deployment is named/tagged as a rollback, or it redeploys
+//     a commit which had already been deployed before a newer one
+//   - REVERT: the commits shipped by the deployment contain a revert commit
+//   - HOTFIX: the deployment ref or any shipped commit is marked as a hotfix
+//
+// Deployments are grouped by cicd_scope_id/repo_url/env the same way
+// EnrichPrevSuccessDeploymentCommit does, and the commits shipped by a
+// deployment are taken from commits_diffs generated by refdiff, hence this
+// subtask must run after `calculateDeploymentCommitsDiff`.
+func DetectDeploymentRework(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+
+	messagesByDiff, err := loadDeployedCommitMessages(db, data)
+	if err != nil {
+		return err
+	}
+
+	clauses := append(
+		[]dal.Clause{
+			dal.Select("dc.*"),
+			dal.From("cicd_deployment_commits dc"),
+		},
+		deploymentReworkClauses(data)...,
+	)
+	if data.Options.ScopeId != nil {
+		clauses = append(clauses, dal.Orderby("dc.repo_url, dc.environment, dc.finished_date"))
+	} else {
+		clauses = append(clauses, dal.Orderby("dc.cicd_scope_id, dc.repo_url, dc.environment, dc.finished_date"))
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prevCicdScopeId := ""
+	prevRepoUrl := ""
+	prevEnv := ""
+	prevCommitSha := ""
+	deployedShas := map[string]bool{}
+
+	enricher, err := api.NewDataEnricher(api.DataEnricherArgs[devops.CicdDeploymentCommit]{
+		Ctx:   taskCtx,
+		Name:  "deployment_rework_detector",
+		Input: cursor,
+		Enrich: func(deploymentCommit *devops.CicdDeploymentCommit) ([]interface{}, errors.Error) {
+			// a new set of consecutive deployments starts whenever cicd_scope_id/repo_url/env shifted
+			if prevCicdScopeId != deploymentCommit.CicdScopeId ||
+				prevRepoUrl != deploymentCommit.RepoUrl ||
+				prevEnv != deploymentCommit.Environment {
+				prevCommitSha = ""
+				deployedShas = map[string]bool{}
+			}
+
+			messages := messagesByDiff[commitsDiffKey(deploymentCommit.CommitSha, prevCommitSha)]
+			reworkType := detectReworkType(deploymentCommit, prevCommitSha, deployedShas, messages)
+			deploymentCommit.IsRework = reworkType != ""
+			deploymentCommit.ReworkType = reworkType
+
+			prevCicdScopeId = deploymentCommit.CicdScopeId
+			prevRepoUrl = deploymentCommit.RepoUrl
+			prevEnv = deploymentCommit.Environment
+			prevCommitSha = deploymentCommit.CommitSha
+			deployedShas[deploymentCommit.CommitSha] = true
+			return []interface{}{deploymentCommit}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return enricher.Execute()
+}
+
+// deploymentReworkClauses selects the successful deployments of the scope or the project
+func deploymentReworkClauses(data *DoraTaskData) []dal.Clause {
+	clauses := []dal.Clause{
+		dal.Where(`
+			dc.finished_date IS NOT NULL
+			AND dc.environment IS NOT NULL
+			AND dc.environment != ''
+			AND dc.repo_url IS NOT NULL
+			AND dc.repo_url != ''
+			AND dc.result = ?
+			`,
+			devops.RESULT_SUCCESS,
+		),
+	}
+	if data.Options.ScopeId != nil {
+		return append(clauses, dal.Where("dc.cicd_scope_id = ?", data.Options.ScopeId))
+	}
+	return append(clauses,
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = dc.cicd_scope_id)"),
+		dal.Where("pm.project_name = ?", data.Options.ProjectName),
+	)
+}
+
+type deployedCommitMessage struct {
+	NewCommitSha string
+	OldCommitSha string
+	CommitSha    string
+	Message      string
+}
+
+func commitsDiffKey(newCommitSha, oldCommitSha string) string {
+	return newCommitSha + ":" + oldCommitSha
+}
+
+// loadDeployedCommitMessages loads the messages of the commits shipped by all the deployments at once, keyed by
+// the new/old commit sha pair of commits_diffs since the previous deployment is only known while enriching
+func loadDeployedCommitMessages(db dal.Dal, data *DoraTaskData) (map[string][]string, errors.Error) {
+	var rows []deployedCommitMessage
+	clauses := append(
+		[]dal.Clause{
+			dal.Select("DISTINCT cd.new_commit_sha, cd.old_commit_sha, cd.commit_sha, c.message"),
+			dal.From("commits_diffs cd"),
+			dal.Join("INNER JOIN commits c ON (c.sha = cd.commit_sha)"),
+			dal.Join("INNER JOIN cicd_deployment_commits dc ON (dc.commit_sha = cd.new_commit_sha)"),
+		},
+		deploymentReworkClauses(data)...,
+	)
+	err := db.All(&rows, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	messagesByDiff := make(map[string][]string)
+	for _, row := range rows {
+		key := commitsDiffKey(row.NewCommitSha, row.OldCommitSha)
+		messagesByDiff[key] = append(messagesByDiff[key], row.Message)
+	}
+	return messagesByDiff, nil
+}
+
+func detectReworkType(
+	deploymentCommit *devops.CicdDeploymentCommit,
+	prevCommitSha string,
+	deployedShas map[string]bool,
+	messages []string,
+) string {
+	for _, text := range []string{deploymentCommit.Name, deploymentCommit.DisplayTitle, deploymentCommit.RefName} {
+		if rollbackPattern.MatchString(text) {
+			return devops.REWORK_ROLLBACK
+		}
+	}
+	// redeploying the currently running commit is not a rollback, going back to an older one is
+	if prevCommitSha != "" && deploymentCommit.CommitSha != prevCommitSha && deployedShas[deploymentCommit.CommitSha] {
+		return devops.REWORK_ROLLBACK
+	}
+	for _, message := range messages {
+		if revertCommitPattern.MatchString(message) {
+			return devops.REWORK_REVERT
+		}
+	}
+	if hotfixPattern.MatchString(deploymentCommit.RefName) {
+		return devops.REWORK_HOTFIX
+	}
+	for _, message := range messages {
+		if hotfixPattern.MatchString(message) {
+			return devops.REWORK_HOTFIX
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+	"github.com/apache/incubator-devlake/plugins/feishu/tasks"
+)
+
+// MakeDataSourcePipelinePlanV200 collects the whole connection in one task since feishu chats are
+// collected connection-wide, the blueprint scopes are chat ids and each of them is emitted as a chat channel
+func MakeDataSourcePipelinePlanV200(
+	subtaskMetas []plugin.SubTaskMeta,
+	connectionId uint64,
+	bpScopes []*coreModels.BlueprintScope,
+) (coreModels.PipelinePlan, []plugin.Scope, errors.Error) {
+	connection := &models.FeishuConnection{}
+	err := connectionHelper.FirstById(connection, connectionId)
+	if err != nil {
+		return nil, nil, errors.Default.Wrap(err, "cannot find feishu connection")
+	}
+
+	task, err := helper.MakePipelinePlanTask(
+		"feishu",
+		subtaskMetas,
+		[]string{plugin.DOMAIN_TYPE_CROSS},
+		tasks.FeishuOptions{ConnectionId: connectionId},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan := coreModels.PipelinePlan{{task}}
+
+	db := basicRes.GetDal()
+	idgen := didgen.NewDomainIdGenerator(&models.FeishuChatItem{})
+	scopes := make([]plugin.Scope, 0, len(bpScopes))
+	for _, bpScope := range bpScopes {
+		// the chat may not have been collected yet, fall back to its id as the name
+		name := bpScope.ScopeId
+		chatItem := &models.FeishuChatItem{}
+		err = db.First(chatItem, dal.Where("connection_id = ? AND chat_id = ?", connectionId, bpScope.ScopeId))
+		if err == nil {
+			name = chatItem.Name
+		} else if !db.IsErrorNotFound(err) {
+			return nil, nil, err
+		}
+		scopes = append(scopes, chat.NewChatChannel(idgen.Generate(connectionId, bpScope.ScopeId), name))
+	}
+	return plan, scopes, nil
+}
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/feishu/impl"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+	"github.com/apache/incubator-devlake/plugins/feishu/tasks"
+)
+
+func TestChatDataFlow(t *testing.T) {
+	var plugin impl.Feishu
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "feishu", plugin)
+
+	taskData := &tasks.FeishuTaskData{
+		Options: &tasks.FeishuOptions{
+			ConnectionId: 1,
+		},
+	}
+
+	// verify chat conversion
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_feishu_chats.csv", &models.FeishuChatItem{})
+	dataflowTester.FlushTabler(&chat.ChatChannel{})
+	dataflowTester.Subtask(tasks.ConvertChatMeta, taskData)
+	dataflowTester.VerifyTable(
+		chat.ChatChannel{},
+		"./snapshot_tables/chat_channels.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"name",
+			"description",
+			"url",
+			"is_private",
+			"is_archived",
+			"creator_id",
+			"created_date",
+		),
+	)
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_feishu_message.csv", "_raw_feishu_message")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.FeishuMessage{})
+	dataflowTester.Subtask(tasks.ExtractMessageMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.FeishuMessage{},
+		"./snapshot_tables/_tool_feishu_messages.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"message_id",
+			"content",
+			"chat_id",
+			"msg_type",
+			"parent_id",
+			"root_id",
+			"sender_id",
+			"sender_id_type",
+			"sender_type",
+			"deleted",
+			"create_time",
+			"update_time",
+			"updated",
+		),
+	)
+
+	// verify message conversion
+	dataflowTester.FlushTabler(&chat.ChatMessage{})
+	dataflowTester.Subtask(tasks.ConvertMessageMeta, taskData)
+	dataflowTester.VerifyTable(
+		chat.ChatMessage{},
+		"./snapshot_tables/chat_messages.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"channel_id",
+			"thread_id",
+			"parent_id",
+			"author_id",
+			"type",
+			"content",
+			"created_date",
+			"updated_date",
+		),
+	)
+
+	// verify thread conversion
+	dataflowTester.FlushTabler(&chat.ChatThread{})
+	dataflowTester.Subtask(tasks.ConvertThreadMeta, taskData)
+	dataflowTester.VerifyTable(
+		chat.ChatThread{},
+		"./snapshot_tables/chat_threads.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"channel_id",
+			"root_message_id",
+			"author_id",
+			"reply_count",
+			"reply_users_count",
+			"created_date",
+			"latest_reply_date",
+		),
+	)
+}
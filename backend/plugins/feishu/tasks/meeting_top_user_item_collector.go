@@ -83,4 +83,5 @@ var CollectMeetingTopUserItemMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectMeetingTopUserItem,
 	EnabledByDefault: true,
 	Description:      "Collect top user meeting data from Feishu api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
 }
@@ -72,4 +72,5 @@ var ExtractMeetingTopUserItemMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractMeetingTopUserItem,
 	EnabledByDefault: true,
 	Description:      "Extract raw top user meeting data into tool layer table feishu_meeting_top_user_item",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
 }
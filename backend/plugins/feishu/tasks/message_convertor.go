@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertMessage
+
+func ConvertMessage(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*FeishuTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.FeishuMessage{}),
+		dal.Where("connection_id = ? AND deleted = ?", data.Options.ConnectionId, false),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	chatIdGen := didgen.NewDomainIdGenerator(&models.FeishuChatItem{})
+	messageIdGen := didgen.NewDomainIdGenerator(&models.FeishuMessage{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: FeishuApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.FeishuMessage{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			message := inputRow.(*models.FeishuMessage)
+			domainMessage := &chat.ChatMessage{
+				DomainEntity: domainlayer.DomainEntity{Id: messageIdGen.Generate(message.ConnectionId, message.MessageId)},
+				ChannelId:    chatIdGen.Generate(message.ConnectionId, message.ChatId),
+				AuthorId:     message.SenderId,
+				Type:         message.MsgType,
+				Content:      message.Content,
+				CreatedDate:  message.CreateTime,
+			}
+			if message.Updated {
+				domainMessage.UpdatedDate = &message.UpdateTime
+			}
+			// replies carry the id of the root message, which is also the id of the thread
+			if message.RootId != "" {
+				domainMessage.ThreadId = messageIdGen.Generate(message.ConnectionId, message.RootId)
+			}
+			if message.ParentId != "" {
+				domainMessage.ParentId = messageIdGen.Generate(message.ConnectionId, message.ParentId)
+			}
+			return []interface{}{domainMessage}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertMessageMeta = plugin.SubTaskMeta{
+	Name:             "convertMessage",
+	EntryPoint:       ConvertMessage,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_feishu_messages into domain layer table chat_messages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
@@ -84,4 +84,5 @@ var ExtractMessageMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractMessage,
 	EnabledByDefault: true,
 	Description:      "Extract raw messages data into tool layer table feishu_meeting_top_user_item",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
 }
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/feishu/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertThread
+
+// threadRootMessage is a root message along with the statistics of its replies
+type threadRootMessage struct {
+	models.FeishuMessage
+	ReplyCount      int
+	ReplyUsersCount int
+	LatestReplyDate *time.Time
+}
+
+func ConvertThread(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*FeishuTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("m.*, r.reply_count, r.reply_users_count, r.latest_reply_date"),
+		dal.From("_tool_feishu_messages m"),
+		dal.Join(`JOIN (
+			SELECT root_id, COUNT(*) AS reply_count, COUNT(DISTINCT sender_id) AS reply_users_count, MAX(create_time) AS latest_reply_date
+			FROM _tool_feishu_messages
+			WHERE connection_id = ? AND root_id != '' AND deleted = ?
+			GROUP BY root_id
+		) r ON r.root_id = m.message_id`, data.Options.ConnectionId, false),
+		dal.Where("m.connection_id = ?", data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	chatIdGen := didgen.NewDomainIdGenerator(&models.FeishuChatItem{})
+	messageIdGen := didgen.NewDomainIdGenerator(&models.FeishuMessage{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: FeishuApiParams{
+				ConnectionId: data.Options.ConnectionId,
+			},
+			Table: RAW_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(threadRootMessage{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			root := inputRow.(*threadRootMessage)
+			rootMessageId := messageIdGen.Generate(root.ConnectionId, root.MessageId)
+			thread := &chat.ChatThread{
+				DomainEntity:    domainlayer.DomainEntity{Id: rootMessageId},
+				ChannelId:       chatIdGen.Generate(root.ConnectionId, root.ChatId),
+				RootMessageId:   rootMessageId,
+				AuthorId:        root.SenderId,
+				ReplyCount:      root.ReplyCount,
+				ReplyUsersCount: root.ReplyUsersCount,
+				CreatedDate:     root.CreateTime,
+				LatestReplyDate: root.LatestReplyDate,
+			}
+			return []interface{}{thread}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+var ConvertThreadMeta = plugin.SubTaskMeta{
+	Name:             "convertThread",
+	EntryPoint:       ConvertThread,
+	EnabledByDefault: true,
+	Description:      "Convert root messages with replies in _tool_feishu_messages into domain layer table chat_threads",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
@@ -0,0 +1,168 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	dsmodels "github.com/apache/incubator-devlake/helpers/pluginhelper/api/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/tasks"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users
+// @Tags plugins/gerrit
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gerrit/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raScopeList.Get(input)
+}
+
+// SearchRemoteScopes use the Search API and only return project
+// @Summary use the Search API and only return project
+// @Description use the Search API and only return project
+// @Tags plugins/gerrit
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gerrit/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raScopeSearch.Get(input)
+}
+
+// Gerrit projects are not grouped, they are listed page by page on the top level
+func listGerritRemoteScopes(
+	connection *models.GerritConnection,
+	apiClient plugin.ApiClient,
+	groupId string,
+	page GerritRemotePagination) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GerritProject],
+	*GerritRemotePagination,
+	errors.Error,
+) {
+	if page.Limit == 0 {
+		page.Limit = 100
+	}
+
+	query := initialQuery(page)
+	children, more, err := listGerritProjects(apiClient, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !more {
+		return children, nil, nil
+	}
+	page.Start += page.Limit
+	return children, &page, nil
+}
+
+func searchGerritProjects(apiClient plugin.ApiClient, params *dsmodels.DsRemoteApiScopeSearchParams) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GerritProject],
+	errors.Error,
+) {
+	query := initialQuery(GerritRemotePagination{
+		Start: (params.Page - 1) * params.PageSize,
+		Limit: params.PageSize,
+	})
+	// m matches projects whose name contains the substring
+	query.Set("m", params.Search)
+	children, _, err := listGerritProjects(apiClient, query)
+	return children, err
+}
+
+// listGerritProjects returns the projects of one page and whether there are more pages, the api returns a map
+// keyed by project name with `_more_projects` set on the last entry when the page is not the last one
+func listGerritProjects(apiClient plugin.ApiClient, query url.Values) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GerritProject],
+	bool,
+	errors.Error,
+) {
+	res, err := apiClient.Get("a/projects/", query, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resBody := map[string]struct {
+		models.GerritApiProject
+		MoreProjects bool `json:"_more_projects"`
+	}{}
+	err = tasks.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the web and clone urls are relative to the root of the server
+	endpoint := strings.TrimSuffix(res.Request.URL.Scheme+"://"+res.Request.URL.Host+res.Request.URL.Path, "a/projects/")
+
+	names := make([]string, 0, len(resBody))
+	for name := range resBody {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	more := false
+	children := []dsmodels.DsRemoteApiScopeListEntry[models.GerritProject]{}
+	for _, name := range names {
+		p := resBody[name]
+		more = more || p.MoreProjects
+		p.Name = name
+		p.Endpoint = endpoint
+		children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.GerritProject]{
+			Type:     api.RAS_ENTRY_TYPE_SCOPE,
+			Id:       name,
+			ParentId: nil,
+			Name:     name,
+			FullName: name,
+			Data:     p.GerritApiProject.ConvertApiScope().(*models.GerritProject),
+		})
+	}
+	return children, more, nil
+}
+
+func initialQuery(page GerritRemotePagination) url.Values {
+	query := url.Values{}
+	// d includes the description of the projects
+	query.Set("d", "")
+	query.Set("S", fmt.Sprintf("%v", page.Start))
+	query.Set("n", fmt.Sprintf("%v", page.Limit))
+	return query
+}
+
+type GerritRemotePagination struct {
+	Start int `json:"start"`
+	Limit int `json:"limit"`
+}
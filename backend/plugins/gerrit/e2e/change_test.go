@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gerrit/impl"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/tasks"
+)
+
+func TestGerritChangeDataFlow(t *testing.T) {
+	var plugin impl.Gerrit
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gerrit", plugin)
+
+	taskData := &tasks.GerritTaskData{
+		Options: &tasks.GerritOptions{
+			ConnectionId: 1,
+			ProjectName:  "devlake",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gerrit_api_changes.csv", "_raw_gerrit_api_changes")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gerrit_api_change_comments.csv", "_raw_gerrit_api_change_comments")
+	dataflowTester.FlushTabler(&models.GerritProject{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_gerrit_projects.csv", &models.GerritProject{})
+
+	// verify change extraction, changes of other projects must be left alone
+	dataflowTester.FlushTabler(&models.GerritChange{})
+	dataflowTester.FlushTabler(&models.GerritPatchSet{})
+	dataflowTester.FlushTabler(&models.GerritChangeReviewer{})
+	dataflowTester.FlushTabler(&models.GerritChangeVote{})
+	dataflowTester.FlushTabler(&models.GerritChangeComment{})
+	dataflowTester.FlushTabler(&models.GerritAccount{})
+	dataflowTester.Subtask(tasks.ExtractChangesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GerritChange{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_changes.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GerritPatchSet{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_patch_sets.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GerritChangeReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_change_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GerritChangeVote{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_change_votes.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GerritChangeComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_change_comments_messages.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify inline comment extraction
+	dataflowTester.Subtask(tasks.ExtractChangeCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GerritChangeComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_change_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GerritAccount{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gerrit_accounts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.Repo{})
+	dataflowTester.Subtask(tasks.ConvertProjectsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.Repo{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/repos.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertChangesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestCommit{})
+	dataflowTester.Subtask(tasks.ConvertPatchSetsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestReviewer{})
+	dataflowTester.Subtask(tasks.ConvertChangeReviewersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// Code-Review votes and comments both end up in pull_request_comments
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertChangeVotesMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertChangeCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertAccountsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.Account{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/accounts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
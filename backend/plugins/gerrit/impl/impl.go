@@ -0,0 +1,266 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/gerrit/tasks"
+)
+
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginTask
+	plugin.PluginApi
+	plugin.PluginModel
+	plugin.PluginMigration
+	plugin.CloseablePluginTask
+	plugin.DataSourcePluginBlueprintV200
+	plugin.PluginSource
+} = (*Gerrit)(nil)
+
+type Gerrit struct{}
+
+func (p Gerrit) Connection() dal.Tabler {
+	return &models.GerritConnection{}
+}
+
+func (p Gerrit) Scope() plugin.ToolLayerScope {
+	return &models.GerritProject{}
+}
+
+func (p Gerrit) ScopeConfig() dal.Tabler {
+	return &models.GerritScopeConfig{}
+}
+
+func (p Gerrit) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes, p)
+
+	return nil
+}
+
+func (p Gerrit) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.GerritConnection{},
+		&models.GerritProject{},
+		&models.GerritScopeConfig{},
+		&models.GerritChange{},
+		&models.GerritPatchSet{},
+		&models.GerritChangeReviewer{},
+		&models.GerritChangeVote{},
+		&models.GerritChangeComment{},
+		&models.GerritAccount{},
+	}
+}
+
+func (p Gerrit) Description() string {
+	return "To collect and enrich data from Gerrit"
+}
+
+func (p Gerrit) Name() string {
+	return "gerrit"
+}
+
+func (p Gerrit) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectChangesMeta,
+		tasks.ExtractChangesMeta,
+
+		tasks.CollectChangeCommentsMeta,
+		tasks.ExtractChangeCommentsMeta,
+
+		tasks.ConvertProjectsMeta,
+		tasks.ConvertChangesMeta,
+		tasks.ConvertPatchSetsMeta,
+		tasks.ConvertChangeReviewersMeta,
+		// votes must be converted before comments, they share pull_request_comments and the raw table of changes
+		tasks.ConvertChangeVotesMeta,
+		tasks.ConvertChangeCommentsMeta,
+
+		tasks.ConvertAccountsMeta,
+	}
+}
+
+func (p Gerrit) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+		p.Name(),
+	)
+	connection := &models.GerritConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get gerrit connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get gerrit API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient, connection.GetEndpoint())
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &tasks.GerritTaskData{
+		Options:   op,
+		ApiClient: apiClient,
+	}
+
+	return taskData, nil
+}
+
+func (p Gerrit) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/gerrit"
+}
+
+func (p Gerrit) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Gerrit) MakeDataSourcePipelinePlanV200(
+	connectionId uint64,
+	scopes []*coreModels.BlueprintScope) (pp coreModels.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
+}
+
+func (p Gerrit) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"connections/:connectionId/test": {
+			"POST": api.TestExistingConnection,
+		},
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			// Behind 'GetScopeDispatcher', there are two paths so far:
+			// GetScopeLatestSyncState "connections/:connectionId/scopes/:scopeId/latest-sync-state"
+			// GetScope "connections/:connectionId/scopes/:scopeId"
+			// Because Gerrit project names may contain slashes, we handle it manually.
+			"GET":    api.GetScopeDispatcher,
+			"PATCH":  api.UpdateScope,
+			"DELETE": api.DeleteScope,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopeList,
+			"PUT": api.PutScope,
+		},
+		"connections/:connectionId/scope-configs": {
+			"POST": api.CreateScopeConfig,
+			"GET":  api.GetScopeConfigList,
+		},
+		"connections/:connectionId/scope-configs/*scopeConfigId": {
+			"PATCH":  api.UpdateScopeConfig,
+			"GET":    api.GetScopeConfig,
+			"DELETE": api.DeleteScopeConfig,
+		},
+		"scope-config/:scopeConfigId/projects": {
+			"GET": api.GetProjectsByScopeConfig,
+		},
+	}
+}
+
+func (p Gerrit) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.GerritTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.GerritOptions,
+	apiClient *helper.ApiClient,
+	endpoint string) errors.Error {
+	var project models.GerritProject
+	err := tasks.ValidateTaskOptions(op)
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+	err = db.First(&project, dal.Where(
+		"connection_id = ? AND name = ?",
+		op.ConnectionId, op.ProjectName))
+	if err == nil {
+		if op.ScopeConfigId == 0 {
+			op.ScopeConfigId = project.ScopeConfigId
+		}
+	} else {
+		if db.IsErrorNotFound(err) {
+			var apiProject *models.GerritApiProject
+			apiProject, err = tasks.GetApiProject(op, apiClient, endpoint)
+			if err != nil {
+				return err
+			}
+			taskCtx.GetLogger().Debug(fmt.Sprintf("Current project: %s", apiProject.Name))
+			scope := apiProject.ConvertApiScope().(*models.GerritProject)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find project %s", op.ProjectName))
+		}
+	}
+	// Set scope config if it's nil, this has lower priority
+	if op.GerritScopeConfig == nil && op.ScopeConfigId != 0 {
+		var scopeConfig models.GerritScopeConfig
+		err = db.First(&scopeConfig, dal.Where("id = ?", op.ScopeConfigId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get scopeConfig")
+		}
+		op.GerritScopeConfig = &scopeConfig
+	}
+	if op.GerritScopeConfig == nil {
+		op.GerritScopeConfig = new(models.GerritScopeConfig)
+	}
+	return nil
+}
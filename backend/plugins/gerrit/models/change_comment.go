@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	CommentTypeMessage = "MESSAGE"
+	CommentTypeInline  = "INLINE"
+)
+
+// GerritChangeComment is either a review message posted on a change or an inline comment on a file of a patch set
+type GerritChangeComment struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ChangeNumber   int    `gorm:"primaryKey;autoIncrement:false"`
+	CommentId      string `gorm:"primaryKey;type:varchar(100)"`
+	Type           string `gorm:"type:varchar(20)"`
+	PatchSetNumber int
+	CommitSha      string `gorm:"type:varchar(40)"`
+	Path           string
+	Line           int
+	InReplyTo      string `gorm:"type:varchar(100)"`
+	Unresolved     bool
+	AuthorId       int
+	Message        string
+	Date           time.Time
+	common.NoPKModel
+}
+
+func (GerritChangeComment) TableName() string {
+	return "_tool_gerrit_change_comments"
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const CodeReviewLabel = "Code-Review"
+
+// GerritChangeReviewer is an account added to a change, the state is one of REVIEWER, CC or REMOVED
+type GerritChangeReviewer struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChangeNumber int    `gorm:"primaryKey;autoIncrement:false"`
+	AccountId    int    `gorm:"primaryKey;autoIncrement:false"`
+	State        string `gorm:"type:varchar(20)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Username     string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (GerritChangeReviewer) TableName() string {
+	return "_tool_gerrit_change_reviewers"
+}
+
+// GerritChangeVote is the latest vote of an account on a label of a change
+type GerritChangeVote struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChangeNumber int    `gorm:"primaryKey;autoIncrement:false"`
+	AccountId    int    `gorm:"primaryKey;autoIncrement:false"`
+	Label        string `gorm:"primaryKey;type:varchar(100)"`
+	Value        int
+	Date         *time.Time
+	common.NoPKModel
+}
+
+func (GerritChangeVote) TableName() string {
+	return "_tool_gerrit_change_votes"
+}
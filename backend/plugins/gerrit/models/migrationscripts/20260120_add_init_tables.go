@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models/migrationscripts/archived"
+)
+
+type addInitTables20260120 struct{}
+
+func (script *addInitTables20260120) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.GerritConnection{},
+		&archived.GerritProject{},
+		&archived.GerritScopeConfig{},
+		&archived.GerritAccount{},
+		&archived.GerritChange{},
+		&archived.GerritPatchSet{},
+		&archived.GerritChangeReviewer{},
+		&archived.GerritChangeVote{},
+		&archived.GerritChangeComment{},
+	)
+}
+
+func (*addInitTables20260120) Version() uint64 {
+	return 20260120000001
+}
+
+func (*addInitTables20260120) Name() string {
+	return "Gerrit init schema 20260120"
+}
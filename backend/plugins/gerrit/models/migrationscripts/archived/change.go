@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type GerritChange struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ChangeNumber    int    `gorm:"primaryKey;autoIncrement:false"`
+	ChangeId        string `gorm:"type:varchar(100)"`
+	ProjectName     string `gorm:"index;type:varchar(255)"`
+	Branch          string `gorm:"type:varchar(255)"`
+	Topic           string `gorm:"type:varchar(255)"`
+	Subject         string
+	Status          string `gorm:"type:varchar(20)"`
+	WorkInProgress  bool
+	OwnerId         int
+	OwnerName       string `gorm:"type:varchar(255)"`
+	SubmitterId     int
+	CurrentRevision string `gorm:"type:varchar(40)"`
+	BaseCommitSha   string `gorm:"type:varchar(40)"`
+	Insertions      int
+	Deletions       int
+	Url             string `gorm:"type:varchar(255)"`
+	GerritCreated   time.Time
+	GerritUpdated   time.Time `gorm:"index"`
+	Submitted       *time.Time
+	archived.NoPKModel
+}
+
+func (GerritChange) TableName() string {
+	return "_tool_gerrit_changes"
+}
+
+type GerritPatchSet struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ChangeNumber   int    `gorm:"primaryKey;autoIncrement:false"`
+	CommitSha      string `gorm:"primaryKey;type:varchar(40)"`
+	PatchSetNumber int
+	Kind           string `gorm:"type:varchar(50)"`
+	Ref            string `gorm:"type:varchar(255)"`
+	UploaderId     int
+	AuthorName     string `gorm:"type:varchar(255)"`
+	AuthorEmail    string `gorm:"type:varchar(255)"`
+	AuthoredDate   *time.Time
+	CreatedDate    time.Time
+	archived.NoPKModel
+}
+
+func (GerritPatchSet) TableName() string {
+	return "_tool_gerrit_patch_sets"
+}
+
+type GerritChangeReviewer struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChangeNumber int    `gorm:"primaryKey;autoIncrement:false"`
+	AccountId    int    `gorm:"primaryKey;autoIncrement:false"`
+	State        string `gorm:"type:varchar(20)"`
+	Name         string `gorm:"type:varchar(255)"`
+	Username     string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (GerritChangeReviewer) TableName() string {
+	return "_tool_gerrit_change_reviewers"
+}
+
+type GerritChangeVote struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	ChangeNumber int    `gorm:"primaryKey;autoIncrement:false"`
+	AccountId    int    `gorm:"primaryKey;autoIncrement:false"`
+	Label        string `gorm:"primaryKey;type:varchar(100)"`
+	Value        int
+	Date         *time.Time
+	archived.NoPKModel
+}
+
+func (GerritChangeVote) TableName() string {
+	return "_tool_gerrit_change_votes"
+}
+
+type GerritChangeComment struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	ChangeNumber   int    `gorm:"primaryKey;autoIncrement:false"`
+	CommentId      string `gorm:"primaryKey;type:varchar(100)"`
+	Type           string `gorm:"type:varchar(20)"`
+	PatchSetNumber int
+	CommitSha      string `gorm:"type:varchar(40)"`
+	Path           string
+	Line           int
+	InReplyTo      string `gorm:"type:varchar(100)"`
+	Unresolved     bool
+	AuthorId       int
+	Message        string
+	Date           time.Time
+	archived.NoPKModel
+}
+
+func (GerritChangeComment) TableName() string {
+	return "_tool_gerrit_change_comments"
+}
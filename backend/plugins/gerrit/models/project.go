@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*GerritProject)(nil)
+var _ plugin.ApiScope = (*GerritApiProject)(nil)
+
+type GerritProject struct {
+	common.Scope `mapstructure:",squash"`
+	Name         string `json:"name" gorm:"primaryKey;type:varchar(255)" validate:"required" mapstructure:"name"`
+	Parent       string `json:"parent" gorm:"type:varchar(255)" mapstructure:"parent,omitempty"`
+	Description  string `json:"description" mapstructure:"description,omitempty"`
+	State        string `json:"state" gorm:"type:varchar(50)" mapstructure:"state,omitempty"`
+	HTMLUrl      string `json:"HTMLUrl" gorm:"type:varchar(255)" mapstructure:"HTMLUrl,omitempty"`
+	CloneUrl     string `json:"cloneUrl" gorm:"type:varchar(255)" mapstructure:"cloneUrl,omitempty"`
+}
+
+func (GerritProject) TableName() string {
+	return "_tool_gerrit_projects"
+}
+
+func (p GerritProject) ScopeId() string {
+	return p.Name
+}
+
+func (p GerritProject) ScopeName() string {
+	return p.Name
+}
+
+func (p GerritProject) ScopeFullName() string {
+	return p.Name
+}
+
+func (p GerritProject) ScopeParams() interface{} {
+	return &GerritApiParams{
+		ConnectionId: p.ConnectionId,
+		ProjectName:  p.Name,
+	}
+}
+
+// GerritApiProject is the ProjectInfo entity returned by the Gerrit REST API, the name is not part of
+// the entity when projects are listed as a map
+type GerritApiProject struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Parent      string `json:"parent"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	// Endpoint is the root url of the Gerrit server, it is used to generate the web and clone urls
+	Endpoint string `json:"-"`
+}
+
+func (p GerritApiProject) ConvertApiScope() plugin.ToolLayerScope {
+	scope := &GerritProject{}
+	scope.Name = p.Name
+	scope.Parent = p.Parent
+	scope.Description = p.Description
+	scope.State = p.State
+	if p.Endpoint != "" {
+		scope.HTMLUrl = p.Endpoint + "admin/repos/" + p.Name
+		scope.CloneUrl = p.Endpoint + "a/" + p.Name
+	}
+	return scope
+}
+
+type GerritApiParams struct {
+	ConnectionId uint64
+	ProjectName  string
+}
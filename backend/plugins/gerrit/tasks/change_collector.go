@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_CHANGE_TABLE = "gerrit_api_changes"
+
+var CollectChangesMeta = plugin.SubTaskMeta{
+	Name:             "collectChanges",
+	EntryPoint:       CollectChanges,
+	EnabledByDefault: true,
+	Description:      "Collect changes with their patch sets, votes and messages from Gerrit api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	apiCollector, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	changeQuery := fmt.Sprintf(`project:"%s"`, data.Options.ProjectName)
+	if apiCollector.GetSince() != nil {
+		changeQuery = fmt.Sprintf(`%s after:"%s"`, changeQuery, apiCollector.GetSince().UTC().Format(gerritTimeFormat))
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: "a/changes/",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{
+				"q": []string{changeQuery},
+				// the detailed labels list every vote, the messages hold the review comments of each patch set
+				"o": []string{"DETAILED_ACCOUNTS", "DETAILED_LABELS", "ALL_REVISIONS", "ALL_COMMITS", "MESSAGES"},
+			}
+			query.Set("n", fmt.Sprintf("%v", reqData.Pager.Size))
+			query.Set("S", fmt.Sprintf("%v", reqData.Pager.Skip))
+			return query, nil
+		},
+		ResponseParser: GetRawMessagesFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertChangeCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertChangeComments",
+	EntryPoint:       ConvertChangeComments,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_change_comments into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertChangeComments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_COMMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("cm.*"),
+		dal.From("_tool_gerrit_change_comments cm"),
+		dal.Join("LEFT JOIN _tool_gerrit_changes c ON (c.connection_id = cm.connection_id AND c.change_number = cm.change_number)"),
+		dal.Where("cm.connection_id = ? AND c.project_name = ?", data.Options.ConnectionId, data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	commentIdGen := didgen.NewDomainIdGenerator(&models.GerritChangeComment{})
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritChangeComment{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*models.GerritChangeComment)
+			domainComment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: commentIdGen.Generate(data.Options.ConnectionId, comment.ChangeNumber, comment.CommentId),
+				},
+				PullRequestId: changeIdGen.Generate(data.Options.ConnectionId, comment.ChangeNumber),
+				Body:          comment.Message,
+				AccountId:     accountIdGen.Generate(data.Options.ConnectionId, comment.AuthorId),
+				CreatedDate:   comment.Date,
+				CommitSha:     comment.CommitSha,
+				Type:          code.NORMAL_COMMENT,
+			}
+			if comment.Type == models.CommentTypeInline {
+				domainComment.Type = code.DIFF_COMMENT
+				domainComment.Status = "RESOLVED"
+				if comment.Unresolved {
+					domainComment.Status = "UNRESOLVED"
+				}
+			}
+			return []interface{}{
+				domainComment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ExtractChangeCommentsMeta = plugin.SubTaskMeta{
+	Name:             "extractChangeComments",
+	EntryPoint:       ExtractChangeComments,
+	EnabledByDefault: true,
+	Description:      "Extract raw change comments data into tool layer table gerrit_change_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type ApiComment struct {
+	Id         string      `json:"id"`
+	PatchSet   int         `json:"patch_set"`
+	CommitId   string      `json:"commit_id"`
+	Line       int         `json:"line"`
+	InReplyTo  string      `json:"in_reply_to"`
+	Message    string      `json:"message"`
+	Updated    GerritTime  `json:"updated"`
+	Author     *ApiAccount `json:"author"`
+	Unresolved bool        `json:"unresolved"`
+}
+
+func ExtractChangeComments(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_CHANGE_COMMENT_TABLE)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[map[string][]ApiComment]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(commentsByPath *map[string][]ApiComment, row *api.RawData) ([]interface{}, errors.Error) {
+			input := &GerritInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			// comments might have been deleted since the last collection
+			err = db.Delete(
+				&models.GerritChangeComment{},
+				dal.Where("connection_id = ? AND change_number = ? AND type = ?", connectionId, input.ChangeNumber, models.CommentTypeInline),
+			)
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0)
+			for path, comments := range *commentsByPath {
+				for _, comment := range comments {
+					gerritComment := &models.GerritChangeComment{
+						ConnectionId:   connectionId,
+						ChangeNumber:   input.ChangeNumber,
+						CommentId:      comment.Id,
+						Type:           models.CommentTypeInline,
+						PatchSetNumber: comment.PatchSet,
+						CommitSha:      comment.CommitId,
+						Path:           path,
+						Line:           comment.Line,
+						InReplyTo:      comment.InReplyTo,
+						Unresolved:     comment.Unresolved,
+						Message:        comment.Message,
+						Date:           comment.Updated.Time,
+					}
+					if comment.Author != nil {
+						gerritComment.AuthorId = comment.Author.AccountId
+						results = append(results, comment.Author.toToolLayer(connectionId))
+					}
+					results = append(results, gerritComment)
+				}
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
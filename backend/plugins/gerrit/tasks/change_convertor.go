@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertChangesMeta = plugin.SubTaskMeta{
+	Name:             "convertChanges",
+	EntryPoint:       ConvertChanges,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_changes into domain layer table pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GerritChange{}),
+		dal.Where("connection_id = ? AND project_name = ?", data.Options.ConnectionId, data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GerritProject{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritChange{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			change := inputRow.(*models.GerritChange)
+			repoId := projectIdGen.Generate(data.Options.ConnectionId, change.ProjectName)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: changeIdGen.Generate(data.Options.ConnectionId, change.ChangeNumber),
+				},
+				BaseRepoId:     repoId,
+				HeadRepoId:     repoId,
+				OriginalStatus: change.Status,
+				Title:          change.Subject,
+				Url:            change.Url,
+				AuthorName:     change.OwnerName,
+				AuthorId:       accountIdGen.Generate(data.Options.ConnectionId, change.OwnerId),
+				PullRequestKey: change.ChangeNumber,
+				CreatedDate:    change.GerritCreated,
+				BaseRef:        change.Branch,
+				BaseCommitSha:  change.BaseCommitSha,
+				HeadCommitSha:  change.CurrentRevision,
+				Additions:      change.Insertions,
+				Deletions:      change.Deletions,
+				IsDraft:        change.WorkInProgress,
+			}
+			switch change.Status {
+			case models.ChangeStatusNew:
+				domainPr.Status = code.OPEN
+			case models.ChangeStatusMerged:
+				domainPr.Status = code.MERGED
+				domainPr.MergedDate = change.Submitted
+				domainPr.ClosedDate = change.Submitted
+				domainPr.MergeCommitSha = change.CurrentRevision
+				if change.SubmitterId != 0 {
+					domainPr.MergedById = accountIdGen.Generate(data.Options.ConnectionId, change.SubmitterId)
+				}
+			case models.ChangeStatusAbandoned:
+				domainPr.Status = code.CLOSED
+				// Gerrit does not tell when a change was abandoned, the last update is the closest approximation
+				closedDate := change.GerritUpdated
+				domainPr.ClosedDate = &closedDate
+			default:
+				domainPr.Status = change.Status
+			}
+			return []interface{}{
+				domainPr,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,246 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ExtractChangesMeta = plugin.SubTaskMeta{
+	Name:             "extractChanges",
+	EntryPoint:       ExtractChanges,
+	EnabledByDefault: true,
+	Description:      "Extract raw changes data into tool layer tables gerrit_changes, gerrit_patch_sets, gerrit_change_reviewers, gerrit_change_votes and gerrit_change_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// autogeneratedMessageTag prefixes the tags of the messages posted by Gerrit itself, e.g. when a patch set is uploaded
+const autogeneratedMessageTag = "autogenerated:gerrit:"
+
+type ApiChange struct {
+	Id              string                 `json:"id"`
+	Project         string                 `json:"project"`
+	Branch          string                 `json:"branch"`
+	Topic           string                 `json:"topic"`
+	ChangeId        string                 `json:"change_id"`
+	Subject         string                 `json:"subject"`
+	Status          string                 `json:"status"`
+	Created         GerritTime             `json:"created"`
+	Updated         GerritTime             `json:"updated"`
+	Submitted       *GerritTime            `json:"submitted"`
+	Submitter       *ApiAccount            `json:"submitter"`
+	Insertions      int                    `json:"insertions"`
+	Deletions       int                    `json:"deletions"`
+	Number          int                    `json:"_number"`
+	Owner           ApiAccount             `json:"owner"`
+	WorkInProgress  bool                   `json:"work_in_progress"`
+	CurrentRevision string                 `json:"current_revision"`
+	Revisions       map[string]ApiRevision `json:"revisions"`
+	Labels          map[string]struct {
+		All []struct {
+			ApiAccount
+			Value int         `json:"value"`
+			Date  *GerritTime `json:"date"`
+		} `json:"all"`
+	} `json:"labels"`
+	Reviewers map[string][]ApiAccount `json:"reviewers"`
+	Messages  []struct {
+		Id             string      `json:"id"`
+		Tag            string      `json:"tag"`
+		Author         *ApiAccount `json:"author"`
+		Date           GerritTime  `json:"date"`
+		Message        string      `json:"message"`
+		RevisionNumber int         `json:"_revision_number"`
+	} `json:"messages"`
+}
+
+type ApiRevision struct {
+	Kind     string     `json:"kind"`
+	Number   int        `json:"_number"`
+	Created  GerritTime `json:"created"`
+	Uploader ApiAccount `json:"uploader"`
+	Ref      string     `json:"ref"`
+	Commit   *struct {
+		Parents []struct {
+			Commit string `json:"commit"`
+		} `json:"parents"`
+		Author struct {
+			Name  string     `json:"name"`
+			Email string     `json:"email"`
+			Date  GerritTime `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+func ExtractChanges(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiChange]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		BeforeExtract: func(change *ApiChange, stateManager *api.SubtaskStateManager) errors.Error {
+			if !stateManager.IsIncremental() {
+				return nil
+			}
+			// patch sets, reviewers and votes might have been removed since the last collection
+			for _, child := range []interface{}{&models.GerritPatchSet{}, &models.GerritChangeReviewer{}, &models.GerritChangeVote{}} {
+				err := db.Delete(child, dal.Where("connection_id = ? AND change_number = ?", connectionId, change.Number))
+				if err != nil {
+					return err
+				}
+			}
+			return db.Delete(
+				&models.GerritChangeComment{},
+				dal.Where("connection_id = ? AND change_number = ? AND type = ?", connectionId, change.Number, models.CommentTypeMessage),
+			)
+		},
+		Extract: func(change *ApiChange, row *api.RawData) ([]interface{}, errors.Error) {
+			if change.Number == 0 {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, 2+len(change.Revisions)+len(change.Messages))
+			gerritChange := &models.GerritChange{
+				ConnectionId:    connectionId,
+				ChangeNumber:    change.Number,
+				ChangeId:        change.ChangeId,
+				ProjectName:     change.Project,
+				Branch:          change.Branch,
+				Topic:           change.Topic,
+				Subject:         change.Subject,
+				Status:          change.Status,
+				WorkInProgress:  change.WorkInProgress,
+				OwnerId:         change.Owner.AccountId,
+				OwnerName:       change.Owner.Name,
+				CurrentRevision: change.CurrentRevision,
+				Insertions:      change.Insertions,
+				Deletions:       change.Deletions,
+				Url:             buildChangeUrl(row.Url, change.Project, change.Number),
+				GerritCreated:   change.Created.Time,
+				GerritUpdated:   change.Updated.Time,
+				Submitted:       change.Submitted.ToNullableTime(),
+			}
+			results = append(results, change.Owner.toToolLayer(connectionId))
+			if change.Submitter != nil {
+				gerritChange.SubmitterId = change.Submitter.AccountId
+				results = append(results, change.Submitter.toToolLayer(connectionId))
+			}
+
+			// patch sets
+			shaByPatchSet := make(map[int]string, len(change.Revisions))
+			for sha, revision := range change.Revisions {
+				shaByPatchSet[revision.Number] = sha
+				patchSet := &models.GerritPatchSet{
+					ConnectionId:   connectionId,
+					ChangeNumber:   change.Number,
+					CommitSha:      sha,
+					PatchSetNumber: revision.Number,
+					Kind:           revision.Kind,
+					Ref:            revision.Ref,
+					UploaderId:     revision.Uploader.AccountId,
+					CreatedDate:    revision.Created.Time,
+				}
+				if revision.Commit != nil {
+					patchSet.AuthorName = revision.Commit.Author.Name
+					patchSet.AuthorEmail = revision.Commit.Author.Email
+					patchSet.AuthoredDate = revision.Commit.Author.Date.ToNullableTime()
+					if sha == change.CurrentRevision && len(revision.Commit.Parents) > 0 {
+						gerritChange.BaseCommitSha = revision.Commit.Parents[0].Commit
+					}
+				}
+				results = append(results, patchSet, revision.Uploader.toToolLayer(connectionId))
+			}
+			results = append(results, gerritChange)
+
+			// reviewers, the map is keyed by the reviewer state
+			states := make([]string, 0, len(change.Reviewers))
+			for state := range change.Reviewers {
+				states = append(states, state)
+			}
+			// the latest record of an account wins, so an account listed as both CC and REVIEWER is kept as a REVIEWER
+			sort.Strings(states)
+			for _, state := range states {
+				for _, reviewer := range change.Reviewers[state] {
+					if reviewer.AccountId == 0 {
+						continue
+					}
+					results = append(results, &models.GerritChangeReviewer{
+						ConnectionId: connectionId,
+						ChangeNumber: change.Number,
+						AccountId:    reviewer.AccountId,
+						State:        state,
+						Name:         reviewer.Name,
+						Username:     reviewer.Username,
+					}, reviewer.toToolLayer(connectionId))
+				}
+			}
+
+			// votes, accounts which were asked to vote but did not are listed with a zero value and no date
+			for label, labelInfo := range change.Labels {
+				for _, vote := range labelInfo.All {
+					if vote.Value == 0 || vote.AccountId == 0 {
+						continue
+					}
+					results = append(results, &models.GerritChangeVote{
+						ConnectionId: connectionId,
+						ChangeNumber: change.Number,
+						AccountId:    vote.AccountId,
+						Label:        label,
+						Value:        vote.Value,
+						Date:         vote.Date.ToNullableTime(),
+					})
+				}
+			}
+
+			// review messages
+			for _, message := range change.Messages {
+				if strings.HasPrefix(message.Tag, autogeneratedMessageTag) {
+					continue
+				}
+				comment := &models.GerritChangeComment{
+					ConnectionId:   connectionId,
+					ChangeNumber:   change.Number,
+					CommentId:      message.Id,
+					Type:           models.CommentTypeMessage,
+					PatchSetNumber: message.RevisionNumber,
+					CommitSha:      shaByPatchSet[message.RevisionNumber],
+					Message:        message.Message,
+					Date:           message.Date.Time,
+				}
+				if message.Author != nil {
+					comment.AuthorId = message.Author.AccountId
+					results = append(results, message.Author.toToolLayer(connectionId))
+				}
+				results = append(results, comment)
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertChangeReviewersMeta = plugin.SubTaskMeta{
+	Name:             "convertChangeReviewers",
+	EntryPoint:       ConvertChangeReviewers,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_change_reviewers into domain layer table pull_request_reviewers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertChangeReviewers(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	// accounts only cc-ed on a change or removed from it are not reviewers
+	cursor, err := db.Cursor(
+		dal.Select("r.*"),
+		dal.From("_tool_gerrit_change_reviewers r"),
+		dal.Join("LEFT JOIN _tool_gerrit_changes c ON (c.connection_id = r.connection_id AND c.change_number = r.change_number)"),
+		dal.Where("r.connection_id = ? AND c.project_name = ? AND r.state = ?", data.Options.ConnectionId, data.Options.ProjectName, "REVIEWER"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritChangeReviewer{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			reviewer := inputRow.(*models.GerritChangeReviewer)
+			domainReviewer := &code.PullRequestReviewer{
+				PullRequestId: changeIdGen.Generate(data.Options.ConnectionId, reviewer.ChangeNumber),
+				ReviewerId:    accountIdGen.Generate(data.Options.ConnectionId, reviewer.AccountId),
+				Name:          reviewer.Name,
+				UserName:      reviewer.Username,
+			}
+			return []interface{}{
+				domainReviewer,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+var ConvertChangeVotesMeta = plugin.SubTaskMeta{
+	Name:             "convertChangeVotes",
+	EntryPoint:       ConvertChangeVotes,
+	EnabledByDefault: true,
+	Description:      "Convert Code-Review votes in tool layer table gerrit_change_votes into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertChangeVotes(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CHANGE_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("v.*"),
+		dal.From("_tool_gerrit_change_votes v"),
+		dal.Join("LEFT JOIN _tool_gerrit_changes c ON (c.connection_id = v.connection_id AND c.change_number = v.change_number)"),
+		dal.Where("v.connection_id = ? AND c.project_name = ? AND v.label = ?", data.Options.ConnectionId, data.Options.ProjectName, models.CodeReviewLabel),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	voteIdGen := didgen.NewDomainIdGenerator(&models.GerritChangeVote{})
+	changeIdGen := didgen.NewDomainIdGenerator(&models.GerritChange{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GerritAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritChangeVote{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			vote := inputRow.(*models.GerritChangeVote)
+			domainComment := &code.PullRequestComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: voteIdGen.Generate(data.Options.ConnectionId, vote.ChangeNumber, vote.AccountId, vote.Label),
+				},
+				PullRequestId: changeIdGen.Generate(data.Options.ConnectionId, vote.ChangeNumber),
+				Body:          fmt.Sprintf("%s%+d", vote.Label, vote.Value),
+				AccountId:     accountIdGen.Generate(data.Options.ConnectionId, vote.AccountId),
+				Type:          code.REVIEW,
+				Status:        convertVoteStatus(vote.Value),
+			}
+			if vote.Date != nil {
+				domainComment.CreatedDate = *vote.Date
+			}
+			return []interface{}{
+				domainComment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func convertVoteStatus(value int) string {
+	switch {
+	case value >= 2:
+		return "APPROVED"
+	case value == 1:
+		return "LOOKS_GOOD"
+	case value == -1:
+		return "CHANGES_REQUESTED"
+	case value <= -2:
+		return "REJECTED"
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+const RAW_PROJECT_TABLE = "gerrit_api_projects"
+
+var ConvertProjectsMeta = plugin.SubTaskMeta{
+	Name:             "convertProjects",
+	EntryPoint:       ConvertProjects,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gerrit_projects into domain layer table repos",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// GetApiProject fetches the project from the Gerrit REST API, the endpoint is the root url of the server
+func GetApiProject(
+	op *GerritOptions,
+	apiClient plugin.ApiClient,
+	endpoint string,
+) (*models.GerritApiProject, errors.Error) {
+	res, err := apiClient.Get("a/projects/"+url.PathEscape(op.ProjectName), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, errors.Default.New(fmt.Sprintf(
+			"unexpected status code when requesting project detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	apiProject := new(models.GerritApiProject)
+	err = UnmarshalResponse(res, apiProject)
+	if err != nil {
+		return nil, err
+	}
+	apiProject.Endpoint = endpoint
+	return apiProject, nil
+}
+
+func ConvertProjects(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROJECT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GerritProject{}),
+		dal.Where("connection_id = ? AND name = ?", data.Options.ConnectionId, data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	projectIdGen := didgen.NewDomainIdGenerator(&models.GerritProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GerritProject{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			project := inputRow.(*models.GerritProject)
+			domainRepo := &code.Repo{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: projectIdGen.Generate(data.Options.ConnectionId, project.Name),
+				},
+				Name:        project.Name,
+				Url:         project.HTMLUrl,
+				Description: project.Description,
+			}
+			return []interface{}{
+				domainRepo,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
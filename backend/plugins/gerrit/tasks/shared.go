@@ -0,0 +1,187 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gerrit/models"
+)
+
+// magicPrefix is prepended to every JSON response of the Gerrit REST API to prevent XSSI attacks
+var magicPrefix = []byte(")]}'")
+
+// gerritTimeFormat is the timestamp format of the Gerrit REST API, timestamps are always in UTC and carry
+// nanoseconds which are accepted by time.Parse even though they are not part of the layout
+const gerritTimeFormat = "2006-01-02 15:04:05"
+
+type GerritInput struct {
+	ChangeNumber int
+}
+
+// ApiAccount is the AccountInfo entity of the Gerrit REST API
+type ApiAccount struct {
+	AccountId int    `json:"_account_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Username  string `json:"username"`
+}
+
+func (a *ApiAccount) toToolLayer(connectionId uint64) *models.GerritAccount {
+	return &models.GerritAccount{
+		ConnectionId: connectionId,
+		AccountId:    a.AccountId,
+		Name:         a.Name,
+		Email:        a.Email,
+		Username:     a.Username,
+	}
+}
+
+// GerritTime parses the timestamps of the Gerrit REST API
+type GerritTime struct {
+	time.Time
+}
+
+func (t *GerritTime) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	parsed, err := time.ParseInLocation(gerritTimeFormat, s, time.UTC)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t *GerritTime) ToNullableTime() *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return &t.Time
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*api.RawDataSubTaskArgs, *GerritTaskData) {
+	data := taskCtx.GetData().(*GerritTaskData)
+	rawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: models.GerritApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ProjectName:  data.Options.ProjectName,
+		},
+		Table: table,
+	}
+	return rawDataSubTaskArgs, data
+}
+
+func CreateSubtaskCommonArgs(taskCtx plugin.SubTaskContext, table string) (*api.SubtaskCommonArgs, *GerritTaskData) {
+	data := taskCtx.GetData().(*GerritTaskData)
+	args := &api.SubtaskCommonArgs{
+		SubTaskContext: taskCtx,
+		Table:          table,
+		Params: models.GerritApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			ProjectName:  data.Options.ProjectName,
+		},
+	}
+	return args, data
+}
+
+// UnmarshalResponse strips the magic prefix from the response body and decodes the JSON into v
+func UnmarshalResponse(res *http.Response, v interface{}) errors.Error {
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error reading response from %s", res.Request.URL.String()))
+	}
+	body = bytes.TrimPrefix(bytes.TrimSpace(body), magicPrefix)
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error decoding response from %s: raw response: %s", res.Request.URL.String(), string(body)))
+	}
+	return nil
+}
+
+// GetRawMessagesFromResponse parses responses of the Gerrit REST API which are JSON arrays
+func GetRawMessagesFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var rawMessages []json.RawMessage
+	err := UnmarshalResponse(res, &rawMessages)
+	if err != nil {
+		return nil, err
+	}
+	return rawMessages, nil
+}
+
+// GetRawMessageFromResponse parses responses of the Gerrit REST API which are JSON objects, the whole
+// object is stored as a single raw record
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var rawMessage json.RawMessage
+	err := UnmarshalResponse(res, &rawMessage)
+	if err != nil {
+		return nil, err
+	}
+	return []json.RawMessage{rawMessage}, nil
+}
+
+// buildChangeUrl generates the web url of a change from the url of the REST API request which collected it,
+// e.g. https://gerrit.example.com/a/changes/?q=... => https://gerrit.example.com/c/project/+/123
+func buildChangeUrl(apiUrl string, projectName string, changeNumber int) string {
+	u, err := url.Parse(apiUrl)
+	if err != nil {
+		return ""
+	}
+	index := strings.Index(u.Path, "/a/changes")
+	if index < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s://%s%s/c/%s/+/%d", u.Scheme, u.Host, u.Path[:index], projectName, changeNumber)
+}
+
+func GetChangesIterator(taskCtx plugin.SubTaskContext, apiCollector *api.StatefulApiCollector) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GerritTaskData)
+	clauses := []dal.Clause{
+		dal.Select("gc.change_number"),
+		dal.From("_tool_gerrit_changes gc"),
+		dal.Where(
+			`gc.project_name = ? and gc.connection_id = ?`,
+			data.Options.ProjectName, data.Options.ConnectionId,
+		),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("gc.gerrit_updated > ?", *apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(GerritInput{}))
+}
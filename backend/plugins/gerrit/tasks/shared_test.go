@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildChangeUrl(t *testing.T) {
+	assert.Equal(t,
+		"https://gerrit.example.com/c/platform/build/+/123",
+		buildChangeUrl("https://gerrit.example.com/a/changes/?n=100&q=project%3A%22platform%2Fbuild%22", "platform/build", 123),
+	)
+	assert.Equal(t,
+		"https://example.com/gerrit/c/devlake/+/7",
+		buildChangeUrl("https://example.com/gerrit/a/changes/?n=100", "devlake", 7),
+	)
+	assert.Equal(t, "", buildChangeUrl("https://gerrit.example.com/a/projects/", "devlake", 7))
+}
+
+func TestGerritTime(t *testing.T) {
+	var v struct {
+		Created   GerritTime  `json:"created"`
+		Submitted *GerritTime `json:"submitted"`
+	}
+	err := json.Unmarshal([]byte(`{"created":"2023-06-01 08:15:30.123000000"}`), &v)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 6, 1, 8, 15, 30, 123000000, time.UTC), v.Created.Time)
+	assert.Nil(t, v.Submitted.ToNullableTime())
+}
+
+func TestUnmarshalResponse(t *testing.T) {
+	res := &http.Response{
+		Body:    io.NopCloser(bytes.NewBufferString(")]}'\n{\"_account_id\":1000001}")),
+		Request: &http.Request{URL: &url.URL{Scheme: "https", Host: "gerrit.example.com", Path: "/a/accounts/self"}},
+	}
+	account := &ApiAccount{}
+	err := UnmarshalResponse(res, account)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000001, account.AccountId)
+}
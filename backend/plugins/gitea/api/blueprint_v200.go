@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/utils"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+	"github.com/apache/incubator-devlake/plugins/gitea/tasks"
+)
+
+func MakeDataSourcePipelinePlanV200(
+	subtaskMetas []plugin.SubTaskMeta,
+	connectionId uint64,
+	bpScopes []*coreModels.BlueprintScope,
+) (coreModels.PipelinePlan, []plugin.Scope, errors.Error) {
+	// get the connection info for url
+	connection, err := dsHelper.ConnSrv.FindByPk(connectionId)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopeDetails, err := dsHelper.ScopeSrv.MapScopeDetails(connectionId, bpScopes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan, err := makeDataSourcePipelinePlanV200(subtaskMetas, scopeDetails, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+	scopes, err := makeScopesV200(scopeDetails, connection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return plan, scopes, nil
+}
+
+func makeDataSourcePipelinePlanV200(
+	subtaskMetas []plugin.SubTaskMeta,
+	scopeDetails []*srvhelper.ScopeDetail[models.GiteaRepo, models.GiteaScopeConfig],
+	connection *models.GiteaConnection,
+) (coreModels.PipelinePlan, errors.Error) {
+	plan := make(coreModels.PipelinePlan, len(scopeDetails))
+	for i, scopeDetail := range scopeDetails {
+		giteaRepo, scopeConfig := scopeDetail.Scope, scopeDetail.ScopeConfig
+		stage := plan[i]
+		if stage == nil {
+			stage = coreModels.PipelineStage{}
+		}
+		task, err := helper.MakePipelinePlanTask(
+			"gitea",
+			subtaskMetas,
+			scopeConfig.Entities,
+			tasks.GiteaOptions{
+				ConnectionId: giteaRepo.ConnectionId,
+				GiteaId:      giteaRepo.GiteaId,
+				Name:         giteaRepo.FullName,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		stage = append(stage, task)
+
+		repoId := didgen.NewDomainIdGenerator(&models.GiteaRepo{}).Generate(connection.ID, giteaRepo.GiteaId)
+		// refdiff
+		if scopeConfig != nil && scopeConfig.Refdiff != nil {
+			// add a new task to next stage
+			j := i + 1
+			if j == len(plan) {
+				plan = append(plan, nil)
+			}
+			refdiffOp := scopeConfig.Refdiff
+			refdiffOp["repoId"] = repoId
+			plan[j] = coreModels.PipelineStage{
+				{
+					Plugin:  "refdiff",
+					Options: refdiffOp,
+				},
+			}
+			scopeConfig.Refdiff = nil
+		}
+		// add gitex stage
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) {
+			rawCloneUrl := giteaRepo.CloneUrl
+			if rawCloneUrl == "" {
+				// repos added without going through the remote api only know their names, the endpoint
+				// is the root of the REST API, e.g. https://gitea.example.com/api/v1/
+				rawCloneUrl = strings.TrimSuffix(connection.GetEndpoint(), "api/v1/") + giteaRepo.FullName + ".git"
+			}
+			cloneUrl, err := errors.Convert01(url.Parse(rawCloneUrl))
+			if err != nil {
+				return nil, err
+			}
+			// the username is ignored when the password is an access token
+			cloneUrl.User = url.UserPassword("git", connection.Token)
+			stage = append(stage, &coreModels.PipelineTask{
+				Plugin: "gitextractor",
+				Options: map[string]interface{}{
+					"url":          cloneUrl.String(),
+					"name":         giteaRepo.Name,
+					"fullName":     giteaRepo.FullName,
+					"repoId":       repoId,
+					"proxy":        connection.Proxy,
+					"connectionId": connection.ID,
+					"pluginName":   "gitea",
+				},
+			})
+
+		}
+		plan[i] = stage
+	}
+	return plan, nil
+}
+
+func makeScopesV200(
+	scopeDetails []*srvhelper.ScopeDetail[models.GiteaRepo, models.GiteaScopeConfig],
+	connection *models.GiteaConnection,
+) ([]plugin.Scope, errors.Error) {
+	scopes := make([]plugin.Scope, 0)
+	for _, scopeDetail := range scopeDetails {
+		giteaRepo, scopeConfig := scopeDetail.Scope, scopeDetail.ScopeConfig
+		// if no entities specified, use all entities enabled by default
+		if len(scopeConfig.Entities) == 0 {
+			scopeConfig.Entities = plugin.DOMAIN_TYPES
+		}
+		id := didgen.NewDomainIdGenerator(&models.GiteaRepo{}).Generate(connection.ID, giteaRepo.GiteaId)
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE_REVIEW) ||
+			utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) ||
+			utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CROSS) {
+			scopes = append(scopes, &code.Repo{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         giteaRepo.FullName,
+			})
+		}
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CICD) {
+			scopes = append(scopes, &devops.CicdScope{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         giteaRepo.FullName,
+			})
+		}
+		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
+			scopes = append(scopes, &ticket.Board{
+				DomainEntity: domainlayer.DomainEntity{Id: id},
+				Name:         giteaRepo.FullName,
+			})
+		}
+	}
+	return scopes, nil
+}
@@ -0,0 +1,225 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	dsmodels "github.com/apache/incubator-devlake/helpers/pluginhelper/api/models"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+// RemoteScopes list all available scope for users
+// @Summary list all available scope for users
+// @Description list all available scope for users
+// @Tags plugins/gitea
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param groupId query string false "group ID"
+// @Param pageToken query string false "page Token"
+// @Success 200  {object} api.RemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gitea/connections/{connectionId}/remote-scopes [GET]
+func RemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raScopeList.Get(input)
+}
+
+// SearchRemoteScopes use the Search API and only return repo
+// @Summary use the Search API and only return repo
+// @Description use the Search API and only return repo
+// @Tags plugins/gitea
+// @Accept application/json
+// @Param connectionId path int false "connection ID"
+// @Param search query string false "search"
+// @Param page query int false "page number"
+// @Param pageSize query int false "page size per page"
+// @Success 200  {object} api.SearchRemoteScopesOutput
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/gitea/connections/{connectionId}/search-remote-scopes [GET]
+func SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return raScopeSearch.Get(input)
+}
+
+// the repos are grouped by their owners, the groups are the authenticated user and the organizations it belongs
+// to, the group id is the api path of the owner, e.g. `users/alice` or `orgs/devlake`
+func listGiteaRemoteScopes(
+	connection *models.GiteaConnection,
+	apiClient plugin.ApiClient,
+	groupId string,
+	page GiteaRemotePagination) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo],
+	*GiteaRemotePagination,
+	errors.Error,
+) {
+	if page.Page == 0 {
+		page.Page = 1
+	}
+	if page.Limit == 0 {
+		page.Limit = 50
+	}
+
+	if groupId == "" {
+		return listGiteaOwners(apiClient, page)
+	}
+
+	return listGiteaRepos(apiClient, groupId, page)
+}
+
+func listGiteaOwners(apiClient plugin.ApiClient, page GiteaRemotePagination) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo],
+	*GiteaRemotePagination,
+	errors.Error,
+) {
+	children := []dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo]{}
+
+	// the authenticated user comes first, only on the first page
+	if page.Page == 1 {
+		res, err := apiClient.Get("user", nil, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		var user struct {
+			Login string `json:"login"`
+		}
+		err = api.UnmarshalResponse(res, &user)
+		if err != nil {
+			return nil, nil, err
+		}
+		children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo]{
+			Type:     api.RAS_ENTRY_TYPE_GROUP,
+			Id:       "users/" + user.Login,
+			ParentId: nil,
+			Name:     user.Login,
+			FullName: user.Login,
+		})
+	}
+
+	res, err := apiClient.Get("user/orgs", initialQuery(page), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var orgs []struct {
+		Username string `json:"username"`
+		FullName string `json:"full_name"`
+	}
+	err = api.UnmarshalResponse(res, &orgs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, org := range orgs {
+		children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo]{
+			Type:     api.RAS_ENTRY_TYPE_GROUP,
+			Id:       "orgs/" + org.Username,
+			ParentId: nil,
+			Name:     org.Username,
+			FullName: org.Username,
+		})
+	}
+
+	if len(orgs) < page.Limit {
+		return children, nil, nil
+	}
+	page.Page++
+	return children, &page, nil
+}
+
+func listGiteaRepos(apiClient plugin.ApiClient, groupId string, page GiteaRemotePagination) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo],
+	*GiteaRemotePagination,
+	errors.Error,
+) {
+	res, err := apiClient.Get(fmt.Sprintf("%s/repos", groupId), initialQuery(page), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var repos []models.GiteaApiRepo
+	err = api.UnmarshalResponse(res, &repos)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	children := make([]dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo], 0, len(repos))
+	for _, r := range repos {
+		parent := groupId
+		children = append(children, toScopeEntry(r, &parent))
+	}
+
+	if len(repos) < page.Limit {
+		return children, nil, nil
+	}
+	page.Page++
+	return children, &page, nil
+}
+
+func searchGiteaRepos(apiClient plugin.ApiClient, params *dsmodels.DsRemoteApiScopeSearchParams) (
+	[]dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo],
+	errors.Error,
+) {
+	query := initialQuery(GiteaRemotePagination{
+		Page:  params.Page,
+		Limit: params.PageSize,
+	})
+	query.Set("q", params.Search)
+	res, err := apiClient.Get("repos/search", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resBody struct {
+		Data []models.GiteaApiRepo `json:"data"`
+	}
+	err = api.UnmarshalResponse(res, &resBody)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([]dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo], 0, len(resBody.Data))
+	for _, r := range resBody.Data {
+		children = append(children, toScopeEntry(r, nil))
+	}
+	return children, nil
+}
+
+func toScopeEntry(r models.GiteaApiRepo, parentId *string) dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo] {
+	return dsmodels.DsRemoteApiScopeListEntry[models.GiteaRepo]{
+		Type:     api.RAS_ENTRY_TYPE_SCOPE,
+		Id:       strconv.Itoa(r.Id),
+		ParentId: parentId,
+		Name:     r.Name,
+		FullName: r.FullName,
+		Data:     r.ConvertApiScope().(*models.GiteaRepo),
+	}
+}
+
+func initialQuery(page GiteaRemotePagination) url.Values {
+	query := url.Values{}
+	query.Set("page", fmt.Sprintf("%v", page.Page))
+	query.Set("limit", fmt.Sprintf("%v", page.Limit))
+	return query
+}
+
+type GiteaRemotePagination struct {
+	Page  int `json:"page"`
+	Limit int `json:"limit"`
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitea/impl"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+	"github.com/apache/incubator-devlake/plugins/gitea/tasks"
+)
+
+func TestGiteaActionRunDataFlow(t *testing.T) {
+	var gitea impl.Gitea
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitea", gitea)
+
+	scopeConfig := &models.GiteaScopeConfig{
+		DeploymentPattern: "deploy",
+		ProductionPattern: "main",
+	}
+	regexEnricher, err := tasks.NewRegexEnricher(scopeConfig)
+	if err != nil {
+		panic(err)
+	}
+	taskData := &tasks.GiteaTaskData{
+		Options: &tasks.GiteaOptions{
+			ConnectionId:     1,
+			GiteaId:          12,
+			Name:             "devlake/lake",
+			GiteaScopeConfig: scopeConfig,
+		},
+		RegexEnricher: regexEnricher,
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_action_runs.csv", "_raw_gitea_api_action_runs")
+	dataflowTester.FlushTabler(&models.GiteaRepo{})
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_gitea_repos.csv", &models.GiteaRepo{})
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GiteaActionRun{})
+	dataflowTester.Subtask(tasks.ExtractActionRunsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GiteaActionRun{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_action_runs.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&devops.CICDPipeline{})
+	dataflowTester.FlushTabler(&devops.CiCDPipelineCommit{})
+	dataflowTester.Subtask(tasks.ConvertActionRunsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&devops.CICDPipeline{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cicd_pipelines.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&devops.CiCDPipelineCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/cicd_pipeline_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
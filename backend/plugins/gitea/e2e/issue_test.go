@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitea/impl"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+	"github.com/apache/incubator-devlake/plugins/gitea/tasks"
+)
+
+func TestGiteaIssueDataFlow(t *testing.T) {
+	var gitea impl.Gitea
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitea", gitea)
+
+	taskData := &tasks.GiteaTaskData{
+		Options: &tasks.GiteaOptions{
+			ConnectionId: 1,
+			GiteaId:      12,
+			Name:         "devlake/lake",
+			GiteaScopeConfig: &models.GiteaScopeConfig{
+				IssueTypeBug:         "bug",
+				IssueTypeIncident:    "incident",
+				IssueTypeRequirement: "enhancement|feature",
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_issues.csv", "_raw_gitea_api_issues")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_comments.csv", "_raw_gitea_api_comments")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GiteaIssue{})
+	dataflowTester.FlushTabler(&models.GiteaIssueLabel{})
+	dataflowTester.FlushTabler(&models.GiteaComment{})
+	dataflowTester.FlushTabler(&models.GiteaAccount{})
+	dataflowTester.Subtask(tasks.ExtractIssuesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GiteaIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GiteaIssueLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_issue_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// comments of issues and pull requests are collected together
+	dataflowTester.Subtask(tasks.ExtractCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GiteaComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GiteaAccount{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_accounts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIssuesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.Issue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&ticket.BoardIssue{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/board_issues.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&ticket.IssueLabel{})
+	dataflowTester.Subtask(tasks.ConvertIssueLabelsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// comments of deleted issues can not be linked to anything and are dropped
+	dataflowTester.FlushTabler(&ticket.IssueComment{})
+	dataflowTester.Subtask(tasks.ConvertIssueCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&ticket.IssueComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&crossdomain.Account{})
+	dataflowTester.Subtask(tasks.ConvertAccountsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&crossdomain.Account{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/accounts.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
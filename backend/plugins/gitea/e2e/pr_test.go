@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitea/impl"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+	"github.com/apache/incubator-devlake/plugins/gitea/tasks"
+)
+
+func TestGiteaPullRequestDataFlow(t *testing.T) {
+	var gitea impl.Gitea
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitea", gitea)
+
+	taskData := &tasks.GiteaTaskData{
+		Options: &tasks.GiteaOptions{
+			ConnectionId: 1,
+			GiteaId:      12,
+			Name:         "devlake/lake",
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_pull_requests.csv", "_raw_gitea_api_pull_requests")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_pull_request_commits.csv", "_raw_gitea_api_pull_request_commits")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_pull_request_reviews.csv", "_raw_gitea_api_pull_request_reviews")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitea_api_comments.csv", "_raw_gitea_api_comments")
+
+	// verify extraction, pull requests of other repos must be left alone
+	dataflowTester.FlushTabler(&models.GiteaPullRequest{})
+	dataflowTester.FlushTabler(&models.GiteaPrLabel{})
+	dataflowTester.FlushTabler(&models.GiteaReviewer{})
+	dataflowTester.FlushTabler(&models.GiteaPrCommit{})
+	dataflowTester.FlushTabler(&models.GiteaPrReview{})
+	dataflowTester.FlushTabler(&models.GiteaComment{})
+	dataflowTester.FlushTabler(&models.GiteaAccount{})
+	dataflowTester.Subtask(tasks.ExtractPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GiteaPullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GiteaPrLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_pull_request_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.Subtask(tasks.ExtractPrCommitsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GiteaPrCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_pull_request_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// pending reviews and review requests are skipped, the requested reviewers come from the pull requests
+	dataflowTester.Subtask(tasks.ExtractPrReviewsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&models.GiteaPrReview{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_pull_request_reviews.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&models.GiteaReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_gitea_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.Subtask(tasks.ExtractCommentsMeta, taskData)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.Subtask(tasks.ConvertPullRequestsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequest{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_requests.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestLabel{})
+	dataflowTester.Subtask(tasks.ConvertPrLabelsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestCommit{})
+	dataflowTester.Subtask(tasks.ConvertPrCommitsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestCommit{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_commits.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&code.PullRequestReviewer{})
+	dataflowTester.Subtask(tasks.ConvertReviewersMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestReviewer{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_reviewers.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// reviews and comments both end up in pull_request_comments
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertPrReviewsMeta, taskData)
+	dataflowTester.Subtask(tasks.ConvertPrCommentsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&code.PullRequestComment{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/pull_request_comments.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
@@ -0,0 +1,295 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+	"github.com/apache/incubator-devlake/plugins/gitea/models/migrationscripts"
+	"github.com/apache/incubator-devlake/plugins/gitea/tasks"
+)
+
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginInit
+	plugin.PluginTask
+	plugin.PluginApi
+	plugin.PluginModel
+	plugin.PluginMigration
+	plugin.CloseablePluginTask
+	plugin.DataSourcePluginBlueprintV200
+	plugin.PluginSource
+} = (*Gitea)(nil)
+
+type Gitea struct{}
+
+func (p Gitea) Connection() dal.Tabler {
+	return &models.GiteaConnection{}
+}
+
+func (p Gitea) Scope() plugin.ToolLayerScope {
+	return &models.GiteaRepo{}
+}
+
+func (p Gitea) ScopeConfig() dal.Tabler {
+	return &models.GiteaScopeConfig{}
+}
+
+func (p Gitea) Init(basicRes context.BasicRes) errors.Error {
+	api.Init(basicRes, p)
+
+	return nil
+}
+
+func (p Gitea) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{
+		&models.GiteaConnection{},
+		&models.GiteaRepo{},
+		&models.GiteaScopeConfig{},
+		&models.GiteaAccount{},
+		&models.GiteaPullRequest{},
+		&models.GiteaPrLabel{},
+		&models.GiteaReviewer{},
+		&models.GiteaPrCommit{},
+		&models.GiteaPrReview{},
+		&models.GiteaIssue{},
+		&models.GiteaIssueLabel{},
+		&models.GiteaComment{},
+		&models.GiteaActionRun{},
+	}
+}
+
+func (p Gitea) Description() string {
+	return "To collect and enrich data from Gitea and Forgejo"
+}
+
+func (p Gitea) Name() string {
+	return "gitea"
+}
+
+func (p Gitea) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.CollectPullRequestsMeta,
+		tasks.ExtractPullRequestsMeta,
+
+		tasks.CollectPrCommitsMeta,
+		tasks.ExtractPrCommitsMeta,
+
+		tasks.CollectPrReviewsMeta,
+		tasks.ExtractPrReviewsMeta,
+
+		tasks.CollectIssuesMeta,
+		tasks.ExtractIssuesMeta,
+
+		tasks.CollectCommentsMeta,
+		tasks.ExtractCommentsMeta,
+
+		tasks.CollectActionRunsMeta,
+		tasks.ExtractActionRunsMeta,
+
+		tasks.ConvertReposMeta,
+		tasks.ConvertPullRequestsMeta,
+		tasks.ConvertPrLabelsMeta,
+		tasks.ConvertPrCommitsMeta,
+		tasks.ConvertReviewersMeta,
+		tasks.ConvertPrReviewsMeta,
+		tasks.ConvertPrCommentsMeta,
+		tasks.ConvertIssuesMeta,
+		tasks.ConvertIssueLabelsMeta,
+		tasks.ConvertIssueCommentsMeta,
+		tasks.ConvertActionRunsMeta,
+
+		tasks.ConvertAccountsMeta,
+	}
+}
+
+func (p Gitea) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	logger := taskCtx.GetLogger()
+	logger.Debug("%v", options)
+	op, err := tasks.DecodeAndValidateTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	connectionHelper := helper.NewConnectionHelper(
+		taskCtx,
+		nil,
+		p.Name(),
+	)
+	connection := &models.GiteaConnection{}
+	err = connectionHelper.FirstById(connection, op.ConnectionId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get gitea connection by the given connection ID")
+	}
+
+	apiClient, err := tasks.CreateApiClient(taskCtx, connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "unable to get gitea API client instance")
+	}
+	err = EnrichOptions(taskCtx, op, apiClient.ApiClient)
+	if err != nil {
+		return nil, err
+	}
+	regexEnricher, err := tasks.NewRegexEnricher(op.GiteaScopeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &tasks.GiteaTaskData{
+		Options:       op,
+		ApiClient:     apiClient,
+		RegexEnricher: regexEnricher,
+	}
+
+	return taskData, nil
+}
+
+func (p Gitea) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/gitea"
+}
+
+func (p Gitea) MigrationScripts() []plugin.MigrationScript {
+	return migrationscripts.All()
+}
+
+func (p Gitea) MakeDataSourcePipelinePlanV200(
+	connectionId uint64,
+	scopes []*coreModels.BlueprintScope) (pp coreModels.PipelinePlan, sc []plugin.Scope, err errors.Error) {
+	return api.MakeDataSourcePipelinePlanV200(p.SubTaskMetas(), connectionId, scopes)
+}
+
+func (p Gitea) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"connections/:connectionId/test": {
+			"POST": api.TestExistingConnection,
+		},
+		"test": {
+			"POST": api.TestConnection,
+		},
+		"connections": {
+			"POST": api.PostConnections,
+			"GET":  api.ListConnections,
+		},
+		"connections/:connectionId": {
+			"PATCH":  api.PatchConnection,
+			"DELETE": api.DeleteConnection,
+			"GET":    api.GetConnection,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			"GET":    api.GetScope,
+			"PATCH":  api.PatchScope,
+			"DELETE": api.DeleteScope,
+		},
+		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
+			"GET": api.GetScopeLatestSyncState,
+		},
+		"connections/:connectionId/remote-scopes": {
+			"GET": api.RemoteScopes,
+		},
+		"connections/:connectionId/search-remote-scopes": {
+			"GET": api.SearchRemoteScopes,
+		},
+		"connections/:connectionId/scopes": {
+			"GET": api.GetScopes,
+			"PUT": api.PutScopes,
+		},
+		"connections/:connectionId/scope-configs": {
+			"POST": api.CreateScopeConfig,
+			"GET":  api.GetScopeConfigList,
+		},
+		"connections/:connectionId/scope-configs/:scopeConfigId": {
+			"PATCH":  api.UpdateScopeConfig,
+			"GET":    api.GetScopeConfig,
+			"DELETE": api.DeleteScopeConfig,
+		},
+		"scope-config/:scopeConfigId/projects": {
+			"GET": api.GetProjectsByScopeConfig,
+		},
+	}
+}
+
+func (p Gitea) Close(taskCtx plugin.TaskContext) errors.Error {
+	data, ok := taskCtx.GetData().(*tasks.GiteaTaskData)
+	if !ok {
+		return errors.Default.New(fmt.Sprintf("GetData failed when try to close %+v", taskCtx))
+	}
+	data.ApiClient.Release()
+	return nil
+}
+
+func EnrichOptions(taskCtx plugin.TaskContext,
+	op *tasks.GiteaOptions,
+	apiClient *helper.ApiClient) errors.Error {
+	var repo models.GiteaRepo
+	err := tasks.ValidateTaskOptions(op)
+	if err != nil {
+		return err
+	}
+	db := taskCtx.GetDal()
+	if op.GiteaId != 0 {
+		err = db.First(&repo, dal.Where("connection_id = ? AND gitea_id = ?", op.ConnectionId, op.GiteaId))
+	} else {
+		err = db.First(&repo, dal.Where("connection_id = ? AND full_name = ?", op.ConnectionId, op.Name))
+	}
+	if err == nil {
+		op.GiteaId = repo.GiteaId
+		op.Name = repo.FullName
+		if op.ScopeConfigId == 0 {
+			op.ScopeConfigId = repo.ScopeConfigId
+		}
+	} else {
+		if db.IsErrorNotFound(err) && op.Name != "" {
+			var apiRepo *models.GiteaApiRepo
+			apiRepo, err = tasks.GetApiRepo(op, apiClient)
+			if err != nil {
+				return err
+			}
+			taskCtx.GetLogger().Debug(fmt.Sprintf("Current repo: %s", apiRepo.FullName))
+			scope := apiRepo.ConvertApiScope().(*models.GiteaRepo)
+			scope.ConnectionId = op.ConnectionId
+			err = db.CreateIfNotExist(scope)
+			if err != nil {
+				return err
+			}
+			op.GiteaId = scope.GiteaId
+			op.Name = scope.FullName
+		} else {
+			return errors.Default.Wrap(err, fmt.Sprintf("fail to find repo %s", op.Name))
+		}
+	}
+	// Set scope config if it's nil, this has lower priority
+	if op.GiteaScopeConfig == nil && op.ScopeConfigId != 0 {
+		var scopeConfig models.GiteaScopeConfig
+		err = db.First(&scopeConfig, dal.Where("id = ?", op.ScopeConfigId))
+		if err != nil && !db.IsErrorNotFound(err) {
+			return errors.BadInput.Wrap(err, "fail to get scopeConfig")
+		}
+		op.GiteaScopeConfig = &scopeConfig
+	}
+	if op.GiteaScopeConfig == nil {
+		op.GiteaScopeConfig = new(models.GiteaScopeConfig)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GiteaActionRun is a workflow run of Gitea Actions
+type GiteaActionRun struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	GiteaId      int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId       int    `gorm:"index"`
+	Name         string `gorm:"type:varchar(255)"` // the workflow file name
+	DisplayTitle string
+	Path         string `gorm:"type:varchar(255)"`
+	Event        string `gorm:"type:varchar(255)"`
+	Status       string `gorm:"type:varchar(100)"`
+	Conclusion   string `gorm:"type:varchar(100)"`
+	RunNumber    int
+	RunAttempt   int
+	HeadBranch   string `gorm:"type:varchar(255)"`
+	HeadSha      string `gorm:"type:varchar(40)"`
+	HTMLUrl      string `gorm:"type:varchar(255)"`
+	StartedAt    *time.Time
+	CompletedAt  *time.Time
+	Type         string `gorm:"type:varchar(255)"`
+	Environment  string `gorm:"type:varchar(255)"`
+	common.NoPKModel
+}
+
+func (GiteaActionRun) TableName() string {
+	return "_tool_gitea_action_runs"
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+	"github.com/apache/incubator-devlake/plugins/gitea/models/migrationscripts/archived"
+)
+
+type addInitTables20260121 struct{}
+
+func (script *addInitTables20260121) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&archived.GiteaConnection{},
+		&archived.GiteaRepo{},
+		&archived.GiteaScopeConfig{},
+		&archived.GiteaAccount{},
+		&archived.GiteaPullRequest{},
+		&archived.GiteaPrLabel{},
+		&archived.GiteaReviewer{},
+		&archived.GiteaPrCommit{},
+		&archived.GiteaPrReview{},
+		&archived.GiteaIssue{},
+		&archived.GiteaIssueLabel{},
+		&archived.GiteaComment{},
+		&archived.GiteaActionRun{},
+	)
+}
+
+func (*addInitTables20260121) Version() uint64 {
+	return 20260121000001
+}
+
+func (*addInitTables20260121) Name() string {
+	return "Gitea init schema 20260121"
+}
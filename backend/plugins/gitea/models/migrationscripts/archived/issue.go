@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type GiteaIssue struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GiteaId         int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId          int    `gorm:"index"`
+	Number          int    `gorm:"index"`
+	State           string `gorm:"type:varchar(255)"`
+	Title           string
+	Body            string
+	HTMLUrl         string `gorm:"type:varchar(255)"`
+	Type            string `gorm:"type:varchar(255)"` // the labels joined by commas
+	StdType         string `gorm:"type:varchar(255)"`
+	AuthorId        int
+	AuthorName      string `gorm:"type:varchar(255)"`
+	AssigneeId      int
+	AssigneeName    string `gorm:"type:varchar(255)"`
+	LeadTimeMinutes *uint
+	GiteaCreatedAt  time.Time
+	GiteaUpdatedAt  time.Time `gorm:"index"`
+	ClosedAt        *time.Time
+	archived.NoPKModel
+}
+
+func (GiteaIssue) TableName() string {
+	return "_tool_gitea_issues"
+}
+
+type GiteaIssueLabel struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	IssueId      int    `gorm:"primaryKey;autoIncrement:false"`
+	LabelName    string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (GiteaIssueLabel) TableName() string {
+	return "_tool_gitea_issue_labels"
+}
+
+// GiteaComment is a comment on the conversation of either an issue or a pull request, IssueNumber is the
+// number of the issue or the pull request
+type GiteaComment struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	GiteaId        int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId         int    `gorm:"index"`
+	IssueNumber    int
+	IsPull         bool
+	Body           string
+	AuthorId       int
+	AuthorName     string `gorm:"type:varchar(255)"`
+	HTMLUrl        string `gorm:"type:varchar(255)"`
+	GiteaCreatedAt time.Time
+	GiteaUpdatedAt time.Time
+	archived.NoPKModel
+}
+
+func (GiteaComment) TableName() string {
+	return "_tool_gitea_comments"
+}
@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type GiteaPullRequest struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	GiteaId        int    `gorm:"primaryKey;autoIncrement:false"`
+	RepoId         int    `gorm:"index"`
+	Number         int    `gorm:"index"` // the number is shared with issues of the same repo
+	State          string `gorm:"type:varchar(255)"`
+	Title          string
+	Body           string
+	HTMLUrl        string `gorm:"type:varchar(255)"`
+	Merged         bool
+	IsDraft        bool
+	AuthorId       int
+	AuthorName     string `gorm:"type:varchar(255)"`
+	MergedById     int
+	MergedByName   string `gorm:"type:varchar(255)"`
+	MergeCommitSha string `gorm:"type:varchar(40)"`
+	HeadRef        string `gorm:"type:varchar(255)"`
+	HeadSha        string `gorm:"type:varchar(40)"`
+	HeadRepoId     int
+	BaseRef        string `gorm:"type:varchar(255)"`
+	BaseSha        string `gorm:"type:varchar(40)"`
+	BaseRepoId     int
+	Additions      int
+	Deletions      int
+	GiteaCreatedAt time.Time
+	GiteaUpdatedAt time.Time `gorm:"index"`
+	MergedAt       *time.Time
+	ClosedAt       *time.Time
+	archived.NoPKModel
+}
+
+func (GiteaPullRequest) TableName() string {
+	return "_tool_gitea_pull_requests"
+}
+
+type GiteaPrLabel struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PullId       int    `gorm:"primaryKey;autoIncrement:false"`
+	LabelName    string `gorm:"primaryKey;type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (GiteaPrLabel) TableName() string {
+	return "_tool_gitea_pull_request_labels"
+}
+
+// GiteaReviewer is either requested to review a pull request or has submitted a review
+type GiteaReviewer struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	PullId       int    `gorm:"primaryKey;autoIncrement:false"`
+	ReviewerId   int    `gorm:"primaryKey;autoIncrement:false"`
+	Login        string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (GiteaReviewer) TableName() string {
+	return "_tool_gitea_reviewers"
+}
+
+type GiteaPrCommit struct {
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	PullId             int    `gorm:"primaryKey;autoIncrement:false"`
+	CommitSha          string `gorm:"primaryKey;type:varchar(40)"`
+	CommitAuthorName   string `gorm:"type:varchar(255)"`
+	CommitAuthorEmail  string `gorm:"type:varchar(255)"`
+	CommitAuthoredDate time.Time
+	Message            string
+	archived.NoPKModel
+}
+
+func (GiteaPrCommit) TableName() string {
+	return "_tool_gitea_pull_request_commits"
+}
+
+type GiteaPrReview struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	GiteaId      int    `gorm:"primaryKey;autoIncrement:false"`
+	PullId       int    `gorm:"index"`
+	AuthorId     int
+	AuthorName   string `gorm:"type:varchar(255)"`
+	State        string `gorm:"type:varchar(255)"`
+	Body         string
+	CommitSha    string `gorm:"type:varchar(40)"`
+	HTMLUrl      string `gorm:"type:varchar(255)"`
+	Stale        bool
+	SubmittedAt  *time.Time
+	archived.NoPKModel
+}
+
+func (GiteaPrReview) TableName() string {
+	return "_tool_gitea_pull_request_reviews"
+}
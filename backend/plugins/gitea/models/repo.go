@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.ToolLayerScope = (*GiteaRepo)(nil)
+var _ plugin.ApiScope = (*GiteaApiRepo)(nil)
+
+type GiteaRepo struct {
+	common.Scope  `mapstructure:",squash"`
+	GiteaId       int        `json:"giteaId" gorm:"primaryKey;autoIncrement:false" validate:"required" mapstructure:"giteaId"`
+	Name          string     `json:"name" gorm:"type:varchar(255)" mapstructure:"name,omitempty"`
+	FullName      string     `json:"fullName" gorm:"type:varchar(255)" mapstructure:"fullName,omitempty"`
+	Description   string     `json:"description" mapstructure:"description,omitempty"`
+	HTMLUrl       string     `json:"HTMLUrl" gorm:"type:varchar(255)" mapstructure:"HTMLUrl,omitempty"`
+	CloneUrl      string     `json:"cloneUrl" gorm:"type:varchar(255)" mapstructure:"cloneUrl,omitempty"`
+	OwnerLogin    string     `json:"ownerLogin" gorm:"type:varchar(255)" mapstructure:"ownerLogin,omitempty"`
+	Language      string     `json:"language" gorm:"type:varchar(255)" mapstructure:"language,omitempty"`
+	DefaultBranch string     `json:"defaultBranch" gorm:"type:varchar(255)" mapstructure:"defaultBranch,omitempty"`
+	CreatedDate   *time.Time `json:"createdDate" mapstructure:"-"`
+	UpdatedDate   *time.Time `json:"updatedDate" mapstructure:"-"`
+}
+
+func (GiteaRepo) TableName() string {
+	return "_tool_gitea_repos"
+}
+
+func (r GiteaRepo) ScopeId() string {
+	return strconv.Itoa(r.GiteaId)
+}
+
+func (r GiteaRepo) ScopeName() string {
+	return r.Name
+}
+
+func (r GiteaRepo) ScopeFullName() string {
+	return r.FullName
+}
+
+func (r GiteaRepo) ScopeParams() interface{} {
+	return &GiteaApiParams{
+		ConnectionId: r.ConnectionId,
+		Name:         r.FullName,
+	}
+}
+
+// GiteaApiRepo is the Repository entity returned by the Gitea REST API
+type GiteaApiRepo struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	Description string `json:"description"`
+	HTMLUrl     string `json:"html_url"`
+	CloneUrl    string `json:"clone_url"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+	Language      string     `json:"language"`
+	DefaultBranch string     `json:"default_branch"`
+	CreatedAt     *time.Time `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+}
+
+func (r GiteaApiRepo) ConvertApiScope() plugin.ToolLayerScope {
+	return &GiteaRepo{
+		GiteaId:       r.Id,
+		Name:          r.Name,
+		FullName:      r.FullName,
+		Description:   r.Description,
+		HTMLUrl:       r.HTMLUrl,
+		CloneUrl:      r.CloneUrl,
+		OwnerLogin:    r.Owner.Login,
+		Language:      r.Language,
+		DefaultBranch: r.DefaultBranch,
+		CreatedDate:   r.CreatedAt,
+		UpdatedDate:   r.UpdatedAt,
+	}
+}
+
+type GiteaApiParams struct {
+	ConnectionId uint64
+	Name         string
+}
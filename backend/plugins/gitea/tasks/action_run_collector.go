@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+const RAW_ACTION_RUN_TABLE = "gitea_api_action_runs"
+
+var CollectActionRunsMeta = plugin.SubTaskMeta{
+	Name:             "collectActionRuns",
+	EntryPoint:       CollectActionRuns,
+	EnabledByDefault: true,
+	Description:      "Collect workflow runs of Gitea Actions from Gitea api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+type simpleActionRun struct {
+	GiteaId int
+}
+
+type simpleApiActionRun struct {
+	StartedAt time.Time `json:"started_at"`
+}
+
+func CollectActionRuns(taskCtx plugin.SubTaskContext) errors.Error {
+	// runs are Finalizable once completed, the ones still queued or running are re-collected one by one
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ACTION_RUN_TABLE)
+	db := taskCtx.GetDal()
+
+	collector, err := api.NewStatefulApiCollectorForFinalizableEntity(api.FinalizableApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		CollectNewRecordsByList: api.FinalizableApiCollectorListArgs{
+			PageSize:    50,
+			Concurrency: 10,
+			FinalizableApiCollectorCommonArgs: api.FinalizableApiCollectorCommonArgs{
+				// runs are listed newest first
+				UrlTemplate: "repos/{{ .Params.Name }}/actions/runs",
+				Query: func(reqData *api.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+					return GetQueryForPage(reqData)
+				},
+				ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+					var body struct {
+						WorkflowRuns []json.RawMessage `json:"workflow_runs"`
+					}
+					err := api.UnmarshalResponse(res, &body)
+					if err != nil {
+						return nil, err
+					}
+					return body.WorkflowRuns, nil
+				},
+			},
+			GetCreated: func(item json.RawMessage) (time.Time, errors.Error) {
+				// queued runs have not started yet, their zero time is tolerated by the collector
+				run := &simpleApiActionRun{}
+				err := json.Unmarshal(item, run)
+				if err != nil {
+					return time.Time{}, errors.BadInput.Wrap(err, "failed to unmarshal gitea action run")
+				}
+				return run.StartedAt, nil
+			},
+		},
+		CollectUnfinishedDetails: &api.FinalizableApiCollectorDetailArgs{
+			BuildInputIterator: func() (api.Iterator, errors.Error) {
+				cursor, err := db.Cursor(
+					dal.Select("gitea_id"),
+					dal.From(&models.GiteaActionRun{}),
+					dal.Where(
+						"repo_id = ? AND connection_id = ? AND status != ?",
+						data.Options.GiteaId, data.Options.ConnectionId, ActionStatusCompleted,
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(simpleActionRun{}))
+			},
+			FinalizableApiCollectorCommonArgs: api.FinalizableApiCollectorCommonArgs{
+				UrlTemplate:    "repos/{{ .Params.Name }}/actions/runs/{{ .Input.GiteaId }}",
+				ResponseParser: GetRawMessageFromResponse,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertActionRunsMeta = plugin.SubTaskMeta{
+	Name:             "convertActionRuns",
+	EntryPoint:       ConvertActionRuns,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitea_action_runs into domain layer tables cicd_pipelines and cicd_pipeline_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertActionRuns(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ACTION_RUN_TABLE)
+	db := taskCtx.GetDal()
+
+	repo := &models.GiteaRepo{}
+	err := db.First(repo, dal.Where("connection_id = ? AND gitea_id = ?", data.Options.ConnectionId, data.Options.GiteaId))
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GiteaActionRun{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.GiteaId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	runIdGen := didgen.NewDomainIdGenerator(&models.GiteaActionRun{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GiteaRepo{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GiteaActionRun{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			run := inputRow.(*models.GiteaActionRun)
+			runId := runIdGen.Generate(data.Options.ConnectionId, run.GiteaId)
+			repoId := repoIdGen.Generate(data.Options.ConnectionId, run.RepoId)
+			// Gitea does not tell when a run was queued, the start time is the closest approximation
+			createdDate := time.Now()
+			if run.StartedAt != nil {
+				createdDate = *run.StartedAt
+			}
+			domainPipeline := &devops.CICDPipeline{
+				DomainEntity: domainlayer.DomainEntity{Id: runId},
+				Name:         run.Name,
+				DisplayTitle: run.DisplayTitle,
+				Url:          run.HTMLUrl,
+				Result: devops.GetResult(&devops.ResultRule{
+					Success: []string{ActionConclusionSuccess},
+					Failure: []string{ActionConclusionFailure, ActionConclusionCancelled},
+					Default: devops.RESULT_DEFAULT,
+				}, run.Conclusion),
+				OriginalResult: run.Conclusion,
+				Status: devops.GetStatus(&devops.StatusRule{
+					Done:       []string{ActionStatusCompleted},
+					InProgress: []string{ActionStatusQueued, ActionStatusWaiting, ActionStatusInProgress},
+					Default:    devops.STATUS_OTHER,
+				}, run.Status),
+				OriginalStatus: run.Status,
+				Type:           run.Type,
+				Environment:    run.Environment,
+				TaskDatesInfo: devops.TaskDatesInfo{
+					CreatedDate:  createdDate,
+					StartedDate:  run.StartedAt,
+					FinishedDate: run.CompletedAt,
+				},
+				CicdScopeId: repoId,
+			}
+			if run.StartedAt != nil && run.CompletedAt != nil {
+				domainPipeline.DurationSec = run.CompletedAt.Sub(*run.StartedAt).Seconds()
+			}
+			domainPipelineCommit := &devops.CiCDPipelineCommit{
+				PipelineId:   runId,
+				CommitSha:    run.HeadSha,
+				Branch:       run.HeadBranch,
+				RepoId:       repoId,
+				RepoUrl:      repo.HTMLUrl,
+				DisplayTitle: run.DisplayTitle,
+				Url:          run.HTMLUrl,
+			}
+			return []interface{}{
+				domainPipeline,
+				domainPipelineCommit,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ExtractActionRunsMeta = plugin.SubTaskMeta{
+	Name:             "extractActionRuns",
+	EntryPoint:       ExtractActionRuns,
+	EnabledByDefault: true,
+	Description:      "Extract raw workflow runs data into tool layer table gitea_action_runs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+const (
+	ActionStatusQueued     = "queued"
+	ActionStatusWaiting    = "waiting"
+	ActionStatusInProgress = "in_progress"
+	ActionStatusCompleted  = "completed"
+
+	ActionConclusionSuccess   = "success"
+	ActionConclusionFailure   = "failure"
+	ActionConclusionCancelled = "cancelled"
+)
+
+// ApiActionRun is the ActionWorkflowRun entity of the Gitea REST API, the path is the workflow file followed
+// by the ref it ran on, e.g. build.yml@refs/heads/main
+type ApiActionRun struct {
+	Id           int        `json:"id"`
+	DisplayTitle string     `json:"display_title"`
+	Path         string     `json:"path"`
+	Event        string     `json:"event"`
+	Status       string     `json:"status"`
+	Conclusion   string     `json:"conclusion"`
+	RunNumber    int        `json:"run_number"`
+	RunAttempt   int        `json:"run_attempt"`
+	HeadBranch   string     `json:"head_branch"`
+	HeadSha      string     `json:"head_sha"`
+	HTMLUrl      string     `json:"html_url"`
+	StartedAt    *time.Time `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
+
+func ExtractActionRuns(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_ACTION_RUN_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiActionRun]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(run *ApiActionRun, row *api.RawData) ([]interface{}, errors.Error) {
+			if run.Id == 0 {
+				return nil, nil
+			}
+			name, _, _ := strings.Cut(run.Path, "@")
+			giteaRun := &models.GiteaActionRun{
+				ConnectionId: connectionId,
+				GiteaId:      run.Id,
+				RepoId:       data.Options.GiteaId,
+				Name:         name,
+				DisplayTitle: run.DisplayTitle,
+				Path:         run.Path,
+				Event:        run.Event,
+				Status:       run.Status,
+				Conclusion:   run.Conclusion,
+				RunNumber:    run.RunNumber,
+				RunAttempt:   run.RunAttempt,
+				HeadBranch:   run.HeadBranch,
+				HeadSha:      run.HeadSha,
+				HTMLUrl:      run.HTMLUrl,
+				StartedAt:    nullableTime(run.StartedAt),
+				CompletedAt:  nullableTime(run.CompletedAt),
+			}
+			giteaRun.Type = data.RegexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, giteaRun.Name)
+			giteaRun.Environment = data.RegexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, giteaRun.Name, giteaRun.HeadBranch)
+			return []interface{}{giteaRun}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
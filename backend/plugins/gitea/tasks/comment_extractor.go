@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ExtractCommentsMeta = plugin.SubTaskMeta{
+	Name:             "extractComments",
+	EntryPoint:       ExtractComments,
+	EnabledByDefault: true,
+	Description:      "Extract raw comments data into tool layer table gitea_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// ApiComment is the Comment entity of the Gitea REST API, either IssueUrl or PullRequestUrl is set depending on
+// what the comment belongs to
+type ApiComment struct {
+	Id             int       `json:"id"`
+	HTMLUrl        string    `json:"html_url"`
+	IssueUrl       string    `json:"issue_url"`
+	PullRequestUrl string    `json:"pull_request_url"`
+	User           *ApiUser  `json:"user"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func ExtractComments(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_COMMENT_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiComment]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(comment *ApiComment, row *api.RawData) ([]interface{}, errors.Error) {
+			if comment.Id == 0 {
+				return nil, nil
+			}
+			giteaComment := &models.GiteaComment{
+				ConnectionId:   connectionId,
+				GiteaId:        comment.Id,
+				RepoId:         data.Options.GiteaId,
+				IssueNumber:    parseNumberFromUrl(comment.IssueUrl),
+				Body:           comment.Body,
+				HTMLUrl:        comment.HTMLUrl,
+				GiteaCreatedAt: comment.CreatedAt,
+				GiteaUpdatedAt: comment.UpdatedAt,
+			}
+			if comment.PullRequestUrl != "" {
+				giteaComment.IsPull = true
+				giteaComment.IssueNumber = parseNumberFromUrl(comment.PullRequestUrl)
+			}
+			results := []interface{}{giteaComment}
+			if comment.User != nil {
+				giteaComment.AuthorId = comment.User.Id
+				giteaComment.AuthorName = comment.User.Login
+				results = append(results, comment.User.toToolLayer(connectionId))
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertIssueCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueComments",
+	EntryPoint:       ConvertIssueComments,
+	EnabledByDefault: true,
+	Description:      "Convert comments of issues in tool layer table gitea_comments into domain layer table issue_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// commentWithIssueId carries the id of the issue the comment belongs to, comments only know its number
+type commentWithIssueId struct {
+	models.GiteaComment
+	IssueId int
+}
+
+func ConvertIssueComments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*, i.gitea_id AS issue_id"),
+		dal.From("_tool_gitea_comments c"),
+		dal.Join("INNER JOIN _tool_gitea_issues i ON (i.connection_id = c.connection_id AND i.repo_id = c.repo_id AND i.number = c.issue_number)"),
+		dal.Where("c.connection_id = ? AND c.repo_id = ? AND c.is_pull = ?", data.Options.ConnectionId, data.Options.GiteaId, false),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	commentIdGen := didgen.NewDomainIdGenerator(&models.GiteaComment{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GiteaIssue{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GiteaAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(commentWithIssueId{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*commentWithIssueId)
+			return []interface{}{
+				&ticket.IssueComment{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: commentIdGen.Generate(data.Options.ConnectionId, comment.GiteaId),
+					},
+					IssueId:     issueIdGen.Generate(data.Options.ConnectionId, comment.IssueId),
+					Body:        comment.Body,
+					AccountId:   accountIdGen.Generate(data.Options.ConnectionId, comment.AuthorId),
+					CreatedDate: comment.GiteaCreatedAt,
+					UpdatedDate: &comment.GiteaUpdatedAt,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ExtractIssuesMeta = plugin.SubTaskMeta{
+	Name:             "extractIssues",
+	EntryPoint:       ExtractIssues,
+	EnabledByDefault: true,
+	Description:      "Extract raw issues data into tool layer tables gitea_issues and gitea_issue_labels",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+// ApiIssue is the Issue entity of the Gitea REST API
+type ApiIssue struct {
+	Id        int        `json:"id"`
+	Number    int        `json:"number"`
+	User      *ApiUser   `json:"user"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Labels    []ApiLabel `json:"labels"`
+	Assignee  *ApiUser   `json:"assignee"`
+	State     string     `json:"state"`
+	HTMLUrl   string     `json:"html_url"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+}
+
+// issueTypeRegexes maps the labels of an issue to the standard issue types
+type issueTypeRegexes map[string]*regexp.Regexp
+
+func newIssueTypeRegexes(config *models.GiteaScopeConfig) (issueTypeRegexes, errors.Error) {
+	regexes := make(issueTypeRegexes)
+	if config == nil {
+		return regexes, nil
+	}
+	for stdType, pattern := range map[string]string{
+		ticket.BUG:         config.IssueTypeBug,
+		ticket.INCIDENT:    config.IssueTypeIncident,
+		ticket.REQUIREMENT: config.IssueTypeRequirement,
+	} {
+		if pattern == "" {
+			continue
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for the issue type pattern of "+stdType)
+		}
+		regexes[stdType] = regex
+	}
+	return regexes, nil
+}
+
+// StdType returns the standard issue type the label would be mapped to, or empty string if none matched
+func (regexes issueTypeRegexes) StdType(label string) string {
+	for _, stdType := range []string{ticket.BUG, ticket.INCIDENT, ticket.REQUIREMENT} {
+		if regex, ok := regexes[stdType]; ok && regex.MatchString(label) {
+			return stdType
+		}
+	}
+	return ""
+}
+
+func ExtractIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_ISSUE_TABLE)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	regexes, err := newIssueTypeRegexes(data.Options.GiteaScopeConfig)
+	if err != nil {
+		return err
+	}
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiIssue]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		BeforeExtract: func(issue *ApiIssue, stateManager *api.SubtaskStateManager) errors.Error {
+			if !stateManager.IsIncremental() {
+				return nil
+			}
+			return db.Delete(&models.GiteaIssueLabel{}, dal.Where("connection_id = ? AND issue_id = ?", connectionId, issue.Id))
+		},
+		Extract: func(issue *ApiIssue, row *api.RawData) ([]interface{}, errors.Error) {
+			if issue.Id == 0 {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, 3+len(issue.Labels))
+			giteaIssue := &models.GiteaIssue{
+				ConnectionId:   connectionId,
+				GiteaId:        issue.Id,
+				RepoId:         data.Options.GiteaId,
+				Number:         issue.Number,
+				State:          issue.State,
+				Title:          issue.Title,
+				Body:           issue.Body,
+				HTMLUrl:        issue.HTMLUrl,
+				GiteaCreatedAt: issue.CreatedAt,
+				GiteaUpdatedAt: issue.UpdatedAt,
+				ClosedAt:       issue.ClosedAt,
+			}
+			if issue.ClosedAt != nil {
+				leadTimeMinutes := uint(issue.ClosedAt.Sub(issue.CreatedAt).Minutes())
+				giteaIssue.LeadTimeMinutes = &leadTimeMinutes
+			}
+			if issue.User != nil {
+				giteaIssue.AuthorId = issue.User.Id
+				giteaIssue.AuthorName = issue.User.Login
+				results = append(results, issue.User.toToolLayer(connectionId))
+			}
+			if issue.Assignee != nil {
+				giteaIssue.AssigneeId = issue.Assignee.Id
+				giteaIssue.AssigneeName = issue.Assignee.Login
+				results = append(results, issue.Assignee.toToolLayer(connectionId))
+			}
+			labelNames := make([]string, 0, len(issue.Labels))
+			for _, label := range issue.Labels {
+				results = append(results, &models.GiteaIssueLabel{
+					ConnectionId: connectionId,
+					IssueId:      issue.Id,
+					LabelName:    label.Name,
+				})
+				if giteaIssue.StdType == "" {
+					giteaIssue.StdType = regexes.StdType(label.Name)
+				}
+				labelNames = append(labelNames, label.Name)
+			}
+			giteaIssue.Type = strings.Join(labelNames, ",")
+			results = append(results, giteaIssue)
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
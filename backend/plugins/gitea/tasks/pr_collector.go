@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+const RAW_PULL_REQUEST_TABLE = "gitea_api_pull_requests"
+
+var CollectPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "collectPullRequests",
+	EntryPoint:       CollectPullRequests,
+	EnabledByDefault: true,
+	Description:      "Collect pull requests from Gitea api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type simpleApiPullRequest struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func CollectPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	// pull requests are Finalizable once merged, the ones still open or closed without merging are re-collected
+	// one by one since they might have changed
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	db := taskCtx.GetDal()
+
+	collector, err := api.NewStatefulApiCollectorForFinalizableEntity(api.FinalizableApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		CollectNewRecordsByList: api.FinalizableApiCollectorListArgs{
+			PageSize:    50,
+			Concurrency: 10,
+			FinalizableApiCollectorCommonArgs: api.FinalizableApiCollectorCommonArgs{
+				UrlTemplate: "repos/{{ .Params.Name }}/pulls",
+				Query: func(reqData *api.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+					// pull requests are listed by creation time, newest first, when no sort is given
+					query, err := GetQueryForPage(reqData)
+					if err != nil {
+						return nil, err
+					}
+					query.Set("state", "all")
+					return query, nil
+				},
+				ResponseParser: GetRawMessagesFromResponse,
+			},
+			GetCreated: func(item json.RawMessage) (time.Time, errors.Error) {
+				pr := &simpleApiPullRequest{}
+				err := json.Unmarshal(item, pr)
+				if err != nil {
+					return time.Time{}, errors.BadInput.Wrap(err, "failed to unmarshal gitea pull request")
+				}
+				return pr.CreatedAt, nil
+			},
+		},
+		CollectUnfinishedDetails: &api.FinalizableApiCollectorDetailArgs{
+			BuildInputIterator: func() (api.Iterator, errors.Error) {
+				cursor, err := db.Cursor(
+					dal.Select("number, gitea_id"),
+					dal.From(&models.GiteaPullRequest{}),
+					dal.Where(
+						"repo_id = ? AND connection_id = ? AND (state != 'closed' OR merged = false)",
+						data.Options.GiteaId, data.Options.ConnectionId,
+					),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(GiteaInput{}))
+			},
+			FinalizableApiCollectorCommonArgs: api.FinalizableApiCollectorCommonArgs{
+				UrlTemplate:    "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}",
+				ResponseParser: GetRawMessageFromResponse,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertPrCommentsMeta = plugin.SubTaskMeta{
+	Name:             "convertPrComments",
+	EntryPoint:       ConvertPrComments,
+	EnabledByDefault: true,
+	Description:      "Convert comments of pull requests in tool layer table gitea_comments into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// commentWithPullId carries the id of the pull request the comment belongs to, comments only know its number
+type commentWithPullId struct {
+	models.GiteaComment
+	PullId int
+}
+
+func ConvertPrComments(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMENT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*, pr.gitea_id AS pull_id"),
+		dal.From("_tool_gitea_comments c"),
+		dal.Join("INNER JOIN _tool_gitea_pull_requests pr ON (pr.connection_id = c.connection_id AND pr.repo_id = c.repo_id AND pr.number = c.issue_number)"),
+		dal.Where("c.connection_id = ? AND c.repo_id = ? AND c.is_pull = ?", data.Options.ConnectionId, data.Options.GiteaId, true),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	commentIdGen := didgen.NewDomainIdGenerator(&models.GiteaComment{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.GiteaPullRequest{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GiteaAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(commentWithPullId{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			comment := inputRow.(*commentWithPullId)
+			return []interface{}{
+				&code.PullRequestComment{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: commentIdGen.Generate(data.Options.ConnectionId, comment.GiteaId),
+					},
+					PullRequestId: prIdGen.Generate(data.Options.ConnectionId, comment.PullId),
+					Body:          comment.Body,
+					AccountId:     accountIdGen.Generate(data.Options.ConnectionId, comment.AuthorId),
+					CreatedDate:   comment.GiteaCreatedAt,
+					Type:          code.NORMAL_COMMENT,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
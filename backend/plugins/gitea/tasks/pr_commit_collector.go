@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PR_COMMIT_TABLE = "gitea_api_pull_request_commits"
+
+var CollectPrCommitsMeta = plugin.SubTaskMeta{
+	Name:             "collectPrCommits",
+	EntryPoint:       CollectPrCommits,
+	EnabledByDefault: true,
+	Description:      "Collect commits of pull requests from Gitea api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectPrCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PR_COMMIT_TABLE)
+	apiCollector, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := GetPullRequestsIterator(taskCtx, apiCollector)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    50,
+		Input:       iterator,
+		UrlTemplate: "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}/commits",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query, err := GetQueryForPage(reqData)
+			if err != nil {
+				return nil, err
+			}
+			// skip the signature verification and the file list which are expensive for the server
+			query.Set("verification", "false")
+			query.Set("files", "false")
+			return query, nil
+		},
+		ResponseParser: GetRawMessagesFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
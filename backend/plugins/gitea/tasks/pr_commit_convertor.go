@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertPrCommitsMeta = plugin.SubTaskMeta{
+	Name:             "convertPrCommits",
+	EntryPoint:       ConvertPrCommits,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitea_pull_request_commits into domain layer table pull_request_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPrCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PR_COMMIT_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*"),
+		dal.From("_tool_gitea_pull_request_commits c"),
+		dal.Join("LEFT JOIN _tool_gitea_pull_requests pr ON (pr.connection_id = c.connection_id AND pr.gitea_id = c.pull_id)"),
+		dal.Where("c.connection_id = ? AND pr.repo_id = ?", data.Options.ConnectionId, data.Options.GiteaId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.GiteaPullRequest{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GiteaPrCommit{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			commit := inputRow.(*models.GiteaPrCommit)
+			return []interface{}{
+				&code.PullRequestCommit{
+					CommitSha:          commit.CommitSha,
+					PullRequestId:      prIdGen.Generate(data.Options.ConnectionId, commit.PullId),
+					CommitAuthorName:   commit.CommitAuthorName,
+					CommitAuthorEmail:  commit.CommitAuthorEmail,
+					CommitAuthoredDate: commit.CommitAuthoredDate,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ExtractPrCommitsMeta = plugin.SubTaskMeta{
+	Name:             "extractPrCommits",
+	EntryPoint:       ExtractPrCommits,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull request commits data into tool layer table gitea_pull_request_commits",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+// ApiCommit is the Commit entity of the Gitea REST API
+type ApiCommit struct {
+	Sha    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name  string    `json:"name"`
+			Email string    `json:"email"`
+			Date  time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+func ExtractPrCommits(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_PR_COMMIT_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiCommit]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(commit *ApiCommit, row *api.RawData) ([]interface{}, errors.Error) {
+			if commit.Sha == "" {
+				return nil, nil
+			}
+			pr := &GiteaInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, pr))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{
+				&models.GiteaPrCommit{
+					ConnectionId:       connectionId,
+					PullId:             pr.GiteaId,
+					CommitSha:          commit.Sha,
+					CommitAuthorName:   commit.Commit.Author.Name,
+					CommitAuthorEmail:  commit.Commit.Author.Email,
+					CommitAuthoredDate: commit.Commit.Author.Date,
+					Message:            commit.Commit.Message,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
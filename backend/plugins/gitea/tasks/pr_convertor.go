@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "convertPullRequests",
+	EntryPoint:       ConvertPullRequests,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitea_pull_requests into domain layer table pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GiteaPullRequest{}),
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.GiteaId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.GiteaPullRequest{})
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GiteaRepo{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GiteaAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GiteaPullRequest{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			pr := inputRow.(*models.GiteaPullRequest)
+			domainPr := &code.PullRequest{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: prIdGen.Generate(data.Options.ConnectionId, pr.GiteaId),
+				},
+				BaseRepoId:     repoIdGen.Generate(data.Options.ConnectionId, pr.BaseRepoId),
+				HeadRepoId:     repoIdGen.Generate(data.Options.ConnectionId, pr.HeadRepoId),
+				OriginalStatus: pr.State,
+				Title:          pr.Title,
+				Description:    pr.Body,
+				Url:            pr.HTMLUrl,
+				AuthorName:     pr.AuthorName,
+				AuthorId:       accountIdGen.Generate(data.Options.ConnectionId, pr.AuthorId),
+				PullRequestKey: pr.Number,
+				CreatedDate:    pr.GiteaCreatedAt,
+				MergedDate:     pr.MergedAt,
+				ClosedDate:     pr.ClosedAt,
+				MergeCommitSha: pr.MergeCommitSha,
+				HeadRef:        pr.HeadRef,
+				BaseRef:        pr.BaseRef,
+				BaseCommitSha:  pr.BaseSha,
+				HeadCommitSha:  pr.HeadSha,
+				Additions:      pr.Additions,
+				Deletions:      pr.Deletions,
+				IsDraft:        pr.IsDraft,
+			}
+			switch {
+			case pr.Merged:
+				domainPr.Status = code.MERGED
+			case pr.State == "closed":
+				domainPr.Status = code.CLOSED
+			default:
+				domainPr.Status = code.OPEN
+			}
+			if pr.MergedById != 0 {
+				domainPr.MergedById = accountIdGen.Generate(data.Options.ConnectionId, pr.MergedById)
+				domainPr.MergedByName = pr.MergedByName
+			}
+			return []interface{}{
+				domainPr,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
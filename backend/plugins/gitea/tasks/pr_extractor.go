@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ExtractPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "extractPullRequests",
+	EntryPoint:       ExtractPullRequests,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull requests data into tool layer tables gitea_pull_requests, gitea_pull_request_labels and gitea_reviewers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS, plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+type ApiBranch struct {
+	Ref    string `json:"ref"`
+	Sha    string `json:"sha"`
+	RepoId int    `json:"repo_id"`
+}
+
+type ApiLabel struct {
+	Name string `json:"name"`
+}
+
+// ApiPullRequest is the PullRequest entity of the Gitea REST API
+type ApiPullRequest struct {
+	Id                 int        `json:"id"`
+	Number             int        `json:"number"`
+	User               *ApiUser   `json:"user"`
+	Title              string     `json:"title"`
+	Body               string     `json:"body"`
+	Labels             []ApiLabel `json:"labels"`
+	RequestedReviewers []ApiUser  `json:"requested_reviewers"`
+	State              string     `json:"state"`
+	Draft              bool       `json:"draft"`
+	HTMLUrl            string     `json:"html_url"`
+	Merged             bool       `json:"merged"`
+	MergedAt           *time.Time `json:"merged_at"`
+	MergedBy           *ApiUser   `json:"merged_by"`
+	MergeCommitSha     string     `json:"merge_commit_sha"`
+	Base               ApiBranch  `json:"base"`
+	Head               ApiBranch  `json:"head"`
+	Additions          int        `json:"additions"`
+	Deletions          int        `json:"deletions"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	ClosedAt           *time.Time `json:"closed_at"`
+}
+
+func ExtractPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	db := taskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiPullRequest]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		BeforeExtract: func(pr *ApiPullRequest, stateManager *api.SubtaskStateManager) errors.Error {
+			if !stateManager.IsIncremental() {
+				return nil
+			}
+			// labels and requested reviewers might have been removed since the last collection
+			err := db.Delete(&models.GiteaPrLabel{}, dal.Where("connection_id = ? AND pull_id = ?", connectionId, pr.Id))
+			if err != nil {
+				return err
+			}
+			return db.Delete(&models.GiteaReviewer{}, dal.Where("connection_id = ? AND pull_id = ?", connectionId, pr.Id))
+		},
+		Extract: func(pr *ApiPullRequest, row *api.RawData) ([]interface{}, errors.Error) {
+			if pr.Id == 0 {
+				return nil, nil
+			}
+			results := make([]interface{}, 0, 3+len(pr.Labels)+2*len(pr.RequestedReviewers))
+			giteaPr := &models.GiteaPullRequest{
+				ConnectionId:   connectionId,
+				GiteaId:        pr.Id,
+				RepoId:         data.Options.GiteaId,
+				Number:         pr.Number,
+				State:          pr.State,
+				Title:          pr.Title,
+				Body:           pr.Body,
+				HTMLUrl:        pr.HTMLUrl,
+				Merged:         pr.Merged,
+				IsDraft:        pr.Draft,
+				MergeCommitSha: pr.MergeCommitSha,
+				HeadRef:        pr.Head.Ref,
+				HeadSha:        pr.Head.Sha,
+				HeadRepoId:     pr.Head.RepoId,
+				BaseRef:        pr.Base.Ref,
+				BaseSha:        pr.Base.Sha,
+				BaseRepoId:     pr.Base.RepoId,
+				Additions:      pr.Additions,
+				Deletions:      pr.Deletions,
+				GiteaCreatedAt: pr.CreatedAt,
+				GiteaUpdatedAt: pr.UpdatedAt,
+				MergedAt:       pr.MergedAt,
+				ClosedAt:       pr.ClosedAt,
+			}
+			if pr.User != nil {
+				giteaPr.AuthorId = pr.User.Id
+				giteaPr.AuthorName = pr.User.Login
+				results = append(results, pr.User.toToolLayer(connectionId))
+			}
+			if pr.MergedBy != nil {
+				giteaPr.MergedById = pr.MergedBy.Id
+				giteaPr.MergedByName = pr.MergedBy.Login
+				results = append(results, pr.MergedBy.toToolLayer(connectionId))
+			}
+			results = append(results, giteaPr)
+			for _, label := range pr.Labels {
+				results = append(results, &models.GiteaPrLabel{
+					ConnectionId: connectionId,
+					PullId:       pr.Id,
+					LabelName:    label.Name,
+				})
+			}
+			for _, reviewer := range pr.RequestedReviewers {
+				results = append(results, &models.GiteaReviewer{
+					ConnectionId: connectionId,
+					PullId:       pr.Id,
+					ReviewerId:   reviewer.Id,
+					Login:        reviewer.Login,
+				}, reviewer.toToolLayer(connectionId))
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
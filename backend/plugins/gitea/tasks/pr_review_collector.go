@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+const RAW_PR_REVIEW_TABLE = "gitea_api_pull_request_reviews"
+
+var CollectPrReviewsMeta = plugin.SubTaskMeta{
+	Name:             "collectPrReviews",
+	EntryPoint:       CollectPrReviews,
+	EnabledByDefault: true,
+	Description:      "Collect reviews of pull requests from Gitea api, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func CollectPrReviews(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PR_REVIEW_TABLE)
+	apiCollector, err := api.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	iterator, err := GetPullRequestsIterator(taskCtx, apiCollector)
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:      data.ApiClient,
+		PageSize:       50,
+		Input:          iterator,
+		UrlTemplate:    "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}/reviews",
+		Query:          GetQueryForPage,
+		ResponseParser: GetRawMessagesFromResponse,
+	})
+	if err != nil {
+		return err
+	}
+
+	return apiCollector.Execute()
+}
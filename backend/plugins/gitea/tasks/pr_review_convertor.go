@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertPrReviewsMeta = plugin.SubTaskMeta{
+	Name:             "convertPrReviews",
+	EntryPoint:       ConvertPrReviews,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitea_pull_request_reviews into domain layer table pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertPrReviews(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PR_REVIEW_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("r.*"),
+		dal.From("_tool_gitea_pull_request_reviews r"),
+		dal.Join("LEFT JOIN _tool_gitea_pull_requests pr ON (pr.connection_id = r.connection_id AND pr.gitea_id = r.pull_id)"),
+		dal.Where("r.connection_id = ? AND pr.repo_id = ?", data.Options.ConnectionId, data.Options.GiteaId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	reviewIdGen := didgen.NewDomainIdGenerator(&models.GiteaPrReview{})
+	prIdGen := didgen.NewDomainIdGenerator(&models.GiteaPullRequest{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GiteaAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GiteaPrReview{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			review := inputRow.(*models.GiteaPrReview)
+			reviewId := reviewIdGen.Generate(data.Options.ConnectionId, review.GiteaId)
+			domainComment := &code.PullRequestComment{
+				DomainEntity:  domainlayer.DomainEntity{Id: reviewId},
+				PullRequestId: prIdGen.Generate(data.Options.ConnectionId, review.PullId),
+				Body:          review.Body,
+				AccountId:     accountIdGen.Generate(data.Options.ConnectionId, review.AuthorId),
+				CommitSha:     review.CommitSha,
+				Type:          code.REVIEW,
+				ReviewId:      reviewId,
+				Status:        review.State,
+			}
+			if review.SubmittedAt != nil {
+				domainComment.CreatedDate = *review.SubmittedAt
+			}
+			return []interface{}{
+				domainComment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ExtractPrReviewsMeta = plugin.SubTaskMeta{
+	Name:             "extractPrReviews",
+	EntryPoint:       ExtractPrReviews,
+	EnabledByDefault: true,
+	Description:      "Extract raw pull request reviews data into tool layer tables gitea_pull_request_reviews and gitea_reviewers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+const (
+	// reviewStatePending is the state of a review not submitted yet, it is only visible to its author
+	reviewStatePending = "PENDING"
+	// reviewStateRequestReview is the state of the placeholder review created when a review is requested
+	reviewStateRequestReview = "REQUEST_REVIEW"
+)
+
+// ApiReview is the PullReview entity of the Gitea REST API
+type ApiReview struct {
+	Id          int        `json:"id"`
+	User        *ApiUser   `json:"user"`
+	State       string     `json:"state"`
+	Body        string     `json:"body"`
+	CommitId    string     `json:"commit_id"`
+	Stale       bool       `json:"stale"`
+	HTMLUrl     string     `json:"html_url"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+}
+
+func ExtractPrReviews(taskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(taskCtx, RAW_PR_REVIEW_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[ApiReview]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(review *ApiReview, row *api.RawData) ([]interface{}, errors.Error) {
+			if review.Id == 0 || review.State == reviewStatePending || review.State == reviewStateRequestReview {
+				return nil, nil
+			}
+			pr := &GiteaInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, pr))
+			if err != nil {
+				return nil, err
+			}
+			giteaReview := &models.GiteaPrReview{
+				ConnectionId: connectionId,
+				GiteaId:      review.Id,
+				PullId:       pr.GiteaId,
+				State:        review.State,
+				Body:         review.Body,
+				CommitSha:    review.CommitId,
+				HTMLUrl:      review.HTMLUrl,
+				Stale:        review.Stale,
+				SubmittedAt:  review.SubmittedAt,
+			}
+			results := []interface{}{giteaReview}
+			if review.User != nil {
+				giteaReview.AuthorId = review.User.Id
+				giteaReview.AuthorName = review.User.Login
+				results = append(results, &models.GiteaReviewer{
+					ConnectionId: connectionId,
+					PullId:       pr.GiteaId,
+					ReviewerId:   review.User.Id,
+					Login:        review.User.Login,
+				}, review.User.toToolLayer(connectionId))
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertReposMeta = plugin.SubTaskMeta{
+	Name:             "convertRepos",
+	EntryPoint:       ConvertRepos,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitea_repos into domain layer tables repos, boards, board_repos and cicd_scopes",
+	DomainTypes: []string{
+		plugin.DOMAIN_TYPE_CODE,
+		plugin.DOMAIN_TYPE_TICKET,
+		plugin.DOMAIN_TYPE_CICD,
+		plugin.DOMAIN_TYPE_CODE_REVIEW,
+		plugin.DOMAIN_TYPE_CROSS,
+	},
+}
+
+// GetApiRepo fetches the repo from the Gitea REST API
+func GetApiRepo(op *GiteaOptions, apiClient plugin.ApiClient) (*models.GiteaApiRepo, errors.Error) {
+	res, err := apiClient.Get(fmt.Sprintf("repos/%s", op.Name), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, errors.Default.New(fmt.Sprintf(
+			"unexpected status code when requesting repo detail %d %s",
+			res.StatusCode, res.Request.URL.String(),
+		))
+	}
+	apiRepo := new(models.GiteaApiRepo)
+	err = api.UnmarshalResponse(res, apiRepo)
+	if err != nil {
+		return nil, err
+	}
+	return apiRepo, nil
+}
+
+func ConvertRepos(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, models.GiteaRepo{}.TableName())
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GiteaRepo{}),
+		dal.Where("connection_id = ? AND gitea_id = ?", data.Options.ConnectionId, data.Options.GiteaId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoIdGen := didgen.NewDomainIdGenerator(&models.GiteaRepo{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(models.GiteaRepo{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			repo := inputRow.(*models.GiteaRepo)
+			repoId := repoIdGen.Generate(data.Options.ConnectionId, repo.GiteaId)
+			domainRepo := &code.Repo{
+				DomainEntity: domainlayer.DomainEntity{Id: repoId},
+				Name:         repo.FullName,
+				Url:          repo.HTMLUrl,
+				Description:  repo.Description,
+				Language:     repo.Language,
+				CreatedDate:  repo.CreatedDate,
+				UpdatedDate:  repo.UpdatedDate,
+			}
+			domainBoard := &ticket.Board{
+				DomainEntity: domainlayer.DomainEntity{Id: repoId},
+				Name:         repo.FullName,
+				Url:          fmt.Sprintf("%s/%s", repo.HTMLUrl, "issues"),
+				Description:  repo.Description,
+				CreatedDate:  repo.CreatedDate,
+			}
+			domainBoardRepo := &crossdomain.BoardRepo{
+				BoardId: repoId,
+				RepoId:  repoId,
+			}
+			domainCicdScope := &devops.CicdScope{
+				DomainEntity: domainlayer.DomainEntity{Id: repoId},
+				Name:         repo.FullName,
+				Url:          repo.HTMLUrl,
+				Description:  repo.Description,
+				CreatedDate:  repo.CreatedDate,
+				UpdatedDate:  repo.UpdatedDate,
+			}
+			return []interface{}{
+				domainRepo,
+				domainBoard,
+				domainBoardRepo,
+				domainCicdScope,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
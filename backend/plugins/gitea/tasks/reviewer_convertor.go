@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+var ConvertReviewersMeta = plugin.SubTaskMeta{
+	Name:             "convertReviewers",
+	EntryPoint:       ConvertReviewers,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitea_reviewers into domain layer table pull_request_reviewers",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+}
+
+func ConvertReviewers(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUEST_TABLE)
+	db := taskCtx.GetDal()
+
+	cursor, err := db.Cursor(
+		dal.Select("r.*, a.full_name"),
+		dal.From("_tool_gitea_reviewers r"),
+		dal.Join("LEFT JOIN _tool_gitea_pull_requests pr ON (pr.connection_id = r.connection_id AND pr.gitea_id = r.pull_id)"),
+		dal.Join("LEFT JOIN _tool_gitea_accounts a ON (a.connection_id = r.connection_id AND a.account_id = r.reviewer_id)"),
+		dal.Where("r.connection_id = ? AND pr.repo_id = ?", data.Options.ConnectionId, data.Options.GiteaId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	prIdGen := didgen.NewDomainIdGenerator(&models.GiteaPullRequest{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GiteaAccount{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(reviewerWithName{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			reviewer := inputRow.(*reviewerWithName)
+			return []interface{}{
+				&code.PullRequestReviewer{
+					PullRequestId: prIdGen.Generate(data.Options.ConnectionId, reviewer.PullId),
+					ReviewerId:    accountIdGen.Generate(data.Options.ConnectionId, reviewer.ReviewerId),
+					Name:          reviewer.FullName,
+					UserName:      reviewer.Login,
+				},
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+type reviewerWithName struct {
+	models.GiteaReviewer
+	FullName string
+}
@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+// GiteaInput identifies a pull request when collecting its details, reviews and commits
+type GiteaInput struct {
+	Number  int
+	GiteaId int
+}
+
+// ApiUser is the User entity of the Gitea REST API
+type ApiUser struct {
+	Id        int    `json:"id"`
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	AvatarUrl string `json:"avatar_url"`
+	HTMLUrl   string `json:"html_url"`
+}
+
+func (u *ApiUser) toToolLayer(connectionId uint64) *models.GiteaAccount {
+	return &models.GiteaAccount{
+		ConnectionId: connectionId,
+		AccountId:    u.Id,
+		Login:        u.Login,
+		FullName:     u.FullName,
+		Email:        u.Email,
+		AvatarUrl:    u.AvatarUrl,
+		HTMLUrl:      u.HTMLUrl,
+	}
+}
+
+func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*api.RawDataSubTaskArgs, *GiteaTaskData) {
+	data := taskCtx.GetData().(*GiteaTaskData)
+	rawDataSubTaskArgs := &api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: models.GiteaApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Name:         data.Options.Name,
+		},
+		Table: table,
+	}
+	return rawDataSubTaskArgs, data
+}
+
+func CreateSubtaskCommonArgs(taskCtx plugin.SubTaskContext, table string) (*api.SubtaskCommonArgs, *GiteaTaskData) {
+	data := taskCtx.GetData().(*GiteaTaskData)
+	args := &api.SubtaskCommonArgs{
+		SubTaskContext: taskCtx,
+		Table:          table,
+		Params: models.GiteaApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			Name:         data.Options.Name,
+		},
+	}
+	return args, data
+}
+
+// GetQueryForPage builds the pagination query of the Gitea REST API, which accepts at most 50 items per page
+// by default
+func GetQueryForPage(reqData *api.RequestData) (url.Values, errors.Error) {
+	query := url.Values{}
+	query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+	query.Set("limit", fmt.Sprintf("%v", reqData.Pager.Size))
+	return query, nil
+}
+
+// GetRawMessagesFromResponse parses responses of the Gitea REST API which are JSON arrays
+func GetRawMessagesFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var rawMessages []json.RawMessage
+	err := api.UnmarshalResponse(res, &rawMessages)
+	if err != nil {
+		return nil, err
+	}
+	return rawMessages, nil
+}
+
+// GetRawMessageFromResponse parses responses of the Gitea REST API which are JSON objects
+func GetRawMessageFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var rawMessage json.RawMessage
+	err := api.UnmarshalResponse(res, &rawMessage)
+	if err != nil {
+		return nil, err
+	}
+	return []json.RawMessage{rawMessage}, nil
+}
+
+// parseNumberFromUrl extracts the number of an issue or a pull request from the last segment of its url,
+// e.g. https://gitea.example.com/owner/repo/pulls/12 => 12, 0 is returned if the url is not recognized
+func parseNumberFromUrl(rawUrl string) int {
+	rawUrl = strings.TrimRight(rawUrl, "/")
+	index := strings.LastIndex(rawUrl, "/")
+	if index < 0 {
+		return 0
+	}
+	number, err := strconv.Atoi(rawUrl[index+1:])
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// nullableTime turns the zero time, which Gitea returns for timestamps not set yet, into nil
+func nullableTime(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func GetPullRequestsIterator(taskCtx plugin.SubTaskContext, apiCollector *api.StatefulApiCollector) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GiteaTaskData)
+	clauses := []dal.Clause{
+		dal.Select("pr.number, pr.gitea_id"),
+		dal.From("_tool_gitea_pull_requests pr"),
+		dal.Where(
+			`pr.repo_id = ? and pr.connection_id = ?`,
+			data.Options.GiteaId, data.Options.ConnectionId,
+		),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("pr.gitea_updated_at > ?", *apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(GiteaInput{}))
+}
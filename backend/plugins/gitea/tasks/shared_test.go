@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNumberFromUrl(t *testing.T) {
+	assert.Equal(t, 12, parseNumberFromUrl("https://gitea.example.com/owner/repo/pulls/12"))
+	assert.Equal(t, 3, parseNumberFromUrl("https://example.com/gitea/owner/repo/issues/3/"))
+	assert.Equal(t, 0, parseNumberFromUrl("https://gitea.example.com/owner/repo"))
+	assert.Equal(t, 0, parseNumberFromUrl(""))
+}
+
+func TestNullableTime(t *testing.T) {
+	assert.Nil(t, nullableTime(nil))
+	assert.Nil(t, nullableTime(&time.Time{}))
+	now := time.Now()
+	assert.Equal(t, &now, nullableTime(&now))
+}
+
+func TestIssueTypeRegexes(t *testing.T) {
+	regexes, err := newIssueTypeRegexes(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "", regexes.StdType("bug"))
+
+	regexes, err = newIssueTypeRegexes(&models.GiteaScopeConfig{
+		IssueTypeBug:         "^(kind/)?bug$",
+		IssueTypeRequirement: "feature",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, ticket.BUG, regexes.StdType("kind/bug"))
+	assert.Equal(t, ticket.REQUIREMENT, regexes.StdType("feature-request"))
+	assert.Equal(t, "", regexes.StdType("question"))
+
+	_, err = newIssueTypeRegexes(&models.GiteaScopeConfig{IssueTypeIncident: "("})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitea/models"
+)
+
+type GiteaOptions struct {
+	ConnectionId             uint64   `json:"connectionId" mapstructure:"connectionId,omitempty"`
+	GiteaId                  int      `json:"giteaId" mapstructure:"giteaId,omitempty"`
+	Name                     string   `json:"name" mapstructure:"name,omitempty"` // the full name of the repo, e.g. owner/repo
+	ScopeConfigId            uint64   `json:"scopeConfigId" mapstructure:"scopeConfigId,omitempty"`
+	Tasks                    []string `json:"tasks,omitempty" mapstructure:",omitempty"`
+	*models.GiteaScopeConfig `mapstructure:"scopeConfig,omitempty" json:"scopeConfig"`
+}
+
+type GiteaTaskData struct {
+	Options       *GiteaOptions
+	ApiClient     *api.ApiAsyncClient
+	RegexEnricher *api.RegexEnricher
+}
+
+func DecodeAndValidateTaskOptions(options map[string]interface{}) (*GiteaOptions, errors.Error) {
+	op, err := DecodeTaskOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = ValidateTaskOptions(op)
+	if err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func DecodeTaskOptions(options map[string]interface{}) (*GiteaOptions, errors.Error) {
+	var op GiteaOptions
+	err := api.Decode(options, &op, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+func ValidateTaskOptions(op *GiteaOptions) errors.Error {
+	if op.Name == "" && op.GiteaId == 0 {
+		return errors.BadInput.New("either name or giteaId is required for Gitea execution")
+	}
+	if op.ConnectionId == 0 {
+		return errors.BadInput.New("connectionId is invalid")
+	}
+	return nil
+}
+
+// NewRegexEnricher compiles the cicd related patterns of the scope config
+func NewRegexEnricher(config *models.GiteaScopeConfig) (*api.RegexEnricher, errors.Error) {
+	regexEnricher := api.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(devops.DEPLOYMENT, config.DeploymentPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.PRODUCTION, config.ProductionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
+	}
+	return regexEnricher, nil
+}
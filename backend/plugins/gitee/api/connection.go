@@ -187,7 +187,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Router /plugins/gitee/connections [GET]
 func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	var connections []models.GiteeConnection
-	err := connectionHelper.List(&connections)
+	err := connectionHelper.ListByWorkspace(&connections, input.Workspace)
 	if err != nil {
 		return nil, err
 	}
@@ -28,5 +28,6 @@ func All() []plugin.MigrationScript {
 		new(addGiteeCommitAuthorInfo),
 		new(addScopeConfigIdToRepo),
 		new(changeIssueComponentType),
+		new(addTlsFieldsToConnections),
 	}
 }
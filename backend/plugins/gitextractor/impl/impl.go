@@ -25,6 +25,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/secrethelper"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/tasks"
 	giturls "github.com/chainguard-dev/git-urls"
@@ -96,6 +97,25 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 		return nil, errors.BadInput.Wrap(err, "failed to parse git url")
 	}
 
+	// the blueprints put the secret references of the connections into the urls as they are
+	if parsedURL.User != nil {
+		if password, ok := parsedURL.User.Password(); ok && secrethelper.IsReference(password) {
+			secret, err := resolveConnectionReference(taskCtx, &op, password)
+			if err != nil {
+				return nil, err
+			}
+			parsedURL.User = url.UserPassword(parsedURL.User.Username(), secret)
+			op.Url = parsedURL.String()
+		}
+	}
+	if secrethelper.IsReference(op.Password) {
+		password, err := resolveConnectionReference(taskCtx, &op, op.Password)
+		if err != nil {
+			return nil, err
+		}
+		op.Password = password
+	}
+
 	// append username to the git url
 	if op.User != "" {
 		parsedURL.User = url.UserPassword(op.User, op.Password)
@@ -126,9 +146,67 @@ func (p GitExtractor) PrepareTaskData(taskCtx plugin.TaskContext, options map[st
 		Options:   &op,
 		ParsedURL: parsedURL,
 	}
+	if parsedURL.Scheme == "https" && op.PluginName != "" && op.ConnectionId != 0 {
+		if err := loadConnectionTls(taskCtx, &op, taskData); err != nil {
+			return nil, err
+		}
+	}
 	return taskData, nil
 }
 
+// loadConnection loads the connection the repo belongs to, nil is returned if the plugin has no connections
+func loadConnection(taskCtx plugin.TaskContext, op *parser.GitExtractorOptions) (dal.Tabler, errors.Error) {
+	pluginInstance, err := plugin.GetPlugin(op.PluginName)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to get plugin instance for plugin: %s", op.PluginName))
+	}
+	pluginSource, ok := pluginInstance.(plugin.PluginSource)
+	if !ok {
+		return nil, nil
+	}
+	connection := pluginSource.Connection()
+	err = taskCtx.GetDal().First(connection, dal.Where("id = ?", op.ConnectionId))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to get %s connection %d", op.PluginName, op.ConnectionId))
+	}
+	return connection, nil
+}
+
+// loadConnectionTls copies the CA bundle and the client certificate of the connection to the task data
+func loadConnectionTls(taskCtx plugin.TaskContext, op *parser.GitExtractorOptions, taskData *parser.GitExtractorTaskData) errors.Error {
+	connection, err := loadConnection(taskCtx, op)
+	if err != nil {
+		return err
+	}
+	if _, ok := connection.(plugin.TlsConnection); !ok {
+		return nil
+	}
+	connection, err = helper.ResolveConnectionSecrets(taskCtx.GetContext(), taskCtx, op.ConnectionId, connection)
+	if err != nil {
+		return err
+	}
+	tlsConnection := connection.(plugin.TlsConnection)
+	taskData.CaCert = tlsConnection.GetCaCert()
+	taskData.ClientCert = tlsConnection.GetClientCert()
+	taskData.ClientKey = tlsConnection.GetClientKey()
+	return nil
+}
+
+// resolveConnectionReference resolves the secret reference with the connection of the plugin the repo belongs to
+func resolveConnectionReference(taskCtx plugin.TaskContext, op *parser.GitExtractorOptions, reference string) (string, errors.Error) {
+	if op.PluginName == "" || op.ConnectionId == 0 {
+		return "", errors.BadInput.New("secret references are only resolved for the repos of a connection")
+	}
+	connection, err := loadConnection(taskCtx, op)
+	if err != nil {
+		return "", err
+	}
+	if connection == nil {
+		return "", errors.BadInput.New(fmt.Sprintf("plugin %s has no connections", op.PluginName))
+	}
+	return helper.ResolveConnectionReference(taskCtx.GetContext(), taskCtx, op.ConnectionId, connection, reference)
+}
+
 func (p GitExtractor) Close(taskCtx plugin.TaskContext) errors.Error {
 	if taskData, ok := taskCtx.GetData().(*parser.GitExtractorTaskData); ok {
 		if !taskCtx.GetConfigReader().GetBool("GIT_EXTRACTOR_KEEP_REPO") {
@@ -56,6 +56,7 @@ type GitcliCloner struct {
 	success      bool
 	syncEnvs     []string
 	syncArgs     []string
+	tempFiles    []string
 }
 
 func NewGitcliCloner(ctx plugin.SubTaskContext, localDir string) (*GitcliCloner, errors.Error) {
@@ -81,7 +82,11 @@ func NewGitcliCloner(ctx plugin.SubTaskContext, localDir string) (*GitcliCloner,
 		localDir:     localDir,
 		success:      false,
 	}
-	return cloner, cloner.prepareSync()
+	if err := cloner.prepareSync(); err != nil {
+		cloner.removeTempFiles()
+		return nil, err
+	}
+	return cloner, nil
 }
 
 func (g *GitcliCloner) prepareSync() errors.Error {
@@ -101,6 +106,11 @@ func (g *GitcliCloner) prepareSync() errors.Error {
 		if remoteUrl.Scheme == "https" && g.ctx.GetConfigReader().GetBool("IN_SECURE_SKIP_VERIFY") {
 			g.syncEnvs = append(g.syncEnvs, "GIT_SSL_NO_VERIFY=true")
 		}
+		if remoteUrl.Scheme == "https" {
+			if err := g.prepareTls(); err != nil {
+				return err
+			}
+		}
 	} else if remoteUrl.Scheme == "ssh" {
 		var sshCmdArgs []string
 		if taskData.Options.Proxy != "" {
@@ -152,6 +162,59 @@ func (g *GitcliCloner) prepareSync() errors.Error {
 	return nil
 }
 
+// prepareTls hands the CA bundle and the client certificate of the connection over to git, the files are
+// kept until the repo is closed since git reads them while cloning
+func (g *GitcliCloner) prepareTls() errors.Error {
+	taskData := g.taskData
+	if strings.TrimSpace(taskData.ClientCert) != "" || strings.TrimSpace(taskData.ClientKey) != "" {
+		if strings.TrimSpace(taskData.ClientCert) == "" || strings.TrimSpace(taskData.ClientKey) == "" {
+			return errors.BadInput.New("client certificate and client key must be provided together")
+		}
+	}
+	tlsEnvs := []struct {
+		name    string
+		content string
+	}{
+		{"GIT_SSL_CAINFO", taskData.CaCert},
+		{"GIT_SSL_CERT", taskData.ClientCert},
+		{"GIT_SSL_KEY", taskData.ClientKey},
+	}
+	for _, tlsEnv := range tlsEnvs {
+		if strings.TrimSpace(tlsEnv.content) == "" {
+			continue
+		}
+		fileName, err := g.writeTempFile(strings.TrimSpace(tlsEnv.content) + "\n")
+		if err != nil {
+			return err
+		}
+		g.syncEnvs = append(g.syncEnvs, fmt.Sprintf("%s=%s", tlsEnv.name, fileName))
+	}
+	return nil
+}
+
+func (g *GitcliCloner) writeTempFile(content string) (string, errors.Error) {
+	file, e := os.CreateTemp("", "gitext-tls")
+	if e != nil {
+		return "", errors.Default.Wrap(e, "failed to create the tls file")
+	}
+	g.tempFiles = append(g.tempFiles, file.Name())
+	defer file.Close()
+	if e := file.Chmod(0600); e != nil {
+		return "", errors.Default.Wrap(e, "failed to modify the tls file")
+	}
+	if _, e := file.WriteString(content); e != nil {
+		return "", errors.Default.Wrap(e, "failed to write the tls file")
+	}
+	return file.Name(), nil
+}
+
+func (g *GitcliCloner) removeTempFiles() {
+	for _, name := range g.tempFiles {
+		_ = os.Remove(name)
+	}
+	g.tempFiles = nil
+}
+
 func (g *GitcliCloner) IsIncremental() bool {
 	if g != nil && g.stateManager != nil {
 		if g.stateManager.GetSince() != nil {
@@ -192,6 +255,7 @@ func (g *GitcliCloner) CloneRepo() errors.Error {
 }
 
 func (g *GitcliCloner) CloseRepo() errors.Error {
+	g.removeTempFiles()
 	if g.success {
 		g.logger.Info("save state")
 		return g.stateManager.Close()
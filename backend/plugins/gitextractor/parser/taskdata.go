@@ -26,6 +26,11 @@ type GitExtractorTaskData struct {
 	ParsedURL       *url.URL
 	GitRepo         RepoCollector
 	SkipAllSubtasks bool // silently skip all tasks without raising errors
+	// TLS settings of the connection the repo belongs to, they are loaded from the connection instead of
+	// the options so the client key would never be stored in the pipeline plans
+	CaCert     string
+	ClientCert string
+	ClientKey  string
 }
 
 type GitExtractorApiParams struct {
@@ -60,6 +60,7 @@ func CloneGitRepo(subTaskCtx plugin.SubTaskContext) errors.Error {
 	}
 	err = repoCloner.CloneRepo()
 	if err != nil {
+		_ = repoCloner.CloseRepo()
 		if errors.Is(err, parser.ErrNoData) {
 			taskData.SkipAllSubtasks = true
 			return nil
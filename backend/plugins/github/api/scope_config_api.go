@@ -109,3 +109,20 @@ func GetProjectsByScopeConfig(input *plugin.ApiResourceInput) (*plugin.ApiResour
 func DeleteScopeConfig(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return dsHelper.ScopeConfigApi.Delete(input)
 }
+
+// DryRunScopeConfig evaluate a draft scope config against the collected data of a scope
+// @Summary evaluate a draft scope config against the collected data of a scope
+// @Description return how many runs/jobs would be classified as (production) deployments and how many issues/prs would be typed, along with samples, nothing would be saved
+// @Tags plugins/github
+// @Accept application/json
+// @Param connectionId path int true "connectionId"
+// @Param scopeId path int true "scopeId"
+// @Param sampleSize query int false "max number of samples per category, default 10"
+// @Param scopeConfig body models.GithubScopeConfig true "draft scope config"
+// @Success 200  {object} api.ScopeConfigDryRunResult
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/github/connections/{connectionId}/scopes/{scopeId}/scope-config-dry-run [POST]
+func DryRunScopeConfig(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeConfigApi.DryRun(input, evaluateScopeConfig)
+}
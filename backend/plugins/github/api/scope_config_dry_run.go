@@ -0,0 +1,254 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+)
+
+type dryRunCicdSample struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	HeadBranch  string `json:"headBranch,omitempty"`
+	Environment string `json:"environment"`
+}
+
+type dryRunLabeledSample struct {
+	Id     int      `json:"id"`
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	Labels []string `json:"labels"`
+}
+
+type labeledRow struct {
+	GithubId  int
+	Number    int
+	Title     string
+	LabelName string
+}
+
+// scopeConfigEvaluator mirrors how the extractors apply the scope config to runs, jobs, issues and pull requests
+type scopeConfigEvaluator struct {
+	regexEnricher *api.RegexEnricher
+	issueRegexes  *tasks.IssueRegexes
+	prTypeRegex   *regexp.Regexp
+	result        *api.ScopeConfigDryRunResult
+}
+
+func newScopeConfigEvaluator(draft *models.GithubScopeConfig, result *api.ScopeConfigDryRunResult) (*scopeConfigEvaluator, errors.Error) {
+	regexEnricher, err := tasks.NewRegexEnricher(draft)
+	if err != nil {
+		return nil, err
+	}
+	issueRegexes, err := tasks.NewIssueRegexes(draft)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid issue patterns")
+	}
+	var prTypeRegex *regexp.Regexp
+	if draft.PrType != "" {
+		prTypeRegex, err = errors.Convert01(regexp.Compile(draft.PrType))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid value for `prType`")
+		}
+	}
+	return &scopeConfigEvaluator{
+		regexEnricher: regexEnricher,
+		issueRegexes:  issueRegexes,
+		prTypeRegex:   prTypeRegex,
+		result:        result,
+	}, nil
+}
+
+// evaluateScopeConfig is the ScopeConfigDryRunEvaluator of github
+func evaluateScopeConfig(repo *models.GithubRepo, draft *models.GithubScopeConfig, result *api.ScopeConfigDryRunResult) errors.Error {
+	evaluator, err := newScopeConfigEvaluator(draft, result)
+	if err != nil {
+		return err
+	}
+	return evaluator.evaluate(basicRes.GetDal(), repo)
+}
+
+func (e *scopeConfigEvaluator) evaluate(db dal.Dal, repo *models.GithubRepo) errors.Error {
+	// workflow runs
+	runs, err := db.Cursor(
+		dal.From(&models.GithubRun{}),
+		dal.Where("connection_id = ? AND repo_id = ?", repo.ConnectionId, repo.GithubId),
+	)
+	if err != nil {
+		return err
+	}
+	defer runs.Close()
+	for runs.Next() {
+		run := &models.GithubRun{}
+		if err = db.Fetch(runs, run); err != nil {
+			return err
+		}
+		e.evaluateRun(run)
+	}
+
+	// jobs
+	jobs, err := db.Cursor(
+		dal.From(&models.GithubJob{}),
+		dal.Where("connection_id = ? AND repo_id = ?", repo.ConnectionId, repo.GithubId),
+	)
+	if err != nil {
+		return err
+	}
+	defer jobs.Close()
+	for jobs.Next() {
+		job := &models.GithubJob{}
+		if err = db.Fetch(jobs, job); err != nil {
+			return err
+		}
+		e.evaluateJob(job)
+	}
+
+	// issues
+	err = evaluateLabeledRows(
+		db,
+		dal.From("_tool_github_issues i"),
+		dal.Join("LEFT JOIN _tool_github_issue_labels l ON (l.connection_id = i.connection_id AND l.issue_id = i.github_id)"),
+		dal.Where("i.connection_id = ? AND i.repo_id = ?", repo.ConnectionId, repo.GithubId),
+		e.evaluateIssue,
+	)
+	if err != nil {
+		return err
+	}
+
+	// pull requests
+	return evaluateLabeledRows(
+		db,
+		dal.From("_tool_github_pull_requests i"),
+		dal.Join("LEFT JOIN _tool_github_pull_request_labels l ON (l.connection_id = i.connection_id AND l.pull_id = i.github_id)"),
+		dal.Where("i.connection_id = ? AND i.repo_id = ?", repo.ConnectionId, repo.GithubId),
+		e.evaluatePullRequest,
+	)
+}
+
+func (e *scopeConfigEvaluator) evaluateRun(run *models.GithubRun) {
+	runTable := run.TableName()
+	e.result.Evaluate(runTable)
+	if e.regexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, run.Name) == "" {
+		return
+	}
+	sample := &dryRunCicdSample{
+		Id:          run.ID,
+		Name:        run.Name,
+		HeadBranch:  run.HeadBranch,
+		Environment: e.regexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, run.Name, run.HeadBranch),
+	}
+	e.result.Match(runTable, devops.DEPLOYMENT, sample)
+	if sample.Environment == devops.PRODUCTION {
+		e.result.Match(runTable, devops.PRODUCTION, sample)
+	}
+}
+
+func (e *scopeConfigEvaluator) evaluateJob(job *models.GithubJob) {
+	jobTable := job.TableName()
+	e.result.Evaluate(jobTable)
+	if e.regexEnricher.ReturnNameIfMatched(devops.DEPLOYMENT, job.Name) == "" {
+		return
+	}
+	sample := &dryRunCicdSample{
+		Id:          job.ID,
+		Name:        job.Name,
+		Environment: e.regexEnricher.ReturnNameIfOmittedOrMatched(devops.PRODUCTION, job.Name),
+	}
+	e.result.Match(jobTable, devops.DEPLOYMENT, sample)
+	if sample.Environment == devops.PRODUCTION {
+		e.result.Match(jobTable, devops.PRODUCTION, sample)
+	}
+}
+
+// evaluateIssue lets the last matched label win just like the extractor does
+func (e *scopeConfigEvaluator) evaluateIssue(sample *dryRunLabeledSample) {
+	issueTable := models.GithubIssue{}.TableName()
+	e.result.Evaluate(issueTable)
+	stdType := ""
+	for _, label := range sample.Labels {
+		if t := e.issueRegexes.StdType(label); t != "" {
+			stdType = t
+		}
+	}
+	if stdType != "" {
+		e.result.Match(issueTable, stdType, sample)
+	}
+}
+
+func (e *scopeConfigEvaluator) evaluatePullRequest(sample *dryRunLabeledSample) {
+	prTable := models.GithubPullRequest{}.TableName()
+	e.result.Evaluate(prTable)
+	if e.prTypeRegex == nil {
+		return
+	}
+	for _, label := range sample.Labels {
+		if e.prTypeRegex.MatchString(label) {
+			e.result.Match(prTable, "PR_TYPE", sample)
+			return
+		}
+	}
+}
+
+// evaluateLabeledRows groups the labels by issue/pull request and feeds them to the callback one by one, the labels
+// are sorted by name so that the last matching label is picked deterministically
+func evaluateLabeledRows(db dal.Dal, from, join, where dal.Clause, callback func(sample *dryRunLabeledSample)) errors.Error {
+	cursor, err := db.Cursor(
+		dal.Select("i.github_id, i.number, i.title, l.label_name"),
+		from,
+		join,
+		where,
+		dal.Orderby("i.github_id, l.label_name"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	var sample *dryRunLabeledSample
+	for cursor.Next() {
+		row := &labeledRow{}
+		if err = db.Fetch(cursor, row); err != nil {
+			return err
+		}
+		if sample != nil && sample.Id != row.GithubId {
+			callback(sample)
+			sample = nil
+		}
+		if sample == nil {
+			sample = &dryRunLabeledSample{
+				Id:     row.GithubId,
+				Number: row.Number,
+				Title:  row.Title,
+				Labels: make([]string, 0),
+			}
+		}
+		if row.LabelName != "" {
+			sample.Labels = append(sample.Labels, row.LabelName)
+		}
+	}
+	if sample != nil {
+		callback(sample)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// dryRunDal serves the rows of each table through Cursor/Fetch, anything else panics
+type dryRunDal struct {
+	dal.Dal
+	rows map[interface{}][]interface{}
+}
+
+type dryRunRows struct {
+	dal.Rows
+	items []interface{}
+	index int
+}
+
+func (r *dryRunRows) Next() bool {
+	r.index++
+	return r.index <= len(r.items)
+}
+
+func (r *dryRunRows) Close() error {
+	return nil
+}
+
+func (d *dryRunDal) Cursor(clauses ...dal.Clause) (dal.Rows, errors.Error) {
+	for _, clause := range clauses {
+		if clause.Type != dal.FromClause {
+			continue
+		}
+		key := clause.Data
+		if _, ok := key.(string); !ok {
+			key = reflect.TypeOf(key).String()
+		}
+		return &dryRunRows{items: d.rows[key]}, nil
+	}
+	return nil, errors.Default.New("missing from clause")
+}
+
+func (d *dryRunDal) Fetch(cursor dal.Rows, dst interface{}) errors.Error {
+	rows := cursor.(*dryRunRows)
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(rows.items[rows.index-1]).Elem())
+	return nil
+}
+
+func TestScopeConfigEvaluator(t *testing.T) {
+	db := &dryRunDal{rows: map[interface{}][]interface{}{
+		"*models.GithubRun": {
+			&models.GithubRun{ID: 1, Name: "deploy-prod", HeadBranch: "main"},
+			&models.GithubRun{ID: 2, Name: "deploy-staging", HeadBranch: "main"},
+			&models.GithubRun{ID: 3, Name: "unit-test", HeadBranch: "main"},
+		},
+		"*models.GithubJob": {
+			&models.GithubJob{ID: 11, Name: "deploy"},
+			&models.GithubJob{ID: 12, Name: "lint"},
+		},
+		"_tool_github_issues i": {
+			&labeledRow{GithubId: 21, Number: 1, Title: "crash", LabelName: "type/bug"},
+			&labeledRow{GithubId: 21, Number: 1, Title: "crash", LabelName: "severity/incident"},
+			&labeledRow{GithubId: 22, Number: 2, Title: "feature", LabelName: "type/feature"},
+			&labeledRow{GithubId: 23, Number: 3, Title: "question"},
+		},
+		"_tool_github_pull_requests i": {
+			&labeledRow{GithubId: 31, Number: 4, Title: "fix", LabelName: "kind/fix"},
+			&labeledRow{GithubId: 32, Number: 5, Title: "docs", LabelName: "kind/docs"},
+		},
+	}}
+	draft := &models.GithubScopeConfig{
+		DeploymentPattern: "deploy",
+		ProductionPattern: "prod",
+		IssueTypeBug:      "bug",
+		IssueTypeIncident: "incident",
+		PrType:            "fix",
+	}
+	result := api.NewScopeConfigDryRunResult(10)
+	evaluator, err := newScopeConfigEvaluator(draft, result)
+	assert.Nil(t, err)
+	assert.Nil(t, evaluator.evaluate(db, &models.GithubRepo{GithubId: 1}))
+
+	runTable := models.GithubRun{}.TableName()
+	jobTable := models.GithubJob{}.TableName()
+	issueTable := models.GithubIssue{}.TableName()
+	prTable := models.GithubPullRequest{}.TableName()
+	assert.Equal(t, map[string]int{runTable: 3, jobTable: 2, issueTable: 3, prTable: 2}, result.Evaluated)
+
+	counts := make(map[string]int)
+	for _, match := range result.Matches {
+		counts[match.Table+"/"+match.Category] = match.Count
+	}
+	assert.Equal(t, map[string]int{
+		runTable + "/" + devops.DEPLOYMENT: 2,
+		runTable + "/" + devops.PRODUCTION: 1,
+		jobTable + "/" + devops.DEPLOYMENT: 1,
+		// the last matched label wins
+		issueTable + "/" + ticket.INCIDENT: 1,
+		prTable + "/PR_TYPE":               1,
+	}, counts)
+}
+
+func TestScopeConfigEvaluatorOmittedProductionPattern(t *testing.T) {
+	result := api.NewScopeConfigDryRunResult(10)
+	evaluator, err := newScopeConfigEvaluator(&models.GithubScopeConfig{DeploymentPattern: "deploy"}, result)
+	assert.Nil(t, err)
+	evaluator.evaluateJob(&models.GithubJob{ID: 1, Name: "deploy"})
+	evaluator.evaluateJob(&models.GithubJob{ID: 2, Name: "build"})
+	// every deployment is a production deployment when productionPattern is omitted
+	assert.Len(t, result.Matches, 2)
+	assert.Equal(t, devops.PRODUCTION, result.Matches[1].Category)
+	assert.Equal(t, 1, result.Matches[1].Count)
+	// nothing would be typed without the patterns
+	evaluator.evaluatePullRequest(&dryRunLabeledSample{Id: 1, Labels: []string{"fix"}})
+	evaluator.evaluateIssue(&dryRunLabeledSample{Id: 2, Labels: []string{"bug"}})
+	assert.Len(t, result.Matches, 2)
+}
+
+func TestScopeConfigEvaluatorInvalidPatterns(t *testing.T) {
+	for name, draft := range map[string]*models.GithubScopeConfig{
+		"deploymentPattern": {DeploymentPattern: "("},
+		"productionPattern": {ProductionPattern: "("},
+		"issueTypeBug":      {IssueTypeBug: "("},
+		"prType":            {PrType: "("},
+	} {
+		_, err := newScopeConfigEvaluator(draft, api.NewScopeConfigDryRunResult(10))
+		assert.NotNil(t, err, name)
+		assert.Equal(t, errors.BadInput, err.GetType(), name)
+	}
+}
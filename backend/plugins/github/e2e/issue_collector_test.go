@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	coremodels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/impl"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/apache/incubator-devlake/plugins/github/tasks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueCollectorDataFlow(t *testing.T) {
+	var plugin impl.Github
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "github", plugin)
+
+	connection := &models.GithubConnection{}
+	connection.Endpoint = "https://api.github.com/"
+	taskData := &tasks.GithubTaskData{
+		Options: &tasks.GithubOptions{
+			ConnectionId: 1,
+			Name:         "panjf2000/ants",
+			GithubId:     134018330,
+		},
+		// the cassette holds a full collection of 2 pages followed by an incremental one
+		ApiClient: dataflowTester.NewCassetteApiClient("./cassettes/issues.json", connection, "since"),
+	}
+
+	dataflowTester.FlushTabler(&coremodels.CollectorLatestState{})
+	dataflowTester.FlushTabler(&coremodels.CollectorCheckpoint{})
+	dataflowTester.FlushRawTable("_raw_" + tasks.RAW_ISSUE_TABLE)
+
+	// full collection goes through all pages
+	dataflowTester.Subtask(tasks.CollectApiIssuesMeta, taskData)
+	var rawIssues []api.RawData
+	assert.Nil(t, dataflowTester.Dal.All(&rawIssues, dal.From("_raw_"+tasks.RAW_ISSUE_TABLE), dal.Orderby("id")))
+	assert.Len(t, rawIssues, 3)
+
+	// incremental collection only requests the issues updated since the previous run and keeps the collected ones
+	dataflowTester.IncrementalSubtask(tasks.CollectApiIssuesMeta, taskData)
+	rawIssues = nil
+	assert.Nil(t, dataflowTester.Dal.All(&rawIssues, dal.From("_raw_"+tasks.RAW_ISSUE_TABLE), dal.Orderby("id")))
+	assert.Len(t, rawIssues, 4)
+	assert.Contains(t, string(rawIssues[3].Data), `"state": "closed"`)
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
-	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/github/api"
@@ -147,15 +146,9 @@ func (p Github) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]i
 		return nil, err
 	}
 
-	regexEnricher := helper.NewRegexEnricher()
-	if err = regexEnricher.TryAdd(devops.DEPLOYMENT, op.ScopeConfig.DeploymentPattern); err != nil {
-		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
-	}
-	if err = regexEnricher.TryAdd(devops.PRODUCTION, op.ScopeConfig.ProductionPattern); err != nil {
-		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
-	}
-	if err = regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, op.ScopeConfig.EnvNamePattern); err != nil {
-		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
+	regexEnricher, err := tasks.NewRegexEnricher(op.ScopeConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	taskData := &tasks.GithubTaskData{
@@ -200,6 +193,9 @@ func (p Github) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
 			"GET": api.GetScopeLatestSyncState,
 		},
+		"connections/:connectionId/scopes/:scopeId/scope-config-dry-run": {
+			"POST": api.DryRunScopeConfig,
+		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopes,
 			"PUT": api.PutScopes,
@@ -256,6 +252,10 @@ func (p Github) GetDynamicGitUrl(taskCtx plugin.TaskContext, connectionId uint64
 	if err != nil {
 		return "", errors.Default.Wrap(err, "unable to get github connection by the given connection ID")
 	}
+	connection, err = helper.ResolveConnectionSecrets(taskCtx.GetContext(), taskCtx, connectionId, connection)
+	if err != nil {
+		return "", err
+	}
 
 	apiClient, err := helper.NewApiClient(taskCtx.GetContext(), connection.GetEndpoint(), nil, 0, connection.GetProxy(), taskCtx)
 	if err != nil {
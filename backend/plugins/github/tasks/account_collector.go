@@ -77,9 +77,10 @@ func CollectAccounts(taskCtx plugin.SubTaskContext) errors.Error {
 			},
 			Table: RAW_ACCOUNT_TABLE,
 		},
-		ApiClient:   data.ApiClient,
-		Input:       iterator,
-		UrlTemplate: "/users/{{ .Input.Login }}",
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "/users/{{ .Input.Login }}",
+		ConditionalRequest: true,
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			body, err := io.ReadAll(res.Body)
 			if err != nil {
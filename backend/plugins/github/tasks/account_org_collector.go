@@ -87,9 +87,10 @@ func CollectAccountOrg(taskCtx plugin.SubTaskContext) errors.Error {
 			},
 			Table: RAW_ACCOUNT_ORG_TABLE,
 		},
-		ApiClient:   data.ApiClient,
-		Input:       iterator,
-		UrlTemplate: "/users/{{ .Input.Login }}/orgs",
+		ApiClient:          data.ApiClient,
+		Input:              iterator,
+		UrlTemplate:        "/users/{{ .Input.Login }}/orgs",
+		ConditionalRequest: true,
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 			body, err := io.ReadAll(res.Body)
 			if err != nil {
@@ -95,6 +95,7 @@ func CollectJobs(taskCtx plugin.SubTaskContext) errors.Error {
 			Table: RAW_JOB_TABLE,
 		},
 		ApiClient:   data.ApiClient,
+		Resumable:   true,
 		PageSize:    100,
 		Input:       iterator,
 		UrlTemplate: "repos/{{ .Params.Name }}/actions/runs/{{ .Input.ID }}/jobs",
@@ -73,7 +73,8 @@ func CollectRuns(taskCtx plugin.SubTaskContext) errors.Error {
 			PageSize:    PAGE_SIZE,
 			Concurrency: 10,
 			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
-				UrlTemplate: "repos/{{ .Params.Name }}/actions/runs",
+				UrlTemplate:        "repos/{{ .Params.Name }}/actions/runs",
+				ConditionalRequest: true,
 				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
 					query := url.Values{}
 					// GitHub API returns only the first 34 pages (with a size of 30) when specifying status=compleleted, try the following API request to verify the problem.
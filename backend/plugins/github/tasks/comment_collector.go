@@ -59,9 +59,11 @@ func CollectApiComments(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 
 	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
-		ApiClient:   data.ApiClient,
-		PageSize:    100,
-		UrlTemplate: "repos/{{ .Params.Name }}/issues/comments",
+		ApiClient:          data.ApiClient,
+		Resumable:          true,
+		PageSize:           100,
+		UrlTemplate:        "repos/{{ .Params.Name }}/issues/comments",
+		ConditionalRequest: true,
 		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
 			query.Set("state", "all")
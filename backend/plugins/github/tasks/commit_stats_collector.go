@@ -98,6 +98,7 @@ func CollectApiCommitStats(taskCtx plugin.SubTaskContext) errors.Error {
 			Table: RAW_COMMIT_STATS_TABLE,
 		},
 		ApiClient: data.ApiClient,
+		Resumable: true,
 		PageSize:  100,
 		Input:     iterator,
 		/*
@@ -60,6 +60,7 @@ func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
 
 	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
 		ApiClient: data.ApiClient,
+		Resumable: true,
 		PageSize:  100,
 		/*
 			url may use arbitrary variables from different source in any order, we need GoTemplate to allow more
@@ -70,7 +71,8 @@ func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
 			avoid duplicate logic for every tasks, and when we have a better idea like improving performance, we can
 			do it in one place
 		*/
-		UrlTemplate: "repos/{{ .Params.Name }}/issues",
+		UrlTemplate:        "repos/{{ .Params.Name }}/issues",
+		ConditionalRequest: true,
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
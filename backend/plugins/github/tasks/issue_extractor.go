@@ -221,12 +221,8 @@ func convertGithubLabels(issueRegexes *IssueRegexes, issue *IssuesResponse, gith
 		if issueRegexes.PriorityRegex != nil && issueRegexes.PriorityRegex.MatchString(label.Name) {
 			githubIssue.Priority = label.Name
 		}
-		if issueRegexes.TypeRequirementRegex != nil && issueRegexes.TypeRequirementRegex.MatchString(label.Name) {
-			githubIssue.StdType = ticket.REQUIREMENT
-		} else if issueRegexes.TypeBugRegex != nil && issueRegexes.TypeBugRegex.MatchString(label.Name) {
-			githubIssue.StdType = ticket.BUG
-		} else if issueRegexes.TypeIncidentRegex != nil && issueRegexes.TypeIncidentRegex.MatchString(label.Name) {
-			githubIssue.StdType = ticket.INCIDENT
+		if stdType := issueRegexes.StdType(label.Name); stdType != "" {
+			githubIssue.StdType = stdType
 		}
 		joinedLabels = append(joinedLabels, label.Name)
 	}
@@ -236,6 +232,18 @@ func convertGithubLabels(issueRegexes *IssueRegexes, issue *IssuesResponse, gith
 	return results, nil
 }
 
+// StdType returns the standard issue type the label would be mapped to, or empty string if none matched
+func (issueRegexes *IssueRegexes) StdType(label string) string {
+	if issueRegexes.TypeRequirementRegex != nil && issueRegexes.TypeRequirementRegex.MatchString(label) {
+		return ticket.REQUIREMENT
+	} else if issueRegexes.TypeBugRegex != nil && issueRegexes.TypeBugRegex.MatchString(label) {
+		return ticket.BUG
+	} else if issueRegexes.TypeIncidentRegex != nil && issueRegexes.TypeIncidentRegex.MatchString(label) {
+		return ticket.INCIDENT
+	}
+	return ""
+}
+
 func NewIssueRegexes(config *models.GithubScopeConfig) (*IssueRegexes, errors.Error) {
 	var issueRegexes IssueRegexes
 	if config == nil {
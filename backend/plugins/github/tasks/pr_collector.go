@@ -76,7 +76,8 @@ func CollectApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 			PageSize:    100,
 			Concurrency: 10,
 			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
-				UrlTemplate: "repos/{{ .Params.Name }}/pulls",
+				UrlTemplate:        "repos/{{ .Params.Name }}/pulls",
+				ConditionalRequest: true,
 				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
 					query := url.Values{}
 					query.Set("state", "all")
@@ -120,7 +121,8 @@ func CollectApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 				return helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(SimpleGithubPr{}))
 			},
 			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
-				UrlTemplate: "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}",
+				UrlTemplate:        "repos/{{ .Params.Name }}/pulls/{{ .Input.Number }}",
+				ConditionalRequest: true,
 				ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
 					body, err := io.ReadAll(res.Body)
 					if err != nil {
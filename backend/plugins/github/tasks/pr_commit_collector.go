@@ -97,6 +97,7 @@ func CollectApiPullRequestCommits(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
 		ApiClient: data.ApiClient,
+		Resumable: true,
 		PageSize:  100,
 		Input:     iterator,
 
@@ -91,6 +91,7 @@ func CollectApiPullRequestReviews(taskCtx plugin.SubTaskContext) errors.Error {
 
 	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
 		ApiClient: data.ApiClient,
+		Resumable: true,
 		PageSize:  100,
 		Input:     iterator,
 
@@ -63,6 +63,7 @@ func CollectPrReviewComments(taskCtx plugin.SubTaskContext) errors.Error {
 
 	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
 		ApiClient: data.ApiClient,
+		Resumable: true,
 		PageSize:  100,
 		Header: func(reqData *helper.RequestData) (http.Header, errors.Error) {
 			// Adding -H "Accept: application/vnd.github+json" solve the issue of getting 502/403 error
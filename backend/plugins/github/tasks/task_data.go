@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/plugins/github/models"
 )
@@ -81,3 +82,18 @@ func ValidateTaskOptions(op *GithubOptions) errors.Error {
 	}
 	return nil
 }
+
+// NewRegexEnricher compiles the cicd related patterns of the scope config
+func NewRegexEnricher(config *models.GithubScopeConfig) (*helper.RegexEnricher, errors.Error) {
+	regexEnricher := helper.NewRegexEnricher()
+	if err := regexEnricher.TryAdd(devops.DEPLOYMENT, config.DeploymentPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `deploymentPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.PRODUCTION, config.ProductionPattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `productionPattern`")
+	}
+	if err := regexEnricher.TryAdd(devops.ENV_NAME_PATTERN, config.EnvNamePattern); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid value for `envNamePattern`")
+	}
+	return regexEnricher, nil
+}
@@ -169,6 +169,11 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "unable to get github connection by the given connection ID: %v")
 	}
+	// the graphql client sends the token by itself, the secret references must be resolved beforehand
+	connection, err = helper.ResolveConnectionSecrets(taskCtx.GetContext(), taskCtx, op.ConnectionId, connection)
+	if err != nil {
+		return nil, err
+	}
 
 	apiClient, err := githubTasks.CreateApiClient(taskCtx, connection)
 	if err != nil {
@@ -184,7 +189,14 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 	src := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: tokens[0]},
 	)
-	oauthContext := taskCtx.GetContext()
+	// the graphql client talks to the same server as the rest client, so it must trust the same CAs
+	// and present the same client certificate
+	tlsConfig, err := helper.NewTlsConfig(taskCtx.GetConfigReader().GetBool("IN_SECURE_SKIP_VERIFY"), connection)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
 	proxy := connection.GetProxy()
 	if proxy != "" {
 		pu, err := url.Parse(proxy)
@@ -192,19 +204,17 @@ func (p GithubGraphql) PrepareTaskData(taskCtx plugin.TaskContext, options map[s
 			return nil, errors.Convert(err)
 		}
 		if pu.Scheme == "http" || pu.Scheme == "socks5" {
-			proxyClient := &http.Client{
-				Transport: &http.Transport{Proxy: http.ProxyURL(pu)},
-			}
-			oauthContext = context.WithValue(
-				taskCtx.GetContext(),
-				oauth2.HTTPClient,
-				proxyClient,
-			)
+			transport.Proxy = http.ProxyURL(pu)
 			logger.Debug("Proxy set in oauthContext to %s", proxy)
 		} else {
 			return nil, errors.BadInput.New("Unsupported scheme set in proxy")
 		}
 	}
+	oauthContext := context.WithValue(
+		taskCtx.GetContext(),
+		oauth2.HTTPClient,
+		&http.Client{Transport: transport},
+	)
 
 	httpClient := oauth2.NewClient(oauthContext, src)
 	endpoint, err := errors.Convert01(url.Parse(connection.Endpoint))
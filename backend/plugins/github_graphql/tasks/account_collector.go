@@ -100,6 +100,7 @@ func CollectAccount(taskCtx plugin.SubTaskContext) errors.Error {
 		Input:         iterator,
 		InputStep:     100,
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
 			query := &GraphqlQueryAccountWrapper{}
 			if reqData == nil {
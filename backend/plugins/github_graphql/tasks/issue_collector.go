@@ -112,6 +112,7 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	since := apiCollector.GetSince()
 	err = apiCollector.InitGraphQLCollector(api.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		PageSize:      10,
 		BuildQuery: func(reqData *api.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
 			query := &GraphqlQueryIssueWrapper{}
@@ -164,6 +165,7 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 	issueUpdatedAt := make(map[int]time.Time)
 	err = apiCollector.InitGraphQLCollector(api.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		Input:         iterator,
 		InputStep:     100,
 		Incremental:   true,
@@ -322,6 +322,7 @@ func CollectJobs(taskCtx plugin.SubTaskContext) errors.Error {
 		Input:         iterator,
 		InputStep:     config.InputStep,
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		BuildQuery:    buildQueryFunc,
 		GetPageInfo:   getPageInfoFunc, // nil for BATCHING, function for PAGINATING
 		ResponseParser: func(queryWrapper any) (messages []json.RawMessage, err errors.Error) {
@@ -185,6 +185,7 @@ func CollectPrs(taskCtx plugin.SubTaskContext) errors.Error {
 	since := apiCollector.GetSince()
 	err = apiCollector.InitGraphQLCollector(api.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		PageSize:      10,
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
@@ -239,6 +240,7 @@ func CollectPrs(taskCtx plugin.SubTaskContext) errors.Error {
 	prUpdatedAt := make(map[int]time.Time)
 	err = apiCollector.InitGraphQLCollector(api.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		Input:         iterator,
 		InputStep:     100,
 		Incremental:   true,
@@ -102,6 +102,7 @@ func CollectRelease(taskCtx plugin.SubTaskContext) errors.Error {
 	since := apiCollector.GetSince()
 	err = apiCollector.InitGraphQLCollector(helper.GraphqlCollectorArgs{
 		GraphqlClient: data.GraphqlClient,
+		Resumable:     true,
 		PageSize:      100,
 		BuildQuery: func(reqData *helper.GraphqlRequestData) (interface{}, map[string]interface{}, error) {
 			query := &GraphqlQueryReleaseWrapper{}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabEpicDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    12345678,
+			ScopeConfig:  new(models.GitlabScopeConfig),
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_epics.csv",
+		"_raw_gitlab_api_epics")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabEpic{})
+	dataflowTester.Subtask(tasks.ExtractApiEpicsMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		models.GitlabEpic{},
+		"./snapshot_tables/_tool_gitlab_epics.csv",
+		[]string{
+			"connection_id",
+			"project_id",
+			"gitlab_id",
+			"iid",
+			"group_id",
+			"parent_id",
+			"title",
+			"description",
+			"state",
+			"web_url",
+			"author_id",
+			"author_username",
+			"start_date",
+			"due_date",
+			"closed_at",
+			"gitlab_created_at",
+			"gitlab_updated_at",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertEpicsMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		ticket.Issue{},
+		"./snapshot_tables/issues_from_epics.csv",
+		[]string{
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"parent_issue_id",
+			"creator_id",
+			"creator_name",
+		},
+	)
+}
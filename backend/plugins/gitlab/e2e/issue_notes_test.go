@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabIssueNoteDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    12345678,
+			ScopeConfig:  new(models.GitlabScopeConfig),
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_issue_notes.csv",
+		"_raw_gitlab_api_issue_notes")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabIssueNote{})
+	dataflowTester.Subtask(tasks.ExtractApiIssueNotesMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabIssueNote{},
+		"./snapshot_tables/_tool_gitlab_issue_notes.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"gitlab_id",
+			"issue_id",
+			"issue_iid",
+			"project_id",
+			"author_user_id",
+			"author_username",
+			"body",
+			"gitlab_created_at",
+			"gitlab_updated_at",
+			"confidential",
+			"is_system",
+		),
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.IssueComment{})
+	dataflowTester.Subtask(tasks.ConvertIssueNotesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.IssueComment{},
+		"./snapshot_tables/issue_comments.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"issue_id",
+			"body",
+			"account_id",
+			"created_date",
+			"updated_date",
+		),
+	)
+}
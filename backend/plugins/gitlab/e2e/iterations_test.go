@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabIterationDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    12345678,
+			ScopeConfig:  new(models.GitlabScopeConfig),
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_iterations.csv",
+		"_raw_gitlab_api_iterations")
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.GitlabIteration{})
+	dataflowTester.Subtask(tasks.ExtractApiIterationsMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		models.GitlabIteration{},
+		"./snapshot_tables/_tool_gitlab_iterations.csv",
+		[]string{
+			"connection_id",
+			"project_id",
+			"gitlab_id",
+			"iid",
+			"group_id",
+			"sequence",
+			"title",
+			"description",
+			"state",
+			"start_date",
+			"due_date",
+			"web_url",
+			"gitlab_created_at",
+			"gitlab_updated_at",
+		},
+	)
+
+	// verify conversion
+	dataflowTester.FlushTabler(&ticket.Sprint{})
+	dataflowTester.FlushTabler(&ticket.BoardSprint{})
+	dataflowTester.Subtask(tasks.ConvertIterationsMeta, taskData)
+	dataflowTester.VerifyTableWithRawData(
+		ticket.Sprint{},
+		"./snapshot_tables/sprints_from_iterations.csv",
+		[]string{
+			"id",
+			"name",
+			"url",
+			"status",
+			"started_date",
+			"ended_date",
+			"completed_date",
+			"original_board_id",
+		},
+	)
+	dataflowTester.VerifyTableWithRawData(
+		ticket.BoardSprint{},
+		"./snapshot_tables/board_sprints_from_iterations.csv",
+		[]string{
+			"board_id",
+			"sprint_id",
+		},
+	)
+}
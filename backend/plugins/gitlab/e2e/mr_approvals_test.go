@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/gitlab/impl"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+	"github.com/apache/incubator-devlake/plugins/gitlab/tasks"
+)
+
+func TestGitlabMrApprovalDataFlow(t *testing.T) {
+
+	var gitlab impl.Gitlab
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "gitlab", gitlab)
+
+	taskData := &tasks.GitlabTaskData{
+		Options: &tasks.GitlabOptions{
+			ConnectionId: 1,
+			ProjectId:    12345678,
+			ScopeConfig:  new(models.GitlabScopeConfig),
+		},
+	}
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_gitlab_api_merge_request_approvals.csv",
+		"_raw_gitlab_api_merge_request_approvals")
+
+	// verify extraction, merge request 2 was collected again after an approval got revoked
+	dataflowTester.FlushTabler(&models.GitlabMrApproval{})
+	dataflowTester.Subtask(tasks.ExtractApiMrApprovalsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.GitlabMrApproval{},
+		"./snapshot_tables/_tool_gitlab_mr_approvals.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"merge_request_id",
+			"approver_id",
+			"project_id",
+			"name",
+			"username",
+			"approved_at",
+		),
+	)
+
+	// verify conversion, approvals without a matching note become review comments
+	dataflowTester.FlushTabler(&models.GitlabMrComment{})
+	dataflowTester.FlushTabler(&code.PullRequestReviewer{})
+	dataflowTester.FlushTabler(&code.PullRequestComment{})
+	dataflowTester.Subtask(tasks.ConvertMrApprovalsMeta, taskData)
+	dataflowTester.VerifyTable(
+		code.PullRequestReviewer{},
+		"./snapshot_tables/pull_request_reviewers_for_mr_approvals_test.csv",
+		e2ehelper.ColumnWithRawData(
+			"pull_request_id",
+			"reviewer_id",
+			"name",
+			"user_name",
+		),
+	)
+	dataflowTester.VerifyTable(
+		code.PullRequestComment{},
+		"./snapshot_tables/pull_request_comments_for_mr_approvals_test.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"pull_request_id",
+			"body",
+			"account_id",
+			"created_date",
+			"type",
+			"status",
+		),
+	)
+}
@@ -93,6 +93,11 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabMrCommit{},
 		&models.GitlabMrLabel{},
 		&models.GitlabMrNote{},
+		&models.GitlabMrApproval{},
+		&models.GitlabIssueNote{},
+		&models.GitlabMilestone{},
+		&models.GitlabIteration{},
+		&models.GitlabEpic{},
 		&models.GitlabPipeline{},
 		&models.GitlabPipelineProject{},
 		&models.GitlabProject{},
@@ -48,6 +48,11 @@ type GitlabIssue struct {
 	Component       string    `gorm:"type:text"`
 	TimeEstimate    *int64
 	TotalTimeSpent  *int64
+	MilestoneId     int
+	IterationId     int
+	EpicId          int
+	EpicIid         int
+	EpicGroupId     int
 	common.NoPKModel
 }
 
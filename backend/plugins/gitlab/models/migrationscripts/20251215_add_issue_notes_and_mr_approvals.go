@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type gitlabIssueNote20251215 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+
+	GitlabId        int `gorm:"primaryKey"`
+	IssueId         int `gorm:"index"`
+	IssueIid        int `gorm:"comment:Used in API requests ex. /api/issues/<THIS_IID>"`
+	ProjectId       int `gorm:"index"`
+	AuthorUserId    int
+	AuthorUsername  string `gorm:"type:varchar(255)"`
+	Body            string
+	GitlabCreatedAt time.Time
+	GitlabUpdatedAt *time.Time
+	Confidential    bool
+	IsSystem        bool `gorm:"comment:Is or is not auto-generated vs. human generated"`
+	archived.NoPKModel
+}
+
+func (gitlabIssueNote20251215) TableName() string {
+	return "_tool_gitlab_issue_notes"
+}
+
+type gitlabMrApproval20251215 struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	MergeRequestId int    `gorm:"primaryKey"`
+	ApproverId     int    `gorm:"primaryKey"`
+	ProjectId      int    `gorm:"index"`
+	Name           string `gorm:"type:varchar(255)"`
+	Username       string `gorm:"type:varchar(255)"`
+	ApprovedAt     *time.Time
+	archived.NoPKModel
+}
+
+func (gitlabMrApproval20251215) TableName() string {
+	return "_tool_gitlab_mr_approvals"
+}
+
+type gitlabMrNote20251215 struct {
+	Resolved     bool
+	ResolvedById int
+	ResolvedAt   *time.Time
+}
+
+func (gitlabMrNote20251215) TableName() string {
+	return "_tool_gitlab_mr_notes"
+}
+
+type gitlabMrComment20251215 struct {
+	Resolved     bool
+	ResolvedById int
+	ResolvedAt   *time.Time
+}
+
+func (gitlabMrComment20251215) TableName() string {
+	return "_tool_gitlab_mr_comments"
+}
+
+type addIssueNotesAndMrApprovals struct{}
+
+func (*addIssueNotesAndMrApprovals) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&gitlabIssueNote20251215{},
+		&gitlabMrApproval20251215{},
+		&gitlabMrNote20251215{},
+		&gitlabMrComment20251215{},
+	)
+}
+
+func (*addIssueNotesAndMrApprovals) Version() uint64 {
+	return 20251215000001
+}
+
+func (*addIssueNotesAndMrApprovals) Name() string {
+	return "add _tool_gitlab_issue_notes, _tool_gitlab_mr_approvals and resolution of mr notes"
+}
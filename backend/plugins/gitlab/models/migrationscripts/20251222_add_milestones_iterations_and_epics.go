@@ -0,0 +1,127 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type gitlabMilestone20251222 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectId       int    `gorm:"primaryKey"`
+	GitlabId        int    `gorm:"primaryKey"`
+	Iid             int
+	GroupId         int
+	Title           string `gorm:"type:varchar(255)"`
+	Description     string
+	State           string `gorm:"type:varchar(100)"`
+	StartDate       *time.Time
+	DueDate         *time.Time
+	WebUrl          string `gorm:"type:varchar(255)"`
+	GitlabCreatedAt *time.Time
+	GitlabUpdatedAt *time.Time
+	archived.NoPKModel
+}
+
+func (gitlabMilestone20251222) TableName() string {
+	return "_tool_gitlab_milestones"
+}
+
+type gitlabIteration20251222 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectId       int    `gorm:"primaryKey"`
+	GitlabId        int    `gorm:"primaryKey"`
+	Iid             int
+	GroupId         int
+	Sequence        int
+	Title           string `gorm:"type:varchar(255)"`
+	Description     string
+	State           int `gorm:"comment:1 upcoming, 2 current, 3 closed"`
+	StartDate       *time.Time
+	DueDate         *time.Time
+	WebUrl          string `gorm:"type:varchar(255)"`
+	GitlabCreatedAt *time.Time
+	GitlabUpdatedAt *time.Time
+	archived.NoPKModel
+}
+
+func (gitlabIteration20251222) TableName() string {
+	return "_tool_gitlab_iterations"
+}
+
+type gitlabEpic20251222 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	ProjectId       int    `gorm:"primaryKey"`
+	GitlabId        int    `gorm:"primaryKey"`
+	Iid             int
+	GroupId         int `gorm:"index"`
+	ParentId        int
+	Title           string
+	Description     string
+	State           string `gorm:"type:varchar(100)"`
+	WebUrl          string `gorm:"type:varchar(255)"`
+	AuthorId        int
+	AuthorUsername  string `gorm:"type:varchar(255)"`
+	StartDate       *time.Time
+	DueDate         *time.Time
+	ClosedAt        *time.Time
+	GitlabCreatedAt time.Time
+	GitlabUpdatedAt time.Time
+	archived.NoPKModel
+}
+
+func (gitlabEpic20251222) TableName() string {
+	return "_tool_gitlab_epics"
+}
+
+type gitlabIssue20251222 struct {
+	MilestoneId int
+	IterationId int
+	EpicId      int
+	EpicIid     int
+	EpicGroupId int
+}
+
+func (gitlabIssue20251222) TableName() string {
+	return "_tool_gitlab_issues"
+}
+
+type addMilestonesIterationsAndEpics struct{}
+
+func (*addMilestonesIterationsAndEpics) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&gitlabMilestone20251222{},
+		&gitlabIteration20251222{},
+		&gitlabEpic20251222{},
+		&gitlabIssue20251222{},
+	)
+}
+
+func (*addMilestonesIterationsAndEpics) Version() uint64 {
+	return 20251222000001
+}
+
+func (*addMilestonesIterationsAndEpics) Name() string {
+	return "add _tool_gitlab_milestones, _tool_gitlab_iterations, _tool_gitlab_epics and their references on issues"
+}
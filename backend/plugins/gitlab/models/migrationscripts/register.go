@@ -53,5 +53,8 @@ func All() []plugin.MigrationScript {
 		new(changeIssueComponentType),
 		new(addIsChildToPipelines240906),
 		new(addPrSizeExcludedFileExtensions),
+		new(addTlsFieldsToConnections),
+		new(addIssueNotesAndMrApprovals),
+		new(addMilestonesIterationsAndEpics),
 	}
 }
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type GitlabMilestone struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	// group milestones are shared by projects, they are stored once per project
+	ProjectId       int `gorm:"primaryKey"`
+	GitlabId        int `gorm:"primaryKey"`
+	Iid             int
+	GroupId         int
+	Title           string `gorm:"type:varchar(255)"`
+	Description     string
+	State           string `gorm:"type:varchar(100)"`
+	StartDate       *time.Time
+	DueDate         *time.Time
+	WebUrl          string `gorm:"type:varchar(255)"`
+	GitlabCreatedAt *time.Time
+	GitlabUpdatedAt *time.Time
+	common.NoPKModel
+}
+
+func (GitlabMilestone) TableName() string {
+	return "_tool_gitlab_milestones"
+}
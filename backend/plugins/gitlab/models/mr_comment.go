@@ -34,6 +34,9 @@ type GitlabMrComment struct {
 	GitlabCreatedAt time.Time
 	Resolvable      bool   `gorm:"comment:Is or is not review comment"`
 	Type            string `gorm:"comment:if type=null, it is normal comment,if type=diffNote,it is diff comment"`
+	Resolved        bool
+	ResolvedById    int
+	ResolvedAt      *time.Time
 	common.NoPKModel
 }
 
@@ -37,6 +37,9 @@ type GitlabMrNote struct {
 	Resolvable      bool   `gorm:"comment:Is or is not review comment"`
 	IsSystem        bool   `gorm:"comment:Is or is not auto-generated vs. human generated"`
 	Type            string `gorm:"comment:if type=null, it is normal comment,if type=diffNote,it is diff comment"`
+	Resolved        bool
+	ResolvedById    int
+	ResolvedAt      *time.Time
 	common.NoPKModel
 }
 
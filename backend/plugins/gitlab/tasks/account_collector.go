@@ -67,6 +67,7 @@ func CollectAccounts(taskCtx plugin.SubTaskContext) errors.Error {
 		ApiClient:          data.ApiClient,
 		UrlTemplate:        urlTemplate,
 		PageSize:           100,
+		ConditionalRequest: true,
 		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
 			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
@@ -82,3 +82,10 @@ func ignoreHTTPStatus404(res *http.Response) errors.Error {
 	}
 	return nil
 }
+
+func ignoreHTTPStatus403And404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusForbidden || res.StatusCode == http.StatusNotFound {
+		return api.ErrIgnoreAndContinue
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiEpicsMeta)
+}
+
+const RAW_EPIC_TABLE = "gitlab_api_epics"
+
+type GitlabGroupInput struct {
+	GroupId int
+}
+
+var CollectApiEpicsMeta = plugin.SubTaskMeta{
+	Name:             "Collect Epics",
+	EntryPoint:       CollectApiEpics,
+	EnabledByDefault: true,
+	Description:      "Collect epics of the groups referenced by the project issues from gitlab api, only available on premium tiers, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertIterationsMeta},
+}
+
+func CollectApiEpics(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EPIC_TABLE)
+	db := taskCtx.GetDal()
+
+	// epics live in groups, collect only the groups the project issues were linked to
+	cursor, err := db.Cursor(
+		dal.Select("DISTINCT epic_group_id AS group_id"),
+		dal.From("_tool_gitlab_issues"),
+		dal.Where("connection_id = ? AND project_id = ? AND epic_group_id > 0", data.Options.ConnectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(GitlabGroupInput{}))
+	if err != nil {
+		return err
+	}
+	defer iterator.Close()
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		Input:              iterator,
+		UrlTemplate:        "groups/{{ .Input.GroupId }}/epics",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			// parent epics may belong to the ancestor groups
+			query.Set("include_ancestor_groups", "true")
+			return query, nil
+		},
+		GetTotalPages:  GetTotalPagesFromResponse,
+		ResponseParser: GetRawMessageFromResponse,
+		AfterResponse:  ignoreHTTPStatus403And404,
+	})
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertEpicsMeta)
+}
+
+var ConvertEpicsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Epics",
+	EntryPoint:       ConvertEpics,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_epics into domain layer table issues and board_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiEpicsMeta},
+}
+
+func ConvertEpics(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EPIC_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabEpic{}),
+		dal.Where("connection_id = ? AND project_id = ?", connectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	epicIdGen := didgen.NewDomainIdGenerator(&models.GitlabEpic{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+	domainBoardId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connectionId, data.Options.ProjectId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GitlabEpic{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			epic := inputRow.(*models.GitlabEpic)
+			domainIssue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: epicIdGen.Generate(connectionId, epic.GitlabId)},
+				IssueKey:       getEpicKey(epic.GroupId, epic.Iid),
+				Title:          epic.Title,
+				Description:    epic.Description,
+				Type:           ticket.REQUIREMENT,
+				OriginalType:   "Epic",
+				OriginalStatus: epic.State,
+				Url:            epic.WebUrl,
+				CreatedDate:    &epic.GitlabCreatedAt,
+				UpdatedDate:    &epic.GitlabUpdatedAt,
+				ResolutionDate: epic.ClosedAt,
+				CreatorId:      accountIdGen.Generate(connectionId, epic.AuthorId),
+				CreatorName:    epic.AuthorUsername,
+			}
+			if epic.State == "opened" {
+				domainIssue.Status = ticket.TODO
+			} else {
+				domainIssue.Status = ticket.DONE
+			}
+			if epic.ParentId > 0 {
+				domainIssue.ParentIssueId = epicIdGen.Generate(connectionId, epic.ParentId)
+			}
+			boardIssue := &ticket.BoardIssue{
+				BoardId: domainBoardId,
+				IssueId: domainIssue.Id,
+			}
+			return []interface{}{domainIssue, boardIssue}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+// getEpicKey follows the gitlab reference syntax of epics, which is the group followed by `&` and the iid.
+// iids are only unique within a group, the group id stands in for the group path which isn't known to the issues
+func getEpicKey(groupId int, iid int) string {
+	return strconv.Itoa(groupId) + "&" + strconv.Itoa(iid)
+}
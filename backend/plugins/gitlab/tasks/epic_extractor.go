@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiEpicsMeta)
+}
+
+type GitlabApiEpic struct {
+	Id          int    `json:"id"`
+	Iid         int    `json:"iid"`
+	GroupId     int    `json:"group_id"`
+	ParentId    int    `json:"parent_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	WebUrl      string `json:"web_url"`
+	Author      *struct {
+		Id       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"author"`
+	StartDate       *common.Iso8601Time `json:"start_date"`
+	DueDate         *common.Iso8601Time `json:"due_date"`
+	ClosedAt        *common.Iso8601Time `json:"closed_at"`
+	GitlabCreatedAt common.Iso8601Time  `json:"created_at"`
+	GitlabUpdatedAt common.Iso8601Time  `json:"updated_at"`
+}
+
+var ExtractApiEpicsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Epics",
+	EntryPoint:       ExtractApiEpics,
+	EnabledByDefault: true,
+	Description:      "Extract raw epics data into tool layer table gitlab_epics",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiEpicsMeta},
+}
+
+func ExtractApiEpics(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_EPIC_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			epic := &GitlabApiEpic{}
+			err := errors.Convert(json.Unmarshal(row.Data, epic))
+			if err != nil {
+				return nil, err
+			}
+			gitlabEpic := &models.GitlabEpic{
+				ConnectionId:    data.Options.ConnectionId,
+				ProjectId:       data.Options.ProjectId,
+				GitlabId:        epic.Id,
+				Iid:             epic.Iid,
+				GroupId:         epic.GroupId,
+				ParentId:        epic.ParentId,
+				Title:           epic.Title,
+				Description:     epic.Description,
+				State:           epic.State,
+				WebUrl:          epic.WebUrl,
+				StartDate:       common.Iso8601TimeToTime(epic.StartDate),
+				DueDate:         common.Iso8601TimeToTime(epic.DueDate),
+				ClosedAt:        common.Iso8601TimeToTime(epic.ClosedAt),
+				GitlabCreatedAt: epic.GitlabCreatedAt.ToTime(),
+				GitlabUpdatedAt: epic.GitlabUpdatedAt.ToTime(),
+			}
+			if epic.Author != nil {
+				gitlabEpic.AuthorId = epic.Author.Id
+				gitlabEpic.AuthorUsername = epic.Author.Username
+			}
+			return []interface{}{gitlabEpic}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
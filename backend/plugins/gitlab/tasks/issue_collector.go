@@ -55,7 +55,8 @@ func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
 		ApiClient: data.ApiClient,
 		PageSize:  100,
 
-		UrlTemplate: "projects/{{ .Params.ProjectId }}/issues",
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/issues",
+		ConditionalRequest: true,
 		/*
 			(Optional) Return query string for request, or you can plug them into UrlTemplate directly
 		*/
@@ -101,6 +101,9 @@ func ConvertIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
 				AssigneeId:              accountIdGen.Generate(data.Options.ConnectionId, issue.AssigneeId),
 				AssigneeName:            issue.AssigneeName,
 			}
+			if issue.EpicIid > 0 {
+				domainIssue.EpicKey = getEpicKey(issue.EpicGroupId, issue.EpicIid)
+			}
 			if strings.ToUpper(issue.Type) == ticket.INCIDENT {
 				domainIssue.Type = ticket.INCIDENT
 			}
@@ -131,6 +131,15 @@ type IssuesResponse struct {
 		Count          int
 		CompletedCount int
 	}
+	// iterations and epics are only available in premium tiers
+	Iteration *struct {
+		Id int `json:"id"`
+	} `json:"iteration"`
+	Epic *struct {
+		Id      int `json:"id"`
+		Iid     int `json:"iid"`
+		GroupId int `json:"group_id"`
+	} `json:"epic"`
 }
 
 func ExtractApiIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
@@ -278,6 +287,15 @@ func convertGitlabIssue(issue *IssuesResponse, projectId int) (*models.GitlabIss
 		TotalTimeSpent:  issue.TimeStats.TotalTimeSpent,
 		CreatorId:       issue.Author.Id,
 		CreatorName:     issue.Author.Username,
+		MilestoneId:     issue.Milestone.Id,
+	}
+	if issue.Iteration != nil {
+		gitlabIssue.IterationId = issue.Iteration.Id
+	}
+	if issue.Epic != nil {
+		gitlabIssue.EpicId = issue.Epic.Id
+		gitlabIssue.EpicIid = issue.Epic.Iid
+		gitlabIssue.EpicGroupId = issue.Epic.GroupId
 	}
 
 	if issue.Assignee != nil {
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIssueNotesMeta)
+}
+
+var ConvertIssueNotesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Issue Notes",
+	EntryPoint:       ConvertIssueNotes,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_issue_notes into domain layer table issue_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertIssuesMeta, &ExtractApiIssueNotesMeta},
+}
+
+func ConvertIssueNotes(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_ISSUE_NOTES_TABLE)
+	db := subtaskCtx.GetDal()
+
+	issueNoteIdGen := didgen.NewDomainIdGenerator(&models.GitlabIssueNote{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GitlabIssue{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+
+	converter, err := api.NewStatefulDataConverter(&api.StatefulDataConverterArgs[models.GitlabIssueNote]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Input: func(stateManager *api.SubtaskStateManager) (dal.Rows, errors.Error) {
+			// system notes like "assigned to @someone" are not comments
+			clauses := []dal.Clause{
+				dal.From(&models.GitlabIssueNote{}),
+				dal.Where(
+					"connection_id = ? AND project_id = ? AND is_system = ?",
+					data.Options.ConnectionId, data.Options.ProjectId, false,
+				),
+			}
+			if stateManager.IsIncremental() {
+				since := stateManager.GetSince()
+				if since != nil {
+					clauses = append(clauses, dal.Where("updated_at >= ? ", since))
+				}
+			}
+			return db.Cursor(clauses...)
+		},
+		Convert: func(issueNote *models.GitlabIssueNote) ([]interface{}, errors.Error) {
+			domainIssueComment := &ticket.IssueComment{
+				DomainEntity: domainlayer.DomainEntity{
+					Id: issueNoteIdGen.Generate(data.Options.ConnectionId, issueNote.GitlabId),
+				},
+				IssueId:     issueIdGen.Generate(data.Options.ConnectionId, issueNote.IssueId),
+				Body:        issueNote.Body,
+				AccountId:   accountIdGen.Generate(data.Options.ConnectionId, issueNote.AuthorUserId),
+				CreatedDate: issueNote.GitlabCreatedAt,
+				UpdatedDate: issueNote.GitlabUpdatedAt,
+			}
+			return []interface{}{
+				domainIssueComment,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
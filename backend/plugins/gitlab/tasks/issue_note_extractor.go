@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiIssueNotesMeta)
+}
+
+type IssueNote struct {
+	GitlabId        int    `json:"id"`
+	IssueId         int    `json:"noteable_id"`
+	IssueIid        int    `json:"noteable_iid"`
+	NoteableType    string `json:"noteable_type"`
+	Body            string
+	GitlabCreatedAt common.Iso8601Time  `json:"created_at"`
+	GitlabUpdatedAt *common.Iso8601Time `json:"updated_at"`
+	Confidential    bool
+	System          bool `json:"system"`
+	Author          struct {
+		Id       int    `json:"id"`
+		Username string `json:"username"`
+	}
+}
+
+var ExtractApiIssueNotesMeta = plugin.SubTaskMeta{
+	Name:             "Extract Issue Notes",
+	EntryPoint:       ExtractApiIssueNotes,
+	EnabledByDefault: true,
+	Description:      "Extract raw issue notes data into tool layer table gitlab_issue_notes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiIssueNotesMeta},
+}
+
+func ExtractApiIssueNotes(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_ISSUE_NOTES_TABLE)
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[IssueNote]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(issueNote *IssueNote, row *api.RawData) ([]interface{}, errors.Error) {
+			toolIssueNote := &models.GitlabIssueNote{
+				ConnectionId:    data.Options.ConnectionId,
+				GitlabId:        issueNote.GitlabId,
+				IssueId:         issueNote.IssueId,
+				IssueIid:        issueNote.IssueIid,
+				ProjectId:       data.Options.ProjectId,
+				AuthorUserId:    issueNote.Author.Id,
+				AuthorUsername:  issueNote.Author.Username,
+				Body:            issueNote.Body,
+				GitlabCreatedAt: issueNote.GitlabCreatedAt.ToTime(),
+				GitlabUpdatedAt: common.Iso8601TimeToTime(issueNote.GitlabUpdatedAt),
+				Confidential:    issueNote.Confidential,
+				IsSystem:        issueNote.System,
+			}
+			return []interface{}{toolIssueNote}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIterationsMeta)
+}
+
+var ConvertIterationsMeta = plugin.SubTaskMeta{
+	Name:             "Convert Iterations",
+	EntryPoint:       ConvertIterations,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_iterations into domain layer table sprints and board_sprints",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractApiIterationsMeta},
+}
+
+var iterationStatusMap = map[int]string{
+	models.IterationStateUpcoming: "FUTURE",
+	models.IterationStateCurrent:  "ACTIVE",
+	models.IterationStateClosed:   "CLOSED",
+}
+
+func ConvertIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ITERATION_TABLE)
+	connectionId := data.Options.ConnectionId
+
+	cursor, err := db.Cursor(
+		dal.From(&models.GitlabIteration{}),
+		dal.Where("connection_id = ? AND project_id = ?", connectionId, data.Options.ProjectId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	sprintIdGen := didgen.NewDomainIdGenerator(&models.GitlabIteration{})
+	domainBoardId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(connectionId, data.Options.ProjectId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GitlabIteration{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			iteration := inputRow.(*models.GitlabIteration)
+			sprint := &ticket.Sprint{
+				DomainEntity:    domainlayer.DomainEntity{Id: sprintIdGen.Generate(connectionId, iteration.GitlabId)},
+				Name:            iteration.Title,
+				Url:             iteration.WebUrl,
+				Status:          iterationStatusMap[iteration.State],
+				StartedDate:     iteration.StartDate,
+				EndedDate:       iteration.DueDate,
+				OriginalBoardID: domainBoardId,
+			}
+			if iteration.State == models.IterationStateClosed {
+				sprint.CompletedDate = iteration.DueDate
+			}
+			boardSprint := &ticket.BoardSprint{
+				BoardId:  domainBoardId,
+				SprintId: sprint.Id,
+			}
+			return []interface{}{sprint, boardSprint}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
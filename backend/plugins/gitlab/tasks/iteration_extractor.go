@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiIterationsMeta)
+}
+
+type GitlabApiIteration struct {
+	Id              int                 `json:"id"`
+	Iid             int                 `json:"iid"`
+	GroupId         int                 `json:"group_id"`
+	Sequence        int                 `json:"sequence"`
+	Title           string              `json:"title"`
+	Description     string              `json:"description"`
+	State           int                 `json:"state"`
+	StartDate       *common.Iso8601Time `json:"start_date"`
+	DueDate         *common.Iso8601Time `json:"due_date"`
+	WebUrl          string              `json:"web_url"`
+	GitlabCreatedAt *common.Iso8601Time `json:"created_at"`
+	GitlabUpdatedAt *common.Iso8601Time `json:"updated_at"`
+}
+
+var ExtractApiIterationsMeta = plugin.SubTaskMeta{
+	Name:             "Extract Iterations",
+	EntryPoint:       ExtractApiIterations,
+	EnabledByDefault: true,
+	Description:      "Extract raw iterations data into tool layer table gitlab_iterations",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiIterationsMeta},
+}
+
+func ExtractApiIterations(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ITERATION_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			iteration := &GitlabApiIteration{}
+			err := errors.Convert(json.Unmarshal(row.Data, iteration))
+			if err != nil {
+				return nil, err
+			}
+			gitlabIteration := &models.GitlabIteration{
+				ConnectionId:    data.Options.ConnectionId,
+				ProjectId:       data.Options.ProjectId,
+				GitlabId:        iteration.Id,
+				Iid:             iteration.Iid,
+				GroupId:         iteration.GroupId,
+				Sequence:        iteration.Sequence,
+				Title:           iteration.Title,
+				Description:     iteration.Description,
+				State:           iteration.State,
+				StartDate:       common.Iso8601TimeToTime(iteration.StartDate),
+				DueDate:         common.Iso8601TimeToTime(iteration.DueDate),
+				WebUrl:          iteration.WebUrl,
+				GitlabCreatedAt: common.Iso8601TimeToTime(iteration.GitlabCreatedAt),
+				GitlabUpdatedAt: common.Iso8601TimeToTime(iteration.GitlabUpdatedAt),
+			}
+			// automatic cadences generate iterations without a title
+			if gitlabIteration.Title == "" && gitlabIteration.StartDate != nil && gitlabIteration.DueDate != nil {
+				gitlabIteration.Title = gitlabIteration.StartDate.Format("Jan 2, 2006") + " - " + gitlabIteration.DueDate.Format("Jan 2, 2006")
+			}
+			return []interface{}{gitlabIteration}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiMilestonesMeta)
+}
+
+type GitlabApiMilestone struct {
+	Id              int                 `json:"id"`
+	Iid             int                 `json:"iid"`
+	GroupId         int                 `json:"group_id"`
+	Title           string              `json:"title"`
+	Description     string              `json:"description"`
+	State           string              `json:"state"`
+	StartDate       *common.Iso8601Time `json:"start_date"`
+	DueDate         *common.Iso8601Time `json:"due_date"`
+	WebUrl          string              `json:"web_url"`
+	GitlabCreatedAt *common.Iso8601Time `json:"created_at"`
+	GitlabUpdatedAt *common.Iso8601Time `json:"updated_at"`
+}
+
+var ExtractApiMilestonesMeta = plugin.SubTaskMeta{
+	Name:             "Extract Milestones",
+	EntryPoint:       ExtractApiMilestones,
+	EnabledByDefault: true,
+	Description:      "Extract raw milestones data into tool layer table gitlab_milestones",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiMilestonesMeta},
+}
+
+func ExtractApiMilestones(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_MILESTONE_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			milestone := &GitlabApiMilestone{}
+			err := errors.Convert(json.Unmarshal(row.Data, milestone))
+			if err != nil {
+				return nil, err
+			}
+			gitlabMilestone := &models.GitlabMilestone{
+				ConnectionId:    data.Options.ConnectionId,
+				ProjectId:       data.Options.ProjectId,
+				GitlabId:        milestone.Id,
+				Iid:             milestone.Iid,
+				GroupId:         milestone.GroupId,
+				Title:           milestone.Title,
+				Description:     milestone.Description,
+				State:           milestone.State,
+				StartDate:       common.Iso8601TimeToTime(milestone.StartDate),
+				DueDate:         common.Iso8601TimeToTime(milestone.DueDate),
+				WebUrl:          milestone.WebUrl,
+				GitlabCreatedAt: common.Iso8601TimeToTime(milestone.GitlabCreatedAt),
+				GitlabUpdatedAt: common.Iso8601TimeToTime(milestone.GitlabUpdatedAt),
+			}
+			return []interface{}{gitlabMilestone}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertMrApprovalsMeta)
+}
+
+var ConvertMrApprovalsMeta = plugin.SubTaskMeta{
+	Name:             "Convert MR Approvals",
+	EntryPoint:       ConvertMrApprovals,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table gitlab_mr_approvals into domain layer table pull_request_reviewers and pull_request_comments",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertMrCommentMeta, &ExtractApiMrApprovalsMeta},
+}
+
+// mrApprovalWithNote tells whether the approval was noted on the merge request as well
+type mrApprovalWithNote struct {
+	models.GitlabMrApproval
+	Noted bool
+}
+
+func ConvertMrApprovals(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_MERGE_REQUEST_APPROVALS_TABLE)
+	db := subtaskCtx.GetDal()
+
+	approvalIdGen := didgen.NewDomainIdGenerator(&models.GitlabMrApproval{})
+	mrIdGen := didgen.NewDomainIdGenerator(&models.GitlabMergeRequest{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GitlabAccount{})
+
+	converter, err := api.NewStatefulDataConverter(&api.StatefulDataConverterArgs[mrApprovalWithNote]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Input: func(stateManager *api.SubtaskStateManager) (dal.Rows, errors.Error) {
+			// the "approved this merge request" note is converted into a review comment already
+			clauses := []dal.Clause{
+				dal.Select("a.*, n.author_user_id IS NOT NULL AS noted"),
+				dal.From("_tool_gitlab_mr_approvals a"),
+				dal.Join(`LEFT JOIN (
+					SELECT DISTINCT connection_id, merge_request_id, author_user_id
+					FROM _tool_gitlab_mr_comments
+					WHERE body = ?
+				) n ON n.connection_id = a.connection_id AND n.merge_request_id = a.merge_request_id AND n.author_user_id = a.approver_id`,
+					"approved this merge request",
+				),
+				dal.Where("a.connection_id = ? AND a.project_id = ?", data.Options.ConnectionId, data.Options.ProjectId),
+			}
+			if stateManager.IsIncremental() {
+				since := stateManager.GetSince()
+				if since != nil {
+					clauses = append(clauses, dal.Where("a.updated_at >= ? ", since))
+				}
+			}
+			return db.Cursor(clauses...)
+		},
+		Convert: func(approval *mrApprovalWithNote) ([]interface{}, errors.Error) {
+			pullRequestId := mrIdGen.Generate(data.Options.ConnectionId, approval.MergeRequestId)
+			reviewerId := accountIdGen.Generate(data.Options.ConnectionId, approval.ApproverId)
+			results := []interface{}{
+				&code.PullRequestReviewer{
+					PullRequestId: pullRequestId,
+					ReviewerId:    reviewerId,
+					Name:          approval.Name,
+					UserName:      approval.Username,
+				},
+			}
+			if approval.ApprovedAt != nil && !approval.Noted {
+				results = append(results, &code.PullRequestComment{
+					DomainEntity: domainlayer.DomainEntity{
+						Id: approvalIdGen.Generate(data.Options.ConnectionId, approval.MergeRequestId, approval.ApproverId),
+					},
+					PullRequestId: pullRequestId,
+					Body:          "approved this merge request",
+					AccountId:     reviewerId,
+					CreatedDate:   *approval.ApprovedAt,
+					Type:          code.REVIEW,
+					Status:        "APPROVED",
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiMrApprovalsMeta)
+}
+
+type MergeRequestApprovals struct {
+	MergeRequestId  int `json:"id"`
+	MergeRequestIid int `json:"iid"`
+	Approved        bool
+	ApprovedBy      []struct {
+		User struct {
+			Id       int    `json:"id"`
+			Username string `json:"username"`
+			Name     string `json:"name"`
+		} `json:"user"`
+		// only returned by recent gitlab versions
+		ApprovedAt *common.Iso8601Time `json:"approved_at"`
+	} `json:"approved_by"`
+}
+
+var ExtractApiMrApprovalsMeta = plugin.SubTaskMeta{
+	Name:             "Extract MR Approvals",
+	EntryPoint:       ExtractApiMergeRequestApprovals,
+	EnabledByDefault: true,
+	Description:      "Extract raw merge requests approvals data into tool layer table gitlab_mr_approvals",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiMrApprovalsMeta},
+}
+
+func ExtractApiMergeRequestApprovals(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_MERGE_REQUEST_APPROVALS_TABLE)
+	db := subtaskCtx.GetDal()
+
+	// the approvals of a merge request are collected again on every run, only its latest response counts since
+	// approvals could be revoked in the meantime
+	latestRawIds := make(map[uint64]bool)
+	if db.HasTable(subtaskCommonArgs.GetRawDataTable()) {
+		var rawIds []uint64
+		err := db.Pluck(
+			"MAX(id)",
+			&rawIds,
+			dal.From(subtaskCommonArgs.GetRawDataTable()),
+			dal.Where("params = ?", subtaskCommonArgs.GetRawDataParams()),
+			dal.Groupby("input"),
+		)
+		if err != nil {
+			return err
+		}
+		for _, rawId := range rawIds {
+			latestRawIds[rawId] = true
+		}
+	}
+
+	extractor, err := api.NewStatefulApiExtractor(&api.StatefulApiExtractorArgs[MergeRequestApprovals]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Extract: func(approvals *MergeRequestApprovals, row *api.RawData) ([]interface{}, errors.Error) {
+			if !latestRawIds[row.ID] {
+				return nil, nil
+			}
+			// no older response of the merge request is pending in the batches, replace the saved approvals
+			err := db.Delete(
+				&models.GitlabMrApproval{},
+				dal.Where("connection_id = ? AND merge_request_id = ?", data.Options.ConnectionId, approvals.MergeRequestId),
+			)
+			if err != nil {
+				return nil, err
+			}
+			results := make([]interface{}, 0, len(approvals.ApprovedBy))
+			for _, approvedBy := range approvals.ApprovedBy {
+				results = append(results, &models.GitlabMrApproval{
+					ConnectionId:   data.Options.ConnectionId,
+					MergeRequestId: approvals.MergeRequestId,
+					ApproverId:     approvedBy.User.Id,
+					ProjectId:      data.Options.ProjectId,
+					Name:           approvedBy.User.Name,
+					Username:       approvedBy.User.Username,
+					ApprovedAt:     common.Iso8601TimeToTime(approvedBy.ApprovedAt),
+				})
+			}
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
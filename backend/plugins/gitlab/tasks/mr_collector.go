@@ -49,11 +49,12 @@ func CollectApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 
 	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
-		ApiClient:      data.ApiClient,
-		PageSize:       100,
-		UrlTemplate:    "projects/{{ .Params.ProjectId }}/merge_requests",
-		GetTotalPages:  GetTotalPagesFromResponse,
-		ResponseParser: GetRawMessageFromResponse,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/merge_requests",
+		ConditionalRequest: true,
+		GetTotalPages:      GetTotalPagesFromResponse,
+		ResponseParser:     GetRawMessageFromResponse,
 		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
 			query, err := GetQuery(reqData)
 			if err != nil {
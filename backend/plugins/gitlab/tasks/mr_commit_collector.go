@@ -52,15 +52,16 @@ func CollectApiMergeRequestsCommits(taskCtx plugin.SubTaskContext) errors.Error
 	}
 
 	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
-		ApiClient:      data.ApiClient,
-		PageSize:       100,
-		Incremental:    false,
-		Input:          iterator,
-		UrlTemplate:    "projects/{{ .Params.ProjectId }}/merge_requests/{{ .Input.Iid }}/commits",
-		Query:          GetQuery,
-		GetTotalPages:  GetTotalPagesFromResponse,
-		ResponseParser: GetRawMessageFromResponse,
-		AfterResponse:  ignoreHTTPStatus404,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		Input:              iterator,
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/merge_requests/{{ .Input.Iid }}/commits",
+		ConditionalRequest: true,
+		Query:              GetQuery,
+		GetTotalPages:      GetTotalPagesFromResponse,
+		ResponseParser:     GetRawMessageFromResponse,
+		AfterResponse:      ignoreHTTPStatus404,
 	})
 	if err != nil {
 		return err
@@ -83,6 +83,14 @@ func ConvertMergeRequestNote(subtaskCtx plugin.SubTaskContext) errors.Error {
 			if domainComment.Body == "approved this merge request" {
 				domainComment.Status = "APPROVED"
 			}
+			// resolvable notes start review threads, keep track of whether they were resolved
+			if domainComment.Status == "" && gitlabComments.Resolvable {
+				if gitlabComments.Resolved {
+					domainComment.Status = "RESOLVED"
+				} else {
+					domainComment.Status = "UNRESOLVED"
+				}
+			}
 			return []interface{}{
 				domainComment,
 			}, nil
@@ -38,8 +38,13 @@ type MergeRequestNote struct {
 	GitlabCreatedAt common.Iso8601Time `json:"created_at"`
 	Confidential    bool
 	Resolvable      bool `json:"resolvable"`
-	System          bool `json:"system"`
-	Author          struct {
+	Resolved        bool `json:"resolved"`
+	ResolvedBy      *struct {
+		Id int `json:"id"`
+	} `json:"resolved_by"`
+	ResolvedAt *common.Iso8601Time `json:"resolved_at"`
+	System     bool                `json:"system"`
+	Author     struct {
 		Id       int    `json:"id"`
 		Username string `json:"username"`
 	}
@@ -78,6 +83,9 @@ func ExtractApiMergeRequestsNotes(subtaskCtx plugin.SubTaskContext) errors.Error
 					GitlabCreatedAt: toolMrNote.GitlabCreatedAt,
 					Resolvable:      toolMrNote.Resolvable,
 					Type:            toolMrNote.Type,
+					Resolved:        toolMrNote.Resolved,
+					ResolvedById:    toolMrNote.ResolvedById,
+					ResolvedAt:      toolMrNote.ResolvedAt,
 					ConnectionId:    data.Options.ConnectionId,
 				}
 				if toolMrNote.Body == "approved this merge request" {
@@ -112,6 +120,11 @@ func convertMergeRequestNote(mrNote *MergeRequestNote) (*models.GitlabMrNote, er
 		Resolvable:      mrNote.Resolvable,
 		IsSystem:        mrNote.System,
 		Type:            mrNote.Type,
+		Resolved:        mrNote.Resolved,
+		ResolvedAt:      common.Iso8601TimeToTime(mrNote.ResolvedAt),
+	}
+	if mrNote.ResolvedBy != nil {
+		GitlabMrNote.ResolvedById = mrNote.ResolvedBy.Id
 	}
 	return GitlabMrNote, nil
 }
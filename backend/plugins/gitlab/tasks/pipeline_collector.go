@@ -60,6 +60,7 @@ func CollectApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
 		MinTickInterval:    &tickInterval,
 		PageSize:           100,
 		UrlTemplate:        "projects/{{ .Params.ProjectId }}/pipelines",
+		ConditionalRequest: true,
 		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
 			query := url.Values{}
 			if apiCollector.GetSince() != nil {
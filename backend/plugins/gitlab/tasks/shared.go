@@ -206,3 +206,28 @@ func GetMergeRequestsIterator(taskCtx plugin.SubTaskContext, apiCollector *api.S
 
 	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(GitlabInput{}))
 }
+
+func GetIssuesIterator(taskCtx plugin.SubTaskContext, apiCollector *api.StatefulApiCollector) (*api.DalCursorIterator, errors.Error) {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GitlabTaskData)
+	clauses := []dal.Clause{
+		dal.Select("gi.gitlab_id, gi.number AS iid"),
+		dal.From("_tool_gitlab_issues gi"),
+		dal.Where(
+			`gi.project_id = ? and gi.connection_id = ?`,
+			data.Options.ProjectId, data.Options.ConnectionId,
+		),
+	}
+	if apiCollector != nil {
+		if apiCollector.GetSince() != nil {
+			clauses = append(clauses, dal.Where("gitlab_updated_at > ?", *apiCollector.GetSince()))
+		}
+	}
+	// construct the input iterator
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.NewDalCursorIterator(db, cursor, reflect.TypeOf(GitlabInput{}))
+}
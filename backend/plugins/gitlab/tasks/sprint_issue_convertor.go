@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertSprintIssuesMeta)
+}
+
+var ConvertSprintIssuesMeta = plugin.SubTaskMeta{
+	Name:             "Convert Sprint Issues",
+	EntryPoint:       ConvertSprintIssues,
+	EnabledByDefault: true,
+	Description:      "Convert the milestones and iterations of gitlab_issues into domain layer table sprint_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	Dependencies:     []*plugin.SubTaskMeta{&ConvertIssuesMeta, &ConvertIterationsMeta},
+}
+
+func ConvertSprintIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
+	subtaskCommonArgs, data := CreateSubtaskCommonArgs(subtaskCtx, RAW_ISSUE_TABLE)
+
+	db := subtaskCtx.GetDal()
+	connectionId := data.Options.ConnectionId
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GitlabIssue{})
+	milestoneIdGen := didgen.NewDomainIdGenerator(&models.GitlabMilestone{})
+	iterationIdGen := didgen.NewDomainIdGenerator(&models.GitlabIteration{})
+
+	converter, err := api.NewStatefulDataConverter(&api.StatefulDataConverterArgs[models.GitlabIssue]{
+		SubtaskCommonArgs: subtaskCommonArgs,
+		Input: func(stateManager *api.SubtaskStateManager) (dal.Rows, errors.Error) {
+			clauses := []dal.Clause{
+				dal.From(&models.GitlabIssue{}),
+				dal.Where("connection_id = ? AND project_id = ?", connectionId, data.Options.ProjectId),
+			}
+			if stateManager.IsIncremental() {
+				since := stateManager.GetSince()
+				if since != nil {
+					clauses = append(clauses, dal.Where("updated_at >= ? ", since))
+				}
+			}
+			return db.Cursor(clauses...)
+		},
+		BeforeConvert: func(issue *models.GitlabIssue, stateManager *api.SubtaskStateManager) errors.Error {
+			// milestone or iteration might have been removed from the issue
+			return db.Delete(&ticket.SprintIssue{}, dal.Where("issue_id = ?", issueIdGen.Generate(connectionId, issue.GitlabId)))
+		},
+		Convert: func(issue *models.GitlabIssue) ([]interface{}, errors.Error) {
+			var result []interface{}
+			issueId := issueIdGen.Generate(connectionId, issue.GitlabId)
+			if issue.MilestoneId > 0 {
+				result = append(result, &ticket.SprintIssue{
+					SprintId: milestoneIdGen.Generate(connectionId, issue.MilestoneId),
+					IssueId:  issueId,
+				})
+			}
+			if issue.IterationId > 0 {
+				result = append(result, &ticket.SprintIssue{
+					SprintId: iterationIdGen.Generate(connectionId, issue.IterationId),
+					IssueId:  issueId,
+				})
+			}
+			return result, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
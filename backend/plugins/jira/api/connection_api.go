@@ -41,7 +41,7 @@ type JiraTestConnResponse struct {
 func testConnection(ctx context.Context, connection models.JiraConn) (*JiraTestConnResponse, errors.Error) {
 	// validate
 	if vld != nil {
-		e := vld.StructExcept(connection, "BasicAuth", "AccessToken")
+		e := vld.StructExcept(connection, "BasicAuth", "AccessToken", "OAuth2")
 		if e != nil {
 			return nil, errors.Convert(e)
 		}
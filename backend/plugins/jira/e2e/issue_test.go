@@ -75,6 +75,7 @@ func TestIssueDataFlow(t *testing.T) {
 	dataflowTester.FlushTabler(&models.JiraIssueType{})
 	dataflowTester.FlushTabler(&models.JiraIssueLabel{})
 	dataflowTester.FlushTabler(&models.JiraIssueField{})
+	dataflowTester.FlushTabler(&models.JiraIssueFixVersion{})
 	dataflowTester.Subtask(tasks.ExtractIssueTypesMeta, taskData)
 	dataflowTester.Subtask(tasks.ExtractIssuesMeta, taskData)
 	dataflowTester.VerifyTable(
@@ -201,6 +202,15 @@ func TestIssueDataFlow(t *testing.T) {
 			"label_name",
 		})
 
+	dataflowTester.VerifyTableWithRawData(
+		models.JiraIssueFixVersion{},
+		"./snapshot_tables/_tool_jira_issue_fix_versions.csv",
+		[]string{
+			"connection_id",
+			"issue_id",
+			"version_id",
+		})
+
 	// verify issue conversion
 	dataflowTester.FlushTabler(&ticket.Issue{})
 	dataflowTester.FlushTabler(&ticket.BoardIssue{})
@@ -371,6 +381,7 @@ func TestIssueCustomizeDueDate(t *testing.T) {
 	dataflowTester.FlushTabler(&models.JiraIssueType{})
 	dataflowTester.FlushTabler(&models.JiraIssueLabel{})
 	dataflowTester.FlushTabler(&models.JiraIssueField{})
+	dataflowTester.FlushTabler(&models.JiraIssueFixVersion{})
 	dataflowTester.Subtask(tasks.ExtractIssueTypesMeta, taskData)
 	dataflowTester.Subtask(tasks.ExtractIssuesMeta, taskData)
 
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestServiceDeskDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+			ScopeConfig: &models.JiraScopeConfig{
+				TypeMappings: map[string]models.TypeMapping{
+					"子任务": {StandardType: "Sub-task"},
+				},
+				RequestTypeMappings: map[string]string{
+					"Report an incident": "incident",
+				},
+			},
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_service_desk_requests.csv", "_raw_jira_api_service_desk_requests")
+
+	// verify service desk request extraction
+	dataflowTester.FlushTabler(&models.JiraServiceDeskRequest{})
+	dataflowTester.FlushTabler(&models.JiraIssueSla{})
+	dataflowTester.Subtask(tasks.ExtractServiceDeskRequestsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JiraServiceDeskRequest{},
+		"./snapshot_tables/_tool_jira_service_desk_requests.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"issue_id",
+			"issue_key",
+			"service_desk_id",
+			"request_type_id",
+			"request_type_name",
+			"current_status",
+			"created_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		models.JiraIssueSla{},
+		"./snapshot_tables/_tool_jira_issue_slas.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"issue_id",
+			"sla_id",
+			"cycle",
+			"name",
+			"ongoing",
+			"breached",
+			"paused",
+			"start_time",
+			"stop_time",
+			"breach_time",
+			"goal_duration_millis",
+			"elapsed_time_millis",
+			"remaining_time_millis",
+		),
+	)
+
+	// verify sla conversion
+	dataflowTester.FlushTabler(&ticket.IssueSla{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_board_issues.csv", &models.JiraBoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertIssueSlasMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.IssueSla{},
+		"./snapshot_tables/issue_slas.csv",
+		e2ehelper.ColumnWithRawData(
+			"issue_id",
+			"name",
+			"cycle",
+			"is_ongoing",
+			"is_breached",
+			"is_paused",
+			"started_date",
+			"stopped_date",
+			"breach_date",
+			"goal_minutes",
+			"elapsed_minutes",
+			"remaining_minutes",
+		),
+	)
+
+	// verify request type mappings override the issue type
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.FlushTabler(&models.JiraIssueType{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issues.csv", &models.JiraIssue{})
+	dataflowTester.Subtask(tasks.ConvertIssuesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues_with_request_type.csv",
+		[]string{
+			"id",
+			"type",
+			"original_type",
+		},
+	)
+}
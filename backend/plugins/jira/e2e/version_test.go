@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/jira/impl"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks"
+)
+
+func TestVersionDataFlow(t *testing.T) {
+	var plugin impl.Jira
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "jira", plugin)
+
+	taskData := &tasks.JiraTaskData{
+		Options: &tasks.JiraOptions{
+			ConnectionId: 2,
+			BoardId:      8,
+		},
+	}
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_jira_api_versions.csv", "_raw_jira_api_versions")
+
+	// verify version extraction, v2.7.0 was planned for 2020-07-01 before it got released
+	dataflowTester.FlushTabler(&models.JiraVersion{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_versions_before_extraction.csv", &models.JiraVersion{})
+	dataflowTester.Subtask(tasks.ExtractVersionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		models.JiraVersion{},
+		"./snapshot_tables/_tool_jira_versions.csv",
+		e2ehelper.ColumnWithRawData(
+			"connection_id",
+			"version_id",
+			"project_id",
+			"self",
+			"name",
+			"description",
+			"archived",
+			"released",
+			"overdue",
+			"start_date",
+			"release_date",
+			"planned_release_date",
+		),
+	)
+
+	// verify version conversion, only versions of the projects the board issues belong to are converted
+	dataflowTester.FlushTabler(&ticket.Release{})
+	dataflowTester.FlushTabler(&ticket.BoardRelease{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issues.csv", &models.JiraIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_board_issues.csv", &models.JiraBoardIssue{})
+	dataflowTester.Subtask(tasks.ConvertVersionsMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Release{},
+		"./snapshot_tables/releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"name",
+			"description",
+			"url",
+			"status",
+			"started_date",
+			"planned_release_date",
+			"released_date",
+		),
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardRelease{},
+		"./snapshot_tables/board_releases.csv",
+		e2ehelper.ColumnWithRawData(
+			"board_id",
+			"release_id",
+		),
+	)
+
+	// verify release issue conversion
+	dataflowTester.FlushTabler(&ticket.ReleaseIssue{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_jira_issue_fix_versions.csv", &models.JiraIssueFixVersion{})
+	dataflowTester.Subtask(tasks.ConvertReleaseIssuesMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.ReleaseIssue{},
+		"./snapshot_tables/release_issues.csv",
+		e2ehelper.ColumnWithRawData(
+			"release_id",
+			"issue_id",
+		),
+	)
+}
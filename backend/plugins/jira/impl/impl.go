@@ -91,6 +91,10 @@ func (p Jira) GetTablesInfo() []dal.Tabler {
 		&models.JiraIssueRelationship{},
 		&models.JiraScopeConfig{},
 		&models.JiraIssueField{},
+		&models.JiraVersion{},
+		&models.JiraIssueFixVersion{},
+		&models.JiraServiceDeskRequest{},
+		&models.JiraIssueSla{},
 	}
 }
 
@@ -135,9 +139,15 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.CollectRemotelinksMeta,
 		tasks.ExtractRemotelinksMeta,
 
+		tasks.CollectServiceDeskRequestsMeta,
+		tasks.ExtractServiceDeskRequestsMeta,
+
 		tasks.CollectSprintsMeta,
 		tasks.ExtractSprintsMeta,
 
+		tasks.CollectVersionsMeta,
+		tasks.ExtractVersionsMeta,
+
 		tasks.CollectEpicsMeta,
 		tasks.ExtractEpicsMeta,
 
@@ -150,10 +160,14 @@ func (p Jira) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ConvertWorklogsMeta,
 		tasks.ConvertIssueChangelogsMeta,
 		tasks.ConvertIssueRelationshipsMeta,
+		tasks.ConvertIssueSlasMeta,
 
 		tasks.ConvertSprintsMeta,
 		tasks.ConvertSprintIssuesMeta,
 
+		tasks.ConvertVersionsMeta,
+		tasks.ConvertReleaseIssuesMeta,
+
 		tasks.CollectDevelopmentPanelMeta,
 		tasks.ExtractDevelopmentPanelMeta,
 
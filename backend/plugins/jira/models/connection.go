@@ -43,11 +43,14 @@ type JiraConn struct {
 	helper.MultiAuth      `mapstructure:",squash"`
 	helper.BasicAuth      `mapstructure:",squash"`
 	helper.AccessToken    `mapstructure:",squash"`
+	helper.OAuth2         `mapstructure:",squash"`
 }
 
 func (jc *JiraConn) Sanitize() JiraConn {
 	jc.Password = ""
 	jc.AccessToken.Token = utils.SanitizeString(jc.AccessToken.Token)
+	jc.ClientSecret = utils.SanitizeString(jc.ClientSecret)
+	jc.RefreshToken = utils.SanitizeString(jc.RefreshToken)
 	return *jc
 }
 
@@ -70,6 +73,8 @@ func (JiraConnection) TableName() string {
 func (connection *JiraConnection) MergeFromRequest(target *JiraConnection, body map[string]interface{}) error {
 	token := target.Token
 	password := target.Password
+	clientSecret := target.ClientSecret
+	refreshToken := target.RefreshToken
 	authMethod := target.AuthMethod
 
 	if err := helper.DecodeMapStruct(body, target, true); err != nil {
@@ -78,6 +83,8 @@ func (connection *JiraConnection) MergeFromRequest(target *JiraConnection, body
 
 	modifiedToken := target.Token
 	modifiedPassword := target.Password
+	modifiedClientSecret := target.ClientSecret
+	modifiedRefreshToken := target.RefreshToken
 	modifiedAuthMethod := target.AuthMethod
 
 	// maybe auth method has changed
@@ -88,6 +95,12 @@ func (connection *JiraConnection) MergeFromRequest(target *JiraConnection, body
 		if modifiedPassword == "" || modifiedPassword == utils.SanitizeString(password) {
 			target.Password = password
 		}
+		if modifiedClientSecret == "" || modifiedClientSecret == utils.SanitizeString(clientSecret) {
+			target.ClientSecret = clientSecret
+		}
+		if modifiedRefreshToken == "" || modifiedRefreshToken == utils.SanitizeString(refreshToken) {
+			target.RefreshToken = refreshToken
+		}
 	}
 
 	return nil
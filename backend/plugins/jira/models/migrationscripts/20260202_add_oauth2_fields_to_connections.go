@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type jiraConnection20260202 struct {
+	TokenUrl     string `gorm:"type:varchar(255)"`
+	ClientId     string `gorm:"type:varchar(255)"`
+	ClientSecret string `gorm:"type:text;serializer:encdec"`
+	Scopes       string `gorm:"type:varchar(255)"`
+	RefreshToken string `gorm:"type:text;serializer:encdec"`
+}
+
+func (jiraConnection20260202) TableName() string {
+	return "_tool_jira_connections"
+}
+
+type addOAuth2FieldsToConnections struct{}
+
+func (*addOAuth2FieldsToConnections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&jiraConnection20260202{},
+	)
+}
+
+func (*addOAuth2FieldsToConnections) Version() uint64 {
+	return 20260202000001
+}
+
+func (*addOAuth2FieldsToConnections) Name() string {
+	return "add oauth2 client and refresh token to _tool_jira_connections"
+}
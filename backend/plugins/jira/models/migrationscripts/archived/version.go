@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archived
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+)
+
+type JiraVersion struct {
+	archived.NoPKModel
+	ConnectionId       uint64 `gorm:"primaryKey"`
+	VersionId          uint64 `gorm:"primaryKey"`
+	ProjectId          uint64 `gorm:"index"`
+	Self               string `gorm:"type:varchar(255)"`
+	Name               string `gorm:"type:varchar(255)"`
+	Description        string
+	Archived           bool
+	Released           bool
+	Overdue            bool
+	StartDate          *time.Time
+	ReleaseDate        *time.Time
+	PlannedReleaseDate *time.Time
+}
+
+type JiraIssueFixVersion struct {
+	archived.NoPKModel
+	ConnectionId uint64 `gorm:"primaryKey"`
+	IssueId      uint64 `gorm:"primaryKey"`
+	VersionId    uint64 `gorm:"primaryKey"`
+}
+
+func (JiraVersion) TableName() string {
+	return "_tool_jira_versions"
+}
+
+func (JiraIssueFixVersion) TableName() string {
+	return "_tool_jira_issue_fix_versions"
+}
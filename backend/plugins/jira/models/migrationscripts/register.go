@@ -55,5 +55,9 @@ func All() []plugin.MigrationScript {
 		new(flushJiraIssues),
 		new(updateScopeConfig),
 		new(addFixVersions20250619),
+		new(addTlsFieldsToConnections),
+		new(addVersionTables),
+		new(addServiceDeskTables),
+		new(addOAuth2FieldsToConnections),
 	}
 }
@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+type ServiceDeskDate struct {
+	EpochMillis int64 `json:"epochMillis"`
+}
+
+func (d *ServiceDeskDate) ToNullableTime() *time.Time {
+	if d == nil || d.EpochMillis == 0 {
+		return nil
+	}
+	t := time.UnixMilli(d.EpochMillis).UTC()
+	return &t
+}
+
+type ServiceDeskDuration struct {
+	Millis int64 `json:"millis"`
+}
+
+type SlaCycle struct {
+	StartTime     *ServiceDeskDate    `json:"startTime"`
+	StopTime      *ServiceDeskDate    `json:"stopTime"`
+	BreachTime    *ServiceDeskDate    `json:"breachTime"`
+	Breached      bool                `json:"breached"`
+	Paused        bool                `json:"paused"`
+	GoalDuration  ServiceDeskDuration `json:"goalDuration"`
+	ElapsedTime   ServiceDeskDuration `json:"elapsedTime"`
+	RemainingTime ServiceDeskDuration `json:"remainingTime"`
+}
+
+type Sla struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	CompletedCycles []SlaCycle `json:"completedCycles"`
+	OngoingCycle    *SlaCycle  `json:"ongoingCycle"`
+}
+
+type ServiceDesk struct {
+	ID          string `json:"id"`
+	ProjectID   uint64 `json:"projectId,string"`
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName"`
+}
+
+type ServiceDeskRequest struct {
+	IssueID       uint64           `json:"issueId,string"`
+	IssueKey      string           `json:"issueKey"`
+	RequestTypeID string           `json:"requestTypeId"`
+	ServiceDeskID string           `json:"serviceDeskId"`
+	CreatedDate   *ServiceDeskDate `json:"createdDate"`
+	CurrentStatus struct {
+		Status string `json:"status"`
+	} `json:"currentStatus"`
+	RequestType struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"requestType"`
+	Sla struct {
+		Values []Sla `json:"values"`
+	} `json:"sla"`
+}
+
+func (r ServiceDeskRequest) ToToolLayer(connectionId uint64) (*models.JiraServiceDeskRequest, []*models.JiraIssueSla) {
+	request := &models.JiraServiceDeskRequest{
+		ConnectionId:    connectionId,
+		IssueId:         r.IssueID,
+		IssueKey:        r.IssueKey,
+		ServiceDeskId:   r.ServiceDeskID,
+		RequestTypeId:   r.RequestTypeID,
+		RequestTypeName: r.RequestType.Name,
+		CurrentStatus:   r.CurrentStatus.Status,
+		CreatedDate:     r.CreatedDate.ToNullableTime(),
+	}
+	var slas []*models.JiraIssueSla
+	for _, sla := range r.Sla.Values {
+		cycles := sla.CompletedCycles
+		if sla.OngoingCycle != nil {
+			cycles = append(cycles, *sla.OngoingCycle)
+		}
+		for i, cycle := range cycles {
+			slas = append(slas, &models.JiraIssueSla{
+				ConnectionId:        connectionId,
+				IssueId:             r.IssueID,
+				SlaId:               sla.ID,
+				Cycle:               i,
+				Name:                sla.Name,
+				Ongoing:             sla.OngoingCycle != nil && i == len(cycles)-1,
+				Breached:            cycle.Breached,
+				Paused:              cycle.Paused,
+				StartTime:           cycle.StartTime.ToNullableTime(),
+				StopTime:            cycle.StopTime.ToNullableTime(),
+				BreachTime:          cycle.BreachTime.ToNullableTime(),
+				GoalDurationMillis:  cycle.GoalDuration.Millis,
+				ElapsedTimeMillis:   cycle.ElapsedTime.Millis,
+				RemainingTimeMillis: cycle.RemainingTime.Millis,
+			})
+		}
+	}
+	return request, slas
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeskRequestToToolLayer(t *testing.T) {
+	data := `{
+		"issueId": "10063",
+		"issueKey": "EE-1",
+		"requestTypeId": "12",
+		"serviceDeskId": "1",
+		"createdDate": {"epochMillis": 1593586800000},
+		"currentStatus": {"status": "Waiting for support"},
+		"requestType": {"id": "12", "name": "Report an incident"},
+		"sla": {"values": [{
+			"id": "2",
+			"name": "Time to resolution",
+			"completedCycles": [{"startTime": {"epochMillis": 1593590400000}, "stopTime": {"epochMillis": 1593698400000}, "breached": true}],
+			"ongoingCycle": {"startTime": {"epochMillis": 1593763200000}, "breached": false, "paused": true, "remainingTime": {"millis": 79200000}}
+		}]}
+	}`
+	var request ServiceDeskRequest
+	assert.Nil(t, json.Unmarshal([]byte(data), &request))
+
+	toolRequest, slas := request.ToToolLayer(2)
+	assert.Equal(t, uint64(10063), toolRequest.IssueId)
+	assert.Equal(t, "Report an incident", toolRequest.RequestTypeName)
+	assert.Equal(t, "2020-07-01T07:00:00Z", toolRequest.CreatedDate.Format("2006-01-02T15:04:05Z07:00"))
+
+	assert.Len(t, slas, 2)
+	assert.Equal(t, 0, slas[0].Cycle)
+	assert.False(t, slas[0].Ongoing)
+	assert.True(t, slas[0].Breached)
+	assert.NotNil(t, slas[0].StopTime)
+	assert.Equal(t, 1, slas[1].Cycle)
+	assert.True(t, slas[1].Ongoing)
+	assert.True(t, slas[1].Paused)
+	assert.Nil(t, slas[1].StopTime)
+	assert.Equal(t, int64(79200000), slas[1].RemainingTimeMillis)
+}
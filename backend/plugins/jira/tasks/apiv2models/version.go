@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiv2models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+type Version struct {
+	ID          uint64              `json:"id,string"`
+	Self        string              `json:"self"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Archived    bool                `json:"archived"`
+	Released    bool                `json:"released"`
+	Overdue     bool                `json:"overdue"`
+	StartDate   *common.Iso8601Time `json:"startDate"`
+	ReleaseDate *common.Iso8601Time `json:"releaseDate"`
+	ProjectId   uint64              `json:"projectId"`
+}
+
+func (v Version) ToToolLayer(connectionId uint64) *models.JiraVersion {
+	return &models.JiraVersion{
+		ConnectionId: connectionId,
+		VersionId:    v.ID,
+		ProjectId:    v.ProjectId,
+		Self:         v.Self,
+		Name:         v.Name,
+		Description:  v.Description,
+		Archived:     v.Archived,
+		Released:     v.Released,
+		Overdue:      v.Overdue,
+		StartDate:    common.Iso8601TimeToTime(v.StartDate),
+		ReleaseDate:  common.Iso8601TimeToTime(v.ReleaseDate),
+	}
+}
@@ -84,6 +84,7 @@ func CollectIssues(taskCtx plugin.SubTaskContext) errors.Error {
 
 	err = apiCollector.InitCollector(api.ApiCollectorArgs{
 		ApiClient: data.ApiClient,
+		Resumable: true,
 		PageSize:  data.Options.PageSize,
 		/*
 			url may use arbitrary variables from different connection in any order, we need GoTemplate to allow more
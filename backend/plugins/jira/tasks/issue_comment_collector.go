@@ -96,6 +96,7 @@ func CollectIssueComments(taskCtx plugin.SubTaskContext) errors.Error {
 	// now, let ApiCollector takes care the rest
 	err = apiCollector.InitCollector(api.ApiCollectorArgs{
 		ApiClient:     data.ApiClient,
+		Resumable:     true,
 		PageSize:      100,
 		GetTotalPages: GetTotalPagesFromResponse,
 		Input:         iterator,
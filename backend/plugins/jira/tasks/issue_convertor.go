@@ -48,6 +48,10 @@ func ConvertIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
 	if err != nil {
 		return err
 	}
+	requestTypes, err := getRequestTypes(data, db, mappings)
+	if err != nil {
+		return err
+	}
 
 	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
 	accountIdGen := didgen.NewDomainIdGenerator(&models.JiraAccount{})
@@ -144,6 +148,10 @@ func ConvertIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
 			if !(ok && mapped != "") && jiraIssue.Subtask {
 				issue.Type = ticket.SUBTASK
 			}
+			// request type mappings of service desk requests take precedence over issue type mappings
+			if stdType := mappings.StdRequestTypeMappings[requestTypes[jiraIssue.IssueId]]; stdType != "" {
+				issue.Type = stdType
+			}
 			result = append(result, issue)
 			if jiraIssue.AssigneeAccountId != "" {
 				issue.AssigneeId = accountIdGen.Generate(data.Options.ConnectionId, jiraIssue.AssigneeAccountId)
@@ -190,6 +198,29 @@ func ConvertIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
 	return converter.Execute()
 }
 
+// getRequestTypes returns the service desk request type names by issue id, only when request type mappings are set
+func getRequestTypes(data *JiraTaskData, db dal.Dal, mappings *typeMappings) (map[uint64]string, errors.Error) {
+	requestTypes := make(map[uint64]string)
+	if len(mappings.StdRequestTypeMappings) == 0 {
+		return requestTypes, nil
+	}
+	var requests []models.JiraServiceDeskRequest
+	err := db.All(
+		&requests,
+		dal.Select("r.issue_id, r.request_type_name"),
+		dal.From("_tool_jira_service_desk_requests r"),
+		dal.Join("JOIN _tool_jira_board_issues bi ON (bi.connection_id = r.connection_id AND bi.issue_id = r.issue_id)"),
+		dal.Where("bi.connection_id = ? AND bi.board_id = ?", data.Options.ConnectionId, data.Options.BoardId),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		requestTypes[request.IssueId] = request.RequestTypeName
+	}
+	return requestTypes, nil
+}
+
 func convertURL(api, issueKey string) string {
 	u, err := url.Parse(api)
 	if err != nil {
@@ -45,6 +45,7 @@ type typeMappings struct {
 	TypeIdMappings         map[string]string
 	StdTypeMappings        map[string]string
 	StandardStatusMappings map[string]models.StatusMappings
+	StdRequestTypeMappings map[string]string
 }
 
 func ExtractIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
@@ -92,6 +93,13 @@ func ExtractIssues(subtaskCtx plugin.SubTaskContext) errors.Error {
 				if err != nil {
 					return err
 				}
+				err = db.Delete(
+					&models.JiraIssueFixVersion{},
+					dal.Where("connection_id = ? AND issue_id = ?", data.Options.ConnectionId, apiIssue.ID),
+				)
+				if err != nil {
+					return err
+				}
 			}
 			return nil
 		},
@@ -222,6 +230,15 @@ func extractIssues(data *JiraTaskData, mappings *typeMappings, apiIssue *apiv2mo
 	var fixVersionsNames []string
 	for _, v := range fixVersions {
 		fixVersionsNames = append(fixVersionsNames, v.Name)
+		versionId, err := strconv.ParseUint(v.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		results = append(results, &models.JiraIssueFixVersion{
+			ConnectionId: data.Options.ConnectionId,
+			IssueId:      issue.IssueId,
+			VersionId:    versionId,
+		})
 	}
 	issue.FixVersions = strings.Join(fixVersionsNames, ",")
 
@@ -266,15 +283,20 @@ func getTypeMappings(data *JiraTaskData, db dal.Dal) (*typeMappings, errors.Erro
 	}
 	stdTypeMappings := make(map[string]string)
 	standardStatusMappings := make(map[string]models.StatusMappings)
+	stdRequestTypeMappings := make(map[string]string)
 	if data.Options.ScopeConfig != nil {
 		for userType, stdType := range data.Options.ScopeConfig.TypeMappings {
 			stdTypeMappings[userType] = strings.ToUpper(stdType.StandardType)
 			standardStatusMappings[userType] = stdType.StatusMappings
 		}
+		for requestType, stdType := range data.Options.ScopeConfig.RequestTypeMappings {
+			stdRequestTypeMappings[requestType] = strings.ToUpper(stdType)
+		}
 	}
 	return &typeMappings{
 		TypeIdMappings:         typeIdMapping,
 		StdTypeMappings:        stdTypeMappings,
 		StandardStatusMappings: standardStatusMappings,
+		StdRequestTypeMappings: stdRequestTypeMappings,
 	}, nil
 }
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertIssueSlasMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueSlas",
+	EntryPoint:       ConvertIssueSlas,
+	EnabledByDefault: false,
+	Description:      "convert Jira Service Management SLAs into issue_slas",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertIssueSlas(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JiraTaskData)
+
+	clauses := []dal.Clause{
+		dal.Select("s.*"),
+		dal.From("_tool_jira_issue_slas s"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues bi ON (bi.connection_id = s.connection_id AND bi.issue_id = s.issue_id)`),
+		dal.Where("s.connection_id = ? AND bi.board_id = ?", data.Options.ConnectionId, data.Options.BoardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.JiraIssueSla{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_SERVICE_DESK_REQUEST_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraSla := inputRow.(*models.JiraIssueSla)
+			issueSla := &ticket.IssueSla{
+				IssueId:          issueIdGen.Generate(data.Options.ConnectionId, jiraSla.IssueId),
+				Name:             jiraSla.Name,
+				Cycle:            jiraSla.Cycle,
+				IsOngoing:        jiraSla.Ongoing,
+				IsBreached:       jiraSla.Breached,
+				IsPaused:         jiraSla.Paused,
+				StartedDate:      jiraSla.StartTime,
+				StoppedDate:      jiraSla.StopTime,
+				BreachDate:       jiraSla.BreachTime,
+				GoalMinutes:      jiraSla.GoalDurationMillis / int64(time.Minute/time.Millisecond),
+				ElapsedMinutes:   jiraSla.ElapsedTimeMillis / int64(time.Minute/time.Millisecond),
+				RemainingMinutes: jiraSla.RemainingTimeMillis / int64(time.Minute/time.Millisecond),
+			}
+			return []interface{}{issueSla}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertReleaseIssuesMeta = plugin.SubTaskMeta{
+	Name:             "convertReleaseIssues",
+	EntryPoint:       ConvertReleaseIssues,
+	EnabledByDefault: true,
+	Description:      "convert Jira issue fix versions into release_issues",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertReleaseIssues(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JiraTaskData)
+
+	// select fix versions of all issues belongs to the board
+	clauses := []dal.Clause{
+		dal.Select("fv.*"),
+		dal.From("_tool_jira_issue_fix_versions fv"),
+		dal.Join(`LEFT JOIN _tool_jira_board_issues bi ON (bi.connection_id = fv.connection_id AND bi.issue_id = fv.issue_id)`),
+		dal.Where("fv.connection_id = ? AND bi.board_id = ?", data.Options.ConnectionId, data.Options.BoardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	issueIdGen := didgen.NewDomainIdGenerator(&models.JiraIssue{})
+	versionIdGen := didgen.NewDomainIdGenerator(&models.JiraVersion{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.JiraIssueFixVersion{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_ISSUE_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			fixVersion := inputRow.(*models.JiraIssueFixVersion)
+			releaseIssue := &ticket.ReleaseIssue{
+				ReleaseId: versionIdGen.Generate(data.Options.ConnectionId, fixVersion.VersionId),
+				IssueId:   issueIdGen.Generate(data.Options.ConnectionId, fixVersion.IssueId),
+			}
+			return []interface{}{releaseIssue}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -87,6 +87,7 @@ func CollectRemotelinks(taskCtx plugin.SubTaskContext) errors.Error {
 
 	err = apiCollector.InitCollector(api.ApiCollectorArgs{
 		ApiClient:   data.ApiClient,
+		Resumable:   true,
 		Input:       iterator,
 		UrlTemplate: "api/2/issue/{{ .Input.IssueId }}/remotelink",
 		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
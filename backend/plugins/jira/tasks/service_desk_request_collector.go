@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks/apiv2models"
+)
+
+const RAW_SERVICE_DESK_REQUEST_TABLE = "jira_api_service_desk_requests"
+
+var _ plugin.SubTaskEntryPoint = CollectServiceDeskRequests
+
+var CollectServiceDeskRequestsMeta = plugin.SubTaskMeta{
+	Name:             "collectServiceDeskRequests",
+	EntryPoint:       CollectServiceDeskRequests,
+	EnabledByDefault: false,
+	Description:      "collect Jira Service Management request types and SLAs, supports both timeFilter and diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func CollectServiceDeskRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	logger.Info("collect service desk requests")
+
+	// only issues of service desk projects are requests, asking for the others would end up with 404s
+	projectIds, err := getServiceDeskProjectIds(data)
+	if err != nil {
+		return err
+	}
+	if len(projectIds) == 0 {
+		logger.Info("no service desk found, skip collecting service desk requests")
+		return nil
+	}
+
+	apiCollector, err := api.NewStatefulApiCollector(api.RawDataSubTaskArgs{
+		Ctx: taskCtx,
+		Params: JiraApiParams{
+			ConnectionId: data.Options.ConnectionId,
+			BoardId:      data.Options.BoardId,
+		},
+		Table: RAW_SERVICE_DESK_REQUEST_TABLE,
+	})
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("i.issue_id AS issue_id, i.updated AS update_time"),
+		dal.From("_tool_jira_board_issues bi"),
+		dal.Join("LEFT JOIN _tool_jira_issues i ON (bi.connection_id = i.connection_id AND bi.issue_id = i.issue_id)"),
+		dal.Where("bi.connection_id = ? AND bi.board_id = ? AND i.project_id IN ?", data.Options.ConnectionId, data.Options.BoardId, projectIds),
+	}
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("i.updated > ?", apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(apiv2models.Input{}))
+	if err != nil {
+		return err
+	}
+
+	err = apiCollector.InitCollector(api.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Resumable:   true,
+		Input:       iterator,
+		UrlTemplate: "servicedeskapi/request/{{ .Input.IssueId }}",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("expand", "requestType,sla")
+			return query, nil
+		},
+		Concurrency: 10,
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var result json.RawMessage
+			err := api.UnmarshalResponse(res, &result)
+			if err != nil {
+				return nil, err
+			}
+			return []json.RawMessage{result}, nil
+		},
+		// issues might be moved out of service desk projects or deleted since they were collected
+		AfterResponse: ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
+
+// getServiceDeskProjectIds returns ids of the projects backing service desks, it returns nothing when
+// Jira Service Management is not installed
+func getServiceDeskProjectIds(data *JiraTaskData) ([]uint64, errors.Error) {
+	var projectIds []uint64
+	const limit = 50
+	for start := 0; ; start += limit {
+		query := url.Values{}
+		query.Set("start", fmt.Sprintf("%d", start))
+		query.Set("limit", fmt.Sprintf("%d", limit))
+		res, err := data.ApiClient.Get("servicedeskapi/servicedesk", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode == http.StatusNotFound {
+			res.Body.Close()
+			return nil, nil
+		}
+		if res.StatusCode >= 300 || res.StatusCode < 200 {
+			res.Body.Close()
+			return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to list service desks, status code: %d", res.StatusCode))
+		}
+		var page struct {
+			Values     []apiv2models.ServiceDesk `json:"values"`
+			IsLastPage bool                      `json:"isLastPage"`
+		}
+		err = api.UnmarshalResponse(res, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, serviceDesk := range page.Values {
+			projectIds = append(projectIds, serviceDesk.ProjectID)
+		}
+		if page.IsLastPage || len(page.Values) == 0 {
+			return projectIds, nil
+		}
+	}
+}
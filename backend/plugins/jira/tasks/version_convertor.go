@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var ConvertVersionsMeta = plugin.SubTaskMeta{
+	Name:             "convertVersions",
+	EntryPoint:       ConvertVersions,
+	EnabledByDefault: true,
+	Description:      "convert Jira versions into releases",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ConvertVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	connectionId := data.Options.ConnectionId
+	boardId := data.Options.BoardId
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
+	logger.Info("convert versions")
+	clauses := []dal.Clause{
+		dal.Select("v.*"),
+		dal.From("_tool_jira_versions v"),
+		dal.Where(`v.connection_id = ? AND v.project_id IN (
+			SELECT i.project_id FROM _tool_jira_board_issues bi
+			LEFT JOIN _tool_jira_issues i ON (bi.connection_id = i.connection_id AND bi.issue_id = i.issue_id)
+			WHERE bi.connection_id = ? AND bi.board_id = ?
+		)`, connectionId, connectionId, boardId),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	versionIdGen := didgen.NewDomainIdGenerator(&models.JiraVersion{})
+	domainBoardId := didgen.NewDomainIdGenerator(&models.JiraBoard{}).Generate(connectionId, boardId)
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: connectionId,
+				BoardId:      boardId,
+			},
+			Table: RAW_VERSION_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.JiraVersion{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jiraVersion := inputRow.(*models.JiraVersion)
+			release := &ticket.Release{
+				DomainEntity:       domainlayer.DomainEntity{Id: versionIdGen.Generate(connectionId, jiraVersion.VersionId)},
+				Name:               jiraVersion.Name,
+				Description:        jiraVersion.Description,
+				Url:                jiraVersion.Self,
+				Status:             ticket.ReleaseUnreleased,
+				StartedDate:        jiraVersion.StartDate,
+				PlannedReleaseDate: jiraVersion.PlannedReleaseDate,
+			}
+			if jiraVersion.Released {
+				release.Status = ticket.ReleaseReleased
+				release.ReleasedDate = jiraVersion.ReleaseDate
+			}
+			if jiraVersion.Archived {
+				release.Status = ticket.ReleaseArchived
+			}
+			boardRelease := &ticket.BoardRelease{
+				BoardId:   domainBoardId,
+				ReleaseId: release.Id,
+			}
+			return []interface{}{release, boardRelease}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return converter.Execute()
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/apache/incubator-devlake/plugins/jira/tasks/apiv2models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractVersions
+
+var ExtractVersionsMeta = plugin.SubTaskMeta{
+	Name:             "extractVersions",
+	EntryPoint:       ExtractVersions,
+	EnabledByDefault: true,
+	Description:      "extract Jira versions",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+func ExtractVersions(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*JiraTaskData)
+	db := taskCtx.GetDal()
+	// the planned release date has to survive the re-extraction, load it before the old records get deleted
+	var previousVersions []models.JiraVersion
+	err := db.All(&previousVersions, dal.Where("connection_id = ?", data.Options.ConnectionId))
+	if err != nil {
+		return err
+	}
+	plannedReleaseDates := make(map[uint64]*time.Time, len(previousVersions))
+	for _, v := range previousVersions {
+		plannedReleaseDates[v.VersionId] = v.PlannedReleaseDate
+	}
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: JiraApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				BoardId:      data.Options.BoardId,
+			},
+			Table: RAW_VERSION_TABLE,
+		},
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			var apiVersion apiv2models.Version
+			err := errors.Convert(json.Unmarshal(row.Data, &apiVersion))
+			if err != nil {
+				return nil, err
+			}
+			version := apiVersion.ToToolLayer(data.Options.ConnectionId)
+			version.PlannedReleaseDate = getPlannedReleaseDate(version, plannedReleaseDates[version.VersionId])
+			return []interface{}{version}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+// getPlannedReleaseDate keeps the release date recorded before the version got released,
+// so the slippage between the planned and the actual release date can be measured.
+func getPlannedReleaseDate(version *models.JiraVersion, previousPlannedReleaseDate *time.Time) *time.Time {
+	if version.Released && previousPlannedReleaseDate != nil {
+		return previousPlannedReleaseDate
+	}
+	return version.ReleaseDate
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPlannedReleaseDate(t *testing.T) {
+	planned := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	released := time.Date(2020, 7, 10, 0, 0, 0, 0, time.UTC)
+
+	// unreleased versions follow the latest release date
+	version := &models.JiraVersion{Released: false, ReleaseDate: &released}
+	assert.Equal(t, &released, getPlannedReleaseDate(version, &planned))
+
+	// released versions keep the date planned before the release
+	version = &models.JiraVersion{Released: true, ReleaseDate: &released}
+	assert.Equal(t, &planned, getPlannedReleaseDate(version, &planned))
+
+	// released versions seen for the first time have nothing to compare with
+	assert.Equal(t, &released, getPlannedReleaseDate(version, nil))
+}
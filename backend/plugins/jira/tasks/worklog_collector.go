@@ -82,6 +82,7 @@ func CollectWorklogs(taskCtx plugin.SubTaskContext) errors.Error {
 	err = apiCollector.InitCollector(api.ApiCollectorArgs{
 		Input:         iterator,
 		ApiClient:     data.ApiClient,
+		Resumable:     true,
 		UrlTemplate:   "api/2/issue/{{ .Input.IssueId }}/worklog",
 		PageSize:      50,
 		GetTotalPages: GetTotalPagesFromResponse,
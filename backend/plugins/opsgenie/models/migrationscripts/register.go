@@ -33,5 +33,6 @@ func All() []plugin.MigrationScript {
 		new(removeScopeConfig),
 		new(addOpsenieScopeConfig20231214),
 		new(updateOpsenieScopeConfig20240614),
+		new(addTlsFieldsToConnections),
 	}
 }
@@ -34,5 +34,6 @@ func All() []plugin.MigrationScript {
 		new(addIncidentPriority),
 		new(addPagerDutyScopeConfig20231214),
 		new(addPagerDutyScopeConfig20240614),
+		new(addTlsFieldsToConnections),
 	}
 }
@@ -85,7 +85,7 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // ListConnections 列出所有连接
 func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	var connections []models.QDevConnection
-	err := connectionHelper.List(&connections)
+	err := connectionHelper.ListByWorkspace(&connections, input.Workspace)
 	if err != nil {
 		return nil, err
 	}
@@ -167,9 +167,9 @@ func DeleteConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput
 // @Failure 400  {string} errcode.Error "Bad Request"
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /plugins/slack/connections [GET]
-func ListConnections(_ *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	var connections []models.SlackConnection
-	err := connectionHelper.List(&connections)
+	err := connectionHelper.ListByWorkspace(&connections, input.Workspace)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +91,10 @@ func (p Slack) SubTaskMetas() []plugin.SubTaskMeta {
 
 		tasks.CollectThreadMeta,
 		tasks.ExtractThreadMeta,
+
+		tasks.ConvertChannelMeta,
+		tasks.ConvertChannelMessageMeta,
+		tasks.ConvertThreadMeta,
 	}
 }
 
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/chat"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/slack/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertChannelMessage
+
+var ConvertChannelMessageMeta = plugin.SubTaskMeta{
+	Name:             "convertChannelMessage",
+	EntryPoint:       ConvertChannelMessage,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table _tool_slack_channel_messages into domain layer table chat_messages",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+func ConvertChannelMessage(taskCtx plugin.SubTaskContext) errors.Error {
+	data := taskCtx.GetData().(*SlackTaskData)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.SlackChannelMessage{}),
+		dal.Where("connection_id = ? AND channel_id = ?", data.Options.ConnectionId, data.Options.ChannelId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	channelIdGen := didgen.NewDomainIdGenerator(&models.SlackChannel{})
+	messageIdGen := didgen.NewDomainIdGenerator(&models.SlackChannelMessage{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:     taskCtx,
+			Options: data.Options,
+			Table:   RAW_CHANNEL_MESSAGE_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.SlackChannelMessage{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			message := inputRow.(*models.SlackChannelMessage)
+			createdDate, err := parseSlackTs(message.Ts)
+			if err != nil {
+				return nil, err
+			}
+			domainMessage := &chat.ChatMessage{
+				DomainEntity: domainlayer.DomainEntity{Id: messageIdGen.Generate(message.ConnectionId, message.ChannelId, message.Ts)},
+				ChannelId:    channelIdGen.Generate(message.ConnectionId, message.ChannelId),
+				AuthorId:     message.User,
+				Type:         message.Type,
+				Content:      message.Text,
+				CreatedDate:  *createdDate,
+			}
+			if message.Subtype != "" {
+				domainMessage.Type = message.Subtype
+			}
+			// the root message of a thread carries its own ts as thread_ts, replies point to the root
+			if message.ThreadTs != "" {
+				domainMessage.ThreadId = messageIdGen.Generate(message.ConnectionId, message.ChannelId, message.ThreadTs)
+				if message.ThreadTs != message.Ts {
+					domainMessage.ParentId = domainMessage.ThreadId
+				}
+			}
+			return []interface{}{domainMessage}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// parseSlackTs converts a slack message timestamp like `1700000000.123456` into time
+func parseSlackTs(ts string) (*time.Time, errors.Error) {
+	if ts == "" {
+		return nil, nil
+	}
+	secs, micros, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid slack ts "+ts)
+	}
+	var usec int64
+	if micros != "" {
+		usec, err = strconv.ParseInt(micros, 10, 64)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid slack ts "+ts)
+		}
+	}
+	t := time.Unix(sec, usec*int64(time.Microsecond)).UTC()
+	return &t, nil
+}
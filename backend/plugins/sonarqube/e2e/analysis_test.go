@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/helpers/e2ehelper"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/impl"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/tasks"
+)
+
+func TestSonarqubeAnalysisDataFlow(t *testing.T) {
+
+	var sonarqube impl.Sonarqube
+	dataflowTester := e2ehelper.NewDataFlowTester(t, "sonarqube", sonarqube)
+
+	// import raw data table
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_analyses.csv",
+		"_raw_sonarqube_api_analyses")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_quality_gates.csv",
+		"_raw_sonarqube_api_quality_gates")
+	dataflowTester.ImportCsvIntoRawTable("./raw_tables/_raw_sonarqube_api_measure_histories.csv",
+		"_raw_sonarqube_api_measure_histories")
+
+	// Standard data
+	taskData := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 1,
+			ProjectKey:   "f5a50c63-2e8f-4107-9014-853f6f467757",
+		},
+		TaskStartTime: time.Now(),
+	}
+	// Interfered data
+	taskData2 := &tasks.SonarqubeTaskData{
+		Options: &tasks.SonarqubeOptions{
+			ConnectionId: 2,
+			ProjectKey:   "testWarrenEtcd",
+		},
+		TaskStartTime: time.Now(),
+	}
+
+	// verify extraction
+	dataflowTester.FlushTabler(&models.SonarqubeAnalysis{})
+	dataflowTester.Subtask(tasks.ExtractAnalysesMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractAnalysesMeta, taskData2)
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeAnalysis{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_analyses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.SonarqubeQualityGateStatus{})
+	dataflowTester.Subtask(tasks.ExtractQualityGatesMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractQualityGatesMeta, taskData2)
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeQualityGateStatus{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_quality_gate_statuses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	dataflowTester.FlushTabler(&models.SonarqubeMeasureHistory{})
+	dataflowTester.Subtask(tasks.ExtractMeasureHistoriesMeta, taskData)
+	dataflowTester.Subtask(tasks.ExtractMeasureHistoriesMeta, taskData2)
+	dataflowTester.VerifyTableWithOptions(&models.SonarqubeMeasureHistory{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_sonarqube_measure_histories.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify convertor
+	dataflowTester.FlushTabler(&codequality.CqAnalysis{})
+	dataflowTester.FlushTabler(&codequality.CqAnalysisMeasure{})
+	dataflowTester.Subtask(tasks.ConvertAnalysesMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(&codequality.CqAnalysis{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/analyses.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(&codequality.CqAnalysisMeasure{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/analysis_measures.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+}
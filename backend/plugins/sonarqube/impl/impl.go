@@ -85,6 +85,9 @@ func (p Sonarqube) GetTablesInfo() []dal.Tabler {
 		&models.SonarqubeHotspot{},
 		&models.SonarqubeFileMetrics{},
 		&models.SonarqubeAccount{},
+		&models.SonarqubeAnalysis{},
+		&models.SonarqubeQualityGateStatus{},
+		&models.SonarqubeMeasureHistory{},
 		&models.SonarqubeScopeConfig{},
 	}
 }
@@ -101,7 +104,14 @@ func (p Sonarqube) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractHotspotsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
+		tasks.CollectAnalysesMeta,
+		tasks.ExtractAnalysesMeta,
+		tasks.CollectQualityGatesMeta,
+		tasks.ExtractQualityGatesMeta,
+		tasks.CollectMeasureHistoriesMeta,
+		tasks.ExtractMeasureHistoriesMeta,
 		tasks.ConvertProjectsMeta,
+		tasks.ConvertAnalysesMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertIssueImpactsMeta,
 		tasks.ConvertIssueCodeBlocksMeta,
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addAnalyses)(nil)
+
+type sonarqubeAnalysis20260119 struct {
+	ConnectionId   uint64 `gorm:"primaryKey"`
+	AnalysisKey    string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectKey     string `gorm:"index;type:varchar(500)"`
+	Date           *time.Time
+	ProjectVersion string `gorm:"type:varchar(255)"`
+	Revision       string `gorm:"type:varchar(128)"`
+	archived.NoPKModel
+}
+
+func (sonarqubeAnalysis20260119) TableName() string {
+	return "_tool_sonarqube_analyses"
+}
+
+type sonarqubeQualityGateStatus20260119 struct {
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	AnalysisKey      string `gorm:"primaryKey;type:varchar(100)"`
+	ProjectKey       string `gorm:"index;type:varchar(500)"`
+	Status           string `gorm:"type:varchar(20)"`
+	FailedConditions string
+	archived.NoPKModel
+}
+
+func (sonarqubeQualityGateStatus20260119) TableName() string {
+	return "_tool_sonarqube_quality_gate_statuses"
+}
+
+type sonarqubeMeasureHistory20260119 struct {
+	ConnectionId uint64    `gorm:"primaryKey"`
+	ProjectKey   string    `gorm:"primaryKey;type:varchar(500)"`
+	Metric       string    `gorm:"primaryKey;type:varchar(100)"`
+	Date         time.Time `gorm:"primaryKey"`
+	Value        float64
+	archived.NoPKModel
+}
+
+func (sonarqubeMeasureHistory20260119) TableName() string {
+	return "_tool_sonarqube_measure_histories"
+}
+
+type addAnalyses struct{}
+
+func (*addAnalyses) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&sonarqubeAnalysis20260119{},
+		&sonarqubeQualityGateStatus20260119{},
+		&sonarqubeMeasureHistory20260119{},
+	)
+}
+
+func (*addAnalyses) Version() uint64 {
+	return 20260119000002
+}
+
+func (*addAnalyses) Name() string {
+	return "add analyses, quality gate statuses and measure histories for sonarqube"
+}
@@ -39,5 +39,7 @@ func All() []plugin.MigrationScript {
 		new(addOrgToConn),
 		new(addIssueImpacts),
 		new(extendSonarqubeFieldSize),
+		new(addTlsFieldsToConnections),
+		new(addAnalyses),
 	}
 }
@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	sonarqubeModels "github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var ConvertAnalysesMeta = plugin.SubTaskMeta{
+	Name:             "convertAnalyses",
+	EntryPoint:       ConvertAnalyses,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table sonarqube_analyses into domain layer table cq_analyses and cq_analysis_measures",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
+
+type analysisWithQualityGate struct {
+	sonarqubeModels.SonarqubeAnalysis
+	QualityGateStatus           string
+	QualityGateFailedConditions string
+}
+
+func ConvertAnalyses(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_ANALYSES_TABLE)
+
+	// measures are recorded at the exact date of the analysis which computed them
+	var histories []sonarqubeModels.SonarqubeMeasureHistory
+	err := db.All(&histories, dal.Where("connection_id = ? and project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey))
+	if err != nil {
+		return err
+	}
+	measuresByDate := make(map[int64]map[string]float64)
+	for _, history := range histories {
+		date := history.Date.Unix()
+		if measuresByDate[date] == nil {
+			measuresByDate[date] = make(map[string]float64)
+		}
+		measuresByDate[date][history.Metric] = history.Value
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("a.*, q.status AS quality_gate_status, q.failed_conditions AS quality_gate_failed_conditions"),
+		dal.From("_tool_sonarqube_analyses a"),
+		dal.Join("LEFT JOIN _tool_sonarqube_quality_gate_statuses q ON (q.connection_id = a.connection_id AND q.analysis_key = a.analysis_key)"),
+		dal.Where("a.connection_id = ? and a.project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	analysisIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeAnalysis{})
+	projectIdGen := didgen.NewDomainIdGenerator(&sonarqubeModels.SonarqubeProject{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType:       reflect.TypeOf(analysisWithQualityGate{}),
+		Input:              cursor,
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			sonarqubeAnalysis := inputRow.(*analysisWithQualityGate)
+			analysisId := analysisIdGen.Generate(data.Options.ConnectionId, sonarqubeAnalysis.AnalysisKey)
+			projectKey := projectIdGen.Generate(data.Options.ConnectionId, sonarqubeAnalysis.ProjectKey)
+			domainAnalysis := &codequality.CqAnalysis{
+				DomainEntity:                domainlayer.DomainEntity{Id: analysisId},
+				ProjectKey:                  projectKey,
+				AnalysisDate:                sonarqubeAnalysis.Date,
+				ProjectVersion:              sonarqubeAnalysis.ProjectVersion,
+				CommitSha:                   sonarqubeAnalysis.Revision,
+				QualityGateStatus:           sonarqubeAnalysis.QualityGateStatus,
+				QualityGateFailedConditions: sonarqubeAnalysis.QualityGateFailedConditions,
+			}
+			domainMeasure := &codequality.CqAnalysisMeasure{
+				AnalysisId:   analysisId,
+				ProjectKey:   projectKey,
+				AnalysisDate: sonarqubeAnalysis.Date,
+			}
+			if sonarqubeAnalysis.Date != nil {
+				measures := measuresByDate[sonarqubeAnalysis.Date.ToTime().Unix()]
+				domainMeasure.Coverage = floatMeasure(measures, "coverage")
+				domainMeasure.DuplicatedLinesDensity = floatMeasure(measures, "duplicated_lines_density")
+				domainMeasure.SqaleIndex = intMeasure(measures, "sqale_index")
+				domainMeasure.Bugs = intMeasure(measures, "bugs")
+				domainMeasure.Vulnerabilities = intMeasure(measures, "vulnerabilities")
+				domainMeasure.CodeSmells = intMeasure(measures, "code_smells")
+				domainMeasure.SecurityHotspots = intMeasure(measures, "security_hotspots")
+				domainMeasure.Ncloc = intMeasure(measures, "ncloc")
+			}
+			return []interface{}{
+				domainAnalysis,
+				domainMeasure,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func floatMeasure(measures map[string]float64, metric string) *float64 {
+	value, ok := measures[metric]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+func intMeasure(measures map[string]float64, metric string) *int {
+	value, ok := measures[metric]
+	if !ok {
+		return nil
+	}
+	intValue := int(value)
+	return &intValue
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+const RAW_QUALITY_GATES_TABLE = "sonarqube_api_quality_gates"
+
+var _ plugin.SubTaskEntryPoint = CollectQualityGates
+
+type analysisInput struct {
+	AnalysisKey string `json:"analysisKey"`
+}
+
+func CollectQualityGates(taskCtx plugin.SubTaskContext) errors.Error {
+	logger := taskCtx.GetLogger()
+	logger.Info("collect quality gates")
+	db := taskCtx.GetDal()
+
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_QUALITY_GATES_TABLE)
+	apiCollector, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	clauses := []dal.Clause{
+		dal.Select("analysis_key"),
+		dal.From(&models.SonarqubeAnalysis{}),
+		dal.Where("connection_id = ? AND project_key = ?", data.Options.ConnectionId, data.Options.ProjectKey),
+	}
+	// the quality gate status of an analysis never changes once it is computed
+	if apiCollector.IsIncremental() && apiCollector.GetSince() != nil {
+		clauses = append(clauses, dal.Where("date > ?", apiCollector.GetSince()))
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	iterator, err := helper.NewDalCursorIterator(db, cursor, reflect.TypeOf(analysisInput{}))
+	if err != nil {
+		return err
+	}
+
+	err = apiCollector.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		Input:       iterator,
+		UrlTemplate: "qualitygates/project_status",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("analysisId", reqData.Input.(*analysisInput).AnalysisKey)
+			return query, nil
+		},
+		ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var resData struct {
+				Data json.RawMessage `json:"projectStatus"`
+			}
+			err := helper.UnmarshalResponse(res, &resData)
+			if err != nil {
+				return nil, err
+			}
+			return []json.RawMessage{resData.Data}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return apiCollector.Execute()
+}
+
+var CollectQualityGatesMeta = plugin.SubTaskMeta{
+	Name:             "CollectQualityGates",
+	EntryPoint:       CollectQualityGates,
+	EnabledByDefault: true,
+	Description:      "Collect quality gate status of each analysis from Sonarqube api",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
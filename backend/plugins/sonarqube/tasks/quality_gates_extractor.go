@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractQualityGates
+
+func ExtractQualityGates(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_QUALITY_GATES_TABLE)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(resData *helper.RawData) ([]interface{}, errors.Error) {
+			var input analysisInput
+			err := errors.Convert(json.Unmarshal(resData.Input, &input))
+			if err != nil {
+				return nil, err
+			}
+			var res struct {
+				Status     string `json:"status"`
+				Conditions []struct {
+					Status    string `json:"status"`
+					MetricKey string `json:"metricKey"`
+				} `json:"conditions"`
+			}
+			err = errors.Convert(json.Unmarshal(resData.Data, &res))
+			if err != nil {
+				return nil, err
+			}
+			var failedConditions []string
+			for _, condition := range res.Conditions {
+				if condition.Status == "ERROR" {
+					failedConditions = append(failedConditions, condition.MetricKey)
+				}
+			}
+			body := &models.SonarqubeQualityGateStatus{
+				ConnectionId:     data.Options.ConnectionId,
+				AnalysisKey:      input.AnalysisKey,
+				ProjectKey:       data.Options.ProjectKey,
+				Status:           res.Status,
+				FailedConditions: strings.Join(failedConditions, ","),
+			}
+			return []interface{}{body}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+var ExtractQualityGatesMeta = plugin.SubTaskMeta{
+	Name:             "ExtractQualityGates",
+	EntryPoint:       ExtractQualityGates,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table sonarqube_quality_gate_statuses",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
@@ -48,6 +48,8 @@ func GetTablesByDomainLayer(domainLayer string) []string {
 		}
 	case "codequality":
 		return []string{
+			"cq_analyses",
+			"cq_analysis_measures",
 			"cq_file_metrics",
 			"cq_issue_code_blocks",
 			"cq_issues",
@@ -82,6 +84,7 @@ func GetTablesByDomainLayer(domainLayer string) []string {
 		return []string{
 			"board_issues",
 			"boards",
+			"board_releases",
 			"board_sprints",
 			"issue_assignees",
 			"issue_changelogs",
@@ -89,7 +92,10 @@ func GetTablesByDomainLayer(domainLayer string) []string {
 			"issue_custom_array_fields",
 			"issue_labels",
 			"issue_relationships",
+			"issue_slas",
 			"issues",
+			"releases",
+			"release_issues",
 			"sprints",
 			"sprint_issues",
 			"issue_worklogs",
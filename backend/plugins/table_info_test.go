@@ -32,6 +32,8 @@ import (
 	dbt "github.com/apache/incubator-devlake/plugins/dbt/impl"
 	dora "github.com/apache/incubator-devlake/plugins/dora/impl"
 	feishu "github.com/apache/incubator-devlake/plugins/feishu/impl"
+	gerrit "github.com/apache/incubator-devlake/plugins/gerrit/impl"
+	gitea "github.com/apache/incubator-devlake/plugins/gitea/impl"
 	gitee "github.com/apache/incubator-devlake/plugins/gitee/impl"
 	gitextractor "github.com/apache/incubator-devlake/plugins/gitextractor/impl"
 	github "github.com/apache/incubator-devlake/plugins/github/impl"
@@ -73,6 +75,8 @@ func Test_GetPluginTablesInfo(t *testing.T) {
 	checker.FeedIn("dbt", dbt.Dbt{}.GetTablesInfo)
 	checker.FeedIn("dora/models", dora.Dora{}.GetTablesInfo)
 	checker.FeedIn("feishu/models", feishu.Feishu{}.GetTablesInfo)
+	checker.FeedIn("gerrit/models", gerrit.Gerrit{}.GetTablesInfo)
+	checker.FeedIn("gitea/models", gitea.Gitea{}.GetTablesInfo)
 	checker.FeedIn("gitee/models", gitee.Gitee{}.GetTablesInfo)
 	checker.FeedIn("gitextractor/models", gitextractor.GitExtractor{}.GetTablesInfo)
 	checker.FeedIn("github/models", github.Github{}.GetTablesInfo)
@@ -36,5 +36,6 @@ func All() []plugin.MigrationScript {
 		new(addCompanyIdToConnection),
 		new(updateScopeConfig20250305),
 		new(addLifetimeTables),
+		new(addTlsFieldsToConnections),
 	}
 }
@@ -19,6 +19,7 @@ package e2e
 
 import (
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
 	"github.com/apache/incubator-devlake/plugins/trello/impl"
 	"github.com/apache/incubator-devlake/plugins/trello/models"
@@ -34,6 +35,11 @@ func TestTrelloCardDataFlow(t *testing.T) {
 		Options: &tasks.TrelloOptions{
 			ConnectionId: 1,
 			BoardId:      "6402f643d23aa9af56b28f4b",
+			ScopeConfig: &models.TrelloScopeConfig{
+				StatusMappings: map[string]string{
+					"🐞 Bugs": ticket.IN_PROGRESS,
+				},
+			},
 		},
 	}
 
@@ -42,9 +48,77 @@ func TestTrelloCardDataFlow(t *testing.T) {
 
 	// verify extraction
 	dataflowTester.FlushTabler(&models.TrelloCard{})
+	dataflowTester.FlushTabler(&models.TrelloCardLabel{})
+	dataflowTester.FlushTabler(&models.TrelloCardMember{})
 	dataflowTester.Subtask(tasks.ExtractCardMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(models.TrelloCard{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/_tool_trello_cards.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+	dataflowTester.VerifyTableWithOptions(models.TrelloCardLabel{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_trello_card_labels.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+	dataflowTester.VerifyTableWithOptions(models.TrelloCardMember{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/_tool_trello_card_members.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
+
+	// verify conversion
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_lists.csv", &models.TrelloList{})
+	dataflowTester.ImportCsvIntoTabler("./snapshot_tables/_tool_trello_members.csv", &models.TrelloMember{})
+	dataflowTester.FlushTabler(&ticket.Issue{})
+	dataflowTester.FlushTabler(&ticket.BoardIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.Subtask(tasks.ConvertCardMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.Issue{},
+		"./snapshot_tables/issues.csv",
+		e2ehelper.ColumnWithRawData(
+			"id",
+			"url",
+			"issue_key",
+			"title",
+			"description",
+			"type",
+			"original_type",
+			"status",
+			"original_status",
+			"resolution_date",
+			"created_date",
+			"updated_date",
+			"lead_time_minutes",
+			"due_date",
+			"assignee_id",
+			"assignee_name",
+		),
+	)
+	dataflowTester.VerifyTable(
+		ticket.BoardIssue{},
+		"./snapshot_tables/board_issues.csv",
+		e2ehelper.ColumnWithRawData(
+			"board_id",
+			"issue_id",
+		),
+	)
+	dataflowTester.VerifyTable(
+		ticket.IssueAssignee{},
+		"./snapshot_tables/issue_assignees.csv",
+		e2ehelper.ColumnWithRawData(
+			"issue_id",
+			"assignee_id",
+			"assignee_name",
+		),
+	)
+
+	dataflowTester.FlushTabler(&ticket.IssueLabel{})
+	dataflowTester.Subtask(tasks.ConvertCardLabelMeta, taskData)
+	dataflowTester.VerifyTable(
+		ticket.IssueLabel{},
+		"./snapshot_tables/issue_labels.csv",
+		e2ehelper.ColumnWithRawData(
+			"issue_id",
+			"label_name",
+		),
+	)
 }
@@ -36,6 +36,9 @@ type TrelloCard struct {
 	ShortUrl         string `gorm:"type:varchar(255)"`
 	Subscribed       bool
 	Url              string `gorm:"type:varchar(255)"`
+	Desc             string `gorm:"type:text"`
+	Due              *time.Time
+	Start            *time.Time
 	common.NoPKModel
 }
 
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type trelloCard20260116 struct {
+	Desc  string `gorm:"type:text"`
+	Due   *time.Time
+	Start *time.Time
+}
+
+func (trelloCard20260116) TableName() string {
+	return "_tool_trello_cards"
+}
+
+type trelloCardLabel20260116 struct {
+	IDCard  string `gorm:"primaryKey;type:varchar(255)"`
+	IDLabel string `gorm:"primaryKey;type:varchar(255)"`
+	IDBoard string `gorm:"type:varchar(255)"`
+	Name    string `gorm:"type:varchar(255)"`
+	Color   string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (trelloCardLabel20260116) TableName() string {
+	return "_tool_trello_card_labels"
+}
+
+type trelloCardMember20260116 struct {
+	IDCard   string `gorm:"primaryKey;type:varchar(255)"`
+	IDMember string `gorm:"primaryKey;type:varchar(255)"`
+	IDBoard  string `gorm:"type:varchar(255)"`
+	archived.NoPKModel
+}
+
+func (trelloCardMember20260116) TableName() string {
+	return "_tool_trello_card_members"
+}
+
+type trelloScopeConfig20260116 struct {
+	StatusMappings map[string]string `gorm:"serializer:json"`
+}
+
+func (trelloScopeConfig20260116) TableName() string {
+	return "_tool_trello_scope_configs"
+}
+
+type addCardRelationsAndStatusMappings struct{}
+
+func (*addCardRelationsAndStatusMappings) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&trelloCard20260116{},
+		&trelloCardLabel20260116{},
+		&trelloCardMember20260116{},
+		&trelloScopeConfig20260116{},
+	)
+}
+
+func (*addCardRelationsAndStatusMappings) Version() uint64 {
+	return 20260116000001
+}
+
+func (*addCardRelationsAndStatusMappings) Name() string {
+	return "add card labels, card members and status mappings for trello"
+}
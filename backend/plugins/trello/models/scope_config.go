@@ -23,6 +23,8 @@ import (
 
 type TrelloScopeConfig struct {
 	common.ScopeConfig `mapstructure:",squash" json:",inline" gorm:"embedded"`
+	// StatusMappings maps list names to standard statuses: TODO, IN_PROGRESS, DONE or OTHER
+	StatusMappings map[string]string `mapstructure:"statusMappings,omitempty" json:"statusMappings" gorm:"serializer:json"`
 }
 
 func (TrelloScopeConfig) TableName() string {
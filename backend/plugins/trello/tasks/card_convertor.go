@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertCard
+
+var ConvertCardMeta = plugin.SubTaskMeta{
+	Name:             "ConvertCard",
+	EntryPoint:       ConvertCard,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_cards into domain layer table issues, board_issues and issue_assignees",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+}
+
+type trelloCardWithList struct {
+	models.TrelloCard
+	ListName string
+}
+
+type trelloCardAssignee struct {
+	IDCard   string
+	IDMember string
+	FullName string
+}
+
+func ConvertCard(taskCtx plugin.SubTaskContext) errors.Error {
+	taskData := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+
+	// load card members upfront, a card may have multiple members and the first one is taken as the assignee
+	var cardAssignees []trelloCardAssignee
+	err := db.All(
+		&cardAssignees,
+		dal.Select("cm.id_card, cm.id_member, m.full_name"),
+		dal.From("_tool_trello_card_members cm"),
+		dal.Join("LEFT JOIN _tool_trello_members m ON m.id = cm.id_member"),
+		dal.Where("cm.id_board = ?", taskData.Options.BoardId),
+		dal.Orderby("cm.id_card, cm.id_member"),
+	)
+	if err != nil {
+		return err
+	}
+	assigneesByCard := make(map[string][]trelloCardAssignee)
+	for _, assignee := range cardAssignees {
+		assigneesByCard[assignee.IDCard] = append(assigneesByCard[assignee.IDCard], assignee)
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("c.*, l.name AS list_name"),
+		dal.From("_tool_trello_cards c"),
+		dal.Join("LEFT JOIN _tool_trello_lists l ON l.id = c.id_list"),
+		dal.Where("c.id_board = ?", taskData.Options.BoardId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	statusRule := newStatusRule(taskData)
+	boardId := didgen.NewDomainIdGenerator(&models.TrelloBoard{}).Generate(taskData.Options.ConnectionId, taskData.Options.BoardId)
+	cardIdGen := didgen.NewDomainIdGenerator(&models.TrelloCard{})
+	memberIdGen := didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: TrelloApiParams{
+				ConnectionId: taskData.Options.ConnectionId,
+				BoardId:      taskData.Options.BoardId,
+			},
+			Table: RAW_CARD_TABLE,
+		},
+		InputRowType: reflect.TypeOf(trelloCardWithList{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			card := inputRow.(*trelloCardWithList)
+			issue := &ticket.Issue{
+				DomainEntity:   domainlayer.DomainEntity{Id: cardIdGen.Generate(card.ID)},
+				Url:            card.Url,
+				IssueKey:       strconv.Itoa(card.IDShort),
+				Title:          card.Name,
+				Description:    card.Desc,
+				Type:           ticket.TASK,
+				OriginalType:   "card",
+				Status:         getStdStatus(statusRule, card.ListName),
+				OriginalStatus: card.ListName,
+				CreatedDate:    getCreatedDate(card.ID),
+				UpdatedDate:    &card.DateLastActivity,
+				DueDate:        card.Due,
+			}
+			if issue.Status == ticket.DONE {
+				issue.ResolutionDate = &card.DateLastActivity
+				if issue.CreatedDate != nil && issue.ResolutionDate.After(*issue.CreatedDate) {
+					leadTimeMinutes := uint(issue.ResolutionDate.Sub(*issue.CreatedDate).Minutes())
+					issue.LeadTimeMinutes = &leadTimeMinutes
+				}
+			}
+			results := []interface{}{issue}
+			for i, assignee := range assigneesByCard[card.ID] {
+				issueAssignee := &ticket.IssueAssignee{
+					IssueId:      issue.Id,
+					AssigneeId:   memberIdGen.Generate(assignee.IDMember),
+					AssigneeName: assignee.FullName,
+				}
+				if i == 0 {
+					issue.AssigneeId = issueAssignee.AssigneeId
+					issue.AssigneeName = issueAssignee.AssigneeName
+				}
+				results = append(results, issueAssignee)
+			}
+			results = append(results, &ticket.BoardIssue{
+				BoardId: boardId,
+				IssueId: issue.Id,
+			})
+			return results, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -32,41 +32,42 @@ var ExtractCardMeta = plugin.SubTaskMeta{
 	Name:             "ExtractCard",
 	EntryPoint:       ExtractCard,
 	EnabledByDefault: true,
-	Description:      "Extract raw data into tool layer table trello_cards",
+	Description:      "Extract raw data into tool layer table trello_cards, trello_card_labels and trello_card_members",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 type TrelloApiCard struct {
-	ID                    string        `json:"id"`
-	Badges                interface{}   `json:"badges"`
-	CheckItemStates       interface{}   `json:"checkItemStates"`
-	Closed                bool          `json:"closed"`
-	DueComplete           bool          `json:"dueComplete"`
-	DateLastActivity      time.Time     `json:"dateLastActivity"`
-	Desc                  string        `json:"desc"`
-	DescData              interface{}   `json:"descData"`
-	Due                   interface{}   `json:"due"`
-	DueReminder           interface{}   `json:"dueReminder"`
-	Email                 interface{}   `json:"email"`
-	IDBoard               string        `json:"idBoard"`
-	IDChecklists          []string      `json:"idChecklists"`
-	IDList                string        `json:"idList"`
-	IDMembers             []string      `json:"idMembers"`
-	IDMembersVoted        []string      `json:"idMembersVoted"`
-	IDShort               int           `json:"idShort"`
-	IDAttachmentCover     string        `json:"idAttachmentCover"`
-	Labels                []interface{} `json:"labels"`
-	IDLabels              []string      `json:"idLabels"`
-	ManualCoverAttachment bool          `json:"manualCoverAttachment"`
-	Name                  string        `json:"name"`
-	Pos                   float64       `json:"pos"`
-	ShortLink             string        `json:"shortLink"`
-	ShortUrl              string        `json:"shortUrl"`
-	Start                 interface{}   `json:"start"`
-	Subscribed            bool          `json:"subscribed"`
-	Url                   string        `json:"url"`
-	Cover                 interface{}   `json:"cover"`
-	IsTemplate            bool          `json:"isTemplate"`
-	CardRole              interface{}   `json:"cardRole"`
+	ID                    string           `json:"id"`
+	Badges                interface{}      `json:"badges"`
+	CheckItemStates       interface{}      `json:"checkItemStates"`
+	Closed                bool             `json:"closed"`
+	DueComplete           bool             `json:"dueComplete"`
+	DateLastActivity      time.Time        `json:"dateLastActivity"`
+	Desc                  string           `json:"desc"`
+	DescData              interface{}      `json:"descData"`
+	Due                   *time.Time       `json:"due"`
+	DueReminder           interface{}      `json:"dueReminder"`
+	Email                 interface{}      `json:"email"`
+	IDBoard               string           `json:"idBoard"`
+	IDChecklists          []string         `json:"idChecklists"`
+	IDList                string           `json:"idList"`
+	IDMembers             []string         `json:"idMembers"`
+	IDMembersVoted        []string         `json:"idMembersVoted"`
+	IDShort               int              `json:"idShort"`
+	IDAttachmentCover     string           `json:"idAttachmentCover"`
+	Labels                []TrelloApiLabel `json:"labels"`
+	IDLabels              []string         `json:"idLabels"`
+	ManualCoverAttachment bool             `json:"manualCoverAttachment"`
+	Name                  string           `json:"name"`
+	Pos                   float64          `json:"pos"`
+	ShortLink             string           `json:"shortLink"`
+	ShortUrl              string           `json:"shortUrl"`
+	Start                 *time.Time       `json:"start"`
+	Subscribed            bool             `json:"subscribed"`
+	Url                   string           `json:"url"`
+	Cover                 interface{}      `json:"cover"`
+	IsTemplate            bool             `json:"isTemplate"`
+	CardRole              interface{}      `json:"cardRole"`
 }
 
 func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
@@ -87,7 +88,7 @@ func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, err
 			}
-			return []interface{}{
+			results := []interface{}{
 				&models.TrelloCard{
 					ID:               apiCard.ID,
 					Name:             apiCard.Name,
@@ -102,8 +103,28 @@ func ExtractCard(taskCtx plugin.SubTaskContext) errors.Error {
 					ShortUrl:         apiCard.ShortUrl,
 					Subscribed:       apiCard.Subscribed,
 					Url:              apiCard.Url,
+					Desc:             apiCard.Desc,
+					Due:              apiCard.Due,
+					Start:            apiCard.Start,
 				},
-			}, nil
+			}
+			for _, label := range apiCard.Labels {
+				results = append(results, &models.TrelloCardLabel{
+					IDCard:  apiCard.ID,
+					IDLabel: label.ID,
+					IDBoard: apiCard.IDBoard,
+					Name:    label.Name,
+					Color:   label.Color,
+				})
+			}
+			for _, memberId := range apiCard.IDMembers {
+				results = append(results, &models.TrelloCardMember{
+					IDCard:   apiCard.ID,
+					IDMember: memberId,
+					IDBoard:  apiCard.IDBoard,
+				})
+			}
+			return results, nil
 		},
 	})
 	if err != nil {
@@ -34,7 +34,7 @@ var CollectLabelMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectLabel,
 	EnabledByDefault: true,
 	Description:      "Collect label data from Trello api",
-	DomainTypes:      []string{},
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
 func CollectLabel(taskCtx plugin.SubTaskContext) errors.Error {
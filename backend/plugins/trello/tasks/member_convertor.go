@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ConvertMember
+
+var ConvertMemberMeta = plugin.SubTaskMeta{
+	Name:             "ConvertMember",
+	EntryPoint:       ConvertMember,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table trello_members into domain layer table accounts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+func ConvertMember(taskCtx plugin.SubTaskContext) errors.Error {
+	taskData := taskCtx.GetData().(*TrelloTaskData)
+	db := taskCtx.GetDal()
+	params := TrelloApiParams{
+		ConnectionId: taskData.Options.ConnectionId,
+		BoardId:      taskData.Options.BoardId,
+	}
+	// members are shared among boards, only those last extracted from this board are converted here
+	cursor, err := db.Cursor(
+		dal.From(&models.TrelloMember{}),
+		dal.Where("_raw_data_table = ? AND _raw_data_params = ?", "_raw_"+RAW_MEMBER_TABLE, plugin.MarshalScopeParams(params)),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	memberIdGen := didgen.NewDomainIdGenerator(&models.TrelloMember{})
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx:    taskCtx,
+			Params: params,
+			Table:  RAW_MEMBER_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.TrelloMember{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			member := inputRow.(*models.TrelloMember)
+			account := &crossdomain.Account{
+				DomainEntity: domainlayer.DomainEntity{Id: memberIdGen.Generate(member.ID)},
+				FullName:     member.FullName,
+				UserName:     member.Username,
+			}
+			return []interface{}{account}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -32,6 +32,7 @@ var ExtractMemberMeta = plugin.SubTaskMeta{
 	EntryPoint:       ExtractMember,
 	EnabledByDefault: true,
 	Description:      "Extract raw data into tool layer table trello_members",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
 }
 
 type TrelloApiMember struct {
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// newStatusRule builds a status rule from the list name mappings configured in the scope config
+func newStatusRule(data *TrelloTaskData) *ticket.StatusRule {
+	rule := &ticket.StatusRule{}
+	if data.Options.ScopeConfig == nil {
+		return rule
+	}
+	for listName, stdStatus := range data.Options.ScopeConfig.StatusMappings {
+		switch strings.ToUpper(stdStatus) {
+		case ticket.TODO:
+			rule.Todo = append(rule.Todo, listName)
+		case ticket.IN_PROGRESS:
+			rule.InProgress = append(rule.InProgress, listName)
+		case ticket.DONE:
+			rule.Done = append(rule.Done, listName)
+		case ticket.OTHER:
+			rule.Other = append(rule.Other, listName)
+		}
+	}
+	return rule
+}
+
+// getStdStatus maps a list name to a standard status, lists that are not configured are guessed by their names
+func getStdStatus(rule *ticket.StatusRule, listName string) string {
+	if status := ticket.GetStatus(rule, listName); status != "" {
+		return status
+	}
+	name := strings.ToLower(listName)
+	switch {
+	case strings.Contains(name, "done") || strings.Contains(name, "complete"):
+		return ticket.DONE
+	case strings.Contains(name, "doing") || strings.Contains(name, "progress") || strings.Contains(name, "working") ||
+		strings.Contains(name, "review") || strings.Contains(name, "testing"):
+		return ticket.IN_PROGRESS
+	default:
+		return ticket.TODO
+	}
+}
+
+// getCreatedDate extracts the creation time encoded in the first 8 hex characters of a trello object id
+func getCreatedDate(id string) *time.Time {
+	if len(id) < 8 {
+		return nil
+	}
+	secs, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return nil
+	}
+	createdDate := time.Unix(secs, 0).UTC()
+	return &createdDate
+}
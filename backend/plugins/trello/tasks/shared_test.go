@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/plugins/trello/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStdStatus(t *testing.T) {
+	data := &TrelloTaskData{
+		Options: &TrelloOptions{
+			ScopeConfig: &models.TrelloScopeConfig{
+				StatusMappings: map[string]string{
+					"Bugs":     "in_progress",
+					"Shipped":  ticket.DONE,
+					"Archived": ticket.OTHER,
+				},
+			},
+		},
+	}
+	rule := newStatusRule(data)
+	assert.Equal(t, ticket.IN_PROGRESS, getStdStatus(rule, "Bugs"))
+	assert.Equal(t, ticket.DONE, getStdStatus(rule, "Shipped"))
+	assert.Equal(t, ticket.OTHER, getStdStatus(rule, "Archived"))
+	// lists without mappings are guessed by name
+	assert.Equal(t, ticket.DONE, getStdStatus(rule, "Sprint - Done"))
+	assert.Equal(t, ticket.IN_PROGRESS, getStdStatus(rule, "In Progress"))
+	assert.Equal(t, ticket.TODO, getStdStatus(rule, "Backlog"))
+
+	empty := newStatusRule(&TrelloTaskData{Options: &TrelloOptions{}})
+	assert.Equal(t, ticket.TODO, getStdStatus(empty, "Bugs"))
+}
+
+func TestGetCreatedDate(t *testing.T) {
+	assert.Equal(t, time.Date(2023, 3, 4, 7, 41, 55, 0, time.UTC), *getCreatedDate("6402f643d23aa9af56b28ffd"))
+	assert.Nil(t, getCreatedDate("abc"))
+	assert.Nil(t, getCreatedDate("zzzzzzzz0000"))
+}
@@ -57,7 +57,8 @@ func PostConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 	name := apiKeyHelper.GenApiKeyNameForPlugin(pluginName, connection.ID)
 	allowedPath := fmt.Sprintf("/plugins/%s/connections/%d/.*", pluginName, connection.ID)
 	extra := fmt.Sprintf("connectionId:%d", connection.ID)
-	apiKeyRecord, err := apiKeyHelper.CreateForPlugin(tx, input.User, name, pluginName, allowedPath, extra)
+	// the key only pushes data to the connection
+	apiKeyRecord, err := apiKeyHelper.CreateForPlugin(tx, input.User, name, pluginName, allowedPath, extra, input.Workspace, coreModels.ROLE_OPERATOR)
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			logger.Error(err, "transaction Rollback")
@@ -201,7 +202,7 @@ type WebhookConnectionResponse struct {
 // @Router /plugins/webhook/connections [GET]
 func ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	var connections []models.WebhookConnection
-	err := connectionHelper.List(&connections)
+	err := connectionHelper.ListByWorkspace(&connections, input.Workspace)
 	if err != nil {
 		return nil, err
 	}
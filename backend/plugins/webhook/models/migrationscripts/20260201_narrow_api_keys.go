@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*narrowApiKeys)(nil)
+
+type apiKey20260201 struct{}
+
+func (apiKey20260201) TableName() string {
+	return "_devlake_api_keys"
+}
+
+type workspaceConnection20260201 struct {
+	ConnectionId uint64
+	Workspace    string
+}
+
+func (workspaceConnection20260201) TableName() string {
+	return "_devlake_workspace_connections"
+}
+
+type narrowApiKeys struct{}
+
+// Up binds the api keys of the webhooks to the workspaces of their connections, they used to be admin keys of
+// all workspaces while they only push data to the connections
+func (*narrowApiKeys) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	keyType := fmt.Sprintf("plugin:%s", pluginName)
+	err := db.UpdateColumn(new(apiKey20260201), "role", "operator", dal.Where("type = ?", keyType))
+	if err != nil {
+		return err
+	}
+	var workspaceConnections []workspaceConnection20260201
+	err = db.All(&workspaceConnections, dal.Where("plugin = ?", pluginName))
+	if err != nil {
+		return err
+	}
+	for _, workspaceConnection := range workspaceConnections {
+		err = db.UpdateColumn(
+			new(apiKey20260201),
+			"workspace", workspaceConnection.Workspace,
+			dal.Where("type = ? AND extra = ?", keyType, fmt.Sprintf("connectionId:%d", workspaceConnection.ConnectionId)),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*narrowApiKeys) Version() uint64 {
+	return 20260201000001
+}
+
+func (*narrowApiKeys) Name() string {
+	return "bind webhook api keys to the workspaces of their connections with the operator role"
+}
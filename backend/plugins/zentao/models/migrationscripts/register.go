@@ -34,5 +34,6 @@ func All() []plugin.MigrationScript {
 		new(dropTotalReal),
 		new(addWorklogs),
 		new(updateScopeConfig),
+		new(addTlsFieldsToConnections),
 	}
 }
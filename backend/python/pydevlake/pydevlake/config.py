@@ -13,10 +13,33 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import logging
+from contextlib import contextmanager
+from contextvars import ContextVar
+
 from pydevlake.logger import log_levels, logger, INFO
 
 
+# the log level of the call served by the current thread, see call_config
+_call_log_level: ContextVar[int] = ContextVar('call_log_level', default=INFO)
+
+
 # sets the global config of pydevlake
 def set_config(cfg: dict):
     log_level = cfg.get("log_level")
     logger.setLevel(log_levels.get(log_level, INFO))
+
+
+# scopes the config to the current call, the calls served concurrently by the same process don't share it
+@contextmanager
+def call_config(cfg: dict):
+    token = _call_log_level.set(log_levels.get(cfg.get("log_level"), INFO))
+    try:
+        yield
+    finally:
+        _call_log_level.reset(token)
+
+
+# filters the log records by the level of the current call, the logger itself has to let all levels through
+def call_log_filter(record: logging.LogRecord) -> bool:
+    return record.levelno >= _call_log_level.get()
@@ -113,6 +113,13 @@ class PluginCommands:
         c = self._plugin.connection_type(**connection)
         yield from self._plugin.make_remote_scopes(c, group_id)
 
+    def serve(self, workers: int = 4):
+        """
+        Keeps the process alive to serve the calls from the go side, see RpcServer
+        """
+        from pydevlake.rpc import RpcServer
+        RpcServer(self, workers).serve()
+
     def _mk_context(self, data: dict):
         db_url = data['db_url']
         scope_dict = data['scope']
@@ -0,0 +1,172 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import os
+import json
+import threading
+from concurrent.futures import ThreadPoolExecutor
+from typing import Generator, Optional, TextIO
+
+from pydevlake.config import call_config, call_log_filter
+from pydevlake.logger import logger, DEBUG
+from pydevlake.message import Message
+
+
+# the go side reads responses from fd 3 and writes requests to fd 4, stdout and stderr are kept for logging
+RESPONSE_FD = 3
+REQUEST_FD = 4
+
+
+class RpcServer:
+    """
+    Serves the @plugin_method methods of PluginCommands over newline delimited JSON messages, so that
+    the process can be kept alive and serve multiple calls concurrently.
+    A request is {"id": 1, "method": "collect", "args": [...]}, each message yielded by the method is sent
+    as {"id": 1, "result": {...}}, followed by {"id": 1, "done": true} or {"id": 1, "error": "..."}.
+    A running call stops at the next message after {"id": 1, "cancel": true} is received.
+    The messages are flow controlled: a call may send as many results as the credits it was granted, the initial
+    ones come with the request as {"id": 1, "method": "collect", "args": [...], "credit": 64}, more are granted
+    with {"id": 1, "credit": 32} as the go side consumes the results. Calls without credits are not limited.
+    """
+
+    def __init__(self, commands, workers: int = 4):
+        self._commands = commands
+        self._executor = ThreadPoolExecutor(max_workers=workers)
+        self._send_lock = threading.Lock()
+        self._calls: dict[int, RpcCall] = {}
+        self._calls_lock = threading.Lock()
+        self._send_ch: TextIO = None
+
+    def serve(self):
+        # the log level is configured per call, see _handle
+        logger.setLevel(DEBUG)
+        for handler in logger.handlers:
+            handler.addFilter(call_log_filter)
+        with os.fdopen(RESPONSE_FD, 'w') as send_ch, os.fdopen(REQUEST_FD, 'r') as recv_ch:
+            self.serve_channels(send_ch, recv_ch)
+
+    def serve_channels(self, send_ch: TextIO, recv_ch: TextIO):
+        self._send_ch = send_ch
+        for line in recv_ch:
+            if not line.strip():
+                continue
+            request = json.loads(line)
+            req_id = request['id']
+            method = request.get('method')
+            if request.get('cancel') or not method:
+                with self._calls_lock:
+                    call = self._calls.get(req_id)
+                if call is not None:
+                    if request.get('cancel'):
+                        call.cancel()
+                    else:
+                        call.grant(request.get('credit', 0))
+                continue
+            if method == 'ping':
+                # answered by the reader thread so health checks pass while all workers are busy
+                self._send(req_id, done=True)
+                continue
+            call = RpcCall(request.get('credit'))
+            with self._calls_lock:
+                self._calls[req_id] = call
+            self._executor.submit(self._handle, req_id, method, request.get('args', []), call)
+        # the go side closed the requests, stop the calls waiting for credits and finish the running ones
+        with self._calls_lock:
+            for call in self._calls.values():
+                call.cancel()
+        self._executor.shutdown(wait=True)
+
+    def _handle(self, req_id: int, method: str, args: list, call: 'RpcCall'):
+        # first arg will always be the remote config, it applies to the whole call including the yielded messages
+        with call_config(args[0] if args else {}):
+            self._handle_call(req_id, method, args, call)
+
+    def _handle_call(self, req_id: int, method: str, args: list, call: 'RpcCall'):
+        try:
+            ret = self._invoke(method, args)
+            if isinstance(ret, Generator):
+                for each in ret:
+                    if not call.acquire():
+                        ret.close()
+                        break
+                    self._send(req_id, result=each)
+            elif ret is not None and call.acquire():
+                self._send(req_id, result=ret)
+            self._send(req_id, done=True)
+        except Exception as e:
+            logger.exception(f"error invoking {method}")
+            self._send(req_id, error=f"{type(e).__name__}: {e}")
+        finally:
+            with self._calls_lock:
+                self._calls.pop(req_id, None)
+
+    def _invoke(self, method: str, args: list):
+        func = getattr(type(self._commands), method.replace('-', '_'), None)
+        # only methods decorated by @plugin_method are remote-callable, call the undecorated one
+        func = getattr(func, '__wrapped__', None)
+        if func is None:
+            raise Exception(f"Unknown method {method}")
+        return func(self._commands, *args)
+
+    def _send(self, req_id: int, result: object = None, done: bool = False, error: str = None):
+        if result is not None and not isinstance(result, Message):
+            raise Exception(f"Not a message: {result}")
+        response = f'{{"id": {req_id}'
+        if result is not None:
+            response += f', "result": {result.json(exclude_none=True, by_alias=True)}'
+        if done:
+            response += ', "done": true'
+        if error is not None:
+            response += f', "error": {json.dumps(error)}'
+        response += '}\n'
+        with self._send_lock:
+            self._send_ch.write(response)
+            self._send_ch.flush()
+
+
+class RpcCall:
+    """
+    Keeps the cancellation and the credits of a call, a call without credits is not flow controlled
+    """
+
+    def __init__(self, credit: Optional[int] = None):
+        self._credit = credit
+        self._cancelled = False
+        self._cond = threading.Condition()
+
+    def grant(self, credit: int):
+        with self._cond:
+            if self._credit is not None:
+                self._credit += credit
+                self._cond.notify_all()
+
+    def cancel(self):
+        with self._cond:
+            self._cancelled = True
+            self._cond.notify_all()
+
+    def acquire(self) -> bool:
+        """
+        Takes a credit for sending a result, it waits for the go side to grant more if there is none left.
+        Returns False if the call was cancelled
+        """
+        with self._cond:
+            while not self._cancelled and self._credit is not None and self._credit <= 0:
+                self._cond.wait()
+            if self._cancelled:
+                return False
+            if self._credit is not None:
+                self._credit -= 1
+            return True
@@ -0,0 +1,168 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+import json
+import os
+import queue
+import threading
+
+import pytest
+
+from pydevlake.ipc import plugin_method
+from pydevlake.message import RemoteProgress
+from pydevlake.rpc import RpcServer
+
+
+class FakeCommands:
+    @plugin_method
+    def count(self, _, n: int):
+        for i in range(1, n + 1):
+            yield RemoteProgress(current=i, total=n)
+
+    @plugin_method
+    def forever(self, _):
+        i = 0
+        while True:
+            i += 1
+            yield RemoteProgress(current=i)
+
+    @plugin_method
+    def fail(self, _):
+        raise Exception("boom")
+
+
+class RpcClient:
+    def __init__(self, send_ch, recv_ch):
+        self._send_ch = send_ch
+        self._responses: dict[int, queue.Queue] = {}
+        self._lock = threading.Lock()
+        self._reader = threading.Thread(target=self._read, args=(recv_ch,), daemon=True)
+        self._reader.start()
+
+    def _queue(self, req_id: int) -> queue.Queue:
+        with self._lock:
+            return self._responses.setdefault(req_id, queue.Queue())
+
+    def _read(self, recv_ch):
+        for line in recv_ch:
+            response = json.loads(line)
+            self._queue(response['id']).put(response)
+
+    def send(self, **request):
+        self._send_ch.write(json.dumps(request) + '\n')
+        self._send_ch.flush()
+
+    def call(self, req_id: int, method: str, *args, credit: int = None):
+        request = dict(id=req_id, method=method, args=[{}, *args])
+        if credit is not None:
+            request['credit'] = credit
+        self.send(**request)
+
+    def receive(self, req_id: int, timeout: float = 5) -> dict:
+        return self._queue(req_id).get(timeout=timeout)
+
+    def nothing_received(self, req_id: int, timeout: float = 0.2) -> bool:
+        try:
+            self._queue(req_id).get(timeout=timeout)
+            return False
+        except queue.Empty:
+            return True
+
+    def receive_all(self, req_id: int) -> tuple[list, dict]:
+        results = []
+        while True:
+            response = self.receive(req_id)
+            if 'result' in response:
+                results.append(response['result'])
+            else:
+                return results, response
+
+    def close(self):
+        self._send_ch.close()
+
+
+@pytest.fixture
+def client():
+    requests_r, requests_w = os.pipe()
+    responses_r, responses_w = os.pipe()
+    server = RpcServer(FakeCommands(), workers=2)
+    with os.fdopen(responses_w, 'w') as send_ch, os.fdopen(requests_r, 'r') as recv_ch:
+        serving = threading.Thread(target=server.serve_channels, args=(send_ch, recv_ch), daemon=True)
+        serving.start()
+        client = RpcClient(os.fdopen(requests_w, 'w'), os.fdopen(responses_r, 'r'))
+        yield client
+        client.close()
+        serving.join(timeout=5)
+        assert not serving.is_alive()
+
+
+def test_concurrent_calls(client):
+    client.call(1, 'count', 3)
+    client.call(2, 'count', 5)
+    results, last = client.receive_all(2)
+    assert [r['current'] for r in results] == [1, 2, 3, 4, 5]
+    assert last == {'id': 2, 'done': True}
+    results, last = client.receive_all(1)
+    assert [r['current'] for r in results] == [1, 2, 3]
+    assert last == {'id': 1, 'done': True}
+
+
+def test_error(client):
+    client.call(1, 'fail')
+    results, last = client.receive_all(1)
+    assert results == []
+    assert last['error'] == 'Exception: boom'
+
+    client.call(2, 'unknown')
+    results, last = client.receive_all(2)
+    assert results == []
+    assert 'Unknown method unknown' in last['error']
+
+
+def test_flow_control(client):
+    client.call(1, 'count', 10, credit=3)
+    for i in range(1, 4):
+        assert client.receive(1)['result']['current'] == i
+    # no more results until more credits are granted
+    assert client.nothing_received(1)
+    client.send(id=1, credit=7)
+    results, last = client.receive_all(1)
+    assert [r['current'] for r in results] == list(range(4, 11))
+    assert last == {'id': 1, 'done': True}
+
+
+def test_cancel(client):
+    client.call(1, 'forever', credit=2)
+    assert client.receive(1)['result']['current'] == 1
+    assert client.receive(1)['result']['current'] == 2
+    # the call waits for credits, the cancellation wakes it up
+    client.send(id=1, cancel=True)
+    results, last = client.receive_all(1)
+    assert results == []
+    assert last == {'id': 1, 'done': True}
+
+
+def test_ping_while_workers_busy(client):
+    client.call(1, 'forever', credit=1)
+    client.call(2, 'forever', credit=1)
+    assert client.receive(1)['result']['current'] == 1
+    assert client.receive(2)['result']['current'] == 1
+    client.send(id=3, method='ping')
+    assert client.receive(3) == {'id': 3, 'done': True}
+    client.send(id=1, cancel=True)
+    client.send(id=2, cancel=True)
+    assert client.receive_all(1)[1] == {'id': 1, 'done': True}
+    assert client.receive_all(2)[1] == {'id': 2, 'done': True}
@@ -36,6 +36,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	_ "github.com/apache/incubator-devlake/server/api/docs"
+	"github.com/apache/incubator-devlake/server/api/oidc"
 	"github.com/apache/incubator-devlake/server/api/ping"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/version"
@@ -104,9 +105,26 @@ func CreateApiServer() *gin.Engine {
 	router.GET("/health", ping.Health)
 	router.GET("/version", version.Get)
 
+	// Only the reverse proxies are allowed to tell the groups of the user
+	router.Use(TrustedProxyHeaders(basicRes))
 	// Api keys
 	router.Use(RestAuthentication(router, basicRes))
+	// OpenID Connect login
+	oidcConfig, err := oidc.LoadConfig(basicRes)
+	if err != nil {
+		panic(err)
+	}
+	if oidcConfig != nil {
+		oidcProvider := oidc.NewProvider(oidcConfig, nil)
+		router.GET("/oidc/login", oidcProvider.Login)
+		router.GET("/oidc/callback", oidcProvider.Callback)
+		router.GET("/oidc/logout", oidcProvider.Logout)
+		router.Use(OIDCSessionAuthentication(oidcProvider, basicRes))
+		router.GET("/oidc/userinfo", oidc.UserInfo)
+	}
 	router.Use(OAuth2ProxyAuthentication(basicRes))
+	router.Use(WorkspaceResolution(basicRes))
+	router.Use(RoleBasedAccessControl(basicRes))
 
 	return router
 }
@@ -50,6 +50,7 @@ func GetApiKeys(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.Workspace = shared.GetWorkspace(c)
 	apiKeys, count, err := services.GetApiKeys(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting api keys"))
@@ -77,6 +78,10 @@ func DeleteApiKey(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
 		return
 	}
+	if err := services.VerifyApiKeyWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err = services.DeleteApiKey(id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting api key"))
@@ -100,6 +105,10 @@ func PutApiKey(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad apiKeyId format supplied"))
 		return
 	}
+	if err := services.VerifyApiKeyWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	user, exist := shared.GetUser(c)
 	if !exist {
 		logruslog.Global.Warn(nil, "user doesn't exist")
@@ -128,6 +137,10 @@ func PostApiKey(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	// api keys created by callers bound to a workspace are bound to the same workspace
+	if workspace := shared.GetWorkspace(c); workspace != "" {
+		apiKeyInput.Workspace = workspace
+	}
 	user, exist := shared.GetUser(c)
 	if !exist {
 		logruslog.Global.Warn(nil, "user doesn't exist")
@@ -50,6 +50,16 @@ func Post(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	// callers bound to a workspace can only create blueprints in their own workspace
+	if workspace := shared.GetWorkspace(c); workspace != "" {
+		if blueprint.ProjectName != "" {
+			if err := services.VerifyProjectWorkspace(workspace, blueprint.ProjectName); err != nil {
+				shared.ApiOutputError(c, err)
+				return
+			}
+		}
+		blueprint.Workspace = workspace
+	}
 	err = services.CreateBlueprint(blueprint)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating blueprint"))
@@ -77,6 +87,7 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.Workspace = shared.GetWorkspace(c)
 	blueprints, count, err := services.GetBlueprints(&query, true)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting blueprints"))
@@ -101,6 +112,10 @@ func Get(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintId format supplied"))
 		return
 	}
+	if err := services.VerifyBlueprintWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	blueprint, err := services.GetBlueprint(id, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting blueprint"))
@@ -146,12 +161,19 @@ func Patch(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipeline ID format supplied"))
 		return
 	}
+	if err := services.VerifyBlueprintWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	var body map[string]interface{}
 	err = c.ShouldBind(&body)
 	if err != nil {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	if shared.GetWorkspace(c) != "" {
+		delete(body, "workspace")
+	}
 	blueprint, err := services.PatchBlueprint(id, body)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error patching the blueprint"))
@@ -178,6 +200,10 @@ func Trigger(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
 		return
 	}
+	if err := services.VerifyBlueprintWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 
 	triggerSyncPolicy := &models.TriggerSyncPolicy{}
 	if c.Request.Body == nil || c.Request.ContentLength == 0 {
@@ -219,6 +245,7 @@ func GetBlueprintPipelines(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad request URI format"))
 		return
 	}
+	query.Workspace = shared.GetWorkspace(c)
 	pipelines, count, err := services.GetPipelines(&query, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipelines"))
@@ -243,6 +270,10 @@ func Delete(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintId format supplied"))
 		return
 	}
+	if err := services.VerifyBlueprintWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err = services.DeleteBlueprint(id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting blueprint"))
@@ -45,7 +45,7 @@ GET /repos
 // @Failure 500  {string} errcode.Error "Internal Error"
 // @Router /domainlayer/repos [get]
 func ReposIndex(c *gin.Context) {
-	repos, count, err := services.GetRepos()
+	repos, count, err := services.GetRepos(shared.GetWorkspace(c))
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting repositories"))
 		return
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"github.com/apache/incubator-devlake/core/log"
+	"net"
 	"net/http"
 	"regexp"
 	"strings"
@@ -29,11 +30,39 @@ import (
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/helpers/apikeyhelper"
+	"github.com/apache/incubator-devlake/server/api/oidc"
+	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/gin-gonic/gin"
 )
 
+// WorkspaceAll binds groups to all workspaces in the WORKSPACE_GROUPS and WORKSPACE_ANONYMOUS configs
+const WorkspaceAll = "*"
+
+// GroupsHeader is set by the reverse proxy (e.g. oauth2-proxy) to the comma separated groups of the user,
+// the groups are mapped to roles by the RBAC_ADMIN_GROUPS, RBAC_OPERATOR_GROUPS and RBAC_VIEWER_GROUPS configs.
+// It is only honoured on requests sent by the proxies listed in the RBAC_TRUSTED_PROXIES config
+const GroupsHeader = "X-Forwarded-Groups"
+
+// operatorRoutes are the mutating routes open to operators, all other mutating routes require the admin role
+var operatorRoutes = map[string]bool{
+	http.MethodPost + " /pipelines":                       true,
+	http.MethodDelete + " /pipelines/:pipelineId":         true,
+	http.MethodPost + " /pipelines/:pipelineId/rerun":     true,
+	http.MethodPost + " /blueprints/:blueprintId/trigger": true,
+	http.MethodPost + " /tasks/:taskId/rerun":             true,
+	// the webhook connections are pushed data with the api keys created along with them
+	http.MethodPost + " /plugins/webhook/connections/:connectionId/deployments":           true,
+	http.MethodPost + " /plugins/webhook/connections/:connectionId/pull_requests":         true,
+	http.MethodPost + " /plugins/webhook/connections/:connectionId/issues":                true,
+	http.MethodPost + " /plugins/webhook/connections/:connectionId/issue/:issueKey/close": true,
+}
+
+// adminRoutePrefixes are the routes exposing sensitive information, reading them requires the admin role as well
+var adminRoutePrefixes = []string{"/api-keys", "/workspaces", "/audit-logs"}
+
 func getOAuthUserInfo(c *gin.Context) (*common.User, error) {
 	if c == nil {
 		return nil, errors.Default.New("request is nil")
@@ -96,6 +125,288 @@ func OAuth2ProxyAuthentication(basicRes context.BasicRes) gin.HandlerFunc {
 	}
 }
 
+// TrustedProxyHeaders strips the GroupsHeader from requests which were not sent by the reverse proxies listed in
+// the RBAC_TRUSTED_PROXIES config, a comma separated list of IPs and CIDRs, so clients can't grant themselves
+// roles and workspaces by setting the header
+func TrustedProxyHeaders(basicRes context.BasicRes) gin.HandlerFunc {
+	logger := basicRes.GetLogger()
+	var trustedProxies []*net.IPNet
+	for _, proxy := range splitCommaSeparated(basicRes.GetConfig("RBAC_TRUSTED_PROXIES")) {
+		cidr := proxy
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.Warn(err, "ignoring invalid trusted proxy %s", proxy)
+			continue
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+	return func(c *gin.Context) {
+		if !isTrustedProxy(net.ParseIP(c.RemoteIP()), trustedProxies) {
+			c.Request.Header.Del(GroupsHeader)
+		}
+		c.Next()
+	}
+}
+
+func isTrustedProxy(ip net.IP, trustedProxies []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, trustedProxy := range trustedProxies {
+		if trustedProxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// OIDCSessionAuthentication authenticates requests by the session cookie issued on OIDC login, requests which
+// have neither a valid session nor an api key are rejected
+func OIDCSessionAuthentication(provider *oidc.Provider, basicRes context.BasicRes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if shared.GetApiKey(c) != nil {
+			c.Next()
+			return
+		}
+		session, err := provider.GetSession(c)
+		if err != nil {
+			c.Abort()
+			c.JSON(http.StatusUnauthorized, &apiBody{
+				Success: false,
+				Message: "login required",
+			})
+			return
+		}
+		c.Set(common.USER, &common.User{
+			Name:  session.Name,
+			Email: session.Email,
+		})
+		workspace, bound := getWorkspaceBySession(session, basicRes)
+		if !bound && hasWorkspaces(basicRes) {
+			abortWorkspaceUnbound(c)
+			return
+		}
+		// unlike the proxy users, SSO users are only let in when their groups are granted a role
+		role, granted := getRoleByGroups(session.Groups, basicRes)
+		if !granted {
+			c.Abort()
+			c.JSON(http.StatusForbidden, &apiBody{
+				Success: false,
+				Message: "no role is granted to the user",
+			})
+			return
+		}
+		shared.SetWorkspace(c, workspace)
+		shared.SetRole(c, role)
+		c.Next()
+	}
+}
+
+// WorkspaceResolution binds requests which were authenticated by neither an api key nor an OIDC session to a
+// workspace, users authenticated by the reverse proxy are bound by their groups, anonymous requests are bound by
+// the WORKSPACE_ANONYMOUS config. Once any workspace exists, requests which can't be bound are rejected instead
+// of seeing all workspaces, before that they are not restricted so installations without workspaces keep working
+func WorkspaceResolution(basicRes context.BasicRes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, resolved := shared.LookupWorkspace(c); !resolved {
+			var workspace string
+			var bound bool
+			if _, authenticated := shared.GetUser(c); authenticated {
+				workspace, bound = getWorkspaceByGroups(splitCommaSeparated(c.GetHeader(GroupsHeader)), basicRes)
+			} else {
+				workspace, bound = parseWorkspaceBinding(basicRes.GetConfig("WORKSPACE_ANONYMOUS"))
+			}
+			if !bound && hasWorkspaces(basicRes) {
+				abortWorkspaceUnbound(c)
+				return
+			}
+			shared.SetWorkspace(c, workspace)
+		}
+		c.Next()
+	}
+}
+
+// getWorkspaceBySession binds the session to the workspace claimed by the OIDC provider, falling back to the
+// workspace its groups are mapped to
+func getWorkspaceBySession(session *oidc.Session, basicRes context.BasicRes) (string, bool) {
+	if workspace, bound := parseWorkspaceBinding(session.Workspace); bound {
+		return workspace, true
+	}
+	return getWorkspaceByGroups(session.Groups, basicRes)
+}
+
+// getWorkspaceByGroups maps the groups to a workspace by the WORKSPACE_GROUPS config, a comma separated list of
+// `group:workspace` pairs where the first pair matching any of the groups wins
+func getWorkspaceByGroups(groups []string, basicRes context.BasicRes) (string, bool) {
+	for _, binding := range splitCommaSeparated(basicRes.GetConfig("WORKSPACE_GROUPS")) {
+		group, workspace, ok := strings.Cut(binding, ":")
+		if !ok {
+			continue
+		}
+		for _, g := range groups {
+			if g == strings.TrimSpace(group) {
+				return parseWorkspaceBinding(workspace)
+			}
+		}
+	}
+	return "", false
+}
+
+// parseWorkspaceBinding returns the workspace and whether it is bound at all, WorkspaceAll is converted to
+// the empty workspace which has access to all workspaces
+func parseWorkspaceBinding(workspace string) (string, bool) {
+	workspace = strings.TrimSpace(workspace)
+	switch workspace {
+	case "":
+		return "", false
+	case WorkspaceAll:
+		return "", true
+	}
+	return workspace, true
+}
+
+// hasWorkspaces tells whether any workspace was created, there is nothing to isolate before that. It fails
+// closed so that a broken database never exposes all workspaces
+func hasWorkspaces(basicRes context.BasicRes) bool {
+	db := basicRes.GetDal()
+	// the table doesn't exist until the database migration is done
+	if !db.HasTable(&models.Workspace{}) {
+		return false
+	}
+	count, err := db.Count(dal.From(&models.Workspace{}))
+	if err != nil {
+		basicRes.GetLogger().Error(err, "failed to count workspaces")
+		return true
+	}
+	return count > 0
+}
+
+func abortWorkspaceUnbound(c *gin.Context) {
+	c.Abort()
+	c.JSON(http.StatusForbidden, &apiBody{
+		Success: false,
+		Message: "no workspace is bound to the user",
+	})
+}
+
+// RoleBasedAccessControl rejects requests whose role doesn't cover the route, and records every mutating call
+// in the audit log. Requests which were not authenticated by an api key get their role from the GroupsHeader,
+// falling back to the RBAC_DEFAULT_ROLE config
+func RoleBasedAccessControl(basicRes context.BasicRes) gin.HandlerFunc {
+	db := basicRes.GetDal()
+	logger := basicRes.GetLogger()
+	return func(c *gin.Context) {
+		startedAt := time.Now()
+		role, resolved := shared.LookupRole(c)
+		if !resolved {
+			role = getProxyUserRole(c, basicRes)
+			shared.SetRole(c, role)
+		}
+		method := c.Request.Method
+		if models.RoleCovers(role, getRequiredRole(method, c.FullPath())) {
+			c.Next()
+		} else {
+			c.Abort()
+			c.JSON(http.StatusForbidden, &apiBody{
+				Success: false,
+				Message: fmt.Sprintf("role [%s] is not allowed to %s %s", role, method, c.Request.URL.Path),
+			})
+		}
+		if isMutatingMethod(method) {
+			auditLog := &models.AuditLog{
+				CreatedAt:  startedAt,
+				Role:       role,
+				Workspace:  shared.GetWorkspace(c),
+				Method:     method,
+				Route:      c.FullPath(),
+				Path:       c.Request.URL.Path,
+				Status:     c.Writer.Status(),
+				ClientIp:   c.ClientIP(),
+				DurationMs: time.Since(startedAt).Milliseconds(),
+			}
+			if apiKey := shared.GetApiKey(c); apiKey != nil {
+				auditLog.ApiKeyId = apiKey.ID
+				auditLog.UserName = apiKey.Creator.Creator
+				auditLog.Email = apiKey.Creator.CreatorEmail
+			} else if user, ok := shared.GetUser(c); ok {
+				auditLog.UserName = user.Name
+				auditLog.Email = user.Email
+			}
+			if err := db.Create(auditLog); err != nil {
+				logger.Error(err, "failed to save audit log for %s %s", method, c.Request.URL.Path)
+			}
+		}
+	}
+}
+
+func getProxyUserRole(c *gin.Context, basicRes context.BasicRes) string {
+	if role, granted := getRoleByGroups(splitCommaSeparated(c.GetHeader(GroupsHeader)), basicRes); granted {
+		return role
+	}
+	if role := basicRes.GetConfig("RBAC_DEFAULT_ROLE"); role != "" {
+		return role
+	}
+	// RBAC is opt-in, the callers keep full access until a default role is configured
+	return models.ROLE_ADMIN
+}
+
+// getRoleByGroups maps the groups to the highest role they are granted by the RBAC_<ROLE>_GROUPS configs
+func getRoleByGroups(groups []string, basicRes context.BasicRes) (string, bool) {
+	if len(groups) > 0 {
+		for _, role := range []string{models.ROLE_ADMIN, models.ROLE_OPERATOR, models.ROLE_VIEWER} {
+			roleGroups := splitCommaSeparated(basicRes.GetConfig(fmt.Sprintf("RBAC_%s_GROUPS", strings.ToUpper(role))))
+			for _, group := range groups {
+				for _, roleGroup := range roleGroups {
+					if group == roleGroup {
+						return role, true
+					}
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+func getRequiredRole(method string, route string) string {
+	for _, prefix := range adminRoutePrefixes {
+		if strings.HasPrefix(route, prefix) {
+			return models.ROLE_ADMIN
+		}
+	}
+	if !isMutatingMethod(method) {
+		// proxy endpoints send requests to the data sources on behalf of the connection
+		if strings.Contains(route, "/proxy/") {
+			return models.ROLE_OPERATOR
+		}
+		return models.ROLE_VIEWER
+	}
+	if operatorRoutes[method+" "+route] {
+		return models.ROLE_OPERATOR
+	}
+	return models.ROLE_ADMIN
+}
+
+func isMutatingMethod(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+func splitCommaSeparated(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 type apiBody struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
@@ -211,5 +522,9 @@ func CheckAuthorizationHeader(c *gin.Context, logger log.Logger, db dal.Dal, api
 		Name:  apiKey.Creator.Creator,
 		Email: apiKey.Creator.CreatorEmail,
 	})
+	// api key decides the workspace and the role, the headers must not be able to override them
+	shared.SetWorkspace(c, apiKey.Workspace)
+	shared.SetApiKey(c, apiKey)
+	shared.SetRole(c, apiKey.Role)
 	return true
 }
@@ -0,0 +1,478 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/apikeyhelper"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/apache/incubator-devlake/server/api/oidc"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRequiredRole(t *testing.T) {
+	tests := []struct {
+		method string
+		route  string
+		want   string
+	}{
+		{http.MethodGet, "/blueprints/:blueprintId", models.ROLE_VIEWER},
+		{http.MethodPatch, "/blueprints/:blueprintId", models.ROLE_ADMIN},
+		{http.MethodDelete, "/blueprints/:blueprintId", models.ROLE_ADMIN},
+		{http.MethodPost, "/blueprints/:blueprintId/trigger", models.ROLE_OPERATOR},
+		{http.MethodPost, "/pipelines", models.ROLE_OPERATOR},
+		{http.MethodDelete, "/pipelines/:pipelineId", models.ROLE_OPERATOR},
+		{http.MethodGet, "/api-keys", models.ROLE_ADMIN},
+		{http.MethodGet, "/plugins/github/connections/:connectionId/proxy/rest/*path", models.ROLE_OPERATOR},
+		{http.MethodPost, "/plugins/github/connections", models.ROLE_ADMIN},
+		{http.MethodPost, "/plugins/webhook/connections/:connectionId/deployments", models.ROLE_OPERATOR},
+		{http.MethodPatch, "/plugins/webhook/connections/:connectionId", models.ROLE_ADMIN},
+		{http.MethodPost, "", models.ROLE_ADMIN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.route, func(t *testing.T) {
+			assert.Equal(t, tt.want, getRequiredRole(tt.method, tt.route))
+		})
+	}
+}
+
+type testBasicRes struct {
+	context.BasicRes
+	config map[string]string
+	db     dal.Dal
+}
+
+func (r *testBasicRes) GetConfig(name string) string {
+	return r.config[name]
+}
+
+func (r *testBasicRes) GetLogger() log.Logger {
+	return logruslog.Global
+}
+
+func (r *testBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+// workspaceDal answers the workspace counting and the connection workspace verification with the workspaces
+// and connections in memory
+type workspaceDal struct {
+	dal.Dal
+	workspaces  []string
+	connections []*models.WorkspaceConnection
+}
+
+func (d *workspaceDal) HasTable(table interface{}) bool {
+	return true
+}
+
+func (d *workspaceDal) Count(clauses ...dal.Clause) (int64, errors.Error) {
+	if _, ok := clauses[0].Data.(*models.Workspace); ok {
+		return int64(len(d.workspaces)), nil
+	}
+	params := clauses[1].Data.(dal.DalClause).Params
+	count := int64(0)
+	for _, c := range d.connections {
+		if c.Plugin == params[0] && c.ConnectionId == params[1] && c.Workspace == params[2] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func serve(router *gin.Engine, method, path, user, groups string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if user != "" {
+		req.Header.Set("X-Forwarded-User", user)
+	}
+	if groups != "" {
+		req.Header.Set(GroupsHeader, groups)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestWorkspaceResolution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := &workspaceDal{workspaces: []string{"a", "b"}}
+	newRouter := func(config map[string]string, preResolved string) *gin.Engine {
+		basicRes := &testBasicRes{config: config, db: db}
+		router := gin.New()
+		if preResolved != "" {
+			// simulates the api key authentication
+			router.Use(func(c *gin.Context) {
+				shared.SetWorkspace(c, preResolved)
+			})
+		}
+		router.Use(OAuth2ProxyAuthentication(basicRes))
+		router.Use(WorkspaceResolution(basicRes))
+		router.GET("/workspace", func(c *gin.Context) {
+			c.String(http.StatusOK, "[%s]", shared.GetWorkspace(c))
+		})
+		return router
+	}
+	router := newRouter(map[string]string{"WORKSPACE_GROUPS": "team-a:a, team-b:b, platform:*"}, "")
+
+	tests := []struct {
+		name   string
+		user   string
+		groups string
+		status int
+		body   string
+	}{
+		{"bound by group", "alice", "dev,team-a", http.StatusOK, "[a]"},
+		{"first binding wins", "alice", "team-b,team-a", http.StatusOK, "[a]"},
+		{"bound to all workspaces", "bob", "platform", http.StatusOK, "[]"},
+		{"unmapped group", "carol", "team-c", http.StatusForbidden, ""},
+		{"no group", "carol", "", http.StatusForbidden, ""},
+		{"anonymous", "", "team-a", http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, http.MethodGet, "/workspace", tt.user, tt.groups)
+			assert.Equal(t, tt.status, w.Code)
+			if tt.body != "" {
+				assert.Equal(t, tt.body, w.Body.String())
+			}
+		})
+	}
+
+	// anonymous requests are bound only if configured explicitly
+	w := serve(newRouter(map[string]string{"WORKSPACE_ANONYMOUS": "a"}, ""), http.MethodGet, "/workspace", "", "")
+	assert.Equal(t, "[a]", w.Body.String())
+	w = serve(newRouter(map[string]string{"WORKSPACE_ANONYMOUS": WorkspaceAll}, ""), http.MethodGet, "/workspace", "", "")
+	assert.Equal(t, "[]", w.Body.String())
+
+	// the groups can't override the workspace of the api key
+	w = serve(newRouter(map[string]string{"WORKSPACE_GROUPS": "platform:*"}, "a"), http.MethodGet, "/workspace", "bob", "platform")
+	assert.Equal(t, "[a]", w.Body.String())
+
+	// nobody is restricted before any workspace is created
+	db.workspaces = nil
+	for _, tt := range tests {
+		t.Run(tt.name+" without workspaces", func(t *testing.T) {
+			w := serve(router, http.MethodGet, "/workspace", tt.user, tt.groups)
+			assert.Equal(t, http.StatusOK, w.Code)
+			if tt.status == http.StatusForbidden {
+				assert.Equal(t, "[]", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPluginEndpointsWorkspaceIsolation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	basicRes := &testBasicRes{
+		config: map[string]string{"WORKSPACE_GROUPS": "team-a:a, team-b:b, platform:*"},
+		db: &workspaceDal{workspaces: []string{"a", "b"}, connections: []*models.WorkspaceConnection{
+			{Plugin: "github", ConnectionId: 1, Workspace: "a"},
+			{Plugin: "github", ConnectionId: 2, Workspace: "b"},
+		}},
+	}
+	echo := func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+		return &plugin.ApiResourceOutput{Body: input.Workspace}, nil
+	}
+	router := gin.New()
+	router.Use(OAuth2ProxyAuthentication(basicRes))
+	router.Use(WorkspaceResolution(basicRes))
+	registerPluginEndpoints(router, basicRes, "github", map[string]map[string]plugin.ApiResourceHandler{
+		"connections": {
+			http.MethodGet: echo,
+		},
+		"connections/:connectionId": {
+			http.MethodGet:    echo,
+			http.MethodPatch:  echo,
+			http.MethodDelete: echo,
+		},
+		"connections/:connectionId/scopes/:scopeId": {
+			http.MethodGet:    echo,
+			http.MethodPatch:  echo,
+			http.MethodDelete: echo,
+		},
+	})
+
+	// listing is handed over to the connection helpers with the workspace of the caller
+	w := serve(router, http.MethodGet, "/plugins/github/connections", "alice", "team-a")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"a"`)
+
+	for _, method := range []string{http.MethodGet, http.MethodPatch, http.MethodDelete} {
+		for _, path := range []string{"/plugins/github/connections/%d", "/plugins/github/connections/%d/scopes/repo"} {
+			own := fmt.Sprintf(path, 1)
+			other := fmt.Sprintf(path, 2)
+			assert.Equal(t, http.StatusOK, serve(router, method, own, "alice", "team-a").Code, method+" "+own)
+			assert.Equal(t, http.StatusNotFound, serve(router, method, other, "alice", "team-a").Code, method+" "+other)
+			assert.Equal(t, http.StatusNotFound, serve(router, method, own, "bob", "team-b").Code, method+" "+own)
+			assert.Equal(t, http.StatusOK, serve(router, method, other, "bob", "team-b").Code, method+" "+other)
+			assert.Equal(t, http.StatusOK, serve(router, method, other, "carl", "platform").Code, method+" "+other)
+		}
+	}
+}
+
+func TestTrustedProxyHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	basicRes := &testBasicRes{config: map[string]string{"RBAC_TRUSTED_PROXIES": "10.0.0.0/8, 192.168.1.1, ::1, invalid"}}
+	router := gin.New()
+	router.Use(TrustedProxyHeaders(basicRes))
+	router.GET("/groups", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetHeader(GroupsHeader))
+	})
+	tests := []struct {
+		remoteAddr string
+		want       string
+	}{
+		{"10.1.2.3:1234", "admins"},
+		{"192.168.1.1:1234", "admins"},
+		{"[::1]:1234", "admins"},
+		{"192.168.1.2:1234", ""},
+		{"172.16.0.1:1234", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/groups", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(GroupsHeader, "admins")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+// auditLogDal records the audit logs and serves the api key for the api key authentication
+type auditLogDal struct {
+	dal.Dal
+	auditLogs []*models.AuditLog
+	apiKey    *models.ApiKey
+}
+
+func (d *auditLogDal) Create(entity interface{}, _ ...dal.Clause) errors.Error {
+	d.auditLogs = append(d.auditLogs, entity.(*models.AuditLog))
+	return nil
+}
+
+func (d *auditLogDal) First(dst interface{}, clauses ...dal.Clause) errors.Error {
+	if d.apiKey == nil || clauses[0].Data.(dal.DalClause).Params[0] != d.apiKey.ApiKey {
+		return errors.NotFound.New("record not found")
+	}
+	*dst.(*models.ApiKey) = *d.apiKey
+	return nil
+}
+
+func (d *auditLogDal) IsErrorNotFound(err error) bool {
+	lakeErr := errors.AsLakeErrorType(err)
+	return lakeErr != nil && lakeErr.GetType() == errors.NotFound
+}
+
+func newRbacRouter(basicRes context.BasicRes) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(TrustedProxyHeaders(basicRes))
+	router.Use(RestAuthentication(router, basicRes))
+	router.Use(OAuth2ProxyAuthentication(basicRes))
+	router.Use(WorkspaceResolution(basicRes))
+	router.Use(RoleBasedAccessControl(basicRes))
+	ok := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+	router.GET("/blueprints/:blueprintId", ok)
+	router.PATCH("/blueprints/:blueprintId", ok)
+	router.POST("/blueprints/:blueprintId/trigger", ok)
+	router.GET("/api-keys", ok)
+	return router
+}
+
+func TestRoleBasedAccessControl(t *testing.T) {
+	t.Setenv("ENCRYPTION_SECRET", "secret")
+	db := &auditLogDal{}
+	basicRes := &testBasicRes{
+		config: map[string]string{
+			"RBAC_TRUSTED_PROXIES": "192.0.2.1",
+			"RBAC_ADMIN_GROUPS":    "admins",
+			"RBAC_OPERATOR_GROUPS": "operators",
+			"RBAC_VIEWER_GROUPS":   "viewers",
+			"RBAC_DEFAULT_ROLE":    models.ROLE_VIEWER,
+			"WORKSPACE_GROUPS":     "admins:*, operators:a, viewers:a, others:a",
+			"WORKSPACE_ANONYMOUS":  "*",
+		},
+		db: db,
+	}
+	router := newRbacRouter(basicRes)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		groups string
+		status int
+		role   string
+	}{
+		{"viewer reads", http.MethodGet, "/blueprints/1", "viewers", http.StatusOK, models.ROLE_VIEWER},
+		{"viewer patches", http.MethodPatch, "/blueprints/1", "viewers", http.StatusForbidden, models.ROLE_VIEWER},
+		{"viewer triggers", http.MethodPost, "/blueprints/1/trigger", "viewers", http.StatusForbidden, models.ROLE_VIEWER},
+		{"viewer reads api keys", http.MethodGet, "/api-keys", "viewers", http.StatusForbidden, models.ROLE_VIEWER},
+		{"operator triggers", http.MethodPost, "/blueprints/1/trigger", "operators", http.StatusOK, models.ROLE_OPERATOR},
+		{"operator patches", http.MethodPatch, "/blueprints/1", "operators", http.StatusForbidden, models.ROLE_OPERATOR},
+		{"admin patches", http.MethodPatch, "/blueprints/1", "admins", http.StatusOK, models.ROLE_ADMIN},
+		{"admin reads api keys", http.MethodGet, "/api-keys", "admins", http.StatusOK, models.ROLE_ADMIN},
+		{"unmapped group falls back to the default role", http.MethodPatch, "/blueprints/1", "others", http.StatusForbidden, models.ROLE_VIEWER},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db.auditLogs = nil
+			w := serve(router, tt.method, tt.path, "alice", tt.groups)
+			assert.Equal(t, tt.status, w.Code)
+			if !isMutatingMethod(tt.method) {
+				assert.Empty(t, db.auditLogs)
+				return
+			}
+			// every mutating call is audited, no matter it was allowed or not
+			assert.Len(t, db.auditLogs, 1)
+			auditLog := db.auditLogs[0]
+			assert.Equal(t, tt.role, auditLog.Role)
+			assert.Equal(t, tt.method, auditLog.Method)
+			assert.Equal(t, tt.path, auditLog.Path)
+			assert.Equal(t, tt.status, auditLog.Status)
+			assert.Equal(t, "alice", auditLog.UserName)
+			assert.Equal(t, uint64(0), auditLog.ApiKeyId)
+		})
+	}
+
+	// groups sent by untrusted clients are ignored
+	db.auditLogs = nil
+	req := httptest.NewRequest(http.MethodPatch, "/blueprints/1", nil)
+	req.RemoteAddr = "198.51.100.1:1234"
+	req.Header.Set(GroupsHeader, "admins")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, models.ROLE_VIEWER, db.auditLogs[0].Role)
+}
+
+func TestRoleBasedAccessControlApiKey(t *testing.T) {
+	t.Setenv("ENCRYPTION_SECRET", "secret")
+	db := &auditLogDal{}
+	basicRes := &testBasicRes{
+		config: map[string]string{
+			"RBAC_TRUSTED_PROXIES": "192.0.2.1",
+			"RBAC_ADMIN_GROUPS":    "admins",
+			"WORKSPACE_GROUPS":     "admins:*",
+		},
+		db: db,
+	}
+	router := newRbacRouter(basicRes)
+	hashedToken, err := apikeyhelper.NewApiKeyHelper(basicRes, logruslog.Global).DigestToken("token")
+	assert.Nil(t, err)
+	db.apiKey = &models.ApiKey{
+		Model:       common.Model{ID: 7},
+		Creator:     common.Creator{Creator: "bob"},
+		ApiKey:      hashedToken,
+		AllowedPath: ".*",
+		Workspace:   "a",
+		Role:        models.ROLE_OPERATOR,
+	}
+	request := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		// neither the proxy headers can elevate the api key
+		req.Header.Set("X-Forwarded-User", "alice")
+		req.Header.Set(GroupsHeader, "admins")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, request(http.MethodPost, "/rest/blueprints/1/trigger").Code)
+	assert.Len(t, db.auditLogs, 1)
+	assert.Equal(t, models.ROLE_OPERATOR, db.auditLogs[0].Role)
+	assert.Equal(t, "a", db.auditLogs[0].Workspace)
+	assert.Equal(t, uint64(7), db.auditLogs[0].ApiKeyId)
+	assert.Equal(t, "bob", db.auditLogs[0].UserName)
+
+	db.auditLogs = nil
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPatch, "/rest/blueprints/1").Code)
+	assert.Len(t, db.auditLogs, 1)
+	assert.Equal(t, http.StatusForbidden, db.auditLogs[0].Status)
+	assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/rest/api-keys").Code)
+}
+
+func TestGetRoleByGroups(t *testing.T) {
+	basicRes := &testBasicRes{
+		config: map[string]string{
+			"RBAC_ADMIN_GROUPS":    "admins",
+			"RBAC_OPERATOR_GROUPS": "operators",
+			"RBAC_DEFAULT_ROLE":    models.ROLE_VIEWER,
+		},
+	}
+	role, granted := getRoleByGroups([]string{"operators", "admins"}, basicRes)
+	assert.True(t, granted)
+	assert.Equal(t, models.ROLE_ADMIN, role)
+
+	// unmapped users are not granted any role, the default role only applies to the proxy users
+	_, granted = getRoleByGroups([]string{"others"}, basicRes)
+	assert.False(t, granted)
+	_, granted = getRoleByGroups(nil, basicRes)
+	assert.False(t, granted)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/blueprints", nil)
+	c.Request.Header.Set(GroupsHeader, "others")
+	assert.Equal(t, models.ROLE_VIEWER, getProxyUserRole(c, basicRes))
+
+	// callers are not restricted until RBAC is configured
+	assert.Equal(t, models.ROLE_ADMIN, getProxyUserRole(c, &testBasicRes{}))
+}
+
+func TestGetWorkspaceBySession(t *testing.T) {
+	basicRes := &testBasicRes{
+		config: map[string]string{
+			"WORKSPACE_GROUPS": "admins:*, team-a:a",
+		},
+	}
+	tests := []struct {
+		name      string
+		session   *oidc.Session
+		workspace string
+		bound     bool
+	}{
+		{"claimed workspace", &oidc.Session{Workspace: "b", Groups: []string{"team-a"}}, "b", true},
+		{"claimed all workspaces", &oidc.Session{Workspace: WorkspaceAll}, "", true},
+		{"mapped by groups", &oidc.Session{Groups: []string{"team-a"}}, "a", true},
+		{"unmapped", &oidc.Session{Groups: []string{"others"}}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace, bound := getWorkspaceBySession(tt.session, basicRes)
+			assert.Equal(t, tt.bound, bound)
+			assert.Equal(t, tt.workspace, workspace)
+		})
+	}
+}
@@ -0,0 +1,486 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	corectx "github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+const (
+	SessionCookie = "devlake_session"
+	stateCookie   = "devlake_oidc_state"
+	stateTtl      = 10 * time.Minute
+)
+
+// Config holds the settings of the OpenID Connect provider DevLake logs users in with
+type Config struct {
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	RedirectUrl  string
+	Scopes       []string
+	GroupsClaim  string
+	// WorkspaceClaim names the claim carrying the workspace the user is bound to, the groups are mapped by the
+	// WORKSPACE_GROUPS config when it is empty or the claim is absent
+	WorkspaceClaim    string
+	SessionTtl        time.Duration
+	PostLoginRedirect string
+	// SessionSecret signs the session and state cookies
+	SessionSecret string
+}
+
+// LoadConfig reads the OIDC_* configs, nil is returned if OIDC login is not enabled
+func LoadConfig(basicRes corectx.BasicRes) (*Config, errors.Error) {
+	cfg := basicRes.GetConfigReader()
+	issuer := strings.TrimSuffix(cfg.GetString("OIDC_ISSUER"), "/")
+	if issuer == "" {
+		return nil, nil
+	}
+	config := &Config{
+		Issuer:            issuer,
+		ClientId:          cfg.GetString("OIDC_CLIENT_ID"),
+		ClientSecret:      cfg.GetString("OIDC_CLIENT_SECRET"),
+		RedirectUrl:       cfg.GetString("OIDC_REDIRECT_URL"),
+		Scopes:            strings.Split(cfg.GetString("OIDC_SCOPES"), ","),
+		GroupsClaim:       cfg.GetString("OIDC_GROUPS_CLAIM"),
+		WorkspaceClaim:    cfg.GetString("OIDC_WORKSPACE_CLAIM"),
+		SessionTtl:        cfg.GetDuration("OIDC_SESSION_TTL"),
+		PostLoginRedirect: cfg.GetString("OIDC_POST_LOGIN_REDIRECT"),
+		SessionSecret:     cfg.GetString("ENCRYPTION_SECRET"),
+	}
+	if config.ClientId == "" || config.RedirectUrl == "" {
+		return nil, errors.BadInput.New("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required when OIDC_ISSUER is set")
+	}
+	if config.SessionSecret == "" {
+		return nil, errors.BadInput.New("ENCRYPTION_SECRET is required to sign the sessions")
+	}
+	return config, nil
+}
+
+// Session is the user logged in through the OIDC provider
+type Session struct {
+	Name   string   `json:"name"`
+	Email  string   `json:"email"`
+	Groups []string `json:"groups"`
+	// Workspace is read from the WorkspaceClaim, empty if the provider doesn't bind the user to a workspace
+	Workspace string `json:"workspace,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type loginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Redirect string `json:"redirect"`
+	jwt.RegisteredClaims
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// Provider implements the authorization code flow against the OIDC provider and manages the sessions
+type Provider struct {
+	config     *Config
+	httpClient *http.Client
+	sessionKey []byte
+	mu         sync.Mutex
+	discovery  *discoveryDocument
+	keys       map[string]crypto.PublicKey
+}
+
+func NewProvider(config *Config, httpClient *http.Client) *Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	sessionKey := sha256.Sum256([]byte("devlake-session:" + config.SessionSecret))
+	return &Provider{
+		config:     config,
+		httpClient: httpClient,
+		sessionKey: sessionKey[:],
+	}
+}
+
+// Login redirects the user to the authorization endpoint of the OIDC provider
+func (p *Provider) Login(c *gin.Context) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		c.String(http.StatusBadGateway, err.Error())
+		return
+	}
+	state := &loginState{
+		State:    randomString(),
+		Nonce:    randomString(),
+		Redirect: p.safeRedirect(c.Query("redirect")),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(stateTtl)),
+		},
+	}
+	signedState, err := p.sign(state)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	p.setCookie(c, stateCookie, signedState, stateTtl)
+	authUrl := p.oauth2Config(discovery).AuthCodeURL(state.State, oauth2.SetAuthURLParam("nonce", state.Nonce))
+	c.Redirect(http.StatusFound, authUrl)
+}
+
+// Callback exchanges the authorization code for the ID token and starts the session
+func (p *Provider) Callback(c *gin.Context) {
+	if errCode := c.Query("error"); errCode != "" {
+		c.String(http.StatusUnauthorized, fmt.Sprintf("login failed: %s %s", errCode, c.Query("error_description")))
+		return
+	}
+	signedState, _ := c.Cookie(stateCookie)
+	state := &loginState{}
+	if _, err := p.parse(signedState, state); err != nil || state.State == "" || state.State != c.Query("state") {
+		c.String(http.StatusBadRequest, "invalid login state, please try again")
+		return
+	}
+	p.setCookie(c, stateCookie, "", -1)
+	session, err := p.exchange(c.Request.Context(), c.Query("code"), state.Nonce)
+	if err != nil {
+		c.String(http.StatusUnauthorized, err.Error())
+		return
+	}
+	session.ExpiresAt = jwt.NewNumericDate(time.Now().Add(p.sessionTtl()))
+	signedSession, err := p.sign(session)
+	if err != nil {
+		c.String(http.StatusInternalServerError, err.Error())
+		return
+	}
+	p.setCookie(c, SessionCookie, signedSession, p.sessionTtl())
+	c.Redirect(http.StatusFound, state.Redirect)
+}
+
+// Logout ends the session
+func (p *Provider) Logout(c *gin.Context) {
+	p.setCookie(c, SessionCookie, "", -1)
+	c.Redirect(http.StatusFound, p.safeRedirect(c.Query("redirect")))
+}
+
+// UserInfo returns the user logged in and the role granted to them
+func UserInfo(c *gin.Context) {
+	user, _ := shared.GetUser(c)
+	role, _ := shared.LookupRole(c)
+	shared.ApiOutputSuccess(c, gin.H{"user": user, "role": role}, http.StatusOK)
+}
+
+// GetSession returns the session of the request, an error is returned if the session is missing or invalid
+func (p *Provider) GetSession(c *gin.Context) (*Session, errors.Error) {
+	signedSession, err := c.Cookie(SessionCookie)
+	if err != nil || signedSession == "" {
+		return nil, errors.Unauthorized.New("session is missing")
+	}
+	session := &Session{}
+	if _, err := p.parse(signedSession, session); err != nil {
+		return nil, errors.Unauthorized.Wrap(err, "session is invalid")
+	}
+	return session, nil
+}
+
+func (p *Provider) exchange(ctx context.Context, code string, nonce string) (*Session, errors.Error) {
+	if code == "" {
+		return nil, errors.BadInput.New("authorization code is missing")
+	}
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, e := p.oauth2Config(discovery).Exchange(ctx, code)
+	if e != nil {
+		return nil, errors.Unauthorized.Wrap(e, "failed to exchange the authorization code")
+	}
+	rawIdToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIdToken == "" {
+		return nil, errors.Unauthorized.New("id_token is missing from the token response")
+	}
+	return p.verifyIdToken(rawIdToken, nonce)
+}
+
+func (p *Provider) verifyIdToken(rawIdToken string, nonce string) (*Session, errors.Error) {
+	claims := jwt.MapClaims{}
+	_, e := jwt.ParseWithClaims(
+		rawIdToken,
+		claims,
+		p.getVerificationKey,
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientId),
+	)
+	if e != nil {
+		return nil, errors.Unauthorized.Wrap(e, "id_token is invalid")
+	}
+	if claims["nonce"] != nonce {
+		return nil, errors.Unauthorized.New("id_token nonce mismatched")
+	}
+	session := &Session{
+		Email:  claimString(claims, "email"),
+		Groups: claimStrings(claims, p.config.GroupsClaim),
+	}
+	if p.config.WorkspaceClaim != "" {
+		session.Workspace = strings.TrimSpace(claimString(claims, p.config.WorkspaceClaim))
+	}
+	for _, name := range []string{"preferred_username", "name", "email", "sub"} {
+		if session.Name = claimString(claims, name); session.Name != "" {
+			break
+		}
+	}
+	session.Subject = claimString(claims, "sub")
+	return session, nil
+}
+
+func (p *Provider) getVerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, refresh := range []bool{false, true} {
+		keys, err := p.getKeys(refresh)
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+		// providers without key ids publish a single key
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+	}
+	return nil, errors.Unauthorized.New(fmt.Sprintf("signing key %s not found", kid))
+}
+
+func (p *Provider) getDiscovery() (*discoveryDocument, errors.Error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	discovery := &discoveryDocument{}
+	if err := p.getJson(p.config.Issuer+"/.well-known/openid-configuration", discovery); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.config.Issuer {
+		return nil, errors.Default.New(fmt.Sprintf("issuer %s mismatched with the discovery document %s", p.config.Issuer, discovery.Issuer))
+	}
+	p.discovery = discovery
+	return discovery, nil
+}
+
+func (p *Provider) getKeys(refresh bool) (map[string]crypto.PublicKey, errors.Error) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keys != nil && !refresh {
+		return p.keys, nil
+	}
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJson(discovery.JwksUri, &jwks); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.publicKey()
+		if err != nil {
+			// keys of unsupported types are not used to sign ID tokens for us
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	return keys, nil
+}
+
+func (p *Provider) getJson(uri string, v interface{}) errors.Error {
+	res, err := p.httpClient.Get(uri)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to request %s", uri))
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("unexpected status code %d from %s", res.StatusCode, uri))
+	}
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("failed to decode response from %s", uri))
+	}
+	return nil
+}
+
+func (p *Provider) oauth2Config(discovery *discoveryDocument) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientId,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectUrl,
+		Scopes:       p.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+	}
+}
+
+func (p *Provider) sign(claims jwt.Claims) (string, errors.Error) {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(p.sessionKey)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "failed to sign")
+	}
+	return signed, nil
+}
+
+func (p *Provider) parse(signed string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(signed, claims, func(token *jwt.Token) (interface{}, error) {
+		return p.sessionKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+}
+
+func (p *Provider) setCookie(c *gin.Context, name, value string, ttl time.Duration) {
+	maxAge := int(ttl.Seconds())
+	if ttl < 0 {
+		maxAge = -1
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(name, value, maxAge, "/", "", strings.HasPrefix(p.config.RedirectUrl, "https://"), true)
+}
+
+func (p *Provider) sessionTtl() time.Duration {
+	if p.config.SessionTtl <= 0 {
+		return 8 * time.Hour
+	}
+	return p.config.SessionTtl
+}
+
+// safeRedirect allows relative redirections only to avoid being used as an open redirector
+func (p *Provider) safeRedirect(redirect string) string {
+	if u, err := url.Parse(redirect); err == nil && redirect != "" && u.Host == "" && u.Scheme == "" && strings.HasPrefix(redirect, "/") && !strings.HasPrefix(redirect, "//") {
+		return redirect
+	}
+	if p.config.PostLoginRedirect != "" {
+		return p.config.PostLoginRedirect
+	}
+	return "/"
+}
+
+func (jwk *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %s", jwk.Kty)
+}
+
+func claimString(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+// claimStrings accepts both array and space/comma separated string claims
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	var values []string
+	switch value := claims[name].(type) {
+	case []interface{}:
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	case string:
+		values = strings.FieldsFunc(value, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+	return values
+}
+
+func randomString() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,203 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockProvider is a minimal OIDC provider issuing ID tokens for whatever authorization code it receives
+type mockProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	nonce  string
+	claims jwt.MapClaims
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.Nil(t, err)
+	m := &mockProvider{key: key}
+	mux := http.NewServeMux()
+	m.server = httptest.NewServer(mux)
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"jwks_uri":               m.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		claims := jwt.MapClaims{
+			"iss":   m.server.URL,
+			"aud":   "devlake",
+			"sub":   "42",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": m.nonce,
+		}
+		for k, v := range m.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		assert.Nil(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+	return m
+}
+
+func newTestRouter(m *mockProvider) (*gin.Engine, *Provider) {
+	gin.SetMode(gin.TestMode)
+	provider := NewProvider(&Config{
+		Issuer:         m.server.URL,
+		ClientId:       "devlake",
+		ClientSecret:   "secret",
+		RedirectUrl:    "http://localhost:4000/oidc/callback",
+		Scopes:         []string{"openid", "email"},
+		GroupsClaim:    "groups",
+		WorkspaceClaim: "devlake_workspace",
+		SessionSecret:  "encryption secret",
+	}, m.server.Client())
+	router := gin.New()
+	router.GET("/oidc/login", provider.Login)
+	router.GET("/oidc/callback", provider.Callback)
+	return router, provider
+}
+
+func login(t *testing.T, router *gin.Engine, m *mockProvider, redirect string) (*httptest.ResponseRecorder, *http.Cookie, string) {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/oidc/login?redirect="+url.QueryEscape(redirect), nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	location, err := url.Parse(w.Header().Get("Location"))
+	assert.Nil(t, err)
+	assert.Equal(t, m.server.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+	assert.Equal(t, "devlake", location.Query().Get("client_id"))
+	m.nonce = location.Query().Get("nonce")
+	cookies := w.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	return w, cookies[0], location.Query().Get("state")
+}
+
+func callback(router *gin.Engine, stateCookie *http.Cookie, state string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/oidc/callback?code=abc&state="+state, nil)
+	req.AddCookie(stateCookie)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestLoginFlow(t *testing.T) {
+	m := newMockProvider(t)
+	defer m.server.Close()
+	m.claims = jwt.MapClaims{
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"groups":             []string{"devlake-admins", "everyone"},
+		"devlake_workspace":  "team-a",
+	}
+	router, provider := newTestRouter(m)
+
+	_, stateCookie, state := login(t, router, m, "/projects")
+	w := callback(router, stateCookie, state)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/projects", w.Header().Get("Location"))
+
+	var sessionCookie *http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == SessionCookie {
+			sessionCookie = cookie
+		}
+	}
+	assert.NotNil(t, sessionCookie)
+	assert.True(t, sessionCookie.HttpOnly)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/blueprints", nil)
+	c.Request.AddCookie(sessionCookie)
+	session, err := provider.GetSession(c)
+	assert.Nil(t, err)
+	assert.Equal(t, "alice", session.Name)
+	assert.Equal(t, "alice@example.com", session.Email)
+	assert.Equal(t, []string{"devlake-admins", "everyone"}, session.Groups)
+	assert.Equal(t, "team-a", session.Workspace)
+
+	// tampered session
+	c.Request = httptest.NewRequest(http.MethodGet, "/blueprints", nil)
+	c.Request.AddCookie(&http.Cookie{Name: SessionCookie, Value: sessionCookie.Value + "x"})
+	_, err = provider.GetSession(c)
+	assert.NotNil(t, err)
+}
+
+func TestCallbackRejectsInvalidState(t *testing.T) {
+	m := newMockProvider(t)
+	defer m.server.Close()
+	router, _ := newTestRouter(m)
+
+	_, stateCookie, _ := login(t, router, m, "/")
+	w := callback(router, stateCookie, "forged")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCallbackRejectsNonceMismatch(t *testing.T) {
+	m := newMockProvider(t)
+	defer m.server.Close()
+	router, _ := newTestRouter(m)
+
+	_, stateCookie, state := login(t, router, m, "/")
+	m.nonce = "replayed"
+	w := callback(router, stateCookie, state)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSafeRedirect(t *testing.T) {
+	provider := NewProvider(&Config{PostLoginRedirect: "/home"}, nil)
+	assert.Equal(t, "/projects?page=2", provider.safeRedirect("/projects?page=2"))
+	assert.Equal(t, "/home", provider.safeRedirect("https://evil.example.com"))
+	assert.Equal(t, "/home", provider.safeRedirect("//evil.example.com"))
+	assert.Equal(t, "/home", provider.safeRedirect(""))
+}
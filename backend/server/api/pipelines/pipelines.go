@@ -48,6 +48,17 @@ func Post(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad JSON request body format"))
 		return
 	}
+	// pipelines belong to the workspace of their blueprint, ad-hoc pipelines are for unbound callers only
+	if workspace := shared.GetWorkspace(c); workspace != "" {
+		if newPipeline.BlueprintId == 0 {
+			shared.ApiOutputError(c, errors.Forbidden.New("pipelines must be created from a blueprint within a workspace"))
+			return
+		}
+		if err := services.VerifyBlueprintWorkspace(workspace, newPipeline.BlueprintId); err != nil {
+			shared.ApiOutputError(c, err)
+			return
+		}
+	}
 
 	pipeline, err := services.CreatePipeline(newPipeline, true)
 	// Return all created tasks to the User
@@ -78,6 +89,7 @@ func Index(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.Workspace = shared.GetWorkspace(c)
 	pipelines, count, err := services.GetPipelines(&query, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipelines"))
@@ -107,6 +119,10 @@ func Get(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
 		return
 	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	pipeline, err := services.GetPipeline(id, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipeline"))
@@ -130,6 +146,10 @@ func Delete(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
 		return
 	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err = services.CancelPipeline(id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error cancelling pipeline"))
@@ -154,6 +174,10 @@ func DownloadLogs(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipeline ID format supplied"))
 		return
 	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	pipeline, err := services.GetPipeline(id, true)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipeline"))
@@ -184,6 +208,10 @@ func PostRerun(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
 		return
 	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	rerunTasks, err := services.RerunPipeline(id, nil)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "failed to rerun pipeline"))
@@ -200,3 +228,59 @@ func PostRerun(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, rerunTasks, http.StatusOK)
 }
+
+// PostPause pause a pipeline, running tasks stop gracefully after their current subtask
+// @Summary pause a pipeline
+// @Description pending pipelines are held in the queue, running pipelines stop after the current subtask
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipelineId"
+// @Success 200  {object} models.Pipeline
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /pipelines/{pipelineId}/pause [post]
+func PostPause(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	pipeline, err := services.PausePipeline(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "failed to pause pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, pipeline, http.StatusOK)
+}
+
+// PostResume resume a paused pipeline from the next subtask
+// @Summary resume a paused pipeline
+// @Description the pipeline is put back into the queue and continues from the next subtask of the paused tasks
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipelineId"
+// @Success 200  {object} models.Pipeline
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /pipelines/{pipelineId}/resume [post]
+func PostResume(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipelineID format supplied"))
+		return
+	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
+	pipeline, err := services.ResumePipeline(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "failed to resume pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, pipeline, http.StatusOK)
+}
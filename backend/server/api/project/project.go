@@ -44,6 +44,10 @@ type PaginatedProjects struct {
 // @Router /projects/{projectName} [get]
 func GetProject(c *gin.Context) {
 	projectName := c.Param("projectName")
+	if err := services.VerifyProjectWorkspace(shared.GetWorkspace(c), projectName); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 
 	projectOutput, err := services.GetProject(projectName)
 	if err != nil {
@@ -66,7 +70,11 @@ func GetProjectCheck(c *gin.Context) {
 	projectName := c.Param("projectName")
 
 	projectOutputCheck := &models.ApiProjectCheck{}
-	_, err := services.GetProject(projectName)
+	// projects of other workspaces are reported as non-existent
+	err := services.VerifyProjectWorkspace(shared.GetWorkspace(c), projectName)
+	if err == nil {
+		_, err = services.GetProject(projectName)
+	}
 	if err != nil {
 		projectOutputCheck.Exist = false
 	} else {
@@ -92,6 +100,7 @@ func GetProjects(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	query.Workspace = shared.GetWorkspace(c)
 	projects, count, err := services.GetProjects(&query)
 	if err != nil {
 		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting projects"))
@@ -124,6 +133,10 @@ func PostProject(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.New("Project name is too long."))
 		return
 	}
+	// callers bound to a workspace can only create projects in their own workspace
+	if workspace := shared.GetWorkspace(c); workspace != "" {
+		projectInput.Workspace = workspace
+	}
 
 	projectOutput, err := services.CreateProject(projectInput)
 	if err != nil {
@@ -145,6 +158,11 @@ func PostProject(c *gin.Context) {
 // @Router /projects/:projectName [patch]
 func PatchProject(c *gin.Context) {
 	projectName := c.Param("projectName")
+	workspace := shared.GetWorkspace(c)
+	if err := services.VerifyProjectWorkspace(workspace, projectName); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 
 	var body map[string]interface{}
 	err := c.ShouldBind(&body)
@@ -152,6 +170,9 @@ func PatchProject(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
+	if workspace != "" {
+		delete(body, "workspace")
+	}
 
 	projectOutput, err := services.PatchProject(projectName, body)
 	if err != nil {
@@ -172,6 +193,10 @@ func PatchProject(c *gin.Context) {
 // @Router /projects/:projectName [delete]
 func DeleteProject(c *gin.Context) {
 	projectName := c.Param("projectName")
+	if err := services.VerifyProjectWorkspace(shared.GetWorkspace(c), projectName); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err := services.DeleteProject(projectName)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting project"))
@@ -49,6 +49,11 @@ import (
 func Post(c *gin.Context) {
 	var err error
 	tableName := c.Param("tableName")
+	// domain layer tables are shared by all workspaces
+	if shared.GetWorkspace(c) != "" {
+		shared.ApiOutputError(c, errors.Forbidden.New("pushing data is not allowed for callers bound to a workspace"))
+		return
+	}
 	var rowsToInsert []map[string]interface{}
 	err = c.ShouldBindJSON(&rowsToInsert)
 	if err != nil {
@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	// runtimePluginRouter serves the api resources of the remote plugins registered while the server is running,
+	// the routes of gin can't be changed once it started serving
+	runtimePluginRouter struct {
+		basicRes context.BasicRes
+		mu       sync.RWMutex
+		routes   map[string][]runtimePluginRoute
+	}
+	runtimePluginRoute struct {
+		method   string
+		segments []string
+		handler  gin.HandlerFunc
+	}
+	remotePluginRequest struct {
+		Url string `json:"url" binding:"required"`
+	}
+	remotePluginResponse struct {
+		Name string `json:"name"`
+	}
+)
+
+func newRuntimePluginRouter(basicRes context.BasicRes) *runtimePluginRouter {
+	return &runtimePluginRouter{
+		basicRes: basicRes,
+		routes:   make(map[string][]runtimePluginRoute),
+	}
+}
+
+// @Summary Register a remote plugin running as a http service
+// @Description Register a remote plugin running as a http service, it is kept until the server restarts
+// @Tags framework/plugins
+// @Accept application/json
+// @Param body body remotePluginRequest true "json"
+// @Success 200  {object} remotePluginResponse
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /remote-plugins [post]
+func (r *runtimePluginRouter) Post(c *gin.Context) {
+	// the migration scripts of the plugin run against the database shared by all workspaces
+	if shared.GetWorkspace(c) != "" {
+		shared.ApiOutputError(c, errors.Forbidden.New("registering remote plugins is not allowed for callers bound to a workspace"))
+		return
+	}
+	request := &remotePluginRequest{}
+	err := c.ShouldBindJSON(request)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	remotePlugin, err := services.RegisterRemotePlugin(strings.TrimSpace(request.Url))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error registering remote plugin"))
+		return
+	}
+	r.register(remotePlugin.Name(), remotePlugin.ApiResources())
+	shared.ApiOutputSuccess(c, remotePluginResponse{Name: remotePlugin.Name()}, http.StatusOK)
+}
+
+func (r *runtimePluginRouter) register(pluginName string, apiResources map[string]map[string]plugin.ApiResourceHandler) {
+	var routes []runtimePluginRoute
+	for resourcePath, resourceHandlers := range apiResources {
+		for method, h := range resourceHandlers {
+			routes = append(routes, runtimePluginRoute{
+				method:   method,
+				segments: strings.Split(strings.Trim(resourcePath, "/"), "/"),
+				handler:  handlePluginCall(r.basicRes, pluginName, h),
+			})
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[pluginName] = routes
+}
+
+// Handle is the fallback of /plugins/:plugin/*path, the plugins loaded on startup are routed by gin directly
+func (r *runtimePluginRouter) Handle(c *gin.Context) {
+	pluginName := c.Param("plugin")
+	r.mu.RLock()
+	routes := r.routes[pluginName]
+	r.mu.RUnlock()
+	// match the escaped path so that the params may contain slashes, like gin does with UseRawPath
+	escapedPath := strings.TrimPrefix(c.Request.URL.EscapedPath(), "/plugins/"+url.PathEscape(pluginName))
+	segments := strings.Split(strings.Trim(escapedPath, "/"), "/")
+	for _, route := range routes {
+		if route.method != c.Request.Method {
+			continue
+		}
+		if params, ok := route.match(segments); ok {
+			c.Params = params
+			route.handler(c)
+			return
+		}
+	}
+	shared.ApiOutputError(c, errors.NotFound.New("404 page not found"))
+}
+
+// match resolves the `:param` and `*param` segments of the route like gin
+func (route *runtimePluginRoute) match(segments []string) (gin.Params, bool) {
+	var params gin.Params
+	for i, pattern := range route.segments {
+		if strings.HasPrefix(pattern, "*") {
+			var rest []string
+			for _, segment := range segments[i:] {
+				rest = append(rest, unescapeSegment(segment))
+			}
+			params = append(params, gin.Param{Key: pattern[1:], Value: "/" + strings.Join(rest, "/")})
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if strings.HasPrefix(pattern, ":") {
+			params = append(params, gin.Param{Key: pattern[1:], Value: unescapeSegment(segments[i])})
+		} else if pattern != segments[i] {
+			return nil, false
+		}
+	}
+	return params, len(segments) == len(route.segments)
+}
+
+func unescapeSegment(segment string) string {
+	unescaped, err := url.PathUnescape(segment)
+	if err != nil {
+		return segment
+	}
+	return unescaped
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimePluginRouter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	basicRes := &testBasicRes{}
+	params := func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+		return &plugin.ApiResourceOutput{Body: input.Params}, nil
+	}
+	router := gin.New()
+	router.GET("/plugins/github/connections", func(c *gin.Context) {
+		c.String(http.StatusOK, "github")
+	})
+	runtimePlugins := newRuntimePluginRouter(basicRes)
+	router.Any("/plugins/:plugin/*path", runtimePlugins.Handle)
+	runtimePlugins.register("fake", map[string]map[string]plugin.ApiResourceHandler{
+		"connections": {
+			http.MethodGet: params,
+		},
+		"connections/:connectionId/scopes/*scopeId": {
+			http.MethodGet: params,
+		},
+	})
+
+	// the plugins loaded on startup keep their routes
+	w := serve(router, http.MethodGet, "/plugins/github/connections", "", "")
+	assert.Equal(t, "github", w.Body.String())
+
+	w = serve(router, http.MethodGet, "/plugins/fake/connections", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"plugin": "fake"}`, w.Body.String())
+
+	w = serve(router, http.MethodGet, "/plugins/fake/connections/1/scopes/group%2Fproject/latest-sync-state", "", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"plugin": "fake", "connectionId": "1", "scopeId": "/group/project/latest-sync-state"}`, w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodPost, "/plugins/fake/connections", "", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/plugins/fake/connections/1", "", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/plugins/other/connections", "", "").Code)
+}
+
+func TestRuntimePluginRouterPostWorkspaceBound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		shared.SetWorkspace(c, "a")
+	})
+	router.POST("/remote-plugins", newRuntimePluginRouter(&testBasicRes{}).Post)
+	w := serve(router, http.MethodPost, "/remote-plugins", "", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
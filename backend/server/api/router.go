@@ -20,12 +20,15 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/helpers/workspacehelper"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/apache/incubator-devlake/server/api/apikeys"
+	"github.com/apache/incubator-devlake/server/api/auditlog"
 	"github.com/apache/incubator-devlake/server/api/store"
 
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -37,6 +40,7 @@ import (
 	"github.com/apache/incubator-devlake/server/api/push"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/task"
+	"github.com/apache/incubator-devlake/server/api/workspace"
 	"github.com/apache/incubator-devlake/server/services"
 
 	"github.com/gin-gonic/gin"
@@ -50,6 +54,8 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
 	r.GET("/pipelines/:pipelineId/subtasks", task.GetSubtaskByPipeline)
 	r.POST("/pipelines/:pipelineId/rerun", pipelines.PostRerun)
+	r.POST("/pipelines/:pipelineId/pause", pipelines.PostPause)
+	r.POST("/pipelines/:pipelineId/resume", pipelines.PostResume)
 	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
 
 	r.GET("/blueprints", blueprints.Index)
@@ -86,6 +92,17 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.PUT("/api-keys/:apiKeyId", apikeys.PutApiKey)
 	r.DELETE("/api-keys/:apiKeyId", apikeys.DeleteApiKey)
 
+	// audit logs api
+	r.GET("/audit-logs", auditlog.GetAuditLogs)
+
+	// workspaces api
+	r.GET("/workspaces", workspace.GetWorkspaces)
+	r.POST("/workspaces", workspace.PostWorkspace)
+	r.DELETE("/workspaces/:workspace", workspace.DeleteWorkspace)
+	r.GET("/workspaces/:workspace/connections", workspace.GetWorkspaceConnections)
+	r.POST("/workspaces/:workspace/connections", workspace.PostWorkspaceConnection)
+	r.DELETE("/workspaces/:workspace/connections/:plugin/:connectionId", workspace.DeleteWorkspaceConnection)
+
 	// mount all api resources for all plugins
 	resources, err := services.GetPluginsApiResources()
 	if err != nil {
@@ -95,6 +112,10 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	for pluginName, apiResources := range resources {
 		registerPluginEndpoints(r, basicRes, pluginName, apiResources)
 	}
+	// remote plugins registered at runtime are served by the fallback route
+	runtimePlugins := newRuntimePluginRouter(basicRes)
+	r.POST("/remote-plugins", runtimePlugins.Post)
+	r.Any("/plugins/:plugin/*path", runtimePlugins.Handle)
 }
 
 func registerPluginEndpoints(r *gin.Engine, basicRes context.BasicRes, pluginName string, apiResources map[string]map[string]plugin.ApiResourceHandler) {
@@ -127,6 +148,20 @@ func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plug
 		} else {
 			input.User = user
 		}
+		input.Workspace = shared.GetWorkspace(c)
+		// connection specific endpoints are restricted to the connections of the workspace
+		if connectionId, ok := input.Params["connectionId"]; ok && input.Workspace != "" {
+			id, parseErr := strconv.ParseUint(connectionId, 10, 64)
+			if parseErr != nil {
+				shared.ApiOutputError(c, errors.BadInput.Wrap(parseErr, "invalid connectionId"))
+				return
+			}
+			err = workspacehelper.NewWorkspaceHelper(basicRes).VerifyConnection(input.Workspace, pluginName, id)
+			if err != nil {
+				shared.ApiOutputError(c, err)
+				return
+			}
+		}
 		if c.Request.Body != nil {
 			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
 				input.Request = c.Request
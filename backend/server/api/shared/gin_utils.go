@@ -18,10 +18,17 @@ limitations under the License.
 package shared
 
 import (
+	"context"
+
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/gin-gonic/gin"
 )
 
+type workspaceKey struct{}
+type apiKeyKey struct{}
+type roleKey struct{}
+
 func GetUser(c *gin.Context) (*common.User, bool) {
 	userObj, exist := c.Get(common.USER)
 	if !exist {
@@ -30,3 +37,43 @@ func GetUser(c *gin.Context) (*common.User, bool) {
 	user := userObj.(*common.User)
 	return user, true
 }
+
+// SetWorkspace binds the request to the workspace, it is stored in the request context
+// since gin.Context keys would be reset by router.HandleContext
+func SetWorkspace(c *gin.Context, workspace string) {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), workspaceKey{}, workspace))
+}
+
+// LookupWorkspace returns the workspace the request is bound to and whether it has been resolved
+func LookupWorkspace(c *gin.Context) (string, bool) {
+	workspace, ok := c.Request.Context().Value(workspaceKey{}).(string)
+	return workspace, ok
+}
+
+// GetWorkspace returns the workspace the request is bound to, empty means not bound to any workspace
+func GetWorkspace(c *gin.Context) string {
+	workspace, _ := LookupWorkspace(c)
+	return workspace
+}
+
+// SetApiKey records the api key the request was authenticated with
+func SetApiKey(c *gin.Context, apiKey *models.ApiKey) {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), apiKeyKey{}, apiKey))
+}
+
+// GetApiKey returns the api key the request was authenticated with, nil if it was not authenticated by an api key
+func GetApiKey(c *gin.Context) *models.ApiKey {
+	apiKey, _ := c.Request.Context().Value(apiKeyKey{}).(*models.ApiKey)
+	return apiKey
+}
+
+// SetRole grants the role to the request
+func SetRole(c *gin.Context, role string) {
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), roleKey{}, role))
+}
+
+// LookupRole returns the role granted to the request and whether it has been resolved
+func LookupRole(c *gin.Context) (string, bool) {
+	role, ok := c.Request.Context().Value(roleKey{}).(string)
+	return role, ok
+}
@@ -39,7 +39,7 @@ import (
 // @Router /store/{storeKey} [get]
 func GetStore(c *gin.Context) {
 	storeKey := c.Param("storeKey")
-	result, err := services.GetStore(storeKey)
+	result, err := services.GetStore(getWorkspaceStoreKey(c, storeKey))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Error fetching %s data", storeKey)})
 		return
@@ -66,9 +66,9 @@ func PutStore(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
 		return
 	}
-	body.StoreKey = storeKey
+	body.StoreKey = getWorkspaceStoreKey(c, storeKey)
 
-	result, err := services.PutStore(storeKey, &body)
+	result, err := services.PutStore(body.StoreKey, &body)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, fmt.Sprintf("PutStore: failed to put %s", storeKey)))
 		return
@@ -76,3 +76,11 @@ func PutStore(c *gin.Context) {
 
 	shared.ApiOutputSuccess(c, result.StoreValue, http.StatusCreated)
 }
+
+// getWorkspaceStoreKey keeps the stores of the workspaces apart, callers bound to no workspace use the key as is
+func getWorkspaceStoreKey(c *gin.Context, storeKey string) string {
+	if workspace := shared.GetWorkspace(c); workspace != "" {
+		return fmt.Sprintf("%s:%s", workspace, storeKey)
+	}
+	return storeKey
+}
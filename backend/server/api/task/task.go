@@ -36,6 +36,10 @@ func Delete(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "invalid task ID format"))
 		return
 	}
+	if err := services.VerifyTaskWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	err = services.CancelTask(id)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error cancelling task"))
@@ -64,6 +68,10 @@ func GetTaskByPipeline(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "invalid pipeline ID format"))
 		return
 	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), pipelineId); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	tasks, err := services.GetTasksWithLastStatus(pipelineId, true, nil)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting tasks"))
@@ -87,6 +95,10 @@ func GetSubtaskByPipeline(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "invalid pipeline ID format"))
 		return
 	}
+	if err := services.VerifyPipelineWorkspace(shared.GetWorkspace(c), pipelineId); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	subTasksOuput, err := services.GetSubTasksInfo(pipelineId, true, nil)
 	if err != nil {
 		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting tasks"))
@@ -111,6 +123,10 @@ func PostRerun(c *gin.Context) {
 		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad taskId format supplied"))
 		return
 	}
+	if err := services.VerifyTaskWorkspace(shared.GetWorkspace(c), id); err != nil {
+		shared.ApiOutputError(c, err)
+		return
+	}
 	task, err := services.RerunTask(id)
 	if err != nil {
 		shared.ApiOutputError(c, err)
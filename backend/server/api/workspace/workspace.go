@@ -0,0 +1,184 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get list of workspaces
+// @Description Get list of workspaces, only available to callers not bound to any workspace
+// @Tags framework/workspaces
+// @Success 200  {object} []models.Workspace
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces [get]
+func GetWorkspaces(c *gin.Context) {
+	if !verifyUnbound(c) {
+		return
+	}
+	workspaces, err := services.GetWorkspaces()
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting workspaces"))
+		return
+	}
+	shared.ApiOutputSuccess(c, workspaces, http.StatusOK)
+}
+
+// @Summary Create a new workspace
+// @Description Create a new workspace, only available to callers not bound to any workspace
+// @Tags framework/workspaces
+// @Accept application/json
+// @Param workspace body models.Workspace true "json"
+// @Success 201  {object} models.Workspace
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces [post]
+func PostWorkspace(c *gin.Context) {
+	if !verifyUnbound(c) {
+		return
+	}
+	workspace := &models.Workspace{}
+	err := c.ShouldBind(workspace)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	user, _ := shared.GetUser(c)
+	workspace, err = services.CreateWorkspace(user, workspace)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating workspace"))
+		return
+	}
+	shared.ApiOutputSuccess(c, workspace, http.StatusCreated)
+}
+
+// @Summary Delete a workspace
+// @Description Delete a workspace which owns no resource, only available to callers not bound to any workspace
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Success 200
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 409  {string} errcode.Error "Conflict"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace} [delete]
+func DeleteWorkspace(c *gin.Context) {
+	if !verifyUnbound(c) {
+		return
+	}
+	err := services.DeleteWorkspace(c.Param("workspace"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting workspace"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Get connections of a workspace
+// @Description Get plugin connections assigned to the workspace, only available to callers not bound to any workspace
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Success 200  {object} []models.WorkspaceConnection
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/connections [get]
+func GetWorkspaceConnections(c *gin.Context) {
+	if !verifyUnbound(c) {
+		return
+	}
+	connections, err := services.GetWorkspaceConnections(c.Param("workspace"))
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting workspace connections"))
+		return
+	}
+	shared.ApiOutputSuccess(c, connections, http.StatusOK)
+}
+
+// @Summary Assign a connection to a workspace
+// @Description Assign a plugin connection to the workspace, only available to callers not bound to any workspace
+// @Tags framework/workspaces
+// @Accept application/json
+// @Param workspace path string true "workspace name"
+// @Param connection body models.WorkspaceConnection true "json"
+// @Success 201  {object} models.WorkspaceConnection
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/connections [post]
+func PostWorkspaceConnection(c *gin.Context) {
+	if !verifyUnbound(c) {
+		return
+	}
+	connection := &models.WorkspaceConnection{}
+	err := c.ShouldBind(connection)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	connection, err = services.AssignWorkspaceConnection(c.Param("workspace"), connection)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error assigning connection"))
+		return
+	}
+	shared.ApiOutputSuccess(c, connection, http.StatusCreated)
+}
+
+// @Summary Unassign a connection from a workspace
+// @Description Unassign a plugin connection from the workspace, only available to callers not bound to any workspace
+// @Tags framework/workspaces
+// @Param workspace path string true "workspace name"
+// @Param plugin path string true "plugin name"
+// @Param connectionId path int true "connection id"
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 403  {string} errcode.Error "Forbidden"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /workspaces/{workspace}/connections/{plugin}/{connectionId} [delete]
+func DeleteWorkspaceConnection(c *gin.Context) {
+	if !verifyUnbound(c) {
+		return
+	}
+	connectionId, err := strconv.ParseUint(c.Param("connectionId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad connectionId format supplied"))
+		return
+	}
+	err = services.UnassignWorkspaceConnection(c.Param("workspace"), c.Param("plugin"), connectionId)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error unassigning connection"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// verifyUnbound makes sure workspaces are managed by callers not bound to any workspace
+func verifyUnbound(c *gin.Context) bool {
+	if shared.GetWorkspace(c) != "" {
+		shared.ApiOutputError(c, errors.Forbidden.New("workspaces can not be managed within a workspace"))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/helpers/secrethelper"
+	"github.com/spf13/cobra"
+)
+
+// rotate-secret re-encrypts all the encrypted values with a new encryption secret, the current secret is read from
+// ENCRYPTION_SECRET and the new one from NEW_ENCRYPTION_SECRET so neither shows up in the process list.
+// Stop the server before the rotation, and replace ENCRYPTION_SECRET with the new secret afterward.
+func main() {
+	cmd := &cobra.Command{
+		Use:   "rotate-secret",
+		Short: "Re-encrypt all encrypted values from ENCRYPTION_SECRET to NEW_ENCRYPTION_SECRET",
+	}
+	dryRun := cmd.Flags().Bool("dry-run", false, "report the encrypted columns without changing anything")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		basicRes := runner.CreateAppBasicRes()
+		logger := basicRes.GetLogger()
+		oldSecret := basicRes.GetConfig(plugin.EncodeKeyEnvStr)
+		newSecret := basicRes.GetConfig("NEW_ENCRYPTION_SECRET")
+		// the encrypted columns are the `serializer:encdec` fields of the framework and plugin models
+		err := runner.LoadPlugins(basicRes)
+		if err != nil {
+			return err
+		}
+		tables := []dal.Tabler{&models.Blueprint{}, &models.Pipeline{}, &models.Task{}}
+		for _, pluginMeta := range plugin.AllPlugins() {
+			if pluginModel, ok := pluginMeta.(plugin.PluginModel); ok {
+				tables = append(tables, pluginModel.GetTablesInfo()...)
+			}
+		}
+		result, err := secrethelper.RotateEncryptionSecret(basicRes.GetDal(), logger, tables, oldSecret, newSecret, *dryRun)
+		if err != nil {
+			return err
+		}
+		for _, column := range result.Columns {
+			fmt.Println(column)
+		}
+		if *dryRun {
+			logger.Info("dry run: %d values in %d columns would be re-encrypted, %d were done already", result.Rotated, len(result.Columns), result.Skipped)
+			return nil
+		}
+		logger.Info("%d values in %d columns were re-encrypted, %d were done already", result.Rotated, len(result.Columns), result.Skipped)
+		logger.Warn(nil, "please set ENCRYPTION_SECRET to the new secret and regenerate the api keys, which were hashed with the old one")
+		return nil
+	}
+	err := cmd.Execute()
+	if err != nil {
+		panic(err)
+	}
+}
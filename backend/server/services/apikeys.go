@@ -28,6 +28,8 @@ import (
 // ApiKeysQuery used to query api keys as the api key input
 type ApiKeysQuery struct {
 	Pagination
+	// Workspace is resolved from the caller instead of the query string
+	Workspace string `form:"-"`
 }
 
 // GetApiKeys returns a paginated list of api keys based on `query`
@@ -40,6 +42,9 @@ func GetApiKeys(query *ApiKeysQuery) ([]*models.ApiKey, int64, errors.Error) {
 		dal.From(&models.ApiKey{}),
 		dal.Where("type = ?", "devlake"),
 	}
+	if query.Workspace != "" {
+		clauses = append(clauses, dal.Where("workspace = ?", query.Workspace))
+	}
 
 	logger.Info("query: %+v", query)
 	count, err := db.Count(clauses...)
@@ -103,7 +108,7 @@ func CreateApiKey(user *common.User, apiKeyInput *models.ApiInputApiKey) (*model
 
 	apiKeyHelper := apikeyhelper.NewApiKeyHelper(basicRes, logger)
 	tx := basicRes.GetDal().Begin()
-	apiKey, err := apiKeyHelper.Create(tx, user, apiKeyInput.Name, apiKeyInput.ExpiredAt, apiKeyInput.AllowedPath, apiKeyInput.Type, "")
+	apiKey, err := apiKeyHelper.Create(tx, user, apiKeyInput.Name, apiKeyInput.ExpiredAt, apiKeyInput.AllowedPath, apiKeyInput.Type, "", apiKeyInput.Workspace, apiKeyInput.Role)
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			logger.Error(err, "transaction Rollback")
@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// AuditLogsQuery used to query audit logs
+type AuditLogsQuery struct {
+	Pagination
+	User   string `form:"user"`
+	Method string `form:"method"`
+}
+
+// GetAuditLogs returns a paginated list of audit logs based on `query`, the most recent come first
+func GetAuditLogs(query *AuditLogsQuery) ([]*models.AuditLog, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.AuditLog{}),
+	}
+	if query.User != "" {
+		clauses = append(clauses, dal.Where("user_name = ?", query.User))
+	}
+	if query.Method != "" {
+		clauses = append(clauses, dal.Where("method = ?", query.Method))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of audit logs")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	auditLogs := make([]*models.AuditLog, 0)
+	err = db.All(&auditLogs, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB audit logs")
+	}
+	return auditLogs, count, nil
+}
@@ -46,6 +46,8 @@ type BlueprintQuery struct {
 	Label    string `form:"label"`
 	// isManual must be omitted or `null` for type to take effect
 	Type string `form:"type" enums:"ALL,MANUAL,DAILY,WEEKLY,MONTHLY,CUSTOM" validate:"oneof=ALL MANUAL DAILY WEEKLY MONTHLY CUSTOM"`
+	// Workspace is resolved from the caller instead of the query string
+	Workspace string `form:"-"`
 }
 
 type BlueprintJob struct {
@@ -81,6 +83,7 @@ func GetBlueprints(query *BlueprintQuery, shouldSanitize bool) ([]*models.Bluepr
 		SkipRecords: query.GetSkip(),
 		PageSize:    query.GetPageSize(),
 		Type:        query.Type,
+		Workspace:   query.Workspace,
 	})
 	if err != nil {
 		return nil, 0, err
@@ -137,6 +140,11 @@ func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
 		return errors.BadInput.WrapRaw(err)
 	}
 
+	// connections must belong to the workspace of the blueprint
+	if err := VerifyBlueprintConnectionsWorkspace(blueprint.Workspace, blueprint); err != nil {
+		return err
+	}
+
 	// checking if the project exist
 	if blueprint.ProjectName != "" {
 		_, err := GetProject(blueprint.ProjectName)
@@ -23,9 +23,16 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 )
 
-// GetRepos FIXME ...
-func GetRepos() ([]*code.Repo, int64, errors.Error) {
+// GetRepos returns the repos visible to the workspace, which are the repos mapped to the projects of the workspace
+func GetRepos(workspace string) ([]*code.Repo, int64, errors.Error) {
 	repos := make([]*code.Repo, 0)
-	err := db.All(&repos, dal.Orderby("id DESC"))
+	clauses := []dal.Clause{dal.Orderby("id DESC")}
+	if workspace != "" {
+		clauses = append(clauses, dal.Where(
+			"id IN (SELECT pm.row_id FROM project_mapping pm WHERE pm.table = ? AND pm.project_name IN (SELECT name FROM projects WHERE workspace = ?))",
+			"repos", workspace,
+		))
+	}
+	err := db.All(&repos, clauses...)
 	return repos, int64(len(repos)), err
 }
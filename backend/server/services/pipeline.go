@@ -76,6 +76,8 @@ type PipelineQuery struct {
 	Pending     int    `form:"pending"`
 	BlueprintId uint64 `uri:"blueprintId" form:"blueprint_id"`
 	Label       string `form:"label"`
+	// Workspace is resolved from the caller instead of the query string
+	Workspace string `form:"-"`
 }
 
 func pipelineServiceInit() {
@@ -114,6 +116,17 @@ func pipelineServiceInit() {
 }
 
 func markInterruptedPipelineAs(status string) {
+	// tasks of paused pipelines stay paused, they would continue from the next subtask once resumed
+	errors.Must(db.UpdateColumns(
+		&models.Task{},
+		[]dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_PAUSED},
+		},
+		dal.Where(
+			"status = ? AND pipeline_id IN (SELECT id FROM _devlake_pipelines WHERE status = ?)",
+			models.TASK_RUNNING, models.TASK_PAUSED,
+		),
+	))
 	errors.Must(db.UpdateColumns(
 		&models.Pipeline{},
 		[]dal.DalSet{
@@ -454,6 +467,26 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 		// the target pipeline is pending, no running, no need to perform the actual cancel operation
 		return nil
 	}
+	if pipeline.Status == models.TASK_PAUSED {
+		pipeline.Status = models.TASK_CANCELLED
+		err = db.Update(pipeline)
+		if err != nil {
+			return errors.Default.Wrap(err, "faile to update pipeline")
+		}
+		// finished tasks keep their status, running ones are cancelled below
+		err = db.UpdateColumn(
+			&models.Task{},
+			"status", models.TASK_CANCELLED,
+			dal.Where(
+				"pipeline_id = ? AND status IN ?",
+				pipelineId,
+				[]string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME, models.TASK_PAUSED},
+			),
+		)
+		if err != nil {
+			return errors.Default.Wrap(err, "faile to update pipeline tasks")
+		}
+	}
 	pendingTasks, count, err := GetTasks(&TaskQuery{PipelineId: pipelineId, Pending: 1, Pagination: Pagination{PageSize: -1}})
 	if err != nil {
 		return errors.Convert(err)
@@ -467,6 +500,92 @@ func CancelPipeline(pipelineId uint64) errors.Error {
 	return errors.Convert(err)
 }
 
+// PausePipeline pauses a pipeline, pending pipelines are held in the queue while running ones stop
+// gracefully once their current subtasks finished
+func PausePipeline(pipelineId uint64) (*models.Pipeline, errors.Error) {
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("pipeline(id: %d) not found", pipelineId))
+		}
+		return nil, errors.Internal.Wrap(err, "error getting the pipeline from database")
+	}
+	pausableStatus := []string{models.TASK_CREATED, models.TASK_RERUN, models.TASK_RESUME, models.TASK_RUNNING}
+	if !utils.StringsContains(pausableStatus, pipeline.Status) {
+		return nil, errors.BadInput.New(fmt.Sprintf("pipeline(id: %d) is %s and can not be paused", pipelineId, pipeline.Status))
+	}
+	// the running tasks stop before their next subtask, the runner checks the status before the next stage
+	err = db.UpdateColumn(
+		&models.Pipeline{},
+		"status", models.TASK_PAUSED,
+		dal.Where("id = ? AND status IN ?", pipelineId, pausableStatus),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to pause pipeline")
+	}
+	pauseSignals.Pause(pipelineId)
+	return GetPipeline(pipelineId, true)
+}
+
+// ResumePipeline puts a paused pipeline back into the queue, it continues from the next subtask of the paused tasks
+func ResumePipeline(pipelineId uint64) (*models.Pipeline, errors.Error) {
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("pipeline(id: %d) not found", pipelineId))
+		}
+		return nil, errors.Internal.Wrap(err, "error getting the pipeline from database")
+	}
+	if pipeline.Status != models.TASK_PAUSED {
+		return nil, errors.BadInput.New(fmt.Sprintf("pipeline(id: %d) is %s and can not be resumed", pipelineId, pipeline.Status))
+	}
+	// the pause hasn't taken effect yet if the pipeline is still running, simply withdraw it
+	withdrawn, err := pauseSignals.Withdraw(pipelineId, func() errors.Error {
+		return db.UpdateColumn(
+			&models.Pipeline{},
+			"status", models.TASK_RUNNING,
+			dal.Where("id = ? AND status = ?", pipelineId, models.TASK_PAUSED),
+		)
+	})
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to resume pipeline")
+	}
+	if withdrawn {
+		return GetPipeline(pipelineId, true)
+	}
+	err = enqueuePausedPipeline(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	return GetPipeline(pipelineId, true)
+}
+
+// enqueuePausedPipeline marks the paused pipeline and its paused tasks as TASK_RESUME so RunPipelineInQueue picks it up
+func enqueuePausedPipeline(pipelineId uint64) (err errors.Error) {
+	txHelper := dbhelper.NewTxHelper(basicRes, &err)
+	defer txHelper.End()
+	tx := txHelper.Begin()
+	err = tx.UpdateColumn(
+		&models.Task{},
+		"status", models.TASK_RESUME,
+		dal.Where("pipeline_id = ? AND status = ?", pipelineId, models.TASK_PAUSED),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to resume pipeline tasks")
+	}
+	err = tx.UpdateColumn(
+		&models.Pipeline{},
+		"status", models.TASK_RESUME,
+		dal.Where("id = ? AND status = ?", pipelineId, models.TASK_PAUSED),
+	)
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to resume pipeline")
+	}
+	return nil
+}
+
 // getPipelineLogsPath gets the logs directory of this pipeline
 func getPipelineLogsPath(pipeline *models.Pipeline) (string, errors.Error) {
 	pipelineLog := GetPipelineLogger(pipeline)
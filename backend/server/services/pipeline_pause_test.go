@@ -0,0 +1,303 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/stretchr/testify/assert"
+)
+
+// pauseDal keeps pipelines and tasks in memory, it only understands the conditions issued by pausing and resuming
+type pauseDal struct {
+	dal.Dal
+	pipelines map[uint64]*models.Pipeline
+	tasks     map[uint64]*models.Task
+}
+
+// pauseTx runs the transaction straight on the records
+type pauseTx struct {
+	*pauseDal
+}
+
+func (tx *pauseTx) Commit() errors.Error                     { return nil }
+func (tx *pauseTx) Rollback() errors.Error                   { return nil }
+func (tx *pauseTx) LockTables(_ dal.LockTables) errors.Error { return nil }
+func (tx *pauseTx) UnlockTables() errors.Error               { return nil }
+
+func (d *pauseDal) Begin() dal.Transaction {
+	return &pauseTx{d}
+}
+
+// rows returns the status columns of the records of the entity
+func (d *pauseDal) rows(entity interface{}) map[*string]map[string]interface{} {
+	rows := make(map[*string]map[string]interface{})
+	switch entity.(type) {
+	case *models.Pipeline:
+		for _, p := range d.pipelines {
+			rows[&p.Status] = map[string]interface{}{"id": p.ID, "status": p.Status}
+		}
+	case *models.Task:
+		for _, t := range d.tasks {
+			rows[&t.Status] = map[string]interface{}{"id": t.ID, "status": t.Status, "pipeline_id": t.PipelineId}
+		}
+	}
+	return rows
+}
+
+func (d *pauseDal) match(row map[string]interface{}, clauses []dal.Clause) bool {
+	for _, clause := range clauses {
+		where, ok := clause.Data.(dal.DalClause)
+		if clause.Type != dal.WhereClause || !ok {
+			continue
+		}
+		for i, cond := range strings.Split(where.Expr, " AND ") {
+			fields := strings.Fields(cond)
+			column, value := row[fields[0]], where.Params[i]
+			var matched bool
+			switch {
+			case strings.Contains(cond, "SELECT id FROM _devlake_pipelines"):
+				p, ok := d.pipelines[column.(uint64)]
+				matched = ok && p.Status == value
+			case fields[1] == "=":
+				matched = fmt.Sprint(column) == fmt.Sprint(value)
+			case fields[1] == "<>":
+				matched = fmt.Sprint(column) != fmt.Sprint(value)
+			case fields[1] == "IN":
+				for _, v := range value.([]string) {
+					matched = matched || column == v
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (d *pauseDal) First(dst interface{}, clauses ...dal.Clause) errors.Error {
+	for _, row := range d.rows(dst) {
+		if d.match(row, clauses) {
+			*dst.(*models.Pipeline) = *d.pipelines[row["id"].(uint64)]
+			return nil
+		}
+	}
+	return errors.NotFound.New("record not found")
+}
+
+func (d *pauseDal) Count(clauses ...dal.Clause) (int64, errors.Error) {
+	count := int64(0)
+	for _, row := range d.rows(clauses[0].Data) {
+		if d.match(row, clauses[1:]) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (d *pauseDal) UpdateColumns(entity interface{}, set []dal.DalSet, clauses ...dal.Clause) errors.Error {
+	for status, row := range d.rows(entity) {
+		if !d.match(row, clauses) {
+			continue
+		}
+		for _, s := range set {
+			if s.ColumnName == "status" {
+				*status = s.Value.(string)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *pauseDal) UpdateColumn(entity interface{}, column string, value interface{}, clauses ...dal.Clause) errors.Error {
+	return d.UpdateColumns(entity, []dal.DalSet{{ColumnName: column, Value: value}}, clauses...)
+}
+
+func (d *pauseDal) Pluck(_ string, _ interface{}, _ ...dal.Clause) errors.Error {
+	return nil
+}
+
+func (d *pauseDal) IsErrorNotFound(err error) bool {
+	lakeErr := errors.AsLakeErrorType(err)
+	return lakeErr != nil && lakeErr.GetType() == errors.NotFound
+}
+
+type pauseBasicRes struct {
+	context.BasicRes
+	db dal.Dal
+}
+
+func (r *pauseBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+func (r *pauseBasicRes) GetLogger() log.Logger {
+	return logruslog.Global
+}
+
+func setupPauseDal(t *testing.T, pipelines []*models.Pipeline, tasks []*models.Task) *pauseDal {
+	originDb, originBasicRes := db, basicRes
+	t.Cleanup(func() {
+		db, basicRes = originDb, originBasicRes
+	})
+	d := &pauseDal{pipelines: make(map[uint64]*models.Pipeline), tasks: make(map[uint64]*models.Task)}
+	for _, p := range pipelines {
+		d.pipelines[p.ID] = p
+	}
+	for _, task := range tasks {
+		d.tasks[task.ID] = task
+	}
+	db = d
+	basicRes = &pauseBasicRes{db: d}
+	return d
+}
+
+func newPausePipeline(id uint64, status string) *models.Pipeline {
+	return &models.Pipeline{Model: common.Model{ID: id}, Status: status}
+}
+
+func newPauseTask(id uint64, pipelineId uint64, status string) *models.Task {
+	return &models.Task{Model: common.Model{ID: id}, PipelineId: pipelineId, Status: status}
+}
+
+func TestPauseResumePipeline(t *testing.T) {
+	d := setupPauseDal(t,
+		[]*models.Pipeline{newPausePipeline(1, models.TASK_RUNNING)},
+		[]*models.Task{
+			newPauseTask(10, 1, models.TASK_COMPLETED),
+			newPauseTask(11, 1, models.TASK_RUNNING),
+			newPauseTask(12, 1, models.TASK_CREATED),
+		},
+	)
+	signal := pauseSignals.Add(1)
+	defer pauseSignals.Remove(1)
+
+	pipeline, err := PausePipeline(1)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TASK_PAUSED, pipeline.Status)
+	assert.True(t, signal.IsPaused())
+	_, err = PausePipeline(1)
+	assert.Equal(t, errors.BadInput, err.GetType())
+
+	// the task is still running, resuming withdraws the pause
+	pipeline, err = ResumePipeline(1)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TASK_RUNNING, pipeline.Status)
+	assert.False(t, signal.IsPaused())
+
+	// this time the task stops before its next subtask and the runner drops the signal
+	_, err = PausePipeline(1)
+	assert.Nil(t, err)
+	assert.True(t, signal.IsPaused())
+	d.tasks[11].Status = models.TASK_PAUSED
+	pauseSignals.Remove(1)
+
+	pipeline, err = ResumePipeline(1)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TASK_RESUME, pipeline.Status)
+	assert.Equal(t, models.TASK_COMPLETED, d.tasks[10].Status)
+	assert.Equal(t, models.TASK_RESUME, d.tasks[11].Status)
+	assert.Equal(t, models.TASK_CREATED, d.tasks[12].Status)
+	_, err = ResumePipeline(1)
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
+
+func TestPausePipelineNotRunning(t *testing.T) {
+	setupPauseDal(t, []*models.Pipeline{
+		newPausePipeline(1, models.TASK_CREATED),
+		newPausePipeline(2, models.TASK_COMPLETED),
+	}, nil)
+
+	// pending pipelines are held in the queue
+	pipeline, err := PausePipeline(1)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TASK_PAUSED, pipeline.Status)
+
+	_, err = PausePipeline(2)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	_, err = PausePipeline(3)
+	assert.Equal(t, errors.NotFound, err.GetType())
+}
+
+func TestMarkInterruptedPipelineKeepsPausedPipelines(t *testing.T) {
+	d := setupPauseDal(t,
+		[]*models.Pipeline{
+			newPausePipeline(1, models.TASK_PAUSED),
+			newPausePipeline(2, models.TASK_RUNNING),
+		},
+		[]*models.Task{
+			newPauseTask(10, 1, models.TASK_COMPLETED),
+			newPauseTask(11, 1, models.TASK_RUNNING),
+			newPauseTask(20, 2, models.TASK_RUNNING),
+		},
+	)
+
+	// the server restarted while pipeline 1 was stopping
+	markInterruptedPipelineAs(models.TASK_RESUME)
+	assert.Equal(t, models.TASK_PAUSED, d.pipelines[1].Status)
+	assert.Equal(t, models.TASK_COMPLETED, d.tasks[10].Status)
+	assert.Equal(t, models.TASK_PAUSED, d.tasks[11].Status)
+	assert.Equal(t, models.TASK_RESUME, d.pipelines[2].Status)
+	assert.Equal(t, models.TASK_RESUME, d.tasks[20].Status)
+
+	pipeline, err := ResumePipeline(1)
+	assert.Nil(t, err)
+	assert.Equal(t, models.TASK_RESUME, pipeline.Status)
+	assert.Equal(t, models.TASK_RESUME, d.tasks[11].Status)
+}
+
+func TestPauseSignalsWithdraw(t *testing.T) {
+	signals := PauseSignals{signals: make(map[uint64]*runner.PauseSignal)}
+	called := false
+	withdrawn, err := signals.Withdraw(1, func() errors.Error {
+		called = true
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.False(t, withdrawn)
+	assert.False(t, called)
+
+	signal := signals.Add(1)
+	signals.Pause(1)
+	withdrawn, err = signals.Withdraw(1, func() errors.Error {
+		return errors.Default.New("failed")
+	})
+	assert.NotNil(t, err)
+	assert.False(t, withdrawn)
+	assert.True(t, signal.IsPaused())
+
+	withdrawn, err = signals.Withdraw(1, func() errors.Error {
+		called = true
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.True(t, withdrawn)
+	assert.True(t, called)
+	assert.False(t, signal.IsPaused())
+}
@@ -26,12 +26,14 @@ import (
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/runner"
 	"github.com/apache/incubator-devlake/impls/logruslog"
+	"sync"
 	"time"
 )
 
 type pipelineRunner struct {
 	logger   log.Logger
 	pipeline *models.Pipeline
+	pause    *runner.PauseSignal
 }
 
 func (p *pipelineRunner) runPipelineStandalone() errors.Error {
@@ -39,11 +41,61 @@ func (p *pipelineRunner) runPipelineStandalone() errors.Error {
 		basicRes.ReplaceLogger(p.logger),
 		p.pipeline.ID,
 		func(taskIds []uint64) errors.Error {
-			return RunTasksStandalone(p.logger, taskIds)
+			return RunTasksStandalone(p.logger, taskIds, p.pause)
 		},
 	)
 }
 
+// PauseSignals keeps the pause signals of the pipelines running in this process
+type PauseSignals struct {
+	mu      sync.Mutex
+	signals map[uint64]*runner.PauseSignal
+}
+
+// Add creates the pause signal for a pipeline about to run
+func (ps *PauseSignals) Add(pipelineId uint64) *runner.PauseSignal {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	signal := &runner.PauseSignal{}
+	ps.signals[pipelineId] = signal
+	return signal
+}
+
+// Remove drops the pause signal once the pipeline stopped running
+func (ps *PauseSignals) Remove(pipelineId uint64) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	delete(ps.signals, pipelineId)
+}
+
+// Pause raises the pause signal of the pipeline, it does nothing if the pipeline isn't running
+func (ps *PauseSignals) Pause(pipelineId uint64) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if signal, ok := ps.signals[pipelineId]; ok {
+		signal.Pause()
+	}
+}
+
+// Withdraw lowers the pause signal of the pipeline if it is still running, the pipeline is running in this process
+// as long as the signal is registered. The callback runs while holding the lock, so the runner couldn't record the
+// pause in between, and the signal is lowered only if the callback succeeds. It returns whether the pause was withdrawn
+func (ps *PauseSignals) Withdraw(pipelineId uint64, withdraw func() errors.Error) (bool, errors.Error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	signal, ok := ps.signals[pipelineId]
+	if !ok {
+		return false, nil
+	}
+	if err := withdraw(); err != nil {
+		return false, err
+	}
+	signal.Withdraw()
+	return true, nil
+}
+
+var pauseSignals = PauseSignals{signals: make(map[uint64]*runner.PauseSignal)}
+
 // GetPipelineLogger returns logger for the pipeline
 func GetPipelineLogger(pipeline *models.Pipeline) log.Logger {
 	pipelineLogger := globalPipelineLog.Nested(
@@ -71,9 +123,26 @@ func runPipeline(pipelineId uint64) errors.Error {
 	pipelineRun := pipelineRunner{
 		logger:   GetPipelineLogger(ppl),
 		pipeline: ppl,
+		pause:    pauseSignals.Add(pipelineId),
 	}
 	// run
 	err = pipelineRun.runPipelineStandalone()
+	// the tasks stopped, a resume from now on puts the pipeline back into the queue instead of withdrawing the pause
+	pauseSignals.Remove(pipelineId)
+	if errors.Is(err, runner.ErrPipelinePaused) {
+		// keep the pipeline unfinished, it would continue from the next subtask once resumed. The pipeline might be
+		// resumed or cancelled in the meantime, leave it alone then
+		err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+			{ColumnName: "status", Value: models.TASK_PAUSED},
+			{ColumnName: "message", Value: ""},
+		}, dal.Where("id = ? AND status IN ?", pipelineId, []string{models.TASK_RUNNING, models.TASK_PAUSED}))
+		if err != nil {
+			globalPipelineLog.Error(err, "update pipeline state failed")
+			return err
+		}
+		pipelineRun.logger.Info("pipeline paused")
+		return NotifyExternal(pipelineId)
+	}
 	isCancelled := errors.Is(err, context.Canceled)
 	if err != nil {
 		err = errors.Default.Wrap(err, fmt.Sprintf("Error running pipeline %d.", pipelineId))
@@ -97,7 +166,14 @@ func runPipeline(pipelineId uint64) errors.Error {
 		globalPipelineLog.Error(err, "compute pipeline status failed")
 		return err
 	}
-	err = db.Update(dbPipeline)
+	// a pause requested in the meantime wins, the pipeline finishes once resumed
+	err = db.UpdateColumns(&models.Pipeline{}, []dal.DalSet{
+		{ColumnName: "status", Value: dbPipeline.Status},
+		{ColumnName: "message", Value: dbPipeline.Message},
+		{ColumnName: "error_name", Value: dbPipeline.ErrorName},
+		{ColumnName: "finished_at", Value: dbPipeline.FinishedAt},
+		{ColumnName: "spent_seconds", Value: dbPipeline.SpentSeconds},
+	}, dal.Where("id = ? AND status <> ?", pipelineId, models.TASK_PAUSED))
 	if err != nil {
 		globalPipelineLog.Error(err, "update pipeline state failed")
 		return err
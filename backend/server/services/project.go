@@ -41,6 +41,8 @@ var projectService ProjectService
 type ProjectQuery struct {
 	Pagination
 	Keyword *string `json:"keyword" form:"keyword"`
+	// Workspace is resolved from the caller instead of the query string
+	Workspace string `json:"-" form:"-"`
 }
 
 func (query *ProjectQuery) GetKeyword() string {
@@ -62,6 +64,9 @@ func GetProjects(query *ProjectQuery) ([]*models.ApiOutputProject, int64, errors
 	if query.Keyword != nil {
 		clauses = append(clauses, dal.Where("LOWER(name) LIKE ?", "%"+query.GetKeyword()+"%"))
 	}
+	if query.Workspace != "" {
+		clauses = append(clauses, dal.Where("workspace = ?", query.Workspace))
+	}
 
 	count, err := db.Count(clauses...)
 	if err != nil {
@@ -157,6 +162,11 @@ func CreateProject(projectInput *models.ApiInputProject) (*models.ApiOutputProje
 	if projectInput.Blueprint != nil {
 		blueprint = projectInput.Blueprint
 	}
+	blueprint.Workspace = project.Workspace
+	err = VerifyBlueprintConnectionsWorkspace(blueprint.Workspace, blueprint)
+	if err != nil {
+		return nil, err
+	}
 	err = tx.Create(blueprint)
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "error creating DB blueprint")
@@ -222,7 +232,22 @@ func PatchProject(name string, body map[string]interface{}) (*models.ApiOutputPr
 	if projectInput.Name == "" {
 		projectInput.Name = name
 	}
+	// workspace stays the same unless specified explicitly
+	if _, ok := body["workspace"]; !ok {
+		projectInput.Workspace = project.Workspace
+	}
+	workspaceChanged := projectInput.Workspace != project.Workspace
 	project.BaseProject = projectInput.BaseProject
+	if workspaceChanged {
+		err = tx.UpdateColumn(
+			&models.Blueprint{},
+			"workspace", project.Workspace,
+			dal.Where("project_name = ?", name),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// name changed, updates the related entities as well
 	if name != project.Name {
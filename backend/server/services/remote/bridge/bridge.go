@@ -18,7 +18,13 @@ limitations under the License.
 package bridge
 
 import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/server/services/remote/models"
 )
@@ -26,6 +32,8 @@ import (
 type (
 	Bridge struct {
 		invoker Invoker
+		// the tables the subtasks are allowed to write records into by RemoteRecords
+		tables map[string]dal.Tabler
 	}
 	Invoker interface {
 		Call(methodName string, ctx plugin.ExecContext, args ...any) *CallResult
@@ -33,8 +41,12 @@ type (
 	}
 )
 
-func NewBridge(invoker Invoker) *Bridge {
-	return &Bridge{invoker: invoker}
+func NewBridge(invoker Invoker, tables ...dal.Tabler) *Bridge {
+	b := &Bridge{invoker: invoker, tables: make(map[string]dal.Tabler)}
+	for _, table := range tables {
+		b.tables[table.TableName()] = table
+	}
+	return b
 }
 
 func (b *Bridge) RemoteSubtaskEntrypointHandler(subtaskMeta models.SubtaskMeta) plugin.SubTaskEntryPoint {
@@ -48,11 +60,19 @@ func (b *Bridge) RemoteSubtaskEntrypointHandler(subtaskMeta models.SubtaskMeta)
 			if recv.Err != nil {
 				return recv.Err
 			}
-			progress := RemoteProgress{}
-			err := recv.Get(&progress)
+			result := remoteSubtaskResult{}
+			err := recv.Get(&result)
 			if err != nil {
 				return err
 			}
+			if result.Table != "" {
+				err = b.saveRecords(ctx.GetDal(), &result.RemoteRecords)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			progress := result.RemoteProgress
 			if progress.Total != 0 {
 				ctx.SetProgress(progress.Current, progress.Total)
 			} else if progress.Increment != 0 {
@@ -62,3 +82,31 @@ func (b *Bridge) RemoteSubtaskEntrypointHandler(subtaskMeta models.SubtaskMeta)
 		return nil
 	}
 }
+
+// saveRecords upserts the records sent by a subtask, which is the only way for plugins without database access,
+// i.e. the ones running as http services, to store their results
+func (b *Bridge) saveRecords(db dal.Dal, records *RemoteRecords) errors.Error {
+	table, ok := b.tables[records.Table]
+	if !ok {
+		return errors.BadInput.New(fmt.Sprintf("remote plugin is not allowed to write into table %s", records.Table))
+	}
+	var rows any
+	if dynamicTable, ok := table.(coreModels.DynamicTabler); ok {
+		rows = dynamicTable.NewSlice()
+	} else {
+		rows = reflect.New(reflect.SliceOf(reflect.TypeOf(table))).Interface()
+	}
+	if err := json.Unmarshal(records.Records, rows); err != nil {
+		return errors.BadInput.Wrap(err, fmt.Sprintf("invalid records of table %s", records.Table))
+	}
+	if dynamicRows, ok := rows.(coreModels.DynamicTabler); ok {
+		if len(dynamicRows.UnwrapSlice()) == 0 {
+			return nil
+		}
+		return db.CreateOrUpdate(dynamicRows)
+	}
+	if reflect.ValueOf(rows).Elem().Len() == 0 {
+		return nil
+	}
+	return db.CreateOrUpdate(rows)
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	coreModels "github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingDal struct {
+	dal.Dal
+	saved []any
+}
+
+func (d *recordingDal) CreateOrUpdate(entity interface{}, _ ...dal.Clause) errors.Error {
+	d.saved = append(d.saved, entity)
+	return nil
+}
+
+type fakeToolRecord struct {
+	Id   int    `json:"id" gorm:"primaryKey"`
+	Name string `json:"name"`
+}
+
+func TestBridgeSaveRecords(t *testing.T) {
+	toolTable := coreModels.NewDynamicTabler("_tool_fake_records", reflect.TypeOf(fakeToolRecord{})).New()
+	b := NewBridge(nil, &code.Repo{}, toolTable)
+	db := &recordingDal{}
+
+	err := b.saveRecords(db, &RemoteRecords{Table: "repos", Records: json.RawMessage(`[{"id": "fake:1", "name": "devlake"}]`)})
+	assert.Nil(t, err)
+	err = b.saveRecords(db, &RemoteRecords{Table: "_tool_fake_records", Records: json.RawMessage(`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`)})
+	assert.Nil(t, err)
+	// nothing to write
+	err = b.saveRecords(db, &RemoteRecords{Table: "repos", Records: json.RawMessage(`[]`)})
+	assert.Nil(t, err)
+	assert.Len(t, db.saved, 2)
+	repos := *db.saved[0].(*[]*code.Repo)
+	assert.Equal(t, "fake:1", repos[0].Id)
+	assert.Equal(t, "devlake", repos[0].Name)
+	toolRecords := db.saved[1].(coreModels.DynamicTabler).UnwrapSlice()
+	assert.Equal(t, fakeToolRecord{Id: 2, Name: "b"}, toolRecords[1])
+
+	// the connection of the plugin is not one of the record tables
+	err = b.saveRecords(db, &RemoteRecords{Table: "_tool_fake_connections", Records: json.RawMessage(`[{"id": 1}]`)})
+	assert.NotNil(t, err)
+	err = b.saveRecords(db, &RemoteRecords{Table: "repos", Records: json.RawMessage(`{"id": "fake:1"}`)})
+	assert.NotNil(t, err)
+}
@@ -19,6 +19,7 @@ package bridge
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/apache/incubator-devlake/core/config"
 	ctx "github.com/apache/incubator-devlake/core/context"
@@ -37,6 +38,17 @@ type RemoteProgress struct {
 	Increment int `json:"increment"`
 }
 
+// RemoteRecords are sent by subtasks to store the records of a tool or domain layer table
+type RemoteRecords struct {
+	Table   string          `json:"table"`
+	Records json.RawMessage `json:"records"`
+}
+
+type remoteSubtaskResult struct {
+	RemoteProgress
+	RemoteRecords
+}
+
 type RemoteContext interface {
 	plugin.ExecContext
 	GetRemoteConfig() *RemoteConfig
@@ -0,0 +1,193 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+type (
+	// HttpInvoker calls a remote plugin running as a http service, which could be written in any language.
+	// A method is invoked by `POST {endpoint}/invoke/{method}` with body `{"args": [...]}`, the first arg is
+	// always the RemoteConfig. The service responds with newline delimited JSON messages, each one being
+	// `{"result": {...}}` for a returned value, `{"error": "..."}` if the method failed, or `{"done": true}`
+	// once the method finished. `GET {endpoint}/health` should respond with 200 when the service is ready.
+	// The service never gets access to the database, subtasks send their records back as results instead, and
+	// its migration scripts may only touch its own tool and raw tables.
+	HttpInvoker struct {
+		endpoint string
+		token    string
+		client   *http.Client
+	}
+	httpInvokeRequest struct {
+		Args []json.RawMessage `json:"args"`
+	}
+)
+
+// IsHttpPlugin returns true if the plugin path is the endpoint of a http service
+func IsHttpPlugin(pluginPath string) bool {
+	return strings.HasPrefix(pluginPath, "http://") || strings.HasPrefix(pluginPath, "https://")
+}
+
+// NewHttpInvoker creates an invoker for the remote plugin service at the endpoint, requests would carry the
+// REMOTE_PLUGIN_HTTP_TOKEN as a bearer token if it was set. The endpoint must be https unless
+// REMOTE_PLUGIN_HTTP_ALLOW_INSECURE is enabled, i.e. for sidecar containers sharing the network namespace
+func NewHttpInvoker(endpoint string) (*HttpInvoker, errors.Error) {
+	cfg := config.GetConfig()
+	if !strings.HasPrefix(endpoint, "https://") && !cfg.GetBool("REMOTE_PLUGIN_HTTP_ALLOW_INSECURE") {
+		return nil, errors.BadInput.New(fmt.Sprintf("remote plugin %s must be served over https, or set REMOTE_PLUGIN_HTTP_ALLOW_INSECURE to allow http", endpoint))
+	}
+	return &HttpInvoker{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    cfg.GetString("REMOTE_PLUGIN_HTTP_TOKEN"),
+		// no timeout for the whole request since subtasks stream their results for hours
+		client: &http.Client{},
+	}, nil
+}
+
+func (h *HttpInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any) *CallResult {
+	var results [][]byte
+	for recv := range h.Stream(methodName, ctx, args...).Receive() {
+		if recv.Err != nil {
+			return NewCallResult(nil, recv.Err)
+		}
+		results = append(results, recv.Results)
+	}
+	if len(results) == 0 {
+		return NewCallResult(nil, nil)
+	}
+	return NewCallResult(append(joinLines(results), '\n'), nil)
+}
+
+func (h *HttpInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...any) *MethodStream {
+	recvChannel := make(chan *StreamResult, 1)
+	stream := &MethodStream{
+		outbound: nil,
+		inbound:  recvChannel,
+	}
+	res, err := h.invoke(methodName, ctx, args...)
+	if err != nil {
+		recvChannel <- NewStreamResult(nil, err)
+		close(recvChannel)
+		return stream
+	}
+	go func() {
+		defer close(recvChannel)
+		defer res.Body.Close()
+		reader := bufio.NewReader(res.Body)
+		for {
+			line, readErr := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				response := &rpcResponse{}
+				if jsonErr := json.Unmarshal(line, response); jsonErr != nil {
+					recvChannel <- NewStreamResult(nil, errors.Default.Wrap(jsonErr, fmt.Sprintf("invalid response from remote plugin: %s", line)))
+					return
+				}
+				if response.Error != "" {
+					recvChannel <- NewStreamResult(nil, errors.Default.New(fmt.Sprintf("get error when invoking remote function %s: %s", methodName, response.Error)))
+					return
+				}
+				if response.Result != nil {
+					recvChannel <- NewStreamResult(response.Result, nil)
+				}
+				if response.Done {
+					return
+				}
+			}
+			if readErr != nil {
+				// cancelled by the context, or the service closed the connection before finishing
+				if ctx.GetContext().Err() != nil {
+					return
+				}
+				recvChannel <- NewStreamResult(nil, errors.Default.Wrap(readErr, fmt.Sprintf("remote function %s ended unexpectedly", methodName)))
+				return
+			}
+		}
+	}()
+	return stream
+}
+
+// Ping checks if the service is ready by requesting the health endpoint
+func (h *HttpInvoker) Ping(timeout time.Duration) errors.Error {
+	req, err := http.NewRequest(http.MethodGet, h.endpoint+"/health", nil)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	h.setHeaders(req)
+	client := &http.Client{Timeout: timeout, Transport: h.client.Transport}
+	res, err := client.Do(req)
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("remote plugin %s is not reachable", h.endpoint))
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("remote plugin %s is not healthy", h.endpoint))
+	}
+	return nil
+}
+
+func (h *HttpInvoker) invoke(methodName string, ctx plugin.ExecContext, args ...any) (*http.Response, errors.Error) {
+	serializedArgs, err := serialize(append([]any{DefaultContext.GetRemoteConfig()}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	body := &httpInvokeRequest{Args: []json.RawMessage{}}
+	for _, arg := range serializedArgs {
+		body.Args = append(body.Args, json.RawMessage(arg))
+	}
+	reqBody, jsonErr := json.Marshal(body)
+	if jsonErr != nil {
+		return nil, errors.Convert(jsonErr)
+	}
+	req, reqErr := http.NewRequestWithContext(ctx.GetContext(), http.MethodPost, fmt.Sprintf("%s/invoke/%s", h.endpoint, methodName), bytes.NewReader(reqBody))
+	if reqErr != nil {
+		return nil, errors.Convert(reqErr)
+	}
+	h.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+	res, resErr := h.client.Do(req)
+	if resErr != nil {
+		return nil, errors.Default.Wrap(resErr, fmt.Sprintf("failed to invoke remote function %s", methodName))
+	}
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+		resBody, _ := io.ReadAll(io.LimitReader(res.Body, 4096))
+		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to invoke remote function %s: %s", methodName, resBody))
+	}
+	return res, nil
+}
+
+func (h *HttpInvoker) setHeaders(req *http.Request) {
+	if h.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", h.token))
+	}
+}
+
+var _ Invoker = (*HttpInvoker)(nil)
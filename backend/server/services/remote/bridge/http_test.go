@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHttpPlugin(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/invoke/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body := httpInvokeRequest{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/invoke/plugin-info":
+			_, _ = w.Write([]byte("{\"result\": {\"name\": \"fake\"}}\n{\"done\": true}\n"))
+		case "/invoke/collect":
+			n := 0
+			assert.Nil(t, json.Unmarshal(body.Args[2], &n))
+			for i := 1; i <= n; i++ {
+				_, _ = fmt.Fprintf(w, "{\"result\": {\"current\": %d, \"total\": %d}}\n", i, n)
+				w.(http.Flusher).Flush()
+			}
+			_, _ = w.Write([]byte("{\"done\": true}\n"))
+		case "/invoke/broken":
+			_, _ = w.Write([]byte("{\"result\": {}}\n"))
+		case "/invoke/failed":
+			_, _ = w.Write([]byte("{\"error\": \"boom\"}\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestHttpInvokerCall(t *testing.T) {
+	server := newTestHttpPlugin(t)
+	defer server.Close()
+	invoker := &HttpInvoker{endpoint: server.URL, token: "secret", client: server.Client()}
+
+	assert.Nil(t, invoker.Ping(time.Second))
+	info := map[string]string{}
+	assert.Nil(t, invoker.Call("plugin-info", DefaultContext).Get(&info))
+	assert.Equal(t, "fake", info["name"])
+
+	assert.NotNil(t, invoker.Call("failed", DefaultContext).Err)
+	// the stream must end with done
+	assert.NotNil(t, invoker.Call("broken", DefaultContext).Err)
+	assert.NotNil(t, invoker.Call("unknown", DefaultContext).Err)
+}
+
+func TestHttpInvokerStream(t *testing.T) {
+	server := newTestHttpPlugin(t)
+	defer server.Close()
+	invoker := &HttpInvoker{endpoint: server.URL, token: "secret", client: server.Client()}
+
+	count := 0
+	for recv := range invoker.Stream("collect", DefaultContext, map[string]any{}, 3).Receive() {
+		progress := RemoteProgress{}
+		assert.Nil(t, recv.Get(&progress))
+		count++
+		assert.Equal(t, count, progress.Current)
+		assert.Equal(t, 3, progress.Total)
+	}
+	assert.Equal(t, 3, count)
+
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+	for recv := range invoker.Stream("collect", cancelledContext{DefaultContext, ctx}, map[string]any{}, 3).Receive() {
+		assert.NotNil(t, recv.Err)
+	}
+}
+
+func TestIsHttpPlugin(t *testing.T) {
+	assert.True(t, IsHttpPlugin("http://plugin:8000"))
+	assert.True(t, IsHttpPlugin("https://plugin"))
+	assert.False(t, IsHttpPlugin("python/plugins/azuredevops/run.sh"))
+}
+
+func TestNewHttpInvokerRequiresHttps(t *testing.T) {
+	cfg := config.GetConfig()
+	_, err := NewHttpInvoker("http://plugin:8000")
+	assert.NotNil(t, err)
+	invoker, err := NewHttpInvoker("https://plugin/")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://plugin", invoker.endpoint)
+
+	cfg.Set("REMOTE_PLUGIN_HTTP_ALLOW_INSECURE", true)
+	defer cfg.Set("REMOTE_PLUGIN_HTTP_ALLOW_INSECURE", false)
+	_, err = NewHttpInvoker("http://plugin:8000")
+	assert.Nil(t, err)
+}
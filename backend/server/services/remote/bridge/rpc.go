@@ -0,0 +1,540 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+const (
+	// RpcInvokerName selects the RpcInvoker with REMOTE_PLUGIN_INVOKER
+	RpcInvokerName = "rpc"
+	// the plugin process writes responses to fd 3 (the first of ExtraFiles) and reads requests from fd 4,
+	// stdout and stderr are left for logging
+	rpcHealthCheckInterval = 30 * time.Second
+	rpcHealthCheckTimeout  = 10 * time.Second
+	// rpcCallWindow is the number of results a call may have in flight, the plugin process waits for credits
+	// granted as the results are consumed before sending more
+	rpcCallWindow = 64
+)
+
+type (
+	// RpcInvoker keeps a plugin process alive and talks to it with newline delimited JSON messages,
+	// multiple calls are served by the process concurrently
+	RpcInvoker struct {
+		resolveCmd  func() (string, []string)
+		workingPath string
+		workers     int
+		logger      log.Logger
+		mu          sync.Mutex
+		process     *rpcProcess
+	}
+	rpcProcess struct {
+		cmd      *exec.Cmd
+		requests io.WriteCloser
+		writeMu  sync.Mutex
+		nextId   uint64
+		calls    map[uint64]*rpcCall
+		callsMu  sync.Mutex
+		done     chan struct{}
+		err      errors.Error
+	}
+	// rpcCall queues the responses of a call, the queue is bounded by the credits granted to the plugin process
+	// so the reader of the process never blocks on a slow consumer, which would hold back the responses of the
+	// other calls and the pings
+	rpcCall struct {
+		id        uint64
+		process   *rpcProcess
+		mu        sync.Mutex
+		closed    bool
+		consumed  int
+		responses chan *rpcResponse
+	}
+	rpcRequest struct {
+		Id     uint64            `json:"id"`
+		Method string            `json:"method,omitempty"`
+		Args   []json.RawMessage `json:"args,omitempty"`
+		Cancel bool              `json:"cancel,omitempty"`
+		// Credit is the number of results the call may send in addition, the initial one comes with the call
+		Credit int `json:"credit,omitempty"`
+	}
+	rpcResponse struct {
+		Id     uint64          `json:"id"`
+		Result json.RawMessage `json:"result,omitempty"`
+		Error  string          `json:"error,omitempty"`
+		Done   bool            `json:"done,omitempty"`
+	}
+)
+
+var rpcInvokers = make(map[string]*RpcInvoker)
+var rpcInvokersMutex sync.Mutex
+
+// NewInvoker returns the Invoker for the plugin, the HttpInvoker if the path is an url, otherwise the one configured
+// by REMOTE_PLUGIN_INVOKER, a new process would be spawned for each call by default
+func NewInvoker(execPath string) (Invoker, errors.Error) {
+	if IsHttpPlugin(execPath) {
+		invoker, err := NewHttpInvoker(execPath)
+		if err != nil {
+			return nil, err
+		}
+		return invoker, nil
+	}
+	if strings.EqualFold(config.GetConfig().GetString("REMOTE_PLUGIN_INVOKER"), RpcInvokerName) {
+		return NewRpcInvoker(execPath), nil
+	}
+	return NewCmdInvoker(execPath), nil
+}
+
+// NewRpcInvoker returns the RpcInvoker of the plugin, the process is shared by all callers of the same plugin
+func NewRpcInvoker(execPath string) *RpcInvoker {
+	rpcInvokersMutex.Lock()
+	defer rpcInvokersMutex.Unlock()
+	if invoker, ok := rpcInvokers[execPath]; ok {
+		return invoker
+	}
+	dir, file := path.Split(execPath)
+	workers := config.GetConfig().GetInt("REMOTE_PLUGIN_RPC_WORKERS")
+	if workers <= 0 {
+		workers = 1
+	}
+	invoker := &RpcInvoker{
+		resolveCmd: func() (string, []string) {
+			return fmt.Sprintf("./%s", file), []string{"serve", fmt.Sprintf("--workers=%d", workers)}
+		},
+		workingPath: dir,
+		workers:     workers,
+		logger:      DefaultContext.GetLogger(),
+	}
+	rpcInvokers[execPath] = invoker
+	return invoker
+}
+
+func (r *RpcInvoker) Call(methodName string, ctx plugin.ExecContext, args ...any) *CallResult {
+	var results [][]byte
+	for recv := range r.Stream(methodName, ctx, args...).Receive() {
+		if recv.Err != nil {
+			return NewCallResult(nil, recv.Err)
+		}
+		results = append(results, recv.Results)
+	}
+	if len(results) == 0 {
+		return NewCallResult(nil, nil)
+	}
+	// same as the output of the CmdInvoker, one message per line
+	return NewCallResult(append(joinLines(results), '\n'), nil)
+}
+
+func (r *RpcInvoker) Stream(methodName string, ctx plugin.ExecContext, args ...any) *MethodStream {
+	recvChannel := make(chan *StreamResult, 1)
+	stream := &MethodStream{
+		outbound: nil,
+		inbound:  recvChannel,
+	}
+	serializedArgs, err := serialize(append([]any{DefaultContext.GetRemoteConfig()}, args...)...)
+	if err != nil {
+		recvChannel <- NewStreamResult(nil, err)
+		close(recvChannel)
+		return stream
+	}
+	request := &rpcRequest{Method: methodName}
+	for _, arg := range serializedArgs {
+		request.Args = append(request.Args, json.RawMessage(arg))
+	}
+	process, err := r.getProcess()
+	if err != nil {
+		recvChannel <- NewStreamResult(nil, err)
+		close(recvChannel)
+		return stream
+	}
+	call, err := process.call(request)
+	if err != nil {
+		recvChannel <- NewStreamResult(nil, err)
+		close(recvChannel)
+		return stream
+	}
+	id, responses := call.id, call.responses
+	go func() {
+		defer close(recvChannel)
+		cancelled := false
+		for {
+			select {
+			case <-ctx.GetContext().Done():
+				if !cancelled {
+					cancelled = true
+					// the remote method stops at the next message, continue until the call gets closed
+					if err := process.send(&rpcRequest{Id: id, Cancel: true}); err != nil {
+						recvChannel <- NewStreamResult(nil, errors.Default.Wrap(err, "error cancelling python target"))
+						return
+					}
+				}
+				// drain the responses without blocking on the context
+				for response := range responses {
+					call.consume()
+					if response.Error != "" {
+						recvChannel <- NewStreamResult(nil, errors.Default.New(response.Error))
+					}
+				}
+				return
+			case response, ok := <-responses:
+				if !ok {
+					return
+				}
+				call.consume()
+				if response.Error != "" {
+					recvChannel <- NewStreamResult(nil, errors.Default.New(fmt.Sprintf("get error when invoking remote function %s: %s", methodName, response.Error)))
+					continue
+				}
+				if response.Result != nil {
+					recvChannel <- NewStreamResult(response.Result, nil)
+				}
+			}
+		}
+	}()
+	return stream
+}
+
+// Ping checks if the plugin process is able to serve requests, the process would be started if it wasn't
+func (r *RpcInvoker) Ping(timeout time.Duration) errors.Error {
+	process, err := r.getProcess()
+	if err != nil {
+		return err
+	}
+	return process.ping(timeout)
+}
+
+// Close stops the plugin process, a new one would be started by the next call
+func (r *RpcInvoker) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.process != nil {
+		r.process.kill()
+		r.process = nil
+	}
+}
+
+// getProcess returns the running process, or starts a new one if it never started or exited
+func (r *RpcInvoker) getProcess() (*rpcProcess, errors.Error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.process != nil && !r.process.exited() {
+		return r.process, nil
+	}
+	process, err := r.startProcess()
+	if err != nil {
+		return nil, err
+	}
+	r.process = process
+	go r.checkHealth(process)
+	return process, nil
+}
+
+func (r *RpcInvoker) startProcess() (*rpcProcess, errors.Error) {
+	executable, args := r.resolveCmd()
+	cmd := exec.Command(executable, args...)
+	if r.workingPath != "" {
+		cmd.Dir = r.workingPath
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	responsesReader, responsesWriter, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	requestsReader, requestsWriter, err := os.Pipe()
+	if err != nil {
+		_ = responsesReader.Close()
+		_ = responsesWriter.Close()
+		return nil, errors.Convert(err)
+	}
+	cmd.ExtraFiles = []*os.File{responsesWriter, requestsReader}
+	err = cmd.Start()
+	// the child process holds its own copies
+	_ = responsesWriter.Close()
+	_ = requestsReader.Close()
+	if err != nil {
+		_ = responsesReader.Close()
+		_ = requestsWriter.Close()
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("failed to start remote plugin %s", executable))
+	}
+	process := &rpcProcess{
+		cmd:      cmd,
+		requests: requestsWriter,
+		calls:    make(map[uint64]*rpcCall),
+		done:     make(chan struct{}),
+	}
+	var logs sync.WaitGroup
+	logs.Add(2)
+	go func() {
+		defer logs.Done()
+		r.forwardLogs(stdout, func(msg string) { r.logger.Info(msg) })
+	}()
+	go func() {
+		defer logs.Done()
+		r.forwardLogs(stderr, func(msg string) { r.logger.Error(nil, msg) })
+	}()
+	go func() {
+		readErr := process.receive(responsesReader)
+		_ = responsesReader.Close()
+		logs.Wait()
+		waitErr := cmd.Wait()
+		if readErr == nil && waitErr != nil {
+			readErr = errors.Default.Wrap(waitErr, "remote plugin process exited")
+		}
+		if readErr == nil {
+			readErr = errors.Default.New("remote plugin process exited")
+		}
+		process.close(readErr)
+	}()
+	r.logger.Info("remote plugin process %s started with pid %d", executable, cmd.Process.Pid)
+	return process, nil
+}
+
+// checkHealth pings the process periodically and kills it if it stops responding, so the next call would restart it
+func (r *RpcInvoker) checkHealth(process *rpcProcess) {
+	ticker := time.NewTicker(rpcHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-process.done:
+			return
+		case <-ticker.C:
+			if err := process.ping(rpcHealthCheckTimeout); err != nil {
+				r.logger.Error(err, "remote plugin process is not responding, killing it")
+				process.kill()
+				return
+			}
+		}
+	}
+}
+
+func (r *RpcInvoker) forwardLogs(reader io.Reader, log func(msg string)) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		log(scanner.Text())
+	}
+}
+
+func (p *rpcProcess) call(request *rpcRequest) (*rpcCall, errors.Error) {
+	request.Id = atomic.AddUint64(&p.nextId, 1)
+	request.Credit = rpcCallWindow
+	p.callsMu.Lock()
+	if p.err != nil {
+		p.callsMu.Unlock()
+		return nil, p.err
+	}
+	call := newRpcCall(p, request.Id)
+	p.calls[request.Id] = call
+	p.callsMu.Unlock()
+	if err := p.send(request); err != nil {
+		p.callsMu.Lock()
+		delete(p.calls, request.Id)
+		p.callsMu.Unlock()
+		call.push(nil, true)
+		return nil, err
+	}
+	return call, nil
+}
+
+func (p *rpcProcess) send(request *rpcRequest) errors.Error {
+	message, err := json.Marshal(request)
+	if err != nil {
+		return errors.Convert(err)
+	}
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	_, err = p.requests.Write(append(message, '\n'))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to send request to remote plugin")
+	}
+	return nil
+}
+
+func (p *rpcProcess) ping(timeout time.Duration) errors.Error {
+	call, err := p.call(&rpcRequest{Method: "ping"})
+	if err != nil {
+		return err
+	}
+	responses := call.responses
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case response, ok := <-responses:
+			if !ok {
+				return nil
+			}
+			if response.Error != "" {
+				return errors.Default.New(response.Error)
+			}
+		case <-timer.C:
+			// the call gets closed at the latest when the process exits, don't leave its queue behind
+			go func() {
+				for range responses {
+				}
+			}()
+			return errors.Default.New("ping timed out")
+		}
+	}
+}
+
+// receive dispatches the responses to the calls until the process closes its end
+func (p *rpcProcess) receive(reader io.Reader) errors.Error {
+	bufReader := bufio.NewReader(reader)
+	for {
+		line, err := bufReader.ReadBytes('\n')
+		if len(line) > 1 {
+			response := &rpcResponse{}
+			if jsonErr := json.Unmarshal(line, response); jsonErr != nil {
+				return errors.Default.Wrap(jsonErr, fmt.Sprintf("invalid response from remote plugin: %s", line))
+			}
+			p.callsMu.Lock()
+			call, ok := p.calls[response.Id]
+			last := response.Done || response.Error != ""
+			if ok && last {
+				delete(p.calls, response.Id)
+			}
+			p.callsMu.Unlock()
+			if ok && !call.push(response, last) {
+				// the process ignored the flow control, fail the call instead of queueing without a bound
+				p.callsMu.Lock()
+				delete(p.calls, response.Id)
+				p.callsMu.Unlock()
+				go func(id uint64) {
+					_ = p.send(&rpcRequest{Id: id, Cancel: true})
+				}(response.Id)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Convert(err)
+		}
+	}
+}
+
+// close fails all pending calls, it is called once the process exited
+func (p *rpcProcess) close(err errors.Error) {
+	p.callsMu.Lock()
+	defer p.callsMu.Unlock()
+	p.err = err
+	for id, call := range p.calls {
+		call.push(&rpcResponse{Id: id, Error: err.Error()}, true)
+		delete(p.calls, id)
+	}
+	_ = p.requests.Close()
+	close(p.done)
+}
+
+func (p *rpcProcess) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *rpcProcess) kill() {
+	if p.cmd.Process != nil {
+		_ = p.cmd.Process.Kill()
+	}
+}
+
+func newRpcCall(process *rpcProcess, id uint64) *rpcCall {
+	return &rpcCall{
+		id:      id,
+		process: process,
+		// one more for the final response which doesn't take any credit
+		responses: make(chan *rpcResponse, rpcCallWindow+1),
+	}
+}
+
+// push queues the response without blocking, the responses channel gets closed after the last one. It returns false
+// if the call was failed because the process sent more results than the credits granted
+func (c *rpcCall) push(response *rpcResponse, last bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return true
+	}
+	if response != nil && !last && len(c.responses) >= rpcCallWindow {
+		c.closed = true
+		c.responses <- &rpcResponse{Id: c.id, Error: "remote plugin sent more results than the credits granted"}
+		close(c.responses)
+		return false
+	}
+	if response != nil {
+		c.responses <- response
+	}
+	if last {
+		c.closed = true
+		close(c.responses)
+	}
+	return true
+}
+
+// consume grants the credits of the consumed responses back to the process, in batches to keep the messages few
+func (c *rpcCall) consume() {
+	c.mu.Lock()
+	c.consumed++
+	credit := 0
+	if c.consumed >= rpcCallWindow/2 && !c.closed {
+		credit = c.consumed
+		c.consumed = 0
+	}
+	c.mu.Unlock()
+	if credit > 0 {
+		_ = c.process.send(&rpcRequest{Id: c.id, Credit: credit})
+	}
+}
+
+func joinLines(lines [][]byte) []byte {
+	var joined []byte
+	for i, line := range lines {
+		if i > 0 {
+			joined = append(joined, '\n')
+		}
+		joined = append(joined, line...)
+	}
+	return joined
+}
+
+var _ Invoker = (*RpcInvoker)(nil)
@@ -0,0 +1,205 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bridge
+
+import (
+	"bufio"
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/impls/logruslog"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRpcHelperProcess acts as the remote plugin when invoked by newTestRpcInvoker
+func TestRpcHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_RPC_HELPER_PROCESS") != "1" {
+		return
+	}
+	responses := os.NewFile(3, "responses")
+	requests := os.NewFile(4, "requests")
+	var sendMu sync.Mutex
+	send := func(response rpcResponse) {
+		message, _ := json.Marshal(response)
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		_, _ = responses.Write(append(message, '\n'))
+	}
+	// the credits granted to the calls, the results are sent only with credits like the python side does
+	var creditsMu sync.Mutex
+	credits := make(map[uint64]chan int)
+	scanner := bufio.NewScanner(requests)
+	for scanner.Scan() {
+		request := rpcRequest{}
+		_ = json.Unmarshal(scanner.Bytes(), &request)
+		if request.Method == "" {
+			creditsMu.Lock()
+			if granted, ok := credits[request.Id]; ok {
+				granted <- request.Credit
+			}
+			creditsMu.Unlock()
+			continue
+		}
+		switch request.Method {
+		case "ping":
+		case "plugin-info":
+			send(rpcResponse{Id: request.Id, Result: json.RawMessage(`{"name":"fake"}`)})
+		case "count", "flood":
+			n := 0
+			_ = json.Unmarshal(request.Args[1], &n)
+			granted := make(chan int, rpcCallWindow)
+			creditsMu.Lock()
+			credits[request.Id] = granted
+			creditsMu.Unlock()
+			go func(request rpcRequest) {
+				credit := request.Credit
+				for i := 1; i <= n; i++ {
+					for request.Method == "count" && credit == 0 {
+						credit += <-granted
+					}
+					send(rpcResponse{Id: request.Id, Result: json.RawMessage(fmt.Sprintf(`{"current":%d,"total":%d}`, i, n))})
+					credit--
+				}
+				send(rpcResponse{Id: request.Id, Done: true})
+			}(request)
+			continue
+		case "exit":
+			os.Exit(1)
+		default:
+			send(rpcResponse{Id: request.Id, Error: "unknown method " + request.Method})
+			continue
+		}
+		send(rpcResponse{Id: request.Id, Done: true})
+	}
+	os.Exit(0)
+}
+
+func newTestRpcInvoker(t *testing.T) *RpcInvoker {
+	t.Setenv("GO_WANT_RPC_HELPER_PROCESS", "1")
+	invoker := &RpcInvoker{
+		resolveCmd: func() (string, []string) {
+			return os.Args[0], []string{"-test.run=TestRpcHelperProcess"}
+		},
+		workers: 1,
+		logger:  logruslog.Global,
+	}
+	t.Cleanup(invoker.Close)
+	return invoker
+}
+
+func TestRpcInvokerCall(t *testing.T) {
+	invoker := newTestRpcInvoker(t)
+	info := map[string]string{}
+	err := invoker.Call("plugin-info", DefaultContext).Get(&info)
+	assert.Nil(t, err)
+	assert.Equal(t, "fake", info["name"])
+
+	err = invoker.Call("unknown", DefaultContext).Get(&info)
+	assert.NotNil(t, err)
+	assert.Nil(t, invoker.Ping(time.Second))
+}
+
+func TestRpcInvokerStream(t *testing.T) {
+	invoker := newTestRpcInvoker(t)
+	// calls are served by the same process concurrently
+	streams := []*MethodStream{
+		invoker.Stream("count", DefaultContext, 3),
+		invoker.Stream("count", DefaultContext, 5),
+	}
+	for i, expected := range []int{3, 5} {
+		count := 0
+		for recv := range streams[i].Receive() {
+			progress := RemoteProgress{}
+			assert.Nil(t, recv.Get(&progress))
+			count++
+			assert.Equal(t, count, progress.Current)
+			assert.Equal(t, expected, progress.Total)
+		}
+		assert.Equal(t, expected, count)
+	}
+}
+
+func TestRpcInvokerRestart(t *testing.T) {
+	invoker := newTestRpcInvoker(t)
+	var err errors.Error
+	for recv := range invoker.Stream("exit", DefaultContext).Receive() {
+		err = recv.Err
+	}
+	assert.NotNil(t, err)
+	// a new process would be started for the next call
+	assert.Nil(t, invoker.Ping(time.Second))
+}
+
+type cancelledContext struct {
+	plugin.ExecContext
+	ctx gocontext.Context
+}
+
+func (c cancelledContext) GetContext() gocontext.Context {
+	return c.ctx
+}
+
+func TestRpcInvokerCancel(t *testing.T) {
+	invoker := newTestRpcInvoker(t)
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+	for recv := range invoker.Stream("count", cancelledContext{DefaultContext, ctx}, 10).Receive() {
+		assert.Nil(t, recv.Err)
+	}
+}
+
+func TestRpcInvokerSlowConsumer(t *testing.T) {
+	invoker := newTestRpcInvoker(t)
+	// the results are not consumed until the ping returned, the other calls must still be dispatched
+	stream := invoker.Stream("count", DefaultContext, 5000)
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, invoker.Ping(time.Second))
+	count := 0
+	for recv := range stream.Receive() {
+		assert.Nil(t, recv.Err)
+		count++
+	}
+	assert.Equal(t, 5000, count)
+}
+
+func TestRpcInvokerFlowControlViolation(t *testing.T) {
+	invoker := newTestRpcInvoker(t)
+	// the results are sent regardless of the credits, the call fails instead of queueing them all
+	stream := invoker.Stream("flood", DefaultContext, 5000)
+	time.Sleep(100 * time.Millisecond)
+	var err errors.Error
+	count := 0
+	for recv := range stream.Receive() {
+		if recv.Err != nil {
+			err = recv.Err
+			continue
+		}
+		count++
+	}
+	assert.NotNil(t, err)
+	assert.Less(t, count, 5000)
+	// the other calls are still served
+	assert.Nil(t, invoker.Ping(time.Second))
+}
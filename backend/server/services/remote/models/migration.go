@@ -19,6 +19,8 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/dal"
@@ -33,6 +35,11 @@ type Operation interface {
 	Execute(basicRes context.BasicRes) errors.Error
 }
 
+// tableOperation is implemented by the operations which touch the given tables only
+type tableOperation interface {
+	tables() []string
+}
+
 type ExecuteOperation struct {
 	Sql         string  `json:"sql"`
 	Dialect     *string `json:"dialect"`
@@ -71,6 +78,10 @@ func (o AddColumnOperation) Execute(basicRes context.BasicRes) errors.Error {
 	return db.AddColumn(o.Table, o.Column, o.ColumnType)
 }
 
+func (o AddColumnOperation) tables() []string {
+	return []string{o.Table}
+}
+
 type DropColumnOperation struct {
 	Table  string `json:"table"`
 	Column string `json:"column"`
@@ -84,6 +95,10 @@ func (o DropColumnOperation) Execute(basicRes context.BasicRes) errors.Error {
 	return nil
 }
 
+func (o DropColumnOperation) tables() []string {
+	return []string{o.Table}
+}
+
 var _ Operation = (*DropColumnOperation)(nil)
 
 type DropTableOperation struct {
@@ -99,6 +114,10 @@ func (o DropTableOperation) Execute(basicRes context.BasicRes) errors.Error {
 	return nil
 }
 
+func (o DropTableOperation) tables() []string {
+	return []string{o.Table}
+}
+
 var _ Operation = (*DropTableOperation)(nil)
 
 type RenameColumnOperation struct {
@@ -121,6 +140,10 @@ func (o RenameColumnOperation) Execute(basicRes context.BasicRes) errors.Error {
 	return db.RenameColumn(o.Table, o.OldName, o.NewName)
 }
 
+func (o RenameColumnOperation) tables() []string {
+	return []string{o.Table}
+}
+
 var _ Operation = (*RenameTableOperation)(nil)
 
 type RenameTableOperation struct {
@@ -136,6 +159,10 @@ func (o RenameTableOperation) Execute(basicRes context.BasicRes) errors.Error {
 	return db.RenameTable(o.OldName, o.NewName)
 }
 
+func (o RenameTableOperation) tables() []string {
+	return []string{o.OldName, o.NewName}
+}
+
 type CreateTableOperation struct {
 	ModelInfo *DynamicModelInfo `json:"model_info"`
 }
@@ -160,6 +187,13 @@ func (o CreateTableOperation) Execute(basicRes context.BasicRes) errors.Error {
 	return nil
 }
 
+func (o CreateTableOperation) tables() []string {
+	if o.ModelInfo == nil {
+		return []string{""}
+	}
+	return []string{o.ModelInfo.TableName}
+}
+
 var _ Operation = (*RenameTableOperation)(nil)
 
 type RemoteMigrationScript struct {
@@ -236,4 +270,21 @@ func (s *RemoteMigrationScript) Name() string {
 	return s.name
 }
 
+// VerifyTables makes sure the operations only touch the tool and raw tables of the plugin, the raw sql of the
+// `execute` operations could touch any table so they are rejected
+func (s *RemoteMigrationScript) VerifyTables(pluginName string) errors.Error {
+	for _, operation := range s.operations {
+		tableOp, ok := operation.(tableOperation)
+		if !ok {
+			return errors.Forbidden.New(fmt.Sprintf("migration script %s: operation %T is not allowed", s.name, operation))
+		}
+		for _, table := range tableOp.tables() {
+			if !strings.HasPrefix(table, fmt.Sprintf("_tool_%s_", pluginName)) && !strings.HasPrefix(table, fmt.Sprintf("_raw_%s_", pluginName)) {
+				return errors.Forbidden.New(fmt.Sprintf("migration script %s: table %s is not owned by plugin %s", s.name, table, pluginName))
+			}
+		}
+	}
+	return nil
+}
+
 var _ plugin.MigrationScript = (*RemoteMigrationScript)(nil)
@@ -65,3 +65,33 @@ func TestUnmarshallMigrationScript(t *testing.T) {
 	op3 := script.operations[2].(*DropTableOperation)
 	assert.Equal(t, "t", op3.Table)
 }
+
+func TestVerifyMigrationScriptTables(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation string
+		allowed   bool
+	}{
+		{"create own table", `{"type": "create_table", "model_info": {"table_name": "_tool_myplugin_items"}}`, true},
+		{"add column to own table", `{"type": "add_column", "table": "_tool_myplugin_items", "column": "c", "column_type": "text"}`, true},
+		{"drop own raw table", `{"type": "drop_table", "table": "_raw_myplugin_items"}`, true},
+		{"rename own table", `{"type": "rename_table", "old_name": "_tool_myplugin_a", "new_name": "_tool_myplugin_b"}`, true},
+		{"execute sql", `{"type": "execute", "sql": "DELETE FROM _tool_myplugin_items"}`, false},
+		{"drop domain table", `{"type": "drop_table", "table": "projects"}`, false},
+		{"drop column of another plugin", `{"type": "drop_column", "table": "_tool_mypluginx_items", "column": "c"}`, false},
+		{"rename table of another plugin", `{"type": "rename_table", "old_name": "_tool_github_repos", "new_name": "_tool_myplugin_repos"}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var script RemoteMigrationScript
+			err := json.Unmarshal([]byte(`{"name": "test", "version": 1, "operations": [`+tt.operation+`]}`), &script)
+			assert.NoError(t, err)
+			verifyErr := script.VerifyTables("myplugin")
+			if tt.allowed {
+				assert.Nil(t, verifyErr)
+			} else {
+				assert.NotNil(t, verifyErr)
+			}
+		})
+	}
+}
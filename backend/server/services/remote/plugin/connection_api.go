@@ -134,7 +134,7 @@ func (pa *pluginAPI) PostConnections(input *plugin.ApiResourceInput) (*plugin.Ap
 
 func (pa *pluginAPI) ListConnections(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	connections := pa.connType.NewSlice()
-	err := pa.connhelper.List(connections)
+	err := pa.connhelper.ListByWorkspace(connections, input.Workspace)
 	if err != nil {
 		return nil, err
 	}
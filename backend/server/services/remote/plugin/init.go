@@ -36,7 +36,10 @@ func Init(br context.BasicRes) {
 }
 
 func NewRemotePlugin(info *models.PluginInfo) (models.RemotePlugin, errors.Error) {
-	invoker := bridge.NewCmdInvoker(info.PluginPath)
+	invoker, err := bridge.NewInvoker(info.PluginPath)
+	if err != nil {
+		return nil, err
+	}
 	plugin, err := newPlugin(info, invoker)
 
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/server/services/remote/bridge"
@@ -49,7 +50,7 @@ type (
 		connHelper        *api.ConnectionApiHelper
 	}
 	RemotePluginTaskData struct {
-		DbUrl       string                 `json:"db_url"`
+		DbUrl       string                 `json:"db_url,omitempty"`
 		Scope       interface{}            `json:"scope"`
 		Connection  interface{}            `json:"connection"`
 		ScopeConfig interface{}            `json:"scope_config"`
@@ -75,12 +76,15 @@ func newPlugin(info *models.PluginInfo, invoker bridge.Invoker) (*remotePluginIm
 		connectionTabler.New(),
 		models.NewDynamicScopeModel(scopeTabler),
 	}
+	// the connection and scope tables are managed by the server, the subtasks only write the tool and domain layer records
+	recordTables := domaininfo.GetDomainTablesInfo()
 	for _, toolModelInfo := range info.ToolModelInfos {
 		toolModelTabler, err := toolModelInfo.LoadDynamicTabler(models.ToolModel{})
 		if err != nil {
 			return nil, errors.Default.Wrap(err, fmt.Sprintf("Couldn't load ToolModel type for plugin %s", info.Name))
 		}
 		toolModelTablers = append(toolModelTablers, toolModelTabler.New())
+		recordTables = append(recordTables, toolModelTabler.New())
 	}
 	openApiSpec, err := doc.GenerateOpenApiSpec(info)
 	if err != nil {
@@ -89,6 +93,13 @@ func newPlugin(info *models.PluginInfo, invoker bridge.Invoker) (*remotePluginIm
 	scripts := make([]plugin.MigrationScript, 0)
 	for _, script := range info.MigrationScripts {
 		script := script
+		// the services run outside of the server and must not touch the tables of the others
+		if bridge.IsHttpPlugin(info.PluginPath) {
+			err = script.VerifyTables(info.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
 		scripts = append(scripts, &script)
 	}
 	connectionHelper := api.NewConnectionHelper(
@@ -111,7 +122,7 @@ func newPlugin(info *models.PluginInfo, invoker bridge.Invoker) (*remotePluginIm
 		openApiSpec:       *openApiSpec,
 		connHelper:        connectionHelper,
 	}
-	remoteBridge := bridge.NewBridge(invoker)
+	remoteBridge := bridge.NewBridge(invoker, recordTables...)
 	for _, subtask := range info.SubtaskMetas {
 		p.subtaskMetas = append(p.subtaskMetas, plugin.SubTaskMeta{
 			Name:             subtask.Name,
@@ -150,7 +161,6 @@ func (p *remotePluginImpl) ScopeConfig() dal.Tabler {
 }
 
 func (p *remotePluginImpl) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
-	dbUrl := taskCtx.GetConfig("db_url")
 	connectionId := uint64(options["connectionId"].(float64))
 
 	helper := api.NewConnectionHelper(
@@ -177,13 +187,17 @@ func (p *remotePluginImpl) PrepareTaskData(taskCtx plugin.TaskContext, options m
 		return nil, err
 	}
 
-	return RemotePluginTaskData{
-		DbUrl:       dbUrl,
+	taskData := RemotePluginTaskData{
 		Scope:       scope,
 		Connection:  connection,
 		ScopeConfig: scopeConfig,
 		Options:     options,
-	}, nil
+	}
+	// http services are not trusted with the database credentials, they send their records through the bridge
+	if !bridge.IsHttpPlugin(p.pluginPath) {
+		taskData.DbUrl = taskCtx.GetConfig("db_url")
+	}
+	return taskData, nil
 }
 
 func (p *remotePluginImpl) getScopeAndConfig(db dal.Dal, connectionId uint64, scopeId string) (scope interface{}, scopeConfig interface{}, err errors.Error) {
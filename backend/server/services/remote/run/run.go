@@ -38,10 +38,13 @@ func main() {
 	_ = cmd.MarkFlagRequired("connectionId")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
-		invoker := bridge.NewCmdInvoker(*pluginPath)
+		invoker, err := bridge.NewInvoker(*pluginPath)
+		if err != nil {
+			panic(fmt.Sprintf("Cannot create invoker: %s", err))
+		}
 
 		pluginInfo := models.PluginInfo{}
-		err := invoker.Call("plugin-info", bridge.DefaultContext).Get(&pluginInfo)
+		err = invoker.Call("plugin-info", bridge.DefaultContext).Get(&pluginInfo)
 
 		if err != nil {
 			panic(fmt.Sprintf("Cannot get plugin info: %s", err))
@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sync"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/runner"
+	"github.com/apache/incubator-devlake/server/services/remote/models"
+)
+
+var remotePluginMutex sync.Mutex
+
+// RegisterRemotePlugin loads the plugin running as a http service at the url and applies its migration scripts.
+// The plugin is kept until the server restarts, add the url to REMOTE_PLUGIN_URLS to load it on startup
+func RegisterRemotePlugin(url string) (models.RemotePlugin, errors.Error) {
+	remotePluginMutex.Lock()
+	defer remotePluginMutex.Unlock()
+	remotePlugin, err := runner.LoadRemotePluginByUrl(basicRes, url)
+	if err != nil {
+		return nil, err
+	}
+	migrator.Register(remotePlugin.MigrationScripts(), remotePlugin.Name())
+	err = migrator.Execute()
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to migrate the remote plugin")
+	}
+	return remotePlugin, nil
+}
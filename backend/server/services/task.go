@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/runner"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 )
 
@@ -175,7 +176,7 @@ func CancelTask(taskId uint64) errors.Error {
 }
 
 // RunTasksStandalone run tasks in parallel
-func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error {
+func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64, pause *runner.PauseSignal) errors.Error {
 	if len(taskIds) == 0 {
 		return nil
 	}
@@ -184,7 +185,7 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 		go func(id uint64) {
 			taskLog.Info("run task #%d in background ", id)
 			var err errors.Error
-			taskErr := runTaskStandalone(parentLogger, id)
+			taskErr := runTaskStandalone(parentLogger, id, pause)
 			if taskErr != nil {
 				err = errors.Default.Wrap(taskErr, fmt.Sprintf("Error running task %d.", id))
 			}
@@ -206,7 +207,11 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 	}
 	if len(errs) > 0 {
 		var sb strings.Builder
+		paused := 0
 		for _, e := range errs {
+			if errors.Is(e, runner.ErrPipelinePaused) {
+				paused++
+			}
 			_, _ = sb.WriteString(e.Error())
 			_, _ = sb.WriteString("\n")
 			if errors.Is(e, context.Canceled) {
@@ -214,6 +219,11 @@ func RunTasksStandalone(parentLogger log.Logger, taskIds []uint64) errors.Error
 				return errors.Convert(e)
 			}
 		}
+		// all failures were caused by pausing, let the pipeline runner know it was paused
+		if paused == len(errs) {
+			parentLogger.Info("tasks paused")
+			return runner.ErrPipelinePaused
+		}
 		err = errors.Default.New(sb.String())
 	}
 	return errors.Convert(err)
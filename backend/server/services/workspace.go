@@ -0,0 +1,217 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/helpers/workspacehelper"
+)
+
+// GetWorkspaces returns all workspaces
+func GetWorkspaces() ([]*models.Workspace, errors.Error) {
+	workspaces := make([]*models.Workspace, 0)
+	err := db.All(&workspaces, dal.Orderby("name"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB workspaces")
+	}
+	return workspaces, nil
+}
+
+// GetWorkspace returns the workspace with the given name
+func GetWorkspace(name string) (*models.Workspace, errors.Error) {
+	if name == "" {
+		return nil, errors.BadInput.New("workspace name is missing")
+	}
+	workspace := &models.Workspace{}
+	err := db.First(workspace, dal.Where("name = ?", name))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.New(fmt.Sprintf("workspace %s not found", name))
+		}
+		return nil, errors.Default.Wrap(err, fmt.Sprintf("error finding workspace %s", name))
+	}
+	return workspace, nil
+}
+
+// CreateWorkspace accepts a workspace instance and insert it to database
+func CreateWorkspace(user *common.User, workspace *models.Workspace) (*models.Workspace, errors.Error) {
+	if err := VerifyStruct(workspace); err != nil {
+		return nil, err
+	}
+	if user != nil {
+		workspace.Creator = common.Creator{Creator: user.Name, CreatorEmail: user.Email}
+	}
+	err := db.Create(workspace)
+	if err != nil {
+		if db.IsDuplicationError(err) {
+			return nil, errors.BadInput.New(fmt.Sprintf("workspace %s already exists", workspace.Name))
+		}
+		return nil, errors.Default.Wrap(err, "error creating workspace")
+	}
+	return workspace, nil
+}
+
+// DeleteWorkspace removes the workspace, it must not own any resource
+func DeleteWorkspace(name string) errors.Error {
+	workspace, err := GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	for _, owned := range []dal.Tabler{
+		&models.Project{},
+		&models.Blueprint{},
+		&models.ApiKey{},
+		&models.WorkspaceConnection{},
+	} {
+		count, err := db.Count(dal.From(owned), dal.Where("workspace = ?", name))
+		if err != nil {
+			return errors.Default.Wrap(err, fmt.Sprintf("error counting %s of workspace %s", owned.TableName(), name))
+		}
+		if count > 0 {
+			return errors.Conflict.New(fmt.Sprintf("workspace %s still owns records in %s", name, owned.TableName()))
+		}
+	}
+	return db.Delete(workspace)
+}
+
+// GetWorkspaceConnections returns the plugin connections assigned to the workspace
+func GetWorkspaceConnections(name string) ([]*models.WorkspaceConnection, errors.Error) {
+	if _, err := GetWorkspace(name); err != nil {
+		return nil, err
+	}
+	connections := make([]*models.WorkspaceConnection, 0)
+	err := db.All(&connections, dal.Where("workspace = ?", name), dal.Orderby("plugin, connection_id"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB workspace connections")
+	}
+	return connections, nil
+}
+
+// AssignWorkspaceConnection moves the plugin connection into the workspace
+func AssignWorkspaceConnection(name string, connection *models.WorkspaceConnection) (*models.WorkspaceConnection, errors.Error) {
+	if _, err := GetWorkspace(name); err != nil {
+		return nil, err
+	}
+	if err := VerifyStruct(connection); err != nil {
+		return nil, err
+	}
+	connection.Workspace = name
+	connection.CreatedAt = time.Now()
+	err := db.CreateOrUpdate(connection)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error assigning connection to workspace")
+	}
+	return connection, nil
+}
+
+// UnassignWorkspaceConnection removes the plugin connection from the workspace
+func UnassignWorkspaceConnection(name string, pluginName string, connectionId uint64) errors.Error {
+	return db.Delete(
+		&models.WorkspaceConnection{},
+		dal.Where("workspace = ? AND plugin = ? AND connection_id = ?", name, pluginName, connectionId),
+	)
+}
+
+// VerifyConnectionWorkspace makes sure the plugin connection is visible to the workspace
+func VerifyConnectionWorkspace(workspace string, pluginName string, connectionId uint64) errors.Error {
+	return workspacehelper.NewWorkspaceHelper(basicRes).VerifyConnection(workspace, pluginName, connectionId)
+}
+
+// VerifyBlueprintConnectionsWorkspace makes sure the connections used by the blueprint are visible to the workspace,
+// scopes are identified by their connection, so scopes of these connections are visible as well
+func VerifyBlueprintConnectionsWorkspace(workspace string, blueprint *models.Blueprint) errors.Error {
+	for _, connection := range blueprint.Connections {
+		err := VerifyConnectionWorkspace(workspace, connection.PluginName, connection.ConnectionId)
+		if err != nil {
+			return errors.BadInput.Wrap(err, fmt.Sprintf("invalid connection for the blueprint [%s]", blueprint.Name))
+		}
+	}
+	return nil
+}
+
+// VerifyProjectWorkspace makes sure the project is visible to the workspace
+func VerifyProjectWorkspace(workspace string, projectName string) errors.Error {
+	return verifyWorkspace(workspace, &models.Project{}, dal.Where("name = ?", projectName), "project", projectName)
+}
+
+// VerifyBlueprintWorkspace makes sure the blueprint is visible to the workspace
+func VerifyBlueprintWorkspace(workspace string, blueprintId uint64) errors.Error {
+	return verifyWorkspace(workspace, &models.Blueprint{}, dal.Where("id = ?", blueprintId), "blueprint", blueprintId)
+}
+
+// VerifyApiKeyWorkspace makes sure the api key is visible to the workspace
+func VerifyApiKeyWorkspace(workspace string, apiKeyId uint64) errors.Error {
+	return verifyWorkspace(workspace, &models.ApiKey{}, dal.Where("id = ?", apiKeyId), "api key", apiKeyId)
+}
+
+// VerifyPipelineWorkspace makes sure the pipeline is visible to the workspace,
+// pipelines are owned by the workspace of their blueprint
+func VerifyPipelineWorkspace(workspace string, pipelineId uint64) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	pipeline := &models.Pipeline{}
+	err := db.First(pipeline, dal.Where("id = ?", pipelineId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New(fmt.Sprintf("pipeline %d not found", pipelineId))
+		}
+		return err
+	}
+	if pipeline.BlueprintId == 0 {
+		return errors.NotFound.New(fmt.Sprintf("pipeline %d not found", pipelineId))
+	}
+	return VerifyBlueprintWorkspace(workspace, pipeline.BlueprintId)
+}
+
+// VerifyTaskWorkspace makes sure the task is visible to the workspace
+func VerifyTaskWorkspace(workspace string, taskId uint64) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	task := &models.Task{}
+	err := db.First(task, dal.Where("id = ?", taskId))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.New(fmt.Sprintf("task %d not found", taskId))
+		}
+		return err
+	}
+	return VerifyPipelineWorkspace(workspace, task.PipelineId)
+}
+
+// verifyWorkspace returns NotFound rather than Forbidden to avoid leaking the existence of the record
+func verifyWorkspace(workspace string, table dal.Tabler, where dal.Clause, kind string, key interface{}) errors.Error {
+	if workspace == "" {
+		return nil
+	}
+	count, err := db.Count(dal.From(table), where, dal.Where("workspace = ?", workspace))
+	if err != nil {
+		return errors.Default.Wrap(err, fmt.Sprintf("error verifying workspace of %s %v", kind, key))
+	}
+	if count == 0 {
+		return errors.NotFound.New(fmt.Sprintf("%s %v not found", kind, key))
+	}
+	return nil
+}
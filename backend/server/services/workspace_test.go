@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// workspaceDal keeps the workspace of the records in memory, it only understands the queries issued by the
+// workspace verifications
+type workspaceDal struct {
+	dal.Dal
+	// table name -> primary key -> workspace
+	workspaces map[string]map[string]string
+	pipelines  map[uint64]*models.Pipeline
+}
+
+func (d *workspaceDal) Count(clauses ...dal.Clause) (int64, errors.Error) {
+	table := clauses[0].Data.(dal.Tabler).TableName()
+	var key, workspace interface{}
+	if table == (&models.WorkspaceConnection{}).TableName() {
+		params := clauses[1].Data.(dal.DalClause).Params
+		key, workspace = fmt.Sprintf("%v:%v", params[0], params[1]), params[2]
+	} else {
+		key, workspace = clauses[1].Data.(dal.DalClause).Params[0], clauses[2].Data.(dal.DalClause).Params[0]
+	}
+	if d.workspaces[table][fmt.Sprintf("%v", key)] == workspace {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func (d *workspaceDal) First(dst interface{}, clauses ...dal.Clause) errors.Error {
+	id := clauses[0].Data.(dal.DalClause).Params[0].(uint64)
+	pipeline, ok := d.pipelines[id]
+	if !ok {
+		return errors.NotFound.New("record not found")
+	}
+	*dst.(*models.Pipeline) = *pipeline
+	return nil
+}
+
+func (d *workspaceDal) IsErrorNotFound(err error) bool {
+	lakeErr := errors.AsLakeErrorType(err)
+	return lakeErr != nil && lakeErr.GetType() == errors.NotFound
+}
+
+type workspaceBasicRes struct {
+	context.BasicRes
+	db dal.Dal
+}
+
+func (r *workspaceBasicRes) GetDal() dal.Dal {
+	return r.db
+}
+
+func setupWorkspaceDal(t *testing.T) {
+	originDb, originBasicRes := db, basicRes
+	t.Cleanup(func() {
+		db, basicRes = originDb, originBasicRes
+	})
+	db = &workspaceDal{
+		workspaces: map[string]map[string]string{
+			(&models.Blueprint{}).TableName():           {"1": "a", "2": "b"},
+			(&models.Project{}).TableName():             {"project-a": "a", "project-b": "b"},
+			(&models.WorkspaceConnection{}).TableName(): {"github:1": "a", "github:2": "b"},
+		},
+		pipelines: map[uint64]*models.Pipeline{
+			10: {BlueprintId: 1},
+			20: {BlueprintId: 2},
+			30: {},
+		},
+	}
+	basicRes = &workspaceBasicRes{db: db}
+}
+
+func TestVerifyBlueprintWorkspace(t *testing.T) {
+	setupWorkspaceDal(t)
+	assert.Nil(t, VerifyBlueprintWorkspace("a", 1))
+	assert.Nil(t, VerifyBlueprintWorkspace("b", 2))
+	assert.Nil(t, VerifyBlueprintWorkspace("", 2))
+	err := VerifyBlueprintWorkspace("a", 2)
+	assert.Equal(t, errors.NotFound, err.GetType())
+	err = VerifyBlueprintWorkspace("b", 1)
+	assert.Equal(t, errors.NotFound, err.GetType())
+
+	assert.Nil(t, VerifyProjectWorkspace("a", "project-a"))
+	err = VerifyProjectWorkspace("a", "project-b")
+	assert.Equal(t, errors.NotFound, err.GetType())
+}
+
+func TestVerifyPipelineWorkspace(t *testing.T) {
+	setupWorkspaceDal(t)
+	assert.Nil(t, VerifyPipelineWorkspace("a", 10))
+	assert.Nil(t, VerifyPipelineWorkspace("", 20))
+	for _, id := range []uint64{20, 30, 40} {
+		err := VerifyPipelineWorkspace("a", id)
+		assert.Equal(t, errors.NotFound, err.GetType(), "pipeline %d", id)
+	}
+}
+
+func TestVerifyBlueprintConnectionsWorkspace(t *testing.T) {
+	setupWorkspaceDal(t)
+	blueprint := &models.Blueprint{
+		Name: "blueprint",
+		Connections: []*models.BlueprintConnection{
+			{PluginName: "github", ConnectionId: 1, Scopes: []*models.BlueprintScope{{ScopeId: "1"}}},
+		},
+	}
+	assert.Nil(t, VerifyBlueprintConnectionsWorkspace("a", blueprint))
+	assert.Nil(t, VerifyBlueprintConnectionsWorkspace("", blueprint))
+	err := VerifyBlueprintConnectionsWorkspace("b", blueprint)
+	assert.Equal(t, errors.BadInput, err.GetType())
+
+	// connections of other workspaces can't be smuggled in along with the own ones
+	blueprint.Connections = append(blueprint.Connections, &models.BlueprintConnection{PluginName: "github", ConnectionId: 2})
+	err = VerifyBlueprintConnectionsWorkspace("a", blueprint)
+	assert.Equal(t, errors.BadInput, err.GetType())
+	err = VerifyBlueprintConnectionsWorkspace("b", blueprint)
+	assert.Equal(t, errors.BadInput, err.GetType())
+}
@@ -28,6 +28,8 @@ import (
 	dbt "github.com/apache/incubator-devlake/plugins/dbt/impl"
 	dora "github.com/apache/incubator-devlake/plugins/dora/impl"
 	feishu "github.com/apache/incubator-devlake/plugins/feishu/impl"
+	gerrit "github.com/apache/incubator-devlake/plugins/gerrit/impl"
+	gitea "github.com/apache/incubator-devlake/plugins/gitea/impl"
 	gitee "github.com/apache/incubator-devlake/plugins/gitee/impl"
 	gitextractor "github.com/apache/incubator-devlake/plugins/gitextractor/impl"
 	github "github.com/apache/incubator-devlake/plugins/github/impl"
@@ -67,6 +69,8 @@ func loadGoPlugins() []plugin.PluginMeta {
 		dbt.Dbt{},
 		dora.Dora{},
 		feishu.Feishu{},
+		gerrit.Gerrit{},
+		gitea.Gitea{},
 		gitee.Gitee{},
 		gitextractor.GitExtractor{},
 		github.Github{},
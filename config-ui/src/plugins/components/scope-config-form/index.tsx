@@ -212,6 +212,7 @@ export const ScopeConfigForm = ({
 
               {plugin === 'azuredevops_go' && (
                 <AzureTransformation
+                  plugin={plugin}
                   entities={entities}
                   transformation={transformation}
                   setTransformation={setTransformation}
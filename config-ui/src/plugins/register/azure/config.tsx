@@ -119,6 +119,7 @@ export const AzureGoConfig: IPluginConfig = {
   scopeConfig: {
     entities: ['CODE', 'CODEREVIEW', 'CROSS', 'CICD'],
     transformation: {
+      envNamePattern: '(?i)prod(.*)',
       deploymentPattern: '(deploy|push-image)',
       productionPattern: 'prod(.*)',
       refdiff: {
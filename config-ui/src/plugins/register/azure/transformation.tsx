@@ -23,12 +23,13 @@ import { ExternalLink, HelpTooltip } from '@/components';
 import { DOC_URL } from '@/release';
 
 interface Props {
+  plugin?: string;
   entities: string[];
   transformation: any;
   setTransformation: React.Dispatch<React.SetStateAction<any>>;
 }
 
-export const AzureTransformation = ({ entities, transformation, setTransformation }: Props) => {
+export const AzureTransformation = ({ plugin, entities, transformation, setTransformation }: Props) => {
   const { token } = theme.useToken();
 
   const panelStyle: React.CSSProperties = {
@@ -46,6 +47,7 @@ export const AzureTransformation = ({ entities, transformation, setTransformatio
       style={{ background: token.colorBgContainer }}
       size="large"
       items={renderCollapseItems({
+        plugin,
         entities,
         panelStyle,
         transformation,
@@ -56,11 +58,13 @@ export const AzureTransformation = ({ entities, transformation, setTransformatio
 };
 
 const renderCollapseItems = ({
+  plugin,
   entities,
   panelStyle,
   transformation,
   onChangeTransformation,
 }: {
+  plugin?: string;
   entities: string[];
   panelStyle: React.CSSProperties;
   transformation: any;
@@ -83,6 +87,27 @@ const renderCollapseItems = ({
             Use Regular Expression to define Deployments in DevLake in order to measure DORA metrics.{' '}
             <ExternalLink link={DOC_URL.PLUGIN.AZUREDEVOPS.TRANSFORMATION}>Learn more</ExternalLink>
           </p>
+          {plugin === 'azuredevops_go' && (
+            <>
+              <div>Convert an Azure Release deployment to a DevLake Deployment</div>
+              <div style={{ margin: '8px 0', paddingLeft: 28 }}>
+                <span>If its stage (environment) name matches</span>
+                <Input
+                  style={{ width: 200, margin: '0 8px' }}
+                  placeholder="(?i)prod(.*)"
+                  value={transformation.envNamePattern ?? ''}
+                  onChange={(e) =>
+                    onChangeTransformation({
+                      ...transformation,
+                      envNamePattern: e.target.value,
+                    })
+                  }
+                />
+                <span>, this deployment is a ‘Production Deployment’</span>
+                <HelpTooltip content="Azure Release pipelines: https://learn.microsoft.com/en-us/azure/devops/pipelines/release/?view=azure-devops" />
+              </div>
+            </>
+          )}
           <div>Convert a Azure Pipeline Run as a DevLake Deployment when: </div>
           <div style={{ margin: '8px 0', paddingLeft: 28 }}>
             <span>
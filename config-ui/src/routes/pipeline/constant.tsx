@@ -22,6 +22,7 @@ import {
   CheckCircleOutlined,
   CloseCircleOutlined,
   UndoOutlined,
+  PauseCircleOutlined,
 } from '@ant-design/icons';
 
 import { IPipelineStatus } from '@/types';
@@ -36,6 +37,7 @@ export const PipeLineStatusIcon = {
   [IPipelineStatus.PARTIAL]: <CheckCircleOutlined />,
   [IPipelineStatus.FAILED]: <CloseCircleOutlined />,
   [IPipelineStatus.CANCELLED]: <UndoOutlined />,
+  [IPipelineStatus.PAUSED]: <PauseCircleOutlined />,
 };
 
 export const PipeLineStatusLabel = {
@@ -48,4 +50,5 @@ export const PipeLineStatusLabel = {
   [IPipelineStatus.PARTIAL]: 'Partial Success',
   [IPipelineStatus.FAILED]: 'Failed',
   [IPipelineStatus.CANCELLED]: 'Cancelled',
+  [IPipelineStatus.PAUSED]: 'Paused',
 };
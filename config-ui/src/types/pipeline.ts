@@ -26,6 +26,7 @@ export enum IPipelineStatus {
   PARTIAL = 'TASK_PARTIAL',
   FAILED = 'TASK_FAILED',
   CANCELLED = 'TASK_CANCELLED',
+  PAUSED = 'TASK_PAUSED',
 }
 
 export interface IPipeline {